@@ -23,6 +23,7 @@ import (
 	"github.com/kubesphere/pvc-autoresizer/runners"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/klog"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -32,6 +33,7 @@ import (
 
 	"kubesphere.io/kubesphere/cmd/controller-manager/app/options"
 	"kubesphere.io/kubesphere/pkg/controller/alerting"
+	"kubesphere.io/kubesphere/pkg/controller/accessrequest"
 	"kubesphere.io/kubesphere/pkg/controller/application"
 	"kubesphere.io/kubesphere/pkg/controller/helm"
 	"kubesphere.io/kubesphere/pkg/controller/namespace"
@@ -51,6 +53,7 @@ import (
 	"kubesphere.io/kubesphere/pkg/simple/client/devops/jenkins"
 	ldapclient "kubesphere.io/kubesphere/pkg/simple/client/ldap"
 	"kubesphere.io/kubesphere/pkg/simple/client/s3"
+	"kubesphere.io/kubesphere/pkg/simple/client/secretstore"
 
 	"kubesphere.io/kubesphere/pkg/controller/storage/snapshotclass"
 
@@ -59,6 +62,7 @@ import (
 	"kubesphere.io/kubesphere/pkg/controller/certificatesigningrequest"
 	"kubesphere.io/kubesphere/pkg/controller/cluster"
 	"kubesphere.io/kubesphere/pkg/controller/clusterrolebinding"
+	kubectlpod "kubesphere.io/kubesphere/pkg/controller/kubectl"
 	"kubesphere.io/kubesphere/pkg/controller/destinationrule"
 	"kubesphere.io/kubesphere/pkg/controller/globalrole"
 	"kubesphere.io/kubesphere/pkg/controller/globalrolebinding"
@@ -70,10 +74,21 @@ import (
 	"kubesphere.io/kubesphere/pkg/controller/network/nsnetworkpolicy"
 	"kubesphere.io/kubesphere/pkg/controller/network/nsnetworkpolicy/provider"
 	"kubesphere.io/kubesphere/pkg/controller/notification"
+	"kubesphere.io/kubesphere/pkg/controller/notificationdispatch"
+	"kubesphere.io/kubesphere/pkg/controller/gitopsconfig"
+	"kubesphere.io/kubesphere/pkg/controller/pipelineretention"
+	"kubesphere.io/kubesphere/pkg/controller/recommendation"
+	"kubesphere.io/kubesphere/pkg/controller/registrysecret"
+	"kubesphere.io/kubesphere/pkg/controller/rollout"
+	"kubesphere.io/kubesphere/pkg/controller/scheduledscaling"
 	"kubesphere.io/kubesphere/pkg/controller/storage/capability"
+	"kubesphere.io/kubesphere/pkg/controller/storageversionmigration"
+	"kubesphere.io/kubesphere/pkg/controller/strategypromotion"
 	"kubesphere.io/kubesphere/pkg/controller/virtualservice"
 	"kubesphere.io/kubesphere/pkg/informers"
+	recommendationmodel "kubesphere.io/kubesphere/pkg/models/recommendation"
 	"kubesphere.io/kubesphere/pkg/simple/client/k8s"
+	"kubesphere.io/kubesphere/pkg/simple/client/monitoring/prometheus"
 	ippoolclient "kubesphere.io/kubesphere/pkg/simple/client/network/ippool"
 )
 
@@ -110,6 +125,17 @@ var allControllers = []string{
 	"csr",
 
 	"clusterrolebinding",
+	"kubectlpod",
+	"storageversionmigration",
+	"registrysecret",
+	"scheduledscaling",
+	"recommendation",
+	"gitopsconfig",
+	"pipelineretention",
+	"notificationdispatch",
+	"eventrule",
+	"rolloutstrategy",
+	"strategypromotion",
 
 	"fedglobalrolecache",
 	"globalrole",
@@ -118,6 +144,7 @@ var allControllers = []string{
 
 	"groupbinding",
 	"group",
+	"accessrequest",
 
 	"notification",
 }
@@ -153,12 +180,27 @@ func addAllControllers(mgr manager.Manager, client k8s.Client, informerFactory i
 		}
 	}
 
+	var secretStore secretstore.Interface
+	if cmOptions.SecretStoreOptions != nil && len(cmOptions.SecretStoreOptions.Endpoint) != 0 {
+		secretStore, err = secretstore.NewSecretStoreClient(cmOptions.SecretStoreOptions)
+		if err != nil {
+			return fmt.Errorf("failed to init secret store client, error: %v", err)
+		}
+	}
+
 	var ldapClient ldapclient.Interface
 	// when there is no ldapOption, we set ldapClient as nil, which means we don't need to sync user info into ldap.
 	if cmOptions.LdapOptions != nil && len(cmOptions.LdapOptions.Host) != 0 {
 		if cmOptions.LdapOptions.Host == ldapclient.FAKE_HOST { // for debug only
 			ldapClient = ldapclient.NewSimpleLdap()
 		} else {
+			if secretStore != nil {
+				managerPassword, err := secretStore.Resolve(cmOptions.LdapOptions.ManagerPassword)
+				if err != nil {
+					return fmt.Errorf("failed to resolve ldap manager password from secret store, error: %v", err)
+				}
+				cmOptions.LdapOptions.ManagerPassword = managerPassword
+			}
 			ldapClient, err = ldapclient.NewLdapClient(cmOptions.LdapOptions, stopCh)
 			if err != nil {
 				return fmt.Errorf("failed to connect to ldap service, please check ldap status, error: %v", err)
@@ -188,6 +230,23 @@ func addAllControllers(mgr manager.Manager, client k8s.Client, informerFactory i
 		addControllerWithSetup(mgr, "user", userController)
 	}
 
+	// "pipelineretention" controller, cleans up expired pipeline runs of
+	// devops projects with a retention policy
+	if devopsClient != nil && cmOptions.IsControllerEnabled("pipelineretention") {
+		var retentionS3Client s3.Interface
+		if cmOptions.S3Options != nil && len(cmOptions.S3Options.Endpoint) != 0 {
+			retentionS3Client, err = s3.NewS3Client(cmOptions.S3Options)
+			if err != nil {
+				return fmt.Errorf("failed to connect to s3, please check s3 service status, error: %v", err)
+			}
+		}
+		pipelineRetentionReconciler := &pipelineretention.Reconciler{
+			DevopsClient: devopsClient,
+			S3Client:     retentionS3Client,
+		}
+		addControllerWithSetup(mgr, "pipelineretention", pipelineRetentionReconciler)
+	}
+
 	// "workspacetemplate" controller
 	if cmOptions.IsControllerEnabled("workspacetemplate") {
 		workspaceTemplateReconciler := &workspacetemplate.Reconciler{MultiClusterEnabled: cmOptions.MultiClusterOptions.Enable}
@@ -218,9 +277,17 @@ func addAllControllers(mgr manager.Manager, client k8s.Client, informerFactory i
 		addControllerWithSetup(mgr, "namespace", namespaceReconciler)
 	}
 
+	// "notificationdispatch" controller, registered before the app store
+	// controllers so they can publish events through it
+	var notificationDispatchReconciler *notificationdispatch.Reconciler
+	if cmOptions.IsControllerEnabled("notificationdispatch") {
+		notificationDispatchReconciler = &notificationdispatch.Reconciler{}
+		addControllerWithSetup(mgr, "notificationdispatch", notificationDispatchReconciler)
+	}
+
 	// "helmrepo" controller
 	if cmOptions.IsControllerEnabled("helmrepo") {
-		helmRepoReconciler := &helmrepo.ReconcileHelmRepo{}
+		helmRepoReconciler := &helmrepo.ReconcileHelmRepo{SecretStore: secretStore}
 		addControllerWithSetup(mgr, "helmrepo", helmRepoReconciler)
 	}
 
@@ -246,6 +313,9 @@ func addAllControllers(mgr manager.Manager, client k8s.Client, informerFactory i
 		// "helmapplicationversion" controller
 		if cmOptions.IsControllerEnabled("helmapplicationversion") {
 			reconcileHelmAppVersion := (&helmapplication.ReconcileHelmApplicationVersion{})
+			if notificationDispatchReconciler != nil {
+				reconcileHelmAppVersion.Notifier = notificationDispatchReconciler
+			}
 			addControllerWithSetup(mgr, "helmapplicationversion", reconcileHelmAppVersion)
 		}
 	}
@@ -401,7 +471,7 @@ func addAllControllers(mgr manager.Manager, client k8s.Client, informerFactory i
 	if cmOptions.IsControllerEnabled("csr") {
 		csrController := certificatesigningrequest.NewController(client.Kubernetes(),
 			kubernetesInformer.Certificates().V1().CertificateSigningRequests(),
-			kubernetesInformer.Core().V1().ConfigMaps(), client.Config())
+			kubernetesInformer.Core().V1().ConfigMaps(), client.Config(), mgr.GetClient())
 		addController(mgr, "csr", csrController)
 	}
 
@@ -412,10 +482,86 @@ func addAllControllers(mgr manager.Manager, client k8s.Client, informerFactory i
 			kubernetesInformer.Apps().V1().Deployments(),
 			kubernetesInformer.Core().V1().Pods(),
 			kubesphereInformer.Iam().V1alpha2().Users(),
-			cmOptions.AuthenticationOptions.KubectlImage)
+			cmOptions.AuthenticationOptions.KubectlOptions)
 		addController(mgr, "clusterrolebinding", clusterRoleBindingController)
 	}
 
+	// "kubectlpod" controller
+	if cmOptions.IsControllerEnabled("kubectlpod") {
+		kubectlPodController := kubectlpod.NewController(client.Kubernetes(),
+			kubernetesInformer.Apps().V1().Deployments(),
+			cmOptions.AuthenticationOptions.KubectlOptions)
+		addController(mgr, "kubectlpod", kubectlPodController)
+	}
+
+	// "storageversionmigration" controller
+	if cmOptions.IsControllerEnabled("storageversionmigration") {
+		migrator, err := storageversionmigration.NewMigrator(client.Config(), client.ApiExtensions())
+		if err != nil {
+			klog.Fatalf("Unable to create storage version migrator: %v", err)
+		}
+		addController(mgr, "storageversionmigration", storageversionmigration.NewController(migrator))
+	}
+
+	// "registrysecret" controller
+	if cmOptions.IsControllerEnabled("registrysecret") {
+		registrySecretReconciler := &registrysecret.Reconciler{}
+		addControllerWithSetup(mgr, "registrysecret", registrySecretReconciler)
+	}
+
+	// "recommendation" controller
+	if monitoringOptionsEnable && cmOptions.IsControllerEnabled("recommendation") {
+		monitoringClient, err := prometheus.NewPrometheus(cmOptions.MonitoringOptions)
+		if err != nil {
+			klog.Fatalf("Unable to create monitoring client for recommendations: %v", err)
+		}
+		recommendationController := recommendation.NewController(client.Kubernetes(),
+			recommendationmodel.NewRecommender(monitoringClient))
+		addController(mgr, "recommendation", recommendationController)
+	}
+
+	// "gitopsconfig" controller
+	if cmOptions.GitOpsOptions != nil && len(cmOptions.GitOpsOptions.Repository) != 0 &&
+		cmOptions.IsControllerEnabled("gitopsconfig") {
+		dynamicClient, err := dynamic.NewForConfig(client.Config())
+		if err != nil {
+			klog.Fatalf("Unable to create dynamic client for gitops: %v", err)
+		}
+		addController(mgr, "gitopsconfig", gitopsconfig.NewController(dynamicClient, cmOptions.GitOpsOptions))
+	}
+
+	// "scheduledscaling" controller
+	if cmOptions.IsControllerEnabled("scheduledscaling") {
+		scheduledScalingReconciler := &scheduledscaling.Reconciler{}
+		addControllerWithSetup(mgr, "scheduledscaling", scheduledScalingReconciler)
+	}
+
+	// "eventrule" controller, needs no prometheus and runs regardless of
+	// the alerting options
+	if cmOptions.IsControllerEnabled("eventrule") {
+		eventRuleReconciler := &alerting.EventRuleReconciler{}
+		if notificationDispatchReconciler != nil {
+			eventRuleReconciler.Notifier = notificationDispatchReconciler
+		}
+		addControllerWithSetup(mgr, "eventrule", eventRuleReconciler)
+	}
+
+	// "strategypromotion" controller
+	if monitoringOptionsEnable && cmOptions.IsControllerEnabled("strategypromotion") {
+		monitoringClient, err := prometheus.NewPrometheus(cmOptions.MonitoringOptions)
+		if err != nil {
+			klog.Fatalf("Unable to create monitoring client for strategy promotion: %v", err)
+		}
+		strategyPromotionReconciler := &strategypromotion.Reconciler{Monitoring: monitoringClient}
+		addControllerWithSetup(mgr, "strategypromotion", strategyPromotionReconciler)
+	}
+
+	// "rolloutstrategy" controller
+	if cmOptions.IsControllerEnabled("rolloutstrategy") {
+		rolloutStrategyReconciler := &rollout.Reconciler{}
+		addControllerWithSetup(mgr, "rolloutstrategy", rolloutStrategyReconciler)
+	}
+
 	// "fedglobalrolecache" controller
 	var fedGlobalRoleCache cache.Store
 	var fedGlobalRoleCacheController cache.Controller
@@ -484,6 +630,15 @@ func addAllControllers(mgr manager.Manager, client k8s.Client, informerFactory i
 		addController(mgr, "group", groupController)
 	}
 
+	// "accessrequest" controller
+	if cmOptions.IsControllerEnabled("accessrequest") {
+		accessRequestReconciler := &accessrequest.Reconciler{}
+		if notificationDispatchReconciler != nil {
+			accessRequestReconciler.Notifier = notificationDispatchReconciler
+		}
+		addControllerWithSetup(mgr, "accessrequest", accessRequestReconciler)
+	}
+
 	// "cluster" controller
 	if cmOptions.IsControllerEnabled("cluster") {
 		if cmOptions.MultiClusterOptions.Enable {
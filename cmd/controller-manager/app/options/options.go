@@ -40,12 +40,15 @@ import (
 
 	"kubesphere.io/kubesphere/pkg/simple/client/devops/jenkins"
 	"kubesphere.io/kubesphere/pkg/simple/client/gateway"
+	"kubesphere.io/kubesphere/pkg/simple/client/gitops"
 	"kubesphere.io/kubesphere/pkg/simple/client/k8s"
+	"kubesphere.io/kubesphere/pkg/server/debug"
 	ldapclient "kubesphere.io/kubesphere/pkg/simple/client/ldap"
 	"kubesphere.io/kubesphere/pkg/simple/client/multicluster"
 	"kubesphere.io/kubesphere/pkg/simple/client/network"
 	"kubesphere.io/kubesphere/pkg/simple/client/openpitrix"
 	"kubesphere.io/kubesphere/pkg/simple/client/s3"
+	"kubesphere.io/kubesphere/pkg/simple/client/secretstore"
 	"kubesphere.io/kubesphere/pkg/simple/client/servicemesh"
 )
 
@@ -62,6 +65,8 @@ type KubeSphereControllerManagerOptions struct {
 	GatewayOptions        *gateway.Options
 	MonitoringOptions     *prometheus.Options
 	AlertingOptions       *alerting.Options
+	SecretStoreOptions    *secretstore.Options
+	GitOpsOptions         *gitops.Options
 	LeaderElect           bool
 	LeaderElection        *leaderelection.LeaderElectionConfig
 	WebhookCertDir        string
@@ -87,6 +92,9 @@ type KubeSphereControllerManagerOptions struct {
 
 	// Enable gops or not.
 	GOPSEnabled bool
+
+	// DebugOptions configures the optional pprof/expvar debug server.
+	DebugOptions *debug.Options
 }
 
 func NewKubeSphereControllerManagerOptions() *KubeSphereControllerManagerOptions {
@@ -102,6 +110,9 @@ func NewKubeSphereControllerManagerOptions() *KubeSphereControllerManagerOptions
 		AuthenticationOptions: authentication.NewOptions(),
 		GatewayOptions:        gateway.NewGatewayOptions(),
 		AlertingOptions:       alerting.NewAlertingOptions(),
+		SecretStoreOptions:    secretstore.NewSecretStoreOptions(),
+		GitOpsOptions:         gitops.NewGitOpsOptions(),
+		DebugOptions:          debug.NewOptions(),
 		LeaderElection: &leaderelection.LeaderElectionConfig{
 			LeaseDuration: 30 * time.Second,
 			RenewDeadline: 15 * time.Second,
@@ -130,6 +141,8 @@ func (s *KubeSphereControllerManagerOptions) Flags(allControllerNameSelectors []
 	s.ServiceMeshOptions.AddFlags(fss.FlagSet("servicemesh"), s.ServiceMeshOptions)
 	s.GatewayOptions.AddFlags(fss.FlagSet("gateway"), s.GatewayOptions)
 	s.AlertingOptions.AddFlags(fss.FlagSet("alerting"), s.AlertingOptions)
+	s.SecretStoreOptions.AddFlags(fss.FlagSet("secretstore"), s.SecretStoreOptions)
+	s.GitOpsOptions.AddFlags(fss.FlagSet("gitops"), s.GitOpsOptions)
 	fs := fss.FlagSet("leaderelection")
 	s.bindLeaderElectionFlags(s.LeaderElection, fs)
 
@@ -153,6 +166,7 @@ func (s *KubeSphereControllerManagerOptions) Flags(allControllerNameSelectors []
 
 	gfs.BoolVar(&s.GOPSEnabled, "gops", s.GOPSEnabled, "Whether to enable gops or not.  When enabled this option, "+
 		"controller-manager will listen on a random port on 127.0.0.1, then you can use the gops tool to list and diagnose the controller-manager currently running.")
+	s.DebugOptions.AddFlags(gfs, s.DebugOptions)
 
 	kfs := fss.FlagSet("klog")
 	local := flag.NewFlagSet("klog", flag.ExitOnError)
@@ -176,6 +190,8 @@ func (o *KubeSphereControllerManagerOptions) Validate(allControllerNameSelectors
 	errs = append(errs, o.LdapOptions.Validate()...)
 	errs = append(errs, o.MultiClusterOptions.Validate()...)
 	errs = append(errs, o.AlertingOptions.Validate()...)
+	errs = append(errs, o.SecretStoreOptions.Validate()...)
+	errs = append(errs, o.GitOpsOptions.Validate()...)
 
 	// genetic option: application-selector
 	if len(o.ApplicationSelector) != 0 {
@@ -247,4 +263,9 @@ func (s *KubeSphereControllerManagerOptions) MergeConfig(cfg *controllerconfig.C
 	s.MultiClusterOptions = cfg.MultiClusterOptions
 	s.ServiceMeshOptions = cfg.ServiceMeshOptions
 	s.GatewayOptions = cfg.GatewayOptions
+	s.SecretStoreOptions = cfg.SecretStoreOptions
+	s.GitOpsOptions = cfg.GitOpsOptions
+	if cfg.DebugOptions != nil {
+		s.DebugOptions = cfg.DebugOptions
+	}
 }
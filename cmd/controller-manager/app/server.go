@@ -40,10 +40,14 @@ import (
 	"kubesphere.io/kubesphere/pkg/apis"
 	controllerconfig "kubesphere.io/kubesphere/pkg/apiserver/config"
 	"kubesphere.io/kubesphere/pkg/controller/cluster"
+	"kubesphere.io/kubesphere/pkg/controller/deletionprotection"
+	"kubesphere.io/kubesphere/pkg/controller/imagepolicy"
 	"kubesphere.io/kubesphere/pkg/controller/network/webhooks"
 	"kubesphere.io/kubesphere/pkg/controller/quota"
+	"kubesphere.io/kubesphere/pkg/controller/storageversionmigration"
 	"kubesphere.io/kubesphere/pkg/controller/user"
 	"kubesphere.io/kubesphere/pkg/informers"
+	"kubesphere.io/kubesphere/pkg/server/debug"
 	"kubesphere.io/kubesphere/pkg/simple/client/k8s"
 	"kubesphere.io/kubesphere/pkg/simple/client/s3"
 	"kubesphere.io/kubesphere/pkg/utils/metrics"
@@ -86,6 +90,17 @@ func NewControllerManagerCommand() *cobra.Command {
 				os.Exit(1)
 			}
 
+			ctx := signals.SetupSignalHandler()
+
+			if s.DebugOptions != nil && s.DebugOptions.Enabled {
+				// the controller manager has no authentication stack, the
+				// debug endpoints must stay on a loopback address.
+				if !s.DebugOptions.IsLoopback() {
+					klog.Fatalf("debug server of ks-controller-manager must bind to a loopback address, got %s", s.DebugOptions.BindAddress)
+				}
+				go debug.RunServer(s.DebugOptions, debug.NewHandler(), ctx.Done())
+			}
+
 			if s.GOPSEnabled {
 				// Add agent to report additional information such as the current stack trace, Go version, memory stats, etc.
 				// Bind to a random port on address 127.0.0.1
@@ -94,7 +109,7 @@ func NewControllerManagerCommand() *cobra.Command {
 				}
 			}
 
-			if err = Run(s, controllerconfig.WatchConfigChange(), signals.SetupSignalHandler()); err != nil {
+			if err = Run(s, controllerconfig.WatchConfigChange(), ctx); err != nil {
 				klog.Error(err)
 				os.Exit(1)
 			}
@@ -125,10 +140,54 @@ func NewControllerManagerCommand() *cobra.Command {
 	}
 
 	cmd.AddCommand(versionCmd)
+	cmd.AddCommand(newMigrateCommand(s))
 
 	return cmd
 }
 
+// newMigrateCommand runs the CRD storage version migration once and exits,
+// for clusters where the long-running migration controller is disabled.
+func newMigrateCommand(s *options.KubeSphereControllerManagerOptions) *cobra.Command {
+	var crdNames []string
+
+	migrateCmd := &cobra.Command{
+		Use:   "migrate",
+		Short: "Migrate stored objects of KubeSphere CRDs to their current storage version",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			kubernetesClient, err := k8s.NewKubernetesClient(s.KubernetesOptions)
+			if err != nil {
+				return fmt.Errorf("failed to create kubernetes clientset: %v", err)
+			}
+
+			migrator, err := storageversionmigration.NewMigrator(kubernetesClient.Config(), kubernetesClient.ApiExtensions())
+			if err != nil {
+				return err
+			}
+
+			ctx := signals.SetupSignalHandler()
+			if len(crdNames) == 0 {
+				return migrator.MigrateAll(ctx)
+			}
+
+			for _, name := range crdNames {
+				crd, err := kubernetesClient.ApiExtensions().ApiextensionsV1().CustomResourceDefinitions().Get(ctx, name, metav1.GetOptions{})
+				if err != nil {
+					return err
+				}
+				if err := migrator.Migrate(ctx, crd); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+		SilenceUsage: true,
+	}
+
+	migrateCmd.Flags().StringSliceVar(&crdNames, "crd", nil, "Names of the CRDs to migrate, all KubeSphere CRDs when empty.")
+
+	return migrateCmd
+}
+
 func Run(s *options.KubeSphereControllerManagerOptions, configCh <-chan controllerconfig.Config, ctx context.Context) error {
 	ictx, cancelFunc := context.WithCancel(context.TODO())
 	errCh := make(chan error)
@@ -252,6 +311,16 @@ func run(s *options.KubeSphereControllerManagerOptions, ctx context.Context) err
 	}
 	hookServer.Register("/validate-quota-kubesphere-io-v1alpha2", &webhook.Admission{Handler: resourceQuotaAdmission})
 
+	devopsQuotaAdmission, err := quota.NewDevOpsQuotaAdmission(mgr.GetClient(), mgr.GetScheme())
+	if err != nil {
+		klog.Fatalf("unable to create devops quota admission: %v", err)
+	}
+	hookServer.Register("/validate-devops-quota-kubesphere-io", &webhook.Admission{Handler: devopsQuotaAdmission})
+
+	hookServer.Register("/validate-deletion-protection-kubesphere-io", &webhook.Admission{Handler: &deletionprotection.ValidatingHandler{Client: mgr.GetClient()}})
+
+	hookServer.Register("/validate-security-kubesphere-io-v1alpha1-imagepolicy", &webhook.Admission{Handler: &imagepolicy.ValidatingHandler{Client: mgr.GetClient()}})
+
 	hookServer.Register("/convert", &conversion.Webhook{})
 
 	rulegroup := alertingv2beta1.RuleGroup{}
@@ -0,0 +1,87 @@
+/*
+Copyright 2022 The KubeSphere Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+)
+
+// Error is the structured body every error response of the native APIs
+// carries, so clients branch on the machine readable reason instead of
+// parsing messages.
+type Error struct {
+	Code    int    `json:"code"`
+	Reason  string `json:"reason"`
+	Message string `json:"message"`
+	// Details carries field-level causes when the underlying error
+	// provides them, e.g. the causes of a kubernetes status.
+	Details []string `json:"details,omitempty"`
+	// Retryable hints whether repeating the same request may succeed.
+	Retryable bool `json:"retryable"`
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// NewError wraps the error into the structured form of the given status
+// code. Kubernetes status errors keep their reason and contribute their
+// causes as details.
+func NewError(code int, err error) *Error {
+	structured := &Error{
+		Code:      code,
+		Reason:    reasonForCode(code),
+		Message:   err.Error(),
+		Retryable: retryable(code),
+	}
+	if status, ok := err.(errors.APIStatus); ok {
+		if reason := string(status.Status().Reason); reason != "" {
+			structured.Reason = reason
+		}
+		if details := status.Status().Details; details != nil {
+			for _, cause := range details.Causes {
+				if cause.Field != "" {
+					structured.Details = append(structured.Details, fmt.Sprintf("%s: %s", cause.Field, cause.Message))
+				} else if cause.Message != "" {
+					structured.Details = append(structured.Details, cause.Message)
+				}
+			}
+		}
+	}
+	return structured
+}
+
+// reasonForCode turns the status text into a camel cased reason, e.g.
+// 404 becomes NotFound.
+func reasonForCode(code int) string {
+	text := http.StatusText(code)
+	if text == "" {
+		return "Unknown"
+	}
+	return strings.ReplaceAll(text, " ", "")
+}
+
+// retryable marks throttling and server side failures, a request failing
+// on its own content will keep failing.
+func retryable(code int) bool {
+	return code == http.StatusTooManyRequests ||
+		(code >= http.StatusInternalServerError && code != http.StatusNotImplemented)
+}
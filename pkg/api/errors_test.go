@@ -0,0 +1,47 @@
+/*
+Copyright 2022 The KubeSphere Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestNewError(t *testing.T) {
+	plain := NewError(http.StatusBadRequest, fmt.Errorf("name must not be empty"))
+	if plain.Reason != "BadRequest" {
+		t.Errorf("expected reason BadRequest, got %s", plain.Reason)
+	}
+	if plain.Retryable {
+		t.Error("expected a bad request not to be retryable")
+	}
+
+	throttled := NewError(http.StatusTooManyRequests, fmt.Errorf("slow down"))
+	if !throttled.Retryable {
+		t.Error("expected too many requests to be retryable")
+	}
+
+	notFound := errors.NewNotFound(schema.GroupResource{Group: "iam.kubesphere.io", Resource: "users"}, "admin")
+	status := NewError(http.StatusNotFound, notFound)
+	if status.Reason != string(errors.ReasonForError(notFound)) {
+		t.Errorf("expected the kubernetes status reason to be kept, got %s", status.Reason)
+	}
+}
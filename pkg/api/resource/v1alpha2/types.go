@@ -27,6 +27,23 @@ type ComponentStatus struct {
 	StartedAt       time.Time   `json:"startedAt" description:"started time"`
 	TotalBackends   int         `json:"totalBackends" description:"the total replicas of each backend system component"`
 	HealthyBackends int         `json:"healthyBackends" description:"the number of healthy backend components"`
+
+	// version reported by the backends, taken from the pod version label or the image tag
+	Version string `json:"version,omitempty" description:"component version"`
+
+	// accumulated container restarts across all backends
+	RestartCount int32 `json:"restartCount" description:"total container restarts of the backends"`
+
+	// the time of the most recent backend container restart, zero if none restarted
+	LatestRestartTime time.Time `json:"latestRestartTime,omitempty" description:"the time of the most recent backend restart"`
+}
+
+// ClusterAgentStatus reports the connectivity of a member cluster agent.
+type ClusterAgentStatus struct {
+	Cluster            string    `json:"cluster" description:"member cluster name"`
+	Ready              bool      `json:"ready" description:"whether the member cluster is ready"`
+	KubernetesVersion  string    `json:"kubernetesVersion,omitempty" description:"kubernetes version of the member cluster"`
+	LastTransitionTime time.Time `json:"lastTransitionTime,omitempty" description:"last time the ready condition changed"`
 }
 
 // NodeStatus assembles cluster nodes status, simply wrap unhealthy and total nodes.
@@ -40,6 +57,7 @@ type NodeStatus struct {
 
 //
 type HealthStatus struct {
-	KubeSphereComponents []ComponentStatus `json:"kubesphereStatus" description:"kubesphere components status"`
-	NodeStatus           NodeStatus        `json:"nodeStatus" description:"nodes status"`
+	KubeSphereComponents []ComponentStatus    `json:"kubesphereStatus" description:"kubesphere components status"`
+	NodeStatus           NodeStatus           `json:"nodeStatus" description:"nodes status"`
+	MemberClusterStatus  []ClusterAgentStatus `json:"memberClusterStatus,omitempty" description:"member cluster agents status"`
 }
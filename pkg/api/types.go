@@ -24,6 +24,10 @@ import (
 type ListResult struct {
 	Items      []interface{} `json:"items"`
 	TotalItems int           `json:"totalItems"`
+
+	// Continue is an opaque cursor resuming the list after the last
+	// returned item, only set when more items follow.
+	Continue string `json:"continue,omitempty"`
 }
 
 type ResourceQuota struct {
@@ -23,12 +23,23 @@ import (
 
 	"github.com/emicklei/go-restful"
 	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/klog"
 )
 
 // Avoid emitting errors that look like valid HTML. Quotes are okay.
 var sanitizer = strings.NewReplacer(`&`, "&amp;", `<`, "&lt;", `>`, "&gt;")
 
+// DryRun extracts the kubernetes style dryRun query parameter, the
+// returned slice feeds create and update options directly so validation
+// and admission run without persisting.
+func DryRun(req *restful.Request) []string {
+	if req.QueryParameter("dryRun") == metav1.DryRunAll {
+		return []string{metav1.DryRunAll}
+	}
+	return nil
+}
+
 func HandleInternalError(response *restful.Response, req *restful.Request, err error) {
 	handle(http.StatusInternalServerError, response, req, err)
 }
@@ -61,6 +72,8 @@ func HandleConflict(response *restful.Response, req *restful.Request, err error)
 func HandleError(response *restful.Response, req *restful.Request, err error) {
 	var statusCode int
 	switch t := err.(type) {
+	case *Error:
+		statusCode = t.Code
 	case errors.APIStatus:
 		statusCode = int(t.Status().Code)
 	case restful.ServiceError:
@@ -74,5 +87,15 @@ func HandleError(response *restful.Response, req *restful.Request, err error) {
 func handle(statusCode int, response *restful.Response, req *restful.Request, err error) {
 	_, fn, line, _ := runtime.Caller(2)
 	klog.Errorf("%s:%d %v", fn, line, err)
-	http.Error(response, sanitizer.Replace(err.Error()), statusCode)
+	structured, ok := err.(*Error)
+	if !ok {
+		structured = NewError(statusCode, err)
+	}
+	structured.Message = sanitizer.Replace(structured.Message)
+	for i := range structured.Details {
+		structured.Details[i] = sanitizer.Replace(structured.Details[i])
+	}
+	if err := response.WriteHeaderAndJson(statusCode, structured, restful.MIME_JSON); err != nil {
+		klog.Errorf("write error response: %s", err)
+	}
 }
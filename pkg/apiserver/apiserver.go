@@ -36,6 +36,7 @@ import (
 	unionauth "k8s.io/apiserver/pkg/authentication/request/union"
 	"k8s.io/apiserver/pkg/endpoints/handlers/responsewriters"
 	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/util/retry"
 	"k8s.io/klog"
 	clusterv1alpha1 "kubesphere.io/api/cluster/v1alpha1"
@@ -61,6 +62,7 @@ import (
 	"kubesphere.io/kubesphere/pkg/apiserver/authorization"
 	"kubesphere.io/kubesphere/pkg/apiserver/authorization/authorizer"
 	"kubesphere.io/kubesphere/pkg/apiserver/authorization/authorizerfactory"
+	"kubesphere.io/kubesphere/pkg/apiserver/authorization/cached"
 	"kubesphere.io/kubesphere/pkg/apiserver/authorization/path"
 	"kubesphere.io/kubesphere/pkg/apiserver/authorization/rbac"
 	unionauthorizer "kubesphere.io/kubesphere/pkg/apiserver/authorization/union"
@@ -68,6 +70,7 @@ import (
 	"kubesphere.io/kubesphere/pkg/apiserver/dispatch"
 	"kubesphere.io/kubesphere/pkg/apiserver/filters"
 	"kubesphere.io/kubesphere/pkg/apiserver/request"
+	"kubesphere.io/kubesphere/pkg/apiserver/validation"
 	"kubesphere.io/kubesphere/pkg/informers"
 	alertingv1 "kubesphere.io/kubesphere/pkg/kapis/alerting/v1"
 	alertingv2alpha1 "kubesphere.io/kubesphere/pkg/kapis/alerting/v2alpha1"
@@ -87,6 +90,7 @@ import (
 	openpitrixv1 "kubesphere.io/kubesphere/pkg/kapis/openpitrix/v1"
 	openpitrixv2alpha1 "kubesphere.io/kubesphere/pkg/kapis/openpitrix/v2alpha1"
 	operationsv1alpha2 "kubesphere.io/kubesphere/pkg/kapis/operations/v1alpha2"
+	"kubesphere.io/kubesphere/pkg/kapis/readyz"
 	resourcesv1alpha2 "kubesphere.io/kubesphere/pkg/kapis/resources/v1alpha2"
 	resourcev1alpha3 "kubesphere.io/kubesphere/pkg/kapis/resources/v1alpha3"
 	servicemeshv1alpha2 "kubesphere.io/kubesphere/pkg/kapis/servicemesh/metrics/v1alpha2"
@@ -95,6 +99,7 @@ import (
 	terminalv1alpha2 "kubesphere.io/kubesphere/pkg/kapis/terminal/v1alpha2"
 	"kubesphere.io/kubesphere/pkg/kapis/version"
 	"kubesphere.io/kubesphere/pkg/models/auth"
+	"kubesphere.io/kubesphere/pkg/models/iam/accessreview"
 	"kubesphere.io/kubesphere/pkg/models/iam/am"
 	"kubesphere.io/kubesphere/pkg/models/iam/group"
 	"kubesphere.io/kubesphere/pkg/models/iam/im"
@@ -103,6 +108,7 @@ import (
 	"kubesphere.io/kubesphere/pkg/models/resources/v1alpha3/user"
 	"kubesphere.io/kubesphere/pkg/simple/client/alerting"
 	"kubesphere.io/kubesphere/pkg/simple/client/auditing"
+	"kubesphere.io/kubesphere/pkg/server/debug"
 	"kubesphere.io/kubesphere/pkg/simple/client/cache"
 	"kubesphere.io/kubesphere/pkg/simple/client/devops"
 	"kubesphere.io/kubesphere/pkg/simple/client/events"
@@ -184,6 +190,9 @@ func (s *APIServer) PrepareRun(stopCh <-chan struct{}) error {
 	s.installCRDAPIs()
 	s.installMetricsAPI()
 	s.container.Filter(monitorRequest)
+	// reject malformed POST/PUT bodies on the native routes with
+	// field-level 400s before they reach the handlers
+	s.container.Filter(validation.NewBodyValidator(s.container.RegisteredWebServices()).Filter)
 
 	for _, ws := range s.container.RegisteredWebServices() {
 		klog.V(2).Infof("%s", ws.RootPath())
@@ -225,22 +234,28 @@ func (s *APIServer) installKubeSphereAPIs(stopCh <-chan struct{}) {
 		s.KubernetesClient.Kubernetes(),
 		s.InformerFactory,
 		s.DevopsClient)
-	rbacAuthorizer := rbac.NewRBACAuthorizer(amOperator)
+	rbacAuthorizer := cached.New(rbac.NewRBACAuthorizer(amOperator), cached.DefaultTTL, s.InformerFactory)
 
 	urlruntime.Must(configv1alpha2.AddToContainer(s.container, s.Config))
-	urlruntime.Must(resourcev1alpha3.AddToContainer(s.container, s.InformerFactory, s.RuntimeCache))
+	var listSnapshotCache cache.Interface
+	if s.Config.CacheOptions != nil && s.Config.CacheOptions.IsDistributed() {
+		listSnapshotCache = s.CacheClient
+	}
+	urlruntime.Must(resourcev1alpha3.AddToContainer(s.container, s.InformerFactory, s.RuntimeCache, listSnapshotCache,
+		dynamic.NewForConfigOrDie(s.KubernetesClient.Config()), rbacAuthorizer, s.MonitoringClient,
+		s.KubernetesClient.Kubernetes().Discovery()))
 	urlruntime.Must(monitoringv1alpha3.AddToContainer(s.container, s.KubernetesClient.Kubernetes(), s.MonitoringClient, s.MetricsClient, s.InformerFactory, s.OpenpitrixClient, s.RuntimeClient))
 	urlruntime.Must(meteringv1alpha1.AddToContainer(s.container, s.KubernetesClient.Kubernetes(), s.MonitoringClient, s.InformerFactory, s.RuntimeCache, s.Config.MeteringOptions, s.OpenpitrixClient, s.RuntimeClient))
 	urlruntime.Must(openpitrixv1.AddToContainer(s.container, s.InformerFactory, s.KubernetesClient.KubeSphere(), s.Config.OpenPitrixOptions, s.OpenpitrixClient))
 	urlruntime.Must(openpitrixv2alpha1.AddToContainer(s.container, s.InformerFactory, s.KubernetesClient.KubeSphere(), s.Config.OpenPitrixOptions))
 	urlruntime.Must(operationsv1alpha2.AddToContainer(s.container, s.KubernetesClient.Kubernetes()))
 	urlruntime.Must(resourcesv1alpha2.AddToContainer(s.container, s.KubernetesClient.Kubernetes(), s.InformerFactory,
-		s.KubernetesClient.Master()))
+		s.KubernetesClient.Master(), s.Config.AuthenticationOptions.OAuthOptions))
 	urlruntime.Must(tenantv1alpha2.AddToContainer(s.container, s.InformerFactory, s.KubernetesClient.Kubernetes(),
 		s.KubernetesClient.KubeSphere(), s.EventsClient, s.LoggingClient, s.AuditingClient, amOperator, imOperator, rbacAuthorizer, s.MonitoringClient, s.RuntimeCache, s.Config.MeteringOptions, s.OpenpitrixClient))
 	urlruntime.Must(tenantv1alpha3.AddToContainer(s.container, s.InformerFactory, s.KubernetesClient.Kubernetes(),
 		s.KubernetesClient.KubeSphere(), s.EventsClient, s.LoggingClient, s.AuditingClient, amOperator, imOperator, rbacAuthorizer, s.MonitoringClient, s.RuntimeCache, s.Config.MeteringOptions, s.OpenpitrixClient))
-	urlruntime.Must(terminalv1alpha2.AddToContainer(s.container, s.KubernetesClient.Kubernetes(), rbacAuthorizer, s.KubernetesClient.Config(), s.Config.TerminalOptions))
+	urlruntime.Must(terminalv1alpha2.AddToContainer(s.container, s.KubernetesClient.Kubernetes(), rbacAuthorizer, s.KubernetesClient.Config(), s.Config.TerminalOptions, s.Config.AuthenticationOptions.KubectlOptions))
 	urlruntime.Must(clusterkapisv1alpha1.AddToContainer(s.container,
 		s.KubernetesClient.KubeSphere(),
 		s.InformerFactory.KubernetesSharedInformerFactory(),
@@ -250,7 +265,7 @@ func (s *APIServer) installKubeSphereAPIs(stopCh <-chan struct{}) {
 		s.Config.MultiClusterOptions.AgentImage))
 	urlruntime.Must(iamapi.AddToContainer(s.container, imOperator, amOperator,
 		group.New(s.InformerFactory, s.KubernetesClient.KubeSphere(), s.KubernetesClient.Kubernetes()),
-		rbacAuthorizer))
+		rbacAuthorizer, accessreview.NewReviewer(amOperator, s.AuditingClient), s.RuntimeClient))
 
 	userLister := s.InformerFactory.KubeSphereSharedInformerFactory().Iam().V1alpha2().Users().Lister()
 	urlruntime.Must(oauth.AddToContainer(s.container, imOperator,
@@ -258,6 +273,8 @@ func (s *APIServer) installKubeSphereAPIs(stopCh <-chan struct{}) {
 		auth.NewPasswordAuthenticator(s.KubernetesClient.KubeSphere(), userLister, s.Config.AuthenticationOptions),
 		auth.NewOAuthAuthenticator(s.KubernetesClient.KubeSphere(), userLister, s.Config.AuthenticationOptions),
 		auth.NewLoginRecorder(s.KubernetesClient.KubeSphere(), userLister),
+		auth.NewLoginChallenger(s.InformerFactory.KubeSphereSharedInformerFactory().Iam().V1alpha2().LoginRecords().Lister(),
+			s.Config.AuthenticationOptions),
 		s.Config.AuthenticationOptions))
 	urlruntime.Must(servicemeshv1alpha2.AddToContainer(s.Config.ServiceMeshOptions, s.container, s.KubernetesClient.Kubernetes(), s.CacheClient))
 	urlruntime.Must(networkv1alpha2.AddToContainer(s.container, s.Config.NetworkOptions.WeaveScopeHost))
@@ -267,6 +284,7 @@ func (s *APIServer) installKubeSphereAPIs(stopCh <-chan struct{}) {
 		s.KubernetesClient.Prometheus(), s.AlertingClient, s.Config.AlertingOptions))
 	urlruntime.Must(alertingv2beta1.AddToContainer(s.container, s.InformerFactory, s.AlertingClient))
 	urlruntime.Must(version.AddToContainer(s.container, s.KubernetesClient.Kubernetes().Discovery()))
+	urlruntime.Must(readyz.AddToContainer(s.container))
 	urlruntime.Must(kubeedgev1alpha1.AddToContainer(s.container, s.Config.KubeEdgeOptions.Endpoint))
 	urlruntime.Must(edgeruntimev1alpha1.AddToContainer(s.container, s.Config.EdgeRuntimeOptions.Endpoint))
 	if s.Config.NotificationOptions.IsEnabled() {
@@ -274,7 +292,7 @@ func (s *APIServer) installKubeSphereAPIs(stopCh <-chan struct{}) {
 		urlruntime.Must(notificationkapisv2beta1.AddToContainer(s.container, s.InformerFactory, s.KubernetesClient.Kubernetes(),
 			s.KubernetesClient.KubeSphere()))
 		urlruntime.Must(notificationkapisv2beta2.AddToContainer(s.container, s.InformerFactory, s.KubernetesClient.Kubernetes(),
-			s.KubernetesClient.KubeSphere(), s.Config.NotificationOptions))
+			s.KubernetesClient.KubeSphere(), s.RuntimeClient, s.Config.NotificationOptions))
 	}
 	urlruntime.Must(gatewayv1alpha1.AddToContainer(s.container, s.Config.GatewayOptions, s.RuntimeCache, s.RuntimeClient, s.InformerFactory, s.KubernetesClient.Kubernetes(), s.LoggingClient))
 }
@@ -357,10 +375,11 @@ func (s *APIServer) buildHandlerChain(stopCh <-chan struct{}) {
 	default:
 		fallthrough
 	case authorization.RBAC:
-		excludedPaths := []string{"/oauth/*", "/kapis/config.kubesphere.io/*", "/kapis/version", "/kapis/metrics"}
+		excludedPaths := []string{"/oauth/*", "/kapis/config.kubesphere.io/*", "/kapis/version", "/kapis/metrics", "/kapis/readyz"}
 		pathAuthorizer, _ := path.NewAuthorizer(excludedPaths)
 		amOperator := am.NewReadOnlyOperator(s.InformerFactory, s.DevopsClient)
-		authorizers = unionauthorizer.New(pathAuthorizer, rbac.NewRBACAuthorizer(amOperator))
+		authorizers = unionauthorizer.New(pathAuthorizer,
+			cached.New(rbac.NewRBACAuthorizer(amOperator), cached.DefaultTTL, s.InformerFactory))
 	}
 
 	handler = filters.WithAuthorization(handler, authorizers)
@@ -386,6 +405,16 @@ func (s *APIServer) buildHandlerChain(stopCh <-chan struct{}) {
 	handler = filters.WithRequestInfo(handler, requestInfoResolver)
 
 	s.Server.Handler = handler
+
+	// serve the pprof/expvar endpoints on a dedicated listener, protected by
+	// the same authentication and RBAC chain as the API so only users granted
+	// non-resource URL access (e.g. platform admins) can reach them.
+	if s.Config.DebugOptions != nil && s.Config.DebugOptions.Enabled {
+		debugHandler := filters.WithAuthorization(debug.NewHandler(), authorizers)
+		debugHandler = filters.WithAuthentication(debugHandler, authn)
+		debugHandler = filters.WithRequestInfo(debugHandler, requestInfoResolver)
+		go debug.RunServer(s.Config.DebugOptions, debugHandler, stopCh)
+	}
 }
 
 func isResourceExists(apiResources []v1.APIResource, resource schema.GroupVersionResource) bool {
@@ -450,6 +479,11 @@ func (s *APIServer) waitForResourceSync(ctx context.Context) error {
 			"secrets",
 			"configmaps",
 			"serviceaccounts",
+			"events",
+			"endpoints",
+		},
+		{Group: "discovery.k8s.io", Version: "v1"}: {
+			"endpointslices",
 		},
 		{Group: "rbac.authorization.k8s.io", Version: "v1"}: {
 			"roles",
@@ -482,6 +516,21 @@ func (s *APIServer) waitForResourceSync(ctx context.Context) error {
 		},
 	}
 
+	// strip managedFields and the last applied configuration annotation
+	// from the heavy kinds, both dominate informer memory on large
+	// clusters; the trimming informers must be registered before the
+	// resources are first requested from the shared factory
+	informers.TrimCachedObjects(s.InformerFactory,
+		schema.GroupVersionResource{Group: "", Version: "v1", Resource: "pods"},
+		schema.GroupVersionResource{Group: "", Version: "v1", Resource: "configmaps"},
+		schema.GroupVersionResource{Group: "", Version: "v1", Resource: "secrets"},
+		schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "deployments"},
+		schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "daemonsets"},
+		schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "statefulsets"},
+		schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "replicasets"},
+		schema.GroupVersionResource{Group: "batch", Version: "v1", Resource: "jobs"},
+	)
+
 	if err := waitForCacheSync(s.KubernetesClient.Kubernetes().Discovery(),
 		s.InformerFactory.KubernetesSharedInformerFactory(),
 		func(resource schema.GroupVersionResource) (interface{}, error) {
@@ -551,20 +600,13 @@ func (s *APIServer) waitForResourceSync(ctx context.Context) error {
 		}
 	}
 
-	// federated resources on cached in multi cluster setup
+	// the namespaced federated kinds are served by lazy getters whose
+	// informers are registered, started and synced on first access, only
+	// the cluster scoped federated rbac kinds are cached up front
 	if s.Config.MultiClusterOptions.Enable {
 		ksGVRs[typesv1beta1.SchemeGroupVersion] = []string{
 			typesv1beta1.ResourcePluralFederatedClusterRole,
 			typesv1beta1.ResourcePluralFederatedClusterRoleBindingBinding,
-			typesv1beta1.ResourcePluralFederatedNamespace,
-			typesv1beta1.ResourcePluralFederatedService,
-			typesv1beta1.ResourcePluralFederatedDeployment,
-			typesv1beta1.ResourcePluralFederatedSecret,
-			typesv1beta1.ResourcePluralFederatedConfigmap,
-			typesv1beta1.ResourcePluralFederatedStatefulSet,
-			typesv1beta1.ResourcePluralFederatedIngress,
-			typesv1beta1.ResourcePluralFederatedPersistentVolumeClaim,
-			typesv1beta1.ResourcePluralFederatedApplication,
 		}
 	}
 
@@ -0,0 +1,255 @@
+/*
+Copyright 2022 KubeSphere Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package auditing
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"k8s.io/klog"
+
+	auditv1alpha1 "kubesphere.io/kubesphere/pkg/apiserver/auditing/v1alpha1"
+	"kubesphere.io/kubesphere/pkg/apiserver/request"
+)
+
+const (
+	// AnomalyRuleAnnotation names the rule an event tripped, set on the
+	// audit event before it is shipped to the backend so downstream
+	// consumers can alert on it.
+	AnomalyRuleAnnotation = "auditing.kubesphere.io/anomaly-rule"
+	// AnomalySeverityAnnotation is the severity of the anomaly.
+	AnomalySeverityAnnotation = "auditing.kubesphere.io/anomaly-severity"
+	// AnomalyMessageAnnotation describes why the event was flagged.
+	AnomalyMessageAnnotation = "auditing.kubesphere.io/anomaly-message"
+
+	SeverityWarning  = "warning"
+	SeverityCritical = "critical"
+
+	// bulkDeletionThreshold deletions within bulkDeletionWindow by the
+	// same user are flagged as a bulk deletion.
+	bulkDeletionThreshold = 10
+	bulkDeletionWindow    = time.Minute
+
+	// Mutations of cluster scoped resources outside the
+	// [offHoursEnd, offHoursStart) working hours are flagged.
+	offHoursStart = 22
+	offHoursEnd   = 6
+)
+
+// Anomaly is a suspicious pattern an analyzer found in the audit stream.
+type Anomaly struct {
+	// Rule that flagged the event.
+	Rule string `json:"rule"`
+	// Severity of the anomaly, warning or critical.
+	Severity string `json:"severity"`
+	// Message explains why the event was flagged.
+	Message string `json:"message"`
+}
+
+// Analyzer inspects audit events for suspicious activity before they are
+// shipped to the backend. Analyzers run inline on the request path, they
+// must be fast and must not block.
+type Analyzer interface {
+	// Name of the rule the analyzer evaluates.
+	Name() string
+	// Analyze returns an anomaly when the event looks suspicious, nil
+	// otherwise.
+	Analyze(e *auditv1alpha1.Event) *Anomaly
+}
+
+func defaultAnalyzers() []Analyzer {
+	return []Analyzer{
+		newBulkDeletionAnalyzer(bulkDeletionThreshold, bulkDeletionWindow, time.Now),
+		&roleEscalationAnalyzer{},
+		&offHoursAnalyzer{start: offHoursStart, end: offHoursEnd, now: time.Now},
+	}
+}
+
+// analyze runs the analyzers on the event and records anomalies in its
+// annotations, the first match wins.
+func (a *auditing) analyze(e *auditv1alpha1.Event) {
+	if e.ObjectRef == nil {
+		return
+	}
+	// Failed requests did not change anything.
+	if e.ResponseStatus != nil && e.ResponseStatus.Code >= 400 {
+		return
+	}
+
+	for _, analyzer := range a.analyzers {
+		anomaly := analyzer.Analyze(e)
+		if anomaly == nil {
+			continue
+		}
+		if e.Annotations == nil {
+			e.Annotations = make(map[string]string)
+		}
+		e.Annotations[AnomalyRuleAnnotation] = anomaly.Rule
+		e.Annotations[AnomalySeverityAnnotation] = anomaly.Severity
+		e.Annotations[AnomalyMessageAnnotation] = anomaly.Message
+		klog.Warningf("audit anomaly detected, rule %s, user %s: %s", anomaly.Rule, e.User.Username, anomaly.Message)
+		return
+	}
+}
+
+// bulkDeletionAnalyzer flags users deleting many resources in a short
+// window, e.g. a compromised account wiping a namespace.
+type bulkDeletionAnalyzer struct {
+	mutex     sync.Mutex
+	threshold int
+	window    time.Duration
+	now       func() time.Time
+	deletions map[string][]time.Time
+}
+
+func newBulkDeletionAnalyzer(threshold int, window time.Duration, now func() time.Time) *bulkDeletionAnalyzer {
+	return &bulkDeletionAnalyzer{
+		threshold: threshold,
+		window:    window,
+		now:       now,
+		deletions: make(map[string][]time.Time),
+	}
+}
+
+func (b *bulkDeletionAnalyzer) Name() string {
+	return "bulk-deletion"
+}
+
+func (b *bulkDeletionAnalyzer) Analyze(e *auditv1alpha1.Event) *Anomaly {
+	if e.Verb != "delete" {
+		return nil
+	}
+
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	now := b.now()
+	recent := make([]time.Time, 0, b.threshold)
+	for _, t := range b.deletions[e.User.Username] {
+		if now.Sub(t) < b.window {
+			recent = append(recent, t)
+		}
+	}
+	recent = append(recent, now)
+	b.deletions[e.User.Username] = recent
+
+	if len(recent) < b.threshold {
+		return nil
+	}
+	return &Anomaly{
+		Rule:     b.Name(),
+		Severity: SeverityCritical,
+		Message:  fmt.Sprintf("user %s deleted %d resources within %s", e.User.Username, len(recent), b.window),
+	}
+}
+
+// roleEscalationAnalyzer flags writes that grant platform or cluster wide
+// roles, parsing the request body for the granted role when available.
+type roleEscalationAnalyzer struct{}
+
+var escalationResources = map[string]bool{
+	"globalroles":           true,
+	"globalrolebindings":    true,
+	"clusterroles":          true,
+	"clusterrolebindings":   true,
+	"workspaceroles":        true,
+	"workspacerolebindings": true,
+}
+
+func (r *roleEscalationAnalyzer) Name() string {
+	return "role-escalation"
+}
+
+func (r *roleEscalationAnalyzer) Analyze(e *auditv1alpha1.Event) *Anomaly {
+	if e.Verb != "create" && e.Verb != "update" && e.Verb != "patch" {
+		return nil
+	}
+	if !escalationResources[e.ObjectRef.Resource] {
+		return nil
+	}
+
+	role := grantedRole(e)
+	message := fmt.Sprintf("user %s changed %s/%s", e.User.Username, e.ObjectRef.Resource, e.ObjectRef.Name)
+	severity := SeverityWarning
+	if role != "" {
+		message = fmt.Sprintf("%s granting role %s", message, role)
+		if strings.Contains(role, "admin") {
+			severity = SeverityCritical
+		}
+	}
+	return &Anomaly{
+		Rule:     r.Name(),
+		Severity: severity,
+		Message:  message,
+	}
+}
+
+// grantedRole extracts the granted role from the request body of a role
+// binding write, empty when the body is absent or not a binding.
+func grantedRole(e *auditv1alpha1.Event) string {
+	if e.RequestObject == nil || len(e.RequestObject.Raw) == 0 {
+		return ""
+	}
+
+	binding := struct {
+		RoleRef struct {
+			Name string `json:"name"`
+		} `json:"roleRef"`
+	}{}
+	if err := json.Unmarshal(e.RequestObject.Raw, &binding); err != nil {
+		return ""
+	}
+	return binding.RoleRef.Name
+}
+
+// offHoursAnalyzer flags mutations of cluster scoped resources outside
+// working hours, when admin activity is unexpected.
+type offHoursAnalyzer struct {
+	start int
+	end   int
+	now   func() time.Time
+}
+
+func (o *offHoursAnalyzer) Name() string {
+	return "off-hours-admin-action"
+}
+
+func (o *offHoursAnalyzer) Analyze(e *auditv1alpha1.Event) *Anomaly {
+	switch e.Verb {
+	case "create", "update", "patch", "delete":
+	default:
+		return nil
+	}
+	// LogRequestObject stores the resource scope in ResourceVersion.
+	if e.ObjectRef.ResourceVersion != request.ClusterScope {
+		return nil
+	}
+
+	hour := o.now().Hour()
+	if hour < o.start && hour >= o.end {
+		return nil
+	}
+	return &Anomaly{
+		Rule:     o.Name(),
+		Severity: SeverityWarning,
+		Message: fmt.Sprintf("user %s ran %s on cluster scoped %s/%s outside working hours",
+			e.User.Username, e.Verb, e.ObjectRef.Resource, e.ObjectRef.Name),
+	}
+}
@@ -0,0 +1,119 @@
+/*
+Copyright 2022 KubeSphere Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package auditing
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apiserver/pkg/apis/audit"
+
+	auditv1alpha1 "kubesphere.io/kubesphere/pkg/apiserver/auditing/v1alpha1"
+	"kubesphere.io/kubesphere/pkg/apiserver/request"
+)
+
+func newEvent(username, verb, resource string) *auditv1alpha1.Event {
+	e := &auditv1alpha1.Event{
+		Event: audit.Event{
+			Verb: verb,
+			ObjectRef: &audit.ObjectReference{
+				Resource: resource,
+				Name:     "foo",
+			},
+		},
+	}
+	e.User.Username = username
+	return e
+}
+
+func TestBulkDeletionAnalyzer(t *testing.T) {
+	now := time.Now()
+	analyzer := newBulkDeletionAnalyzer(3, time.Minute, func() time.Time { return now })
+
+	e := newEvent("admin", "delete", "deployments")
+	assert.Nil(t, analyzer.Analyze(e))
+	assert.Nil(t, analyzer.Analyze(e))
+
+	anomaly := analyzer.Analyze(e)
+	if assert.NotNil(t, anomaly) {
+		assert.Equal(t, "bulk-deletion", anomaly.Rule)
+		assert.Equal(t, SeverityCritical, anomaly.Severity)
+	}
+
+	// Deletions of another user are counted separately.
+	assert.Nil(t, analyzer.Analyze(newEvent("bob", "delete", "deployments")))
+
+	// Deletions outside the window are forgotten.
+	now = now.Add(2 * time.Minute)
+	assert.Nil(t, analyzer.Analyze(e))
+}
+
+func TestRoleEscalationAnalyzer(t *testing.T) {
+	analyzer := &roleEscalationAnalyzer{}
+
+	assert.Nil(t, analyzer.Analyze(newEvent("admin", "create", "deployments")))
+	assert.Nil(t, analyzer.Analyze(newEvent("admin", "list", "globalrolebindings")))
+
+	e := newEvent("admin", "create", "globalrolebindings")
+	e.RequestObject = &runtime.Unknown{Raw: []byte(`{"roleRef":{"name":"platform-admin"}}`)}
+	anomaly := analyzer.Analyze(e)
+	if assert.NotNil(t, anomaly) {
+		assert.Equal(t, "role-escalation", anomaly.Rule)
+		assert.Equal(t, SeverityCritical, anomaly.Severity)
+	}
+
+	e = newEvent("admin", "update", "workspacerolebindings")
+	anomaly = analyzer.Analyze(e)
+	if assert.NotNil(t, anomaly) {
+		assert.Equal(t, SeverityWarning, anomaly.Severity)
+	}
+}
+
+func TestOffHoursAnalyzer(t *testing.T) {
+	night := time.Date(2022, 1, 1, 23, 0, 0, 0, time.UTC)
+	analyzer := &offHoursAnalyzer{start: offHoursStart, end: offHoursEnd, now: func() time.Time { return night }}
+
+	e := newEvent("admin", "delete", "workspaces")
+	e.ObjectRef.ResourceVersion = request.ClusterScope
+
+	anomaly := analyzer.Analyze(e)
+	if assert.NotNil(t, anomaly) {
+		assert.Equal(t, "off-hours-admin-action", anomaly.Rule)
+	}
+
+	// Reads and namespaced resources are not flagged.
+	assert.Nil(t, analyzer.Analyze(newEvent("admin", "get", "workspaces")))
+	assert.Nil(t, analyzer.Analyze(newEvent("admin", "delete", "deployments")))
+
+	// The same action during working hours is fine.
+	day := time.Date(2022, 1, 1, 10, 0, 0, 0, time.UTC)
+	analyzer.now = func() time.Time { return day }
+	assert.Nil(t, analyzer.Analyze(e))
+}
+
+func TestAnalyzeAnnotatesEvent(t *testing.T) {
+	a := &auditing{analyzers: defaultAnalyzers()}
+
+	e := newEvent("admin", "create", "globalrolebindings")
+	a.analyze(e)
+
+	assert.Equal(t, "role-escalation", e.Annotations[AnomalyRuleAnnotation])
+	assert.Equal(t, SeverityWarning, e.Annotations[AnomalySeverityAnnotation])
+	assert.NotEmpty(t, e.Annotations[AnomalyMessageAnnotation])
+}
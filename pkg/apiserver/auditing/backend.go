@@ -39,6 +39,12 @@ const (
 	WebhookURL           = "https://kube-auditing-webhook-svc.kubesphere-logging-system.svc:6443/audit/webhook/event"
 )
 
+// Enricher fills in event fields that need lookups, such as the workspace
+// of a devops scoped event. Enrichers run in the backend worker after the
+// response has been served, so audit processing adds no lookup latency to
+// the request path.
+type Enricher func(e *v1alpha1.Event)
+
 type Backend struct {
 	url                string
 	senderCh           chan interface{}
@@ -48,10 +54,12 @@ type Backend struct {
 	getSenderTimeout   time.Duration
 	eventBatchSize     int
 	eventBatchInterval time.Duration
+	signer             signer
+	enrichers          []Enricher
 	stopCh             <-chan struct{}
 }
 
-func NewBackend(opts *options.Options, cache chan *v1alpha1.Event, stopCh <-chan struct{}) *Backend {
+func NewBackend(opts *options.Options, cache chan *v1alpha1.Event, stopCh <-chan struct{}, enrichers ...Enricher) *Backend {
 
 	b := Backend{
 		url:                opts.WebhookUrl,
@@ -60,6 +68,7 @@ func NewBackend(opts *options.Options, cache chan *v1alpha1.Event, stopCh <-chan
 		sendTimeout:        SendTimeout,
 		eventBatchSize:     opts.EventBatchSize,
 		eventBatchInterval: opts.EventBatchInterval,
+		enrichers:          enrichers,
 		stopCh:             stopCh,
 	}
 
@@ -81,6 +90,16 @@ func NewBackend(opts *options.Options, cache chan *v1alpha1.Event, stopCh <-chan
 	}
 	b.senderCh = make(chan interface{}, sendersNum)
 
+	if opts.SigningMethod != "" {
+		s, err := newSigner(opts.SigningMethod, opts.SigningKeyFile)
+		if err != nil {
+			// signing is a tamper evidence feature, silently sending
+			// unsigned batches would defeat it
+			klog.Fatalf("load auditing signing key error, %s", err)
+		}
+		b.signer = s
+	}
+
 	b.client = http.Client{
 		Transport: &http.Transport{
 			TLSClientConfig: &tls.Config{
@@ -107,10 +126,21 @@ func (b *Backend) worker() {
 			continue
 		}
 
+		b.enrich(events)
+
 		go b.sendEvents(events)
 	}
 }
 
+// enrich runs every enricher over the batch before it is sent.
+func (b *Backend) enrich(events *v1alpha1.EventList) {
+	for i := range events.Items {
+		for _, enricher := range b.enrichers {
+			enricher(&events.Items[i])
+		}
+	}
+}
+
 func (b *Backend) getEvents() *v1alpha1.EventList {
 
 	ctx, cancel := context.WithTimeout(context.Background(), b.eventBatchInterval)
@@ -167,7 +197,23 @@ func (b *Backend) sendEvents(events *v1alpha1.EventList) {
 
 		klog.V(8).Infof("%s", string(bs))
 
-		response, err := b.client.Post(b.url, "application/json", bytes.NewBuffer(bs))
+		request, err := http.NewRequest(http.MethodPost, b.url, bytes.NewBuffer(bs))
+		if err != nil {
+			klog.Errorf("send audit events error, %s", err)
+			return
+		}
+		request.Header.Set("Content-Type", "application/json")
+
+		if b.signer != nil {
+			signature, err := b.signer.Sign(bs)
+			if err != nil {
+				klog.Errorf("sign audit events error, %s", err)
+				return
+			}
+			request.Header.Set(SignatureHeader, signature)
+		}
+
+		response, err := b.client.Do(request)
 		if err != nil {
 			klog.Errorf("send audit events error, %s", err)
 			return
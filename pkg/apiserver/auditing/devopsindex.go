@@ -0,0 +1,112 @@
+/*
+Copyright 2022 The KubeSphere Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package auditing
+
+import (
+	"sync"
+
+	"k8s.io/client-go/tools/cache"
+	devopsv1alpha3 "kubesphere.io/api/devops/v1alpha3"
+	tenantv1alpha1 "kubesphere.io/api/tenant/v1alpha1"
+)
+
+// devopsProjectEntry is what the index keeps per devops project, just the
+// fields the audit events need.
+type devopsProjectEntry struct {
+	name           string
+	adminNamespace string
+	workspace      string
+}
+
+// devopsIndex resolves the devops path segment of a request, a project
+// name or its admin namespace, to the project and its workspace. The maps
+// are kept up to date by informer event handlers, so the lookup on the
+// request hot path costs two map reads instead of listing every project.
+type devopsIndex struct {
+	mutex       sync.RWMutex
+	byName      map[string]*devopsProjectEntry
+	byNamespace map[string]*devopsProjectEntry
+}
+
+func newDevopsIndex(informer cache.SharedIndexInformer) *devopsIndex {
+	index := &devopsIndex{
+		byName:      make(map[string]*devopsProjectEntry),
+		byNamespace: make(map[string]*devopsProjectEntry),
+	}
+	informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: index.set,
+		UpdateFunc: func(old, new interface{}) {
+			// the admin namespace or the workspace label may have changed,
+			// drop the old keys before indexing the new state
+			index.remove(old)
+			index.set(new)
+		},
+		DeleteFunc: index.remove,
+	})
+	return index
+}
+
+// lookup resolves a project name or admin namespace to the project name
+// and its workspace.
+func (i *devopsIndex) lookup(devops string) (name, workspace string, found bool) {
+	i.mutex.RLock()
+	defer i.mutex.RUnlock()
+	if entry, ok := i.byName[devops]; ok {
+		return entry.name, entry.workspace, true
+	}
+	if entry, ok := i.byNamespace[devops]; ok {
+		return entry.name, entry.workspace, true
+	}
+	return "", "", false
+}
+
+func (i *devopsIndex) set(obj interface{}) {
+	project, ok := obj.(*devopsv1alpha3.DevOpsProject)
+	if !ok {
+		return
+	}
+	entry := &devopsProjectEntry{
+		name:           project.Name,
+		adminNamespace: project.Status.AdminNamespace,
+		workspace:      project.Labels[tenantv1alpha1.WorkspaceLabel],
+	}
+	i.mutex.Lock()
+	defer i.mutex.Unlock()
+	i.byName[entry.name] = entry
+	if entry.adminNamespace != "" {
+		i.byNamespace[entry.adminNamespace] = entry
+	}
+}
+
+func (i *devopsIndex) remove(obj interface{}) {
+	project, ok := obj.(*devopsv1alpha3.DevOpsProject)
+	if !ok {
+		tombstone, ok := obj.(cache.DeletedFinalStateUnknown)
+		if !ok {
+			return
+		}
+		if project, ok = tombstone.Obj.(*devopsv1alpha3.DevOpsProject); !ok {
+			return
+		}
+	}
+	i.mutex.Lock()
+	defer i.mutex.Unlock()
+	delete(i.byName, project.Name)
+	if project.Status.AdminNamespace != "" {
+		delete(i.byNamespace, project.Status.AdminNamespace)
+	}
+}
@@ -0,0 +1,93 @@
+/*
+Copyright 2022 The KubeSphere Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package auditing
+
+import (
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	devopsv1alpha3 "kubesphere.io/api/devops/v1alpha3"
+	tenantv1alpha1 "kubesphere.io/api/tenant/v1alpha1"
+
+	auditv1alpha1 "kubesphere.io/kubesphere/pkg/apiserver/auditing/v1alpha1"
+)
+
+func TestDevopsIndex(t *testing.T) {
+	index := &devopsIndex{
+		byName:      make(map[string]*devopsProjectEntry),
+		byNamespace: make(map[string]*devopsProjectEntry),
+	}
+
+	project := &devopsv1alpha3.DevOpsProject{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   "demo",
+			Labels: map[string]string{tenantv1alpha1.WorkspaceLabel: "workspace1"},
+		},
+		Status: devopsv1alpha3.DevOpsProjectStatus{AdminNamespace: "demo-abcde"},
+	}
+	index.set(project)
+
+	name, workspace, found := index.lookup("demo")
+	if !found || name != "demo" || workspace != "workspace1" {
+		t.Errorf("expected lookup by name to resolve demo/workspace1, got %s/%s found=%v", name, workspace, found)
+	}
+
+	name, workspace, found = index.lookup("demo-abcde")
+	if !found || name != "demo" || workspace != "workspace1" {
+		t.Errorf("expected lookup by admin namespace to resolve demo/workspace1, got %s/%s found=%v", name, workspace, found)
+	}
+
+	if _, _, found = index.lookup("unknown"); found {
+		t.Error("expected lookup of an unknown project to miss")
+	}
+
+	index.remove(project)
+	if _, _, found = index.lookup("demo"); found {
+		t.Error("expected lookup after removal to miss")
+	}
+	if _, _, found = index.lookup("demo-abcde"); found {
+		t.Error("expected lookup by admin namespace after removal to miss")
+	}
+}
+
+func TestEnrichDevops(t *testing.T) {
+	index := &devopsIndex{
+		byName:      make(map[string]*devopsProjectEntry),
+		byNamespace: make(map[string]*devopsProjectEntry),
+	}
+	index.set(&devopsv1alpha3.DevOpsProject{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   "demo",
+			Labels: map[string]string{tenantv1alpha1.WorkspaceLabel: "workspace1"},
+		},
+		Status: devopsv1alpha3.DevOpsProjectStatus{AdminNamespace: "demo-abcde"},
+	})
+	a := &auditing{devopsIndex: index}
+
+	e := &auditv1alpha1.Event{Devops: "demo-abcde"}
+	a.enrichDevops(e)
+	if e.Devops != "demo" || e.Workspace != "workspace1" {
+		t.Errorf("expected enrichment to resolve demo/workspace1, got %s/%s", e.Devops, e.Workspace)
+	}
+
+	// a workspace already set on the event is kept
+	e = &auditv1alpha1.Event{Devops: "demo", Workspace: "other"}
+	a.enrichDevops(e)
+	if e.Workspace != "other" {
+		t.Errorf("expected existing workspace to be kept, got %s", e.Workspace)
+	}
+}
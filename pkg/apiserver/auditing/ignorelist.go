@@ -0,0 +1,79 @@
+/*
+Copyright 2022 The KubeSphere Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package auditing
+
+import (
+	"strings"
+
+	compbasemetrics "k8s.io/component-base/metrics"
+
+	"kubesphere.io/kubesphere/pkg/apiserver/request"
+	"kubesphere.io/kubesphere/pkg/utils/metrics"
+)
+
+var auditIgnoredRequests = compbasemetrics.NewCounterVec(
+	&compbasemetrics.CounterOpts{
+		Name:           "ks_server_audit_ignored_requests_total",
+		Help:           "Counter of requests skipped by the auditing ignore list, broken out for each matched url prefix or verb.",
+		StabilityLevel: compbasemetrics.ALPHA,
+	},
+	[]string{"rule"},
+)
+
+func init() {
+	metrics.MustRegister(auditIgnoredRequests)
+}
+
+// ignoreList skips high-frequency requests such as health probes, metrics
+// scrapes and watches before an audit event is constructed, so they do not
+// consume audit pipeline capacity. Both dimensions are bounded by the
+// configuration, so the matched rule is safe as a metric label.
+type ignoreList struct {
+	// urls are path prefixes, "/healthz" also covers "/healthz/ping".
+	urls  []string
+	verbs map[string]struct{}
+}
+
+func newIgnoreList(urls, verbs []string) *ignoreList {
+	l := &ignoreList{
+		urls:  urls,
+		verbs: make(map[string]struct{}, len(verbs)),
+	}
+	for _, verb := range verbs {
+		l.verbs[verb] = struct{}{}
+	}
+	return l
+}
+
+// ignored reports whether the request matches the ignore list, counting
+// the match against the rule that caught it.
+func (l *ignoreList) ignored(info *request.RequestInfo) bool {
+	if l == nil {
+		return false
+	}
+	for _, url := range l.urls {
+		if info.Path == url || strings.HasPrefix(info.Path, url+"/") {
+			auditIgnoredRequests.WithLabelValues(url).Inc()
+			return true
+		}
+	}
+	if _, ok := l.verbs[info.Verb]; ok {
+		auditIgnoredRequests.WithLabelValues(info.Verb).Inc()
+		return true
+	}
+	return false
+}
@@ -0,0 +1,76 @@
+/*
+Copyright 2022 The KubeSphere Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package auditing
+
+import (
+	"testing"
+
+	k8srequest "k8s.io/apiserver/pkg/endpoints/request"
+
+	"kubesphere.io/kubesphere/pkg/apiserver/request"
+)
+
+func TestIgnoreList(t *testing.T) {
+	list := newIgnoreList([]string{"/healthz", "/metrics"}, []string{"watch"})
+
+	tests := []struct {
+		name    string
+		info    *request.RequestInfo
+		ignored bool
+	}{
+		{
+			name:    "exact url match",
+			info:    &request.RequestInfo{RequestInfo: &k8srequest.RequestInfo{Path: "/metrics", Verb: "get"}},
+			ignored: true,
+		},
+		{
+			name:    "url prefix match",
+			info:    &request.RequestInfo{RequestInfo: &k8srequest.RequestInfo{Path: "/healthz/ping", Verb: "get"}},
+			ignored: true,
+		},
+		{
+			name:    "prefix requires a path separator",
+			info:    &request.RequestInfo{RequestInfo: &k8srequest.RequestInfo{Path: "/metricsfoo", Verb: "get"}},
+			ignored: false,
+		},
+		{
+			name:    "verb match",
+			info:    &request.RequestInfo{RequestInfo: &k8srequest.RequestInfo{Path: "/kapis/resources.kubesphere.io/v1alpha3/pods", Verb: "watch"}},
+			ignored: true,
+		},
+		{
+			name:    "ordinary request",
+			info:    &request.RequestInfo{RequestInfo: &k8srequest.RequestInfo{Path: "/kapis/resources.kubesphere.io/v1alpha3/pods", Verb: "list"}},
+			ignored: false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := list.ignored(test.info); got != test.ignored {
+				t.Errorf("expected ignored=%v, got %v", test.ignored, got)
+			}
+		})
+	}
+}
+
+func TestIgnoreListNil(t *testing.T) {
+	var list *ignoreList
+	if list.ignored(&request.RequestInfo{RequestInfo: &k8srequest.RequestInfo{Path: "/healthz", Verb: "get"}}) {
+		t.Error("expected a nil ignore list to ignore nothing")
+	}
+}
@@ -0,0 +1,111 @@
+/*
+Copyright 2022 The KubeSphere Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package auditing
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apiserver/pkg/apis/audit"
+	"k8s.io/klog"
+	iamv1alpha2 "kubesphere.io/api/iam/v1alpha2"
+
+	auditv1alpha1 "kubesphere.io/kubesphere/pkg/apiserver/auditing/v1alpha1"
+)
+
+const (
+	// LoginVerb marks audit events exported from LoginRecord objects.
+	LoginVerb = "login"
+
+	// LoginProviderAnnotation carries the identity provider of a login attempt.
+	LoginProviderAnnotation = "auditing.kubesphere.io/login-provider"
+
+	// LoginTypeAnnotation carries the authentication method, Basic/OAuth/Token.
+	LoginTypeAnnotation = "auditing.kubesphere.io/login-type"
+
+	// LoginResultAnnotation is "success" or "failure".
+	LoginResultAnnotation = "auditing.kubesphere.io/login-result"
+
+	// LoginReasonAnnotation carries the failure reason of a login attempt.
+	LoginReasonAnnotation = "auditing.kubesphere.io/login-reason"
+
+	LoginResultSuccess = "success"
+	LoginResultFailure = "failure"
+
+	// loginExportMaxAge skips stale records replayed by the informer on
+	// restart, those were already exported when they were created.
+	loginExportMaxAge = time.Minute
+)
+
+// exportLoginRecord sends a newly created LoginRecord, successful or not, to
+// the audit sink in the same normalized schema as request based events. The
+// exported copy is retained by the sink under its own retention policy, so
+// login history outlives the in-cluster LoginRecord retention enforced by the
+// loginrecord controller.
+func (a *auditing) exportLoginRecord(obj interface{}) {
+	record, ok := obj.(*iamv1alpha2.LoginRecord)
+	if !ok {
+		return
+	}
+
+	if !a.Enabled() {
+		return
+	}
+
+	if time.Since(record.CreationTimestamp.Time) > loginExportMaxAge {
+		return
+	}
+
+	username := record.Labels[iamv1alpha2.UserReferenceLabel]
+	if len(username) == 0 {
+		klog.V(4).Infof("login record %s doesn't belong to any user", record.Name)
+		return
+	}
+
+	result := LoginResultSuccess
+	if !record.Spec.Success {
+		result = LoginResultFailure
+	}
+
+	e := &auditv1alpha1.Event{
+		Event: audit.Event{
+			Verb:                     LoginVerb,
+			Level:                    audit.LevelMetadata,
+			AuditID:                  types.UID(uuid.New().String()),
+			Stage:                    audit.StageResponseComplete,
+			UserAgent:                record.Spec.UserAgent,
+			RequestReceivedTimestamp: metav1.NewMicroTime(record.CreationTimestamp.Time),
+			StageTimestamp:           metav1.NewMicroTime(record.CreationTimestamp.Time),
+			SourceIPs:                []string{record.Spec.SourceIP},
+			ObjectRef: &audit.ObjectReference{
+				Resource: iamv1alpha2.ResourcesPluralUser,
+				Name:     username,
+			},
+			Annotations: map[string]string{
+				LoginProviderAnnotation: record.Spec.Provider,
+				LoginTypeAnnotation:     string(record.Spec.Type),
+				LoginResultAnnotation:   result,
+				LoginReasonAnnotation:   record.Spec.Reason,
+			},
+		},
+	}
+	e.User.Username = username
+
+	a.cacheEvent(*e)
+}
@@ -0,0 +1,107 @@
+/*
+Copyright 2022 The KubeSphere Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package auditing
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	fakek8s "k8s.io/client-go/kubernetes/fake"
+
+	auditingv1alpha1 "kubesphere.io/api/auditing/v1alpha1"
+	iamv1alpha2 "kubesphere.io/api/iam/v1alpha2"
+
+	auditv1alpha1 "kubesphere.io/kubesphere/pkg/apiserver/auditing/v1alpha1"
+	"kubesphere.io/kubesphere/pkg/client/clientset/versioned/fake"
+	"kubesphere.io/kubesphere/pkg/informers"
+)
+
+func TestExportLoginRecord(t *testing.T) {
+	webhook := &auditingv1alpha1.Webhook{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: auditingv1alpha1.SchemeGroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "kube-auditing-webhook",
+		},
+		Spec: auditingv1alpha1.WebhookSpec{
+			AuditLevel: auditingv1alpha1.LevelMetadata,
+		},
+	}
+
+	ksClient := fake.NewSimpleClientset()
+	k8sClient := fakek8s.NewSimpleClientset()
+	fakeInformerFactory := informers.NewInformerFactories(k8sClient, ksClient, nil, nil, nil, nil)
+
+	a := auditing{
+		webhookLister: fakeInformerFactory.KubeSphereSharedInformerFactory().Auditing().V1alpha1().Webhooks().Lister(),
+		cache:         make(chan *auditv1alpha1.Event, DefaultCacheCapacity),
+	}
+
+	err := fakeInformerFactory.KubeSphereSharedInformerFactory().Auditing().V1alpha1().Webhooks().Informer().GetIndexer().Add(webhook)
+	if err != nil {
+		panic(err)
+	}
+
+	record := &iamv1alpha2.LoginRecord{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              "admin-x6zwf",
+			CreationTimestamp: metav1.Now(),
+			Labels: map[string]string{
+				iamv1alpha2.UserReferenceLabel: "admin",
+			},
+		},
+		Spec: iamv1alpha2.LoginRecordSpec{
+			Type:      iamv1alpha2.Token,
+			Provider:  "LDAP",
+			SourceIP:  "192.168.0.2",
+			UserAgent: "Mozilla/5.0",
+			Success:   false,
+			Reason:    "incorrect password",
+		},
+	}
+
+	a.exportLoginRecord(record)
+
+	select {
+	case e := <-a.cache:
+		assert.Equal(t, LoginVerb, e.Verb)
+		assert.Equal(t, "admin", e.User.Username)
+		assert.Equal(t, "admin", e.ObjectRef.Name)
+		assert.Equal(t, []string{"192.168.0.2"}, e.SourceIPs)
+		assert.Equal(t, "Mozilla/5.0", e.UserAgent)
+		assert.Equal(t, "LDAP", e.Annotations[LoginProviderAnnotation])
+		assert.Equal(t, string(iamv1alpha2.Token), e.Annotations[LoginTypeAnnotation])
+		assert.Equal(t, LoginResultFailure, e.Annotations[LoginResultAnnotation])
+		assert.Equal(t, "incorrect password", e.Annotations[LoginReasonAnnotation])
+	default:
+		t.Fatal("expected login record to be exported")
+	}
+
+	// records replayed by the informer after a restart are not exported again
+	stale := record.DeepCopy()
+	stale.CreationTimestamp = metav1.NewTime(time.Now().Add(-time.Hour))
+	a.exportLoginRecord(stale)
+
+	select {
+	case <-a.cache:
+		t.Fatal("stale login record should not be exported")
+	default:
+	}
+}
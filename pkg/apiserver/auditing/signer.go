@@ -0,0 +1,161 @@
+/*
+Copyright 2022 The KubeSphere Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package auditing
+
+import (
+	"crypto"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"os"
+	"strings"
+)
+
+const (
+	// SignatureHeader carries the signature of the event batch on webhook
+	// requests, formatted as "<method>:<base64 signature>", so the sink can
+	// verify the audit trail has not been tampered with in transit.
+	SignatureHeader = "X-Auditing-Signature"
+
+	SigningMethodHMAC = "hmac"
+	SigningMethodRSA  = "rsa"
+)
+
+// signer signs a marshaled event batch before it is sent to the webhook.
+type signer interface {
+	Sign(payload []byte) (string, error)
+}
+
+func newSigner(method, keyFile string) (signer, error) {
+	key, err := os.ReadFile(keyFile)
+	if err != nil {
+		return nil, fmt.Errorf("read signing key file: %s", err)
+	}
+
+	switch method {
+	case SigningMethodHMAC:
+		return &hmacSigner{key: key}, nil
+	case SigningMethodRSA:
+		privateKey, err := parseRSAPrivateKey(key)
+		if err != nil {
+			return nil, err
+		}
+		return &rsaSigner{key: privateKey}, nil
+	default:
+		return nil, fmt.Errorf("unsupported signing method %s", method)
+	}
+}
+
+type hmacSigner struct {
+	key []byte
+}
+
+func (s *hmacSigner) Sign(payload []byte) (string, error) {
+	mac := hmac.New(sha256.New, s.key)
+	mac.Write(payload)
+	return fmt.Sprintf("%s:%s", SigningMethodHMAC, base64.StdEncoding.EncodeToString(mac.Sum(nil))), nil
+}
+
+type rsaSigner struct {
+	key *rsa.PrivateKey
+}
+
+func (s *rsaSigner) Sign(payload []byte) (string, error) {
+	digest := sha256.Sum256(payload)
+	signature, err := rsa.SignPKCS1v15(rand.Reader, s.key, crypto.SHA256, digest[:])
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s:%s", SigningMethodRSA, base64.StdEncoding.EncodeToString(signature)), nil
+}
+
+// Verify checks the signature a signer produced for the payload. For the
+// hmac method key is the shared secret, for the rsa method key is the PEM
+// encoded public key. It is meant for the receiving sink.
+func Verify(payload []byte, signature string, key []byte) error {
+	parts := strings.SplitN(signature, ":", 2)
+	if len(parts) != 2 {
+		return fmt.Errorf("malformed signature %s", signature)
+	}
+
+	raw, err := base64.StdEncoding.DecodeString(parts[1])
+	if err != nil {
+		return fmt.Errorf("malformed signature %s", signature)
+	}
+
+	switch parts[0] {
+	case SigningMethodHMAC:
+		mac := hmac.New(sha256.New, key)
+		mac.Write(payload)
+		if !hmac.Equal(raw, mac.Sum(nil)) {
+			return fmt.Errorf("signature mismatch")
+		}
+		return nil
+	case SigningMethodRSA:
+		publicKey, err := parseRSAPublicKey(key)
+		if err != nil {
+			return err
+		}
+		digest := sha256.Sum256(payload)
+		return rsa.VerifyPKCS1v15(publicKey, crypto.SHA256, digest[:], raw)
+	default:
+		return fmt.Errorf("unsupported signing method %s", parts[0])
+	}
+}
+
+func parseRSAPrivateKey(data []byte) (*rsa.PrivateKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("signing key is not PEM encoded")
+	}
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("parse signing key: %s", err)
+	}
+	rsaKey, ok := key.(*rsa.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("signing key is not an RSA private key")
+	}
+	return rsaKey, nil
+}
+
+func parseRSAPublicKey(data []byte) (*rsa.PublicKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("verification key is not PEM encoded")
+	}
+	if key, err := x509.ParsePKCS1PublicKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("parse verification key: %s", err)
+	}
+	rsaKey, ok := key.(*rsa.PublicKey)
+	if !ok {
+		return nil, fmt.Errorf("verification key is not an RSA public key")
+	}
+	return rsaKey, nil
+}
@@ -0,0 +1,103 @@
+/*
+Copyright 2022 The KubeSphere Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package auditing
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestHMACSignAndVerify(t *testing.T) {
+	key := []byte("shared-secret")
+	keyFile := filepath.Join(t.TempDir(), "hmac.key")
+	if err := os.WriteFile(keyFile, key, 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	s, err := newSigner(SigningMethodHMAC, keyFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	payload := []byte(`{"items":[{"AuditID":"xxx"}]}`)
+	signature, err := s.Sign(payload)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := Verify(payload, signature, key); err != nil {
+		t.Errorf("expected signature to verify, got %v", err)
+	}
+	if err := Verify(append(payload, '!'), signature, key); err == nil {
+		t.Error("expected tampered payload to fail verification")
+	}
+	if err := Verify(payload, signature, []byte("other-secret")); err == nil {
+		t.Error("expected wrong key to fail verification")
+	}
+}
+
+func TestRSASignAndVerify(t *testing.T) {
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyFile := filepath.Join(t.TempDir(), "rsa.key")
+	keyPEM := pem.EncodeToMemory(&pem.Block{
+		Type:  "RSA PRIVATE KEY",
+		Bytes: x509.MarshalPKCS1PrivateKey(privateKey),
+	})
+	if err := os.WriteFile(keyFile, keyPEM, 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	s, err := newSigner(SigningMethodRSA, keyFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	payload := []byte(`{"items":[{"AuditID":"xxx"}]}`)
+	signature, err := s.Sign(payload)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	publicPEM := pem.EncodeToMemory(&pem.Block{
+		Type:  "RSA PUBLIC KEY",
+		Bytes: x509.MarshalPKCS1PublicKey(&privateKey.PublicKey),
+	})
+	if err := Verify(payload, signature, publicPEM); err != nil {
+		t.Errorf("expected signature to verify, got %v", err)
+	}
+	if err := Verify(append(payload, '!'), signature, publicPEM); err == nil {
+		t.Error("expected tampered payload to fail verification")
+	}
+}
+
+func TestNewSignerUnsupportedMethod(t *testing.T) {
+	keyFile := filepath.Join(t.TempDir(), "key")
+	if err := os.WriteFile(keyFile, []byte("key"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := newSigner("ecdsa", keyFile); err == nil {
+		t.Error("expected unsupported method to fail")
+	}
+}
@@ -24,6 +24,7 @@ import (
 	"io"
 	"net"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -32,17 +33,14 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apiserver/pkg/apis/audit"
+	"k8s.io/client-go/tools/cache"
 	"k8s.io/klog"
-	devopsv1alpha3 "kubesphere.io/api/devops/v1alpha3"
 	"kubesphere.io/api/iam/v1alpha2"
 
 	auditv1alpha1 "kubesphere.io/kubesphere/pkg/apiserver/auditing/v1alpha1"
-	"kubesphere.io/kubesphere/pkg/apiserver/query"
 	"kubesphere.io/kubesphere/pkg/apiserver/request"
 	"kubesphere.io/kubesphere/pkg/client/listers/auditing/v1alpha1"
 	"kubesphere.io/kubesphere/pkg/informers"
-	"kubesphere.io/kubesphere/pkg/models/resources/v1alpha3"
-	"kubesphere.io/kubesphere/pkg/models/resources/v1alpha3/devops"
 	options "kubesphere.io/kubesphere/pkg/simple/client/auditing"
 	"kubesphere.io/kubesphere/pkg/utils/iputil"
 )
@@ -51,8 +49,34 @@ const (
 	DefaultWebhook       = "kube-auditing-webhook"
 	DefaultCacheCapacity = 10000
 	CacheTimeout         = time.Second
+
+	// ContainerAnnotation carries the container an exec or attach request
+	// targets, the streamed body of such requests cannot be audited.
+	ContainerAnnotation = "auditing.kubesphere.io/container"
+
+	// CommandAnnotation carries the command an exec request runs.
+	CommandAnnotation = "auditing.kubesphere.io/command"
+
+	// SessionIDAnnotation links the audit event to the terminal session it
+	// started, recordings of the session carry the same id.
+	SessionIDAnnotation = "auditing.kubesphere.io/session-id"
+
+	// ValuesChangedKeysAnnotation lists the values keys an application
+	// upgrade changed, key names only so nothing sensitive leaks.
+	ValuesChangedKeysAnnotation = "auditing.kubesphere.io/values-changed-keys"
 )
 
+// IsUpgradeSubresource reports whether the subresource upgrades the
+// connection to a stream, such requests bypass body based auditing and
+// are captured as dedicated events instead.
+func IsUpgradeSubresource(subresource string) bool {
+	switch subresource {
+	case "exec", "attach", "portforward":
+		return true
+	}
+	return false
+}
+
 type Auditing interface {
 	Enabled() bool
 	K8sAuditingEnabled() bool
@@ -62,20 +86,33 @@ type Auditing interface {
 
 type auditing struct {
 	webhookLister v1alpha1.WebhookLister
-	devopsGetter  v1alpha3.Interface
+	devopsIndex   *devopsIndex
+	ignoreList    *ignoreList
 	cache         chan *auditv1alpha1.Event
 	backend       *Backend
+	analyzers     []Analyzer
 }
 
 func NewAuditing(informers informers.InformerFactory, opts *options.Options, stopCh <-chan struct{}) Auditing {
 
 	a := &auditing{
 		webhookLister: informers.KubeSphereSharedInformerFactory().Auditing().V1alpha1().Webhooks().Lister(),
-		devopsGetter:  devops.New(informers.KubeSphereSharedInformerFactory()),
+		devopsIndex:   newDevopsIndex(informers.KubeSphereSharedInformerFactory().Devops().V1alpha3().DevOpsProjects().Informer()),
+		ignoreList:    newIgnoreList(opts.IgnoredUrls, opts.IgnoredVerbs),
 		cache:         make(chan *auditv1alpha1.Event, DefaultCacheCapacity),
+		analyzers:     defaultAnalyzers(),
 	}
 
-	a.backend = NewBackend(opts, a.cache, stopCh)
+	// enrichment that needs lookups runs in the backend worker, not on the
+	// request path
+	a.backend = NewBackend(opts, a.cache, stopCh, a.enrichDevops)
+
+	// Export every new login record, successful or failed, to the sink.
+	informers.KubeSphereSharedInformerFactory().Iam().V1alpha2().LoginRecords().Informer().
+		AddEventHandler(cache.ResourceEventHandlerFuncs{
+			AddFunc: a.exportLoginRecord,
+		})
+
 	return a
 }
 
@@ -118,6 +155,12 @@ func (a *auditing) K8sAuditingEnabled() bool {
 //	}
 func (a *auditing) LogRequestObject(req *http.Request, info *request.RequestInfo) *auditv1alpha1.Event {
 
+	// High-frequency endpoints on the ignore list are dropped before any
+	// event is constructed.
+	if a.ignoreList.ignored(info) {
+		return nil
+	}
+
 	// Ignore the dryRun k8s request.
 	if info.IsKubernetesRequest {
 		if len(req.URL.Query()["dryRun"]) != 0 {
@@ -153,22 +196,17 @@ func (a *auditing) LogRequestObject(req *http.Request, info *request.RequestInfo
 		},
 	}
 
-	// Get the workspace which the devops project be in.
-	if len(e.Devops) > 0 && len(e.Workspace) == 0 {
-		res, err := a.devopsGetter.List("", query.New())
-		if err != nil {
-			klog.Error(err)
+	// Exec, attach and portforward stream through an upgraded connection
+	// whose body cannot be audited, record the target container and
+	// command on the event instead.
+	if IsUpgradeSubresource(info.Subresource) {
+		e.Verb = info.Subresource
+		e.Annotations = make(map[string]string)
+		if container := req.URL.Query().Get("container"); container != "" {
+			e.Annotations[ContainerAnnotation] = container
 		}
-
-		for _, obj := range res.Items {
-			d := obj.(*devopsv1alpha3.DevOpsProject)
-
-			if d.Name == e.Devops {
-				e.Workspace = d.Labels["kubesphere.io/workspace"]
-			} else if d.Status.AdminNamespace == e.Devops {
-				e.Workspace = d.Labels["kubesphere.io/workspace"]
-				e.Devops = d.Name
-			}
+		if command := req.URL.Query()["command"]; len(command) > 0 {
+			e.Annotations[CommandAnnotation] = strings.Join(command, " ")
 		}
 	}
 
@@ -225,6 +263,19 @@ func (a *auditing) LogRequestObject(req *http.Request, info *request.RequestInfo
 	return e
 }
 
+// enrichDevops resolves the workspace a devops scoped event belongs to.
+// The devops path segment is either the project name or its admin
+// namespace, the informer-backed index resolves both.
+func (a *auditing) enrichDevops(e *auditv1alpha1.Event) {
+	if len(e.Devops) == 0 || len(e.Workspace) > 0 {
+		return
+	}
+	if name, workspace, ok := a.devopsIndex.lookup(e.Devops); ok {
+		e.Devops = name
+		e.Workspace = workspace
+	}
+}
+
 func (a *auditing) needAnalyzeRequestBody(e *auditv1alpha1.Event, req *http.Request) bool {
 
 	if req.ContentLength <= 0 {
@@ -255,6 +306,7 @@ func (a *auditing) LogResponseObject(e *auditv1alpha1.Event, resp *ResponseCaptu
 		e.ResponseObject = &runtime.Unknown{Raw: resp.Bytes()}
 	}
 
+	a.analyze(e)
 	a.cacheEvent(*e)
 }
 
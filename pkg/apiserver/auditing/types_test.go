@@ -219,6 +219,68 @@ func TestAuditing_LogRequestObject(t *testing.T) {
 	assert.Equal(t, expectedEvent, e)
 }
 
+func TestAuditing_LogExecRequest(t *testing.T) {
+	webhook := &auditingv1alpha1.Webhook{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: auditingv1alpha1.SchemeGroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "kube-auditing-webhook",
+		},
+		Spec: auditingv1alpha1.WebhookSpec{
+			AuditLevel:         auditingv1alpha1.LevelMetadata,
+			K8sAuditingEnabled: true,
+		},
+	}
+
+	ksClient := fake.NewSimpleClientset()
+	k8sClient := fakek8s.NewSimpleClientset()
+	fakeInformerFactory := informers.NewInformerFactories(k8sClient, ksClient, nil, nil, nil, nil)
+
+	a := auditing{
+		webhookLister: fakeInformerFactory.KubeSphereSharedInformerFactory().Auditing().V1alpha1().Webhooks().Lister(),
+	}
+
+	err := fakeInformerFactory.KubeSphereSharedInformerFactory().Auditing().V1alpha1().Webhooks().Informer().GetIndexer().Add(webhook)
+	if err != nil {
+		panic(err)
+	}
+
+	req := &http.Request{}
+	u, err := url.Parse("http://139.198.121.143:32306/api/v1/namespaces/default/pods/foo/exec?container=main&command=sh&command=-c&command=ls")
+	if err != nil {
+		panic(err)
+	}
+
+	req.URL = u
+	req.Header = http.Header{}
+	req.Header.Add(iputil.XClientIP, "192.168.0.2")
+	req = req.WithContext(request.WithUser(req.Context(), &user.DefaultInfo{
+		Name: "admin",
+	}))
+
+	info := &request.RequestInfo{
+		RequestInfo: &k8srequest.RequestInfo{
+			IsResourceRequest: true,
+			Path:              "/api/v1/namespaces/default/pods/foo/exec",
+			Verb:              "create",
+			APIGroup:          "",
+			APIVersion:        "v1",
+			Resource:          "pods",
+			Subresource:       "exec",
+			Namespace:         "default",
+			Name:              "foo",
+		},
+	}
+
+	e := a.LogRequestObject(req, info)
+
+	assert.Equal(t, "exec", e.Verb)
+	assert.Equal(t, "main", e.Annotations[ContainerAnnotation])
+	assert.Equal(t, "sh -c ls", e.Annotations[CommandAnnotation])
+	assert.Equal(t, "admin", e.User.Username)
+}
+
 func TestAuditing_LogResponseObject(t *testing.T) {
 	webhook := &auditingv1alpha1.Webhook{
 		TypeMeta: metav1.TypeMeta{
@@ -62,16 +62,22 @@ func SetupWithOptions(options []oauth.IdentityProviderOptions) error {
 			if provider, err := factory.Create(o.Provider); err != nil {
 				// don’t return errors, decoupling external dependencies
 				klog.Error(fmt.Sprintf("failed to create identity provider %s: %s", o.Name, err))
+				providerRequestErrors.WithLabelValues(o.Name, operationSetup).Inc()
+				recordProviderStatus(o.Name, o.Type, err)
 			} else {
-				oauthProviders[o.Name] = provider
+				oauthProviders[o.Name] = &instrumentedOAuthProvider{name: o.Name, delegate: provider}
+				recordProviderStatus(o.Name, o.Type, nil)
 				klog.V(4).Infof("create identity provider %s successfully", o.Name)
 			}
 		}
 		if factory, ok := genericProviderFactories[o.Type]; ok {
 			if provider, err := factory.Create(o.Provider); err != nil {
 				klog.Error(fmt.Sprintf("failed to create identity provider %s: %s", o.Name, err))
+				providerRequestErrors.WithLabelValues(o.Name, operationSetup).Inc()
+				recordProviderStatus(o.Name, o.Type, err)
 			} else {
-				genericProviders[o.Name] = provider
+				genericProviders[o.Name] = &instrumentedGenericProvider{name: o.Name, delegate: provider}
+				recordProviderStatus(o.Name, o.Type, nil)
 				klog.V(4).Infof("create identity provider %s successfully", o.Name)
 			}
 		}
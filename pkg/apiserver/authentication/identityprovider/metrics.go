@@ -0,0 +1,158 @@
+/*
+Copyright 2022 The KubeSphere Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package identityprovider
+
+import (
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	compbasemetrics "k8s.io/component-base/metrics"
+
+	"kubesphere.io/kubesphere/pkg/utils/metrics"
+)
+
+const (
+	// operationAuthenticate covers generic provider logins, for the LDAP
+	// provider this is the duration of the search and bind round trip.
+	operationAuthenticate = "authenticate"
+	// operationTokenExchange covers the oauth callback, exchanging the
+	// authorization code for a token at the remote server.
+	operationTokenExchange = "token_exchange"
+	// operationSetup covers provider initialization, e.g. OIDC issuer
+	// discovery.
+	operationSetup = "setup"
+)
+
+var (
+	providerRequestLatencies = compbasemetrics.NewHistogramVec(
+		&compbasemetrics.HistogramOpts{
+			Name:           "ks_server_identity_provider_request_duration_seconds",
+			Help:           "Latency distribution in seconds of identity provider requests, broken out for each provider and operation.",
+			Buckets:        []float64{0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10},
+			StabilityLevel: compbasemetrics.ALPHA,
+		},
+		[]string{"provider", "operation"},
+	)
+
+	providerRequestErrors = compbasemetrics.NewCounterVec(
+		&compbasemetrics.CounterOpts{
+			Name:           "ks_server_identity_provider_errors_total",
+			Help:           "Counter of failed identity provider requests, including setup failures such as OIDC discovery errors, broken out for each provider and operation.",
+			StabilityLevel: compbasemetrics.ALPHA,
+		},
+		[]string{"provider", "operation"},
+	)
+)
+
+func init() {
+	metrics.MustRegister(providerRequestLatencies, providerRequestErrors)
+}
+
+// ProviderStatus reports whether a configured identity provider is
+// currently usable, based on its most recent request.
+type ProviderStatus struct {
+	Name  string `json:"name"`
+	Type  string `json:"type"`
+	Ready bool   `json:"ready"`
+	// Error describes why the provider is not ready.
+	Error string `json:"error,omitempty"`
+}
+
+var (
+	statusMutex      sync.RWMutex
+	providerStatuses = make(map[string]*ProviderStatus)
+)
+
+// Status returns the health of all configured identity providers sorted
+// by name, for the readiness check of the apiserver.
+func Status() []ProviderStatus {
+	statusMutex.RLock()
+	defer statusMutex.RUnlock()
+	statuses := make([]ProviderStatus, 0, len(providerStatuses))
+	for _, status := range providerStatuses {
+		statuses = append(statuses, *status)
+	}
+	sort.Slice(statuses, func(i, j int) bool {
+		return statuses[i].Name < statuses[j].Name
+	})
+	return statuses
+}
+
+func recordProviderStatus(name, providerType string, err error) {
+	statusMutex.Lock()
+	defer statusMutex.Unlock()
+	status := providerStatuses[name]
+	if status == nil {
+		status = &ProviderStatus{Name: name, Type: providerType}
+		providerStatuses[name] = status
+	}
+	if err != nil {
+		status.Ready = false
+		status.Error = err.Error()
+	} else {
+		status.Ready = true
+		status.Error = ""
+	}
+}
+
+// observeProviderRequest records the latency of a provider request and,
+// when it failed, counts the error and marks the provider unready.
+// Unauthorized errors are successful round trips to the remote server,
+// rejected credentials are not a provider outage.
+func observeProviderRequest(name, operation string, start time.Time, err error) {
+	providerRequestLatencies.WithLabelValues(name, operation).Observe(time.Since(start).Seconds())
+	if err != nil && errors.IsUnauthorized(err) {
+		err = nil
+	}
+	if err != nil {
+		providerRequestErrors.WithLabelValues(name, operation).Inc()
+	}
+	statusMutex.RLock()
+	providerType := ""
+	if status := providerStatuses[name]; status != nil {
+		providerType = status.Type
+	}
+	statusMutex.RUnlock()
+	recordProviderStatus(name, providerType, err)
+}
+
+type instrumentedGenericProvider struct {
+	name     string
+	delegate GenericProvider
+}
+
+func (p *instrumentedGenericProvider) Authenticate(username, password string) (Identity, error) {
+	start := time.Now()
+	identity, err := p.delegate.Authenticate(username, password)
+	observeProviderRequest(p.name, operationAuthenticate, start, err)
+	return identity, err
+}
+
+type instrumentedOAuthProvider struct {
+	name     string
+	delegate OAuthProvider
+}
+
+func (p *instrumentedOAuthProvider) IdentityExchangeCallback(req *http.Request) (Identity, error) {
+	start := time.Now()
+	identity, err := p.delegate.IdentityExchangeCallback(req)
+	observeProviderRequest(p.name, operationTokenExchange, start, err)
+	return identity, err
+}
@@ -0,0 +1,68 @@
+/*
+
+ Copyright 2022 The KubeSphere Authors.
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+
+*/
+
+package identityprovider
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+)
+
+func TestProviderStatus(t *testing.T) {
+	recordProviderStatus("status-ldap", "LDAPIdentityProvider", nil)
+
+	find := func(name string) *ProviderStatus {
+		for _, status := range Status() {
+			if status.Name == name {
+				return &status
+			}
+		}
+		return nil
+	}
+
+	status := find("status-ldap")
+	if status == nil || !status.Ready {
+		t.Fatalf("expected provider to be ready, got %+v", status)
+	}
+
+	// a failed request marks the provider unready
+	observeProviderRequest("status-ldap", operationAuthenticate, time.Now(), fmt.Errorf("connection refused"))
+	status = find("status-ldap")
+	if status == nil || status.Ready {
+		t.Fatalf("expected provider to be unready, got %+v", status)
+	}
+	if status.Error != "connection refused" {
+		t.Fatalf("expected last error to be recorded, got %+v", status)
+	}
+
+	// rejected credentials are a successful round trip, not an outage
+	unauthorized := errors.NewUnauthorized("incorrect password")
+	observeProviderRequest("status-ldap", operationAuthenticate, time.Now(), unauthorized)
+	status = find("status-ldap")
+	if status == nil || !status.Ready {
+		t.Fatalf("expected provider to recover on unauthorized, got %+v", status)
+	}
+
+	// unknown providers stay untracked until their first record
+	if find("status-missing") != nil {
+		t.Fatal("expected unknown provider to be absent from status")
+	}
+}
@@ -68,6 +68,15 @@ type Options struct {
 	// Raw RSA private key. Base64 encoded PEM file
 	SignKeyData string `json:"-,omitempty" yaml:"signKeyData,omitempty"`
 
+	// SignKeyRotationInterval enables automatic rotation of the generated
+	// signing material, tokens carry the key ID they were signed with.
+	// Zero disables rotation, explicitly configured sign keys never rotate.
+	SignKeyRotationInterval time.Duration `json:"signKeyRotationInterval,omitempty" yaml:"signKeyRotationInterval,omitempty"`
+
+	// SignKeyGracePeriod is how long keys rotated out keep validating
+	// previously issued tokens and stay published on the JWKS endpoint.
+	SignKeyGracePeriod time.Duration `json:"signKeyGracePeriod,omitempty" yaml:"signKeyGracePeriod,omitempty"`
+
 	// Register identity providers.
 	IdentityProviders []IdentityProviderOptions `json:"identityProviders,omitempty" yaml:"identityProviders,omitempty"`
 
@@ -287,5 +296,6 @@ func NewOptions() *Options {
 		Clients:                      make([]Client, 0),
 		AccessTokenMaxAge:            time.Hour * 2,
 		AccessTokenInactivityTimeout: time.Hour * 2,
+		SignKeyGracePeriod:           time.Hour * 2,
 	}
 }
@@ -31,6 +31,7 @@ import (
 	_ "kubesphere.io/kubesphere/pkg/apiserver/authentication/identityprovider/ldap"
 	_ "kubesphere.io/kubesphere/pkg/apiserver/authentication/identityprovider/oidc"
 	"kubesphere.io/kubesphere/pkg/apiserver/authentication/oauth"
+	"kubesphere.io/kubesphere/pkg/models/kubectl"
 )
 
 type Options struct {
@@ -58,8 +59,37 @@ type Options struct {
 	JwtSecret string `json:"-" yaml:"jwtSecret"`
 	// OAuthOptions defines options needed for integrated oauth plugins
 	OAuthOptions *oauth.Options `json:"oauthOptions" yaml:"oauthOptions"`
-	// KubectlImage is the image address we use to create kubectl pod for users who have admin access to the cluster.
-	KubectlImage string `json:"kubectlImage" yaml:"kubectlImage"`
+	// KubectlOptions configures the kubectl pods we create for users who have admin access to the cluster.
+	KubectlOptions *kubectl.Options `json:"kubectl,omitempty" yaml:"kubectl,omitempty"`
+	// LoginChallenge guards the password grant with an external challenge verifier.
+	LoginChallenge *LoginChallengeOptions `json:"loginChallenge,omitempty" yaml:"loginChallenge,omitempty"`
+}
+
+// LoginChallengeOptions wires an external verifier, e.g. a captcha or
+// proof of work service, into the password grant. Once a user accumulates
+// FailedLoginThreshold failed attempts within AuthenticateRateLimiterDuration,
+// token requests of that user must carry a challenge_token parameter the
+// verifier accepts. The threshold should stay below
+// AuthenticateRateLimiterMaxTries so a human gets a chance to solve the
+// challenge before the account is blocked.
+type LoginChallengeOptions struct {
+	Enabled bool `json:"enabled" yaml:"enabled"`
+	// FailedLoginThreshold is the number of recent failed attempts after
+	// which the challenge is required.
+	FailedLoginThreshold int `json:"failedLoginThreshold" yaml:"failedLoginThreshold"`
+	// VerifyURL is the endpoint the challenge token is posted to for
+	// validation, it replies with a JSON body carrying a success field.
+	VerifyURL string `json:"verifyURL" yaml:"verifyURL"`
+	// Timeout of a verification request, default to 5s.
+	Timeout time.Duration `json:"timeout" yaml:"timeout"`
+}
+
+func NewLoginChallengeOptions() *LoginChallengeOptions {
+	return &LoginChallengeOptions{
+		Enabled:              false,
+		FailedLoginThreshold: 3,
+		Timeout:              5 * time.Second,
+	}
 }
 
 func NewOptions() *Options {
@@ -72,7 +102,8 @@ func NewOptions() *Options {
 		OAuthOptions:                    oauth.NewOptions(),
 		MultipleLogin:                   false,
 		JwtSecret:                       "",
-		KubectlImage:                    "kubesphere/kubectl:v1.0.0",
+		KubectlOptions:                  kubectl.NewOptions(),
+		LoginChallenge:                  NewLoginChallengeOptions(),
 	}
 }
 
@@ -87,6 +118,17 @@ func (options *Options) Validate() []error {
 	if err := identityprovider.SetupWithOptions(options.OAuthOptions.IdentityProviders); err != nil {
 		errs = append(errs, err)
 	}
+	if options.KubectlOptions != nil {
+		errs = append(errs, options.KubectlOptions.Validate()...)
+	}
+	if options.LoginChallenge != nil && options.LoginChallenge.Enabled {
+		if options.LoginChallenge.VerifyURL == "" {
+			errs = append(errs, errors.New("login challenge verify URL MUST not be empty"))
+		}
+		if options.LoginChallenge.FailedLoginThreshold <= 0 {
+			errs = append(errs, errors.New("login challenge failed login threshold MUST be greater than 0"))
+		}
+	}
 	return errs
 }
 
@@ -98,6 +140,9 @@ func (options *Options) AddFlags(fs *pflag.FlagSet, s *Options) {
 	fs.DurationVar(&options.LoginHistoryRetentionPeriod, "login-history-retention-period", s.LoginHistoryRetentionPeriod, "login-history-retention-period defines how long login history should be kept.")
 	fs.IntVar(&options.LoginHistoryMaximumEntries, "login-history-maximum-entries", s.LoginHistoryMaximumEntries, "login-history-maximum-entries defines how many entries of login history should be kept.")
 	fs.DurationVar(&options.OAuthOptions.AccessTokenMaxAge, "access-token-max-age", s.OAuthOptions.AccessTokenMaxAge, "access-token-max-age control the lifetime of access tokens, 0 means no expiration.")
-	fs.StringVar(&s.KubectlImage, "kubectl-image", s.KubectlImage, "Setup the image used by kubectl terminal pod")
+	options.KubectlOptions.AddFlags(fs, s.KubectlOptions)
 	fs.DurationVar(&options.MaximumClockSkew, "maximum-clock-skew", s.MaximumClockSkew, "The maximum time difference between the system clocks of the ks-apiserver that issued a JWT and the ks-apiserver that verified the JWT.")
+	fs.BoolVar(&options.LoginChallenge.Enabled, "login-challenge", s.LoginChallenge.Enabled, "Require a challenge token on the password grant after repeated failed login attempts.")
+	fs.IntVar(&options.LoginChallenge.FailedLoginThreshold, "login-challenge-failed-login-threshold", s.LoginChallenge.FailedLoginThreshold, "Number of recent failed login attempts after which the challenge token is required.")
+	fs.StringVar(&options.LoginChallenge.VerifyURL, "login-challenge-verify-url", s.LoginChallenge.VerifyURL, "Endpoint the challenge token is posted to for validation.")
 }
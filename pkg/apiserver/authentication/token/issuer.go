@@ -77,6 +77,10 @@ type Issuer interface {
 
 	// Keys hold encryption and signing keys.
 	Keys() *Keys
+
+	// PublicKeys lists the public keys of all generations still validating
+	// tokens, for the JWKS endpoint.
+	PublicKeys() []jose.JSONWebKey
 }
 
 type Claims struct {
@@ -116,6 +120,9 @@ type issuer struct {
 	secret []byte
 	// signing id_token
 	signKey *Keys
+	// rotates the signing material on a fixed interval, nil when rotation
+	// is disabled or an explicit sign key is configured
+	rotator *keyRotator
 	// Token verification maximum time difference
 	maximumClockSkew time.Duration
 }
@@ -158,14 +165,28 @@ func (s *issuer) IssueTo(request *IssueRequest) (string, error) {
 		claims.ExpiresAt = claims.IssuedAt + int64(request.ExpiresIn.Seconds())
 	}
 
+	secret := s.secret
+	signKey := s.signKey
+	var keyID string
+	if s.rotator != nil {
+		keys := s.rotator.currentKeys()
+		secret = keys.secret
+		signKey = keys.signKey
+		keyID = keys.keyID
+	}
+
 	var token string
 	var err error
 	if request.TokenType == IDToken {
 		t := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
-		t.Header[headerKeyID] = s.signKey.SigningKey.KeyID
-		token, err = t.SignedString(s.signKey.SigningKey.Key)
+		t.Header[headerKeyID] = signKey.SigningKey.KeyID
+		token, err = t.SignedString(signKey.SigningKey.Key)
 	} else {
-		token, err = jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(s.secret)
+		t := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+		if keyID != "" {
+			t.Header[headerKeyID] = keyID
+		}
+		token, err = t.SignedString(secret)
 	}
 	if err != nil {
 		klog.Warningf("jwt: failed to issue token: %v", err)
@@ -216,16 +237,43 @@ func (s *issuer) Verify(token string) (*VerifiedResponse, error) {
 }
 
 func (s *issuer) Keys() *Keys {
+	if s.rotator != nil {
+		return s.rotator.currentKeys().signKey
+	}
 	return s.signKey
 }
 
+func (s *issuer) PublicKeys() []jose.JSONWebKey {
+	if s.rotator != nil {
+		return s.rotator.publicKeys()
+	}
+	return []jose.JSONWebKey{*s.signKey.SigningKeyPub}
+}
+
 func (s *issuer) keyFunc(token *jwt.Token) (i interface{}, err error) {
 	alg, _ := token.Header[headerAlgorithm].(string)
+	keyID, _ := token.Header[headerKeyID].(string)
 	switch alg {
 	case jwt.SigningMethodHS256.Alg():
-		return s.secret, nil
+		// tokens issued before rotation was enabled carry no key ID and
+		// were signed with the static secret
+		if s.rotator == nil || keyID == "" {
+			return s.secret, nil
+		}
+		keys, err := s.rotator.keysByID(keyID)
+		if err != nil {
+			return nil, err
+		}
+		return keys.secret, nil
 	case jwt.SigningMethodRS256.Alg():
-		return s.signKey.SigningKey.Key, nil
+		if s.rotator == nil || keyID == "" {
+			return s.signKey.SigningKey.Key, nil
+		}
+		keys, err := s.rotator.keysByID(keyID)
+		if err != nil {
+			return nil, err
+		}
+		return keys.signKey.SigningKey.Key, nil
 	default:
 		return nil, fmt.Errorf("unexpect signature algorithm %v", token.Header[headerAlgorithm])
 	}
@@ -298,14 +346,25 @@ func loadSignKey(options *authentication.Options) (*rsa.PrivateKey, string, erro
 }
 
 func NewIssuer(options *authentication.Options) (Issuer, error) {
-	// TODO(hongming) automatically rotates keys
 	signKey, keyID, err := loadSignKey(options)
 	if err != nil {
 		return nil, err
 	}
+	// explicitly configured sign keys are never rotated, verifiers may
+	// have pinned them out of band
+	var rotator *keyRotator
+	if options.OAuthOptions.SignKeyRotationInterval > 0 &&
+		options.OAuthOptions.SignKey == "" && options.OAuthOptions.SignKeyData == "" {
+		if rotator, err = newKeyRotator([]byte(options.JwtSecret),
+			options.OAuthOptions.SignKeyRotationInterval,
+			options.OAuthOptions.SignKeyGracePeriod); err != nil {
+			return nil, err
+		}
+	}
 	return &issuer{
 		name:             options.OAuthOptions.Issuer,
 		secret:           []byte(options.JwtSecret),
+		rotator:          rotator,
 		maximumClockSkew: options.MaximumClockSkew,
 		signKey: &Keys{
 			SigningKey: &jose.JSONWebKey{
@@ -0,0 +1,188 @@
+/*
+Copyright 2022 The KubeSphere Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package token
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/form3tech-oss/jwt-go"
+	"gopkg.in/square/go-jose.v2"
+	"k8s.io/klog"
+)
+
+// signingKeys is one generation of token signing material, an HMAC secret
+// derived from the configured jwt secret and a generated RSA keypair,
+// both identified by the same key ID.
+type signingKeys struct {
+	keyID     string
+	secret    []byte
+	signKey   *Keys
+	createdAt time.Time
+	// retiredAt is when the generation was rotated out, it keeps
+	// validating tokens for the grace period from this point on
+	retiredAt time.Time
+}
+
+// keyRotator rotates the token signing material on a fixed interval.
+// Tokens carry the key ID of the generation they were signed with, and
+// generations rotated out keep validating tokens for a grace period, so
+// rotation does not invalidate sessions at once.
+type keyRotator struct {
+	// baseSecret the per-generation HMAC secrets are derived from
+	baseSecret  []byte
+	interval    time.Duration
+	gracePeriod time.Duration
+
+	mutex    sync.RWMutex
+	current  *signingKeys
+	previous []*signingKeys
+}
+
+func newKeyRotator(baseSecret []byte, interval, gracePeriod time.Duration) (*keyRotator, error) {
+	rotator := &keyRotator{
+		baseSecret:  baseSecret,
+		interval:    interval,
+		gracePeriod: gracePeriod,
+	}
+	current, err := rotator.generate()
+	if err != nil {
+		return nil, err
+	}
+	rotator.current = current
+	return rotator, nil
+}
+
+func (r *keyRotator) generate() (*signingKeys, error) {
+	signKeyData, err := generatePrivateKeyData()
+	if err != nil {
+		return nil, err
+	}
+	signKey, err := loadPrivateKey(signKeyData)
+	if err != nil {
+		return nil, err
+	}
+	keyID := fmt.Sprint(fnv32a(signKeyData))
+	return &signingKeys{
+		keyID:     keyID,
+		secret:    deriveSecret(r.baseSecret, keyID),
+		createdAt: time.Now(),
+		signKey: &Keys{
+			SigningKey: &jose.JSONWebKey{
+				Key:       signKey,
+				KeyID:     keyID,
+				Algorithm: jwt.SigningMethodRS256.Alg(),
+				Use:       "sig",
+			},
+			SigningKeyPub: &jose.JSONWebKey{
+				Key:       signKey.Public(),
+				KeyID:     keyID,
+				Algorithm: jwt.SigningMethodRS256.Alg(),
+				Use:       "sig",
+			},
+		},
+	}, nil
+}
+
+// deriveSecret derives the HMAC secret of a generation, the base secret
+// itself is never used to sign tokens once rotation is enabled.
+func deriveSecret(baseSecret []byte, keyID string) []byte {
+	mac := hmac.New(sha256.New, baseSecret)
+	mac.Write([]byte(keyID))
+	return mac.Sum(nil)
+}
+
+// currentKeys returns the signing material for newly issued tokens,
+// rotating first when the current generation exceeded the interval.
+func (r *keyRotator) currentKeys() *signingKeys {
+	r.mutex.RLock()
+	current := r.current
+	rotationDue := time.Since(current.createdAt) >= r.interval
+	r.mutex.RUnlock()
+	if !rotationDue {
+		return current
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	// lost the race against a concurrent rotation
+	if time.Since(r.current.createdAt) < r.interval {
+		return r.current
+	}
+	next, err := r.generate()
+	if err != nil {
+		// keep signing with the old generation rather than failing issuance
+		klog.Errorf("jwt: failed to rotate signing keys: %v", err)
+		return r.current
+	}
+	r.current.retiredAt = time.Now()
+	r.previous = append(r.previous, r.current)
+	r.current = next
+	r.prune()
+	klog.V(2).Infof("jwt: rotated token signing keys, new key id %s", next.keyID)
+	return r.current
+}
+
+// prune drops generations that exceeded the grace period, callers must
+// hold the write lock.
+func (r *keyRotator) prune() {
+	retained := r.previous[:0]
+	for _, keys := range r.previous {
+		if time.Since(keys.retiredAt) < r.gracePeriod {
+			retained = append(retained, keys)
+		}
+	}
+	r.previous = retained
+}
+
+// keysByID returns the generation a token was signed with, current or
+// still within its grace period.
+func (r *keyRotator) keysByID(keyID string) (*signingKeys, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+	if r.current.keyID == keyID {
+		return r.current, nil
+	}
+	for _, keys := range r.previous {
+		if keys.keyID != keyID {
+			continue
+		}
+		if time.Since(keys.retiredAt) >= r.gracePeriod {
+			break
+		}
+		return keys, nil
+	}
+	return nil, fmt.Errorf("jwt: unknown or expired signing key id %q", keyID)
+}
+
+// publicKeys lists the public keys of all generations still validating
+// tokens, newest first, for the JWKS endpoint.
+func (r *keyRotator) publicKeys() []jose.JSONWebKey {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+	keys := []jose.JSONWebKey{*r.current.signKey.SigningKeyPub}
+	for i := len(r.previous) - 1; i >= 0; i-- {
+		if time.Since(r.previous[i].retiredAt) >= r.gracePeriod {
+			continue
+		}
+		keys = append(keys, *r.previous[i].signKey.SigningKeyPub)
+	}
+	return keys
+}
@@ -0,0 +1,106 @@
+/*
+Copyright 2022 The KubeSphere Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package token
+
+import (
+	"testing"
+	"time"
+
+	"k8s.io/apiserver/pkg/authentication/user"
+
+	"kubesphere.io/kubesphere/pkg/apiserver/authentication"
+	"kubesphere.io/kubesphere/pkg/apiserver/authentication/oauth"
+)
+
+func TestKeyRotation(t *testing.T) {
+	rotator, err := newKeyRotator([]byte("kubesphere"), time.Hour, time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	first := rotator.currentKeys()
+	if first != rotator.currentKeys() {
+		t.Fatal("expected stable keys within the rotation interval")
+	}
+
+	// age the current generation past the interval to force a rotation
+	rotator.mutex.Lock()
+	rotator.current.createdAt = time.Now().Add(-2 * time.Hour)
+	rotator.mutex.Unlock()
+
+	second := rotator.currentKeys()
+	if second.keyID == first.keyID {
+		t.Fatal("expected a new key generation after rotation")
+	}
+
+	// the rotated out generation keeps validating within the grace period
+	if _, err = rotator.keysByID(first.keyID); err != nil {
+		t.Errorf("expected previous keys to stay valid within grace period, got %v", err)
+	}
+	if got := len(rotator.publicKeys()); got != 2 {
+		t.Errorf("expected JWKS to list both generations, got %d", got)
+	}
+
+	// and stops once the grace period has passed
+	rotator.mutex.Lock()
+	rotator.previous[0].retiredAt = time.Now().Add(-2 * time.Hour)
+	rotator.mutex.Unlock()
+	if _, err = rotator.keysByID(first.keyID); err == nil {
+		t.Error("expected expired keys to be rejected")
+	}
+	if got := len(rotator.publicKeys()); got != 1 {
+		t.Errorf("expected JWKS to drop expired generations, got %d", got)
+	}
+}
+
+func TestTokenSurvivesRotation(t *testing.T) {
+	options := authentication.NewOptions()
+	options.JwtSecret = "kubesphere"
+	options.OAuthOptions = oauth.NewOptions()
+	options.OAuthOptions.SignKeyRotationInterval = time.Hour
+
+	tokenIssuer, err := NewIssuer(options)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	admin := &user.DefaultInfo{Name: "admin"}
+	accessToken, err := tokenIssuer.IssueTo(&IssueRequest{
+		User:      admin,
+		Claims:    Claims{TokenType: AccessToken},
+		ExpiresIn: time.Hour,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// force a rotation, the already issued token must keep validating
+	// through its key ID during the grace period
+	rotator := tokenIssuer.(*issuer).rotator
+	rotator.mutex.Lock()
+	rotator.current.createdAt = time.Now().Add(-2 * time.Hour)
+	rotator.mutex.Unlock()
+	rotator.currentKeys()
+
+	verified, err := tokenIssuer.Verify(accessToken)
+	if err != nil {
+		t.Fatalf("expected token to survive rotation, got %v", err)
+	}
+	if verified.User.GetName() != admin.Name {
+		t.Errorf("expected user %s, got %s", admin.Name, verified.User.GetName())
+	}
+}
@@ -0,0 +1,136 @@
+/*
+Copyright 2022 The KubeSphere Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cached
+
+import (
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	utilcache "k8s.io/apimachinery/pkg/util/cache"
+	k8scache "k8s.io/client-go/tools/cache"
+
+	"kubesphere.io/kubesphere/pkg/apiserver/authorization/authorizer"
+	"kubesphere.io/kubesphere/pkg/informers"
+)
+
+const (
+	// DefaultTTL is how long a decision stays cached. Bindings rarely
+	// change and informer events flush the cache, the TTL only bounds
+	// staleness when an event is missed.
+	DefaultTTL = 10 * time.Second
+
+	cacheSize = 4096
+)
+
+// cachedAuthorizer caches the decisions of the delegate for a short TTL,
+// so repeated requests of the same user to the same resource do not
+// resolve the whole binding chain each time. Any change to a role or
+// binding invalidates all cached decisions at once.
+type cachedAuthorizer struct {
+	delegate authorizer.Authorizer
+	cache    *utilcache.LRUExpireCache
+	ttl      time.Duration
+	// generation is embedded in every cache key and bumped on role or
+	// binding events, making stale entries unreachable
+	generation int64
+}
+
+type cachedDecision struct {
+	decision authorizer.Decision
+	reason   string
+}
+
+// New wraps the delegate with a decision cache invalidated by role and
+// binding events of the shared informer factory.
+func New(delegate authorizer.Authorizer, ttl time.Duration, factory informers.InformerFactory) authorizer.Authorizer {
+	c := &cachedAuthorizer{
+		delegate: delegate,
+		cache:    utilcache.NewLRUExpireCache(cacheSize),
+		ttl:      ttl,
+	}
+
+	handler := k8scache.ResourceEventHandlerFuncs{
+		AddFunc:    func(interface{}) { c.invalidate() },
+		UpdateFunc: func(interface{}, interface{}) { c.invalidate() },
+		DeleteFunc: func(interface{}) { c.invalidate() },
+	}
+
+	rbac := factory.KubernetesSharedInformerFactory().Rbac().V1()
+	rbac.Roles().Informer().AddEventHandler(handler)
+	rbac.RoleBindings().Informer().AddEventHandler(handler)
+	rbac.ClusterRoles().Informer().AddEventHandler(handler)
+	rbac.ClusterRoleBindings().Informer().AddEventHandler(handler)
+
+	iam := factory.KubeSphereSharedInformerFactory().Iam().V1alpha2()
+	iam.GlobalRoles().Informer().AddEventHandler(handler)
+	iam.GlobalRoleBindings().Informer().AddEventHandler(handler)
+	iam.WorkspaceRoles().Informer().AddEventHandler(handler)
+	iam.WorkspaceRoleBindings().Informer().AddEventHandler(handler)
+
+	return c
+}
+
+func (c *cachedAuthorizer) Authorize(attr authorizer.Attributes) (authorizer.Decision, string, error) {
+	key := c.cacheKey(attr)
+	if key != "" {
+		if cached, ok := c.cache.Get(key); ok {
+			decision := cached.(cachedDecision)
+			return decision.decision, decision.reason, nil
+		}
+	}
+
+	decision, reason, err := c.delegate.Authorize(attr)
+	if err == nil && key != "" {
+		c.cache.Add(key, cachedDecision{decision: decision, reason: reason}, c.ttl)
+	}
+	return decision, reason, err
+}
+
+// invalidate makes every cached decision unreachable, entries age out of
+// the LRU through the TTL.
+func (c *cachedAuthorizer) invalidate() {
+	atomic.AddInt64(&c.generation, 1)
+}
+
+// cacheKey captures every attribute a decision depends on, an empty key
+// disables caching for the request.
+func (c *cachedAuthorizer) cacheKey(attr authorizer.Attributes) string {
+	requestUser := attr.GetUser()
+	if requestUser == nil {
+		return ""
+	}
+	return fmt.Sprintf("%d/%s/%s/%v/%v/%s/%s/%s/%s/%s/%s/%s/%s/%s/%s/%s/%s",
+		atomic.LoadInt64(&c.generation),
+		requestUser.GetName(),
+		strings.Join(requestUser.GetGroups(), ","),
+		attr.IsResourceRequest(),
+		attr.IsKubernetesRequest(),
+		attr.GetVerb(),
+		attr.GetResourceScope(),
+		attr.GetCluster(),
+		attr.GetWorkspace(),
+		attr.GetNamespace(),
+		attr.GetDevOps(),
+		attr.GetAPIGroup(),
+		attr.GetAPIVersion(),
+		attr.GetResource(),
+		attr.GetSubresource(),
+		attr.GetName(),
+		attr.GetPath())
+}
@@ -0,0 +1,91 @@
+/*
+Copyright 2022 The KubeSphere Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cached
+
+import (
+	"testing"
+	"time"
+
+	"k8s.io/apiserver/pkg/authentication/user"
+	"k8s.io/client-go/kubernetes/fake"
+	fakeks "kubesphere.io/kubesphere/pkg/client/clientset/versioned/fake"
+
+	"kubesphere.io/kubesphere/pkg/apiserver/authorization/authorizer"
+	"kubesphere.io/kubesphere/pkg/informers"
+)
+
+// countingAuthorizer counts how often the delegate was consulted
+type countingAuthorizer struct {
+	decision authorizer.Decision
+	calls    int
+}
+
+func (c *countingAuthorizer) Authorize(authorizer.Attributes) (authorizer.Decision, string, error) {
+	c.calls++
+	return c.decision, "", nil
+}
+
+func TestCachedAuthorizer(t *testing.T) {
+	delegate := &countingAuthorizer{decision: authorizer.DecisionAllow}
+	factory := informers.NewInformerFactories(fake.NewSimpleClientset(), fakeks.NewSimpleClientset(), nil, nil, nil, nil)
+	cachedAuthorizer := New(delegate, time.Minute, factory).(*cachedAuthorizer)
+
+	admin := authorizer.AttributesRecord{
+		User:            &user.DefaultInfo{Name: "admin"},
+		Verb:            "list",
+		Resource:        "pods",
+		Namespace:       "default",
+		ResourceRequest: true,
+	}
+
+	if decision, _, err := cachedAuthorizer.Authorize(admin); err != nil || decision != authorizer.DecisionAllow {
+		t.Fatalf("unexpected decision %v, error %v", decision, err)
+	}
+	if decision, _, err := cachedAuthorizer.Authorize(admin); err != nil || decision != authorizer.DecisionAllow {
+		t.Fatalf("unexpected decision %v, error %v", decision, err)
+	}
+	if delegate.calls != 1 {
+		t.Fatalf("expected the repeated request to be served from cache, delegate consulted %d times", delegate.calls)
+	}
+
+	// a different verb misses the cache
+	watch := admin
+	watch.Verb = "watch"
+	if _, _, err := cachedAuthorizer.Authorize(watch); err != nil {
+		t.Fatal(err)
+	}
+	if delegate.calls != 2 {
+		t.Fatalf("expected a different request to consult the delegate, got %d calls", delegate.calls)
+	}
+
+	// a role or binding event invalidates cached decisions
+	cachedAuthorizer.invalidate()
+	if _, _, err := cachedAuthorizer.Authorize(admin); err != nil {
+		t.Fatal(err)
+	}
+	if delegate.calls != 3 {
+		t.Fatalf("expected invalidation to consult the delegate again, got %d calls", delegate.calls)
+	}
+
+	// requests without a user are never cached
+	anonymous := authorizer.AttributesRecord{Verb: "list", Resource: "pods", ResourceRequest: true}
+	cachedAuthorizer.Authorize(anonymous)
+	cachedAuthorizer.Authorize(anonymous)
+	if delegate.calls != 5 {
+		t.Fatalf("expected requests without a user to bypass the cache, got %d calls", delegate.calls)
+	}
+}
@@ -93,11 +93,33 @@ func ResourceNameMatches(rule *rbacv1.PolicyRule, requestedName string) bool {
 		if ruleName == requestedName {
 			return true
 		}
+		// a trailing * matches any name with the preceding prefix,
+		// e.g. "web-*" covers every member of a scaled workload
+		if strings.HasSuffix(ruleName, "*") &&
+			len(requestedName) > 0 &&
+			strings.HasPrefix(requestedName, strings.TrimSuffix(ruleName, "*")) {
+			return true
+		}
 	}
 
 	return false
 }
 
+// SubresourceVerbMatches supports granting a subresource through an explicit
+// verb, e.g. verbs: ["log"] on pods allows viewing logs without covering
+// exec or any other pod subresource.
+func SubresourceVerbMatches(rule *rbacv1.PolicyRule, requestedSubresource string) bool {
+	if len(requestedSubresource) == 0 {
+		return false
+	}
+	for _, ruleVerb := range rule.Verbs {
+		if ruleVerb == requestedSubresource {
+			return true
+		}
+	}
+	return false
+}
+
 func NonResourceURLMatches(rule *rbacv1.PolicyRule, requestedURL string) bool {
 	for _, ruleURL := range rule.NonResourceURLs {
 		if ruleURL == rbacv1.NonResourceAll {
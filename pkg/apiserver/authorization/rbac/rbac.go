@@ -160,9 +160,18 @@ func ruleAllows(requestAttributes authorizer.Attributes, rule *rbacv1.PolicyRule
 			combinedResource = requestAttributes.GetResource() + "/" + requestAttributes.GetSubresource()
 		}
 
-		return VerbMatches(rule, requestAttributes.GetVerb()) &&
+		if VerbMatches(rule, requestAttributes.GetVerb()) &&
 			APIGroupMatches(rule, requestAttributes.GetAPIGroup()) &&
 			ResourceMatches(rule, combinedResource, requestAttributes.GetSubresource()) &&
+			ResourceNameMatches(rule, requestAttributes.GetName()) {
+			return true
+		}
+
+		// a rule may name the subresource as an explicit verb, e.g.
+		// verbs: ["log"] on pods grants that subresource alone
+		return SubresourceVerbMatches(rule, requestAttributes.GetSubresource()) &&
+			APIGroupMatches(rule, requestAttributes.GetAPIGroup()) &&
+			ResourceMatches(rule, requestAttributes.GetResource(), "") &&
 			ResourceNameMatches(rule, requestAttributes.GetName())
 	}
 
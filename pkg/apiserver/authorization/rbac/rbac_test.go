@@ -1036,3 +1036,140 @@ func TestAppliesTo(t *testing.T) {
 		}
 	}
 }
+
+func TestRuleAllows(t *testing.T) {
+	tests := []struct {
+		rule     rbacv1.PolicyRule
+		attr     authorizer.AttributesRecord
+		allowed  bool
+		testCase string
+	}{
+		{
+			rule: rbacv1.PolicyRule{
+				Verbs:     []string{"get"},
+				APIGroups: []string{""},
+				Resources: []string{"pods/log"},
+			},
+			attr: authorizer.AttributesRecord{
+				Verb:            "get",
+				Resource:        "pods",
+				Subresource:     "log",
+				ResourceRequest: true,
+			},
+			allowed:  true,
+			testCase: "explicit subresource rule allows the subresource",
+		},
+		{
+			rule: rbacv1.PolicyRule{
+				Verbs:     []string{"get"},
+				APIGroups: []string{""},
+				Resources: []string{"pods/log"},
+			},
+			attr: authorizer.AttributesRecord{
+				Verb:            "create",
+				Resource:        "pods",
+				Subresource:     "exec",
+				ResourceRequest: true,
+			},
+			allowed:  false,
+			testCase: "log subresource rule does not cover exec",
+		},
+		{
+			rule: rbacv1.PolicyRule{
+				Verbs:     []string{"log"},
+				APIGroups: []string{""},
+				Resources: []string{"pods"},
+			},
+			attr: authorizer.AttributesRecord{
+				Verb:            "get",
+				Resource:        "pods",
+				Subresource:     "log",
+				ResourceRequest: true,
+			},
+			allowed:  true,
+			testCase: "subresource granted through an explicit verb",
+		},
+		{
+			rule: rbacv1.PolicyRule{
+				Verbs:     []string{"log"},
+				APIGroups: []string{""},
+				Resources: []string{"pods"},
+			},
+			attr: authorizer.AttributesRecord{
+				Verb:            "get",
+				Resource:        "pods",
+				ResourceRequest: true,
+			},
+			allowed:  false,
+			testCase: "explicit subresource verb does not grant the resource itself",
+		},
+		{
+			rule: rbacv1.PolicyRule{
+				Verbs:     []string{"log"},
+				APIGroups: []string{""},
+				Resources: []string{"pods"},
+			},
+			attr: authorizer.AttributesRecord{
+				Verb:            "create",
+				Resource:        "pods",
+				Subresource:     "exec",
+				ResourceRequest: true,
+			},
+			allowed:  false,
+			testCase: "explicit log verb does not cover exec",
+		},
+		{
+			rule: rbacv1.PolicyRule{
+				Verbs:         []string{"get"},
+				APIGroups:     []string{""},
+				Resources:     []string{"pods"},
+				ResourceNames: []string{"web-*"},
+			},
+			attr: authorizer.AttributesRecord{
+				Verb:            "get",
+				Resource:        "pods",
+				Name:            "web-0",
+				ResourceRequest: true,
+			},
+			allowed:  true,
+			testCase: "resource name prefix pattern matches",
+		},
+		{
+			rule: rbacv1.PolicyRule{
+				Verbs:         []string{"get"},
+				APIGroups:     []string{""},
+				Resources:     []string{"pods"},
+				ResourceNames: []string{"web-*"},
+			},
+			attr: authorizer.AttributesRecord{
+				Verb:            "get",
+				Resource:        "pods",
+				Name:            "db-0",
+				ResourceRequest: true,
+			},
+			allowed:  false,
+			testCase: "resource name prefix pattern rejects other names",
+		},
+		{
+			rule: rbacv1.PolicyRule{
+				Verbs:         []string{"get"},
+				APIGroups:     []string{""},
+				Resources:     []string{"pods"},
+				ResourceNames: []string{"web-*"},
+			},
+			attr: authorizer.AttributesRecord{
+				Verb:            "list",
+				Resource:        "pods",
+				ResourceRequest: true,
+			},
+			allowed:  false,
+			testCase: "name pattern does not match requests without a name",
+		},
+	}
+
+	for _, tc := range tests {
+		if got := ruleAllows(tc.attr, &tc.rule); got != tc.allowed {
+			t.Errorf("case %q want %v, got %v", tc.testCase, tc.allowed, got)
+		}
+	}
+}
@@ -34,6 +34,7 @@ import (
 	"kubesphere.io/kubesphere/pkg/apiserver/authorization"
 	"kubesphere.io/kubesphere/pkg/constants"
 	"kubesphere.io/kubesphere/pkg/models/terminal"
+	"kubesphere.io/kubesphere/pkg/server/debug"
 	"kubesphere.io/kubesphere/pkg/simple/client/alerting"
 	"kubesphere.io/kubesphere/pkg/simple/client/auditing"
 	"kubesphere.io/kubesphere/pkg/simple/client/cache"
@@ -41,6 +42,7 @@ import (
 	"kubesphere.io/kubesphere/pkg/simple/client/edgeruntime"
 	"kubesphere.io/kubesphere/pkg/simple/client/events"
 	"kubesphere.io/kubesphere/pkg/simple/client/gateway"
+	"kubesphere.io/kubesphere/pkg/simple/client/gitops"
 	"kubesphere.io/kubesphere/pkg/simple/client/gpu"
 	"kubesphere.io/kubesphere/pkg/simple/client/k8s"
 	"kubesphere.io/kubesphere/pkg/simple/client/kubeedge"
@@ -53,6 +55,7 @@ import (
 	"kubesphere.io/kubesphere/pkg/simple/client/notification"
 	"kubesphere.io/kubesphere/pkg/simple/client/openpitrix"
 	"kubesphere.io/kubesphere/pkg/simple/client/s3"
+	"kubesphere.io/kubesphere/pkg/simple/client/secretstore"
 	"kubesphere.io/kubesphere/pkg/simple/client/servicemesh"
 	"kubesphere.io/kubesphere/pkg/simple/client/sonarqube"
 )
@@ -176,6 +179,9 @@ type Config struct {
 	GatewayOptions        *gateway.Options        `json:"gateway,omitempty" yaml:"gateway,omitempty" mapstructure:"gateway"`
 	GPUOptions            *gpu.Options            `json:"gpu,omitempty" yaml:"gpu,omitempty" mapstructure:"gpu"`
 	TerminalOptions       *terminal.Options       `json:"terminal,omitempty" yaml:"terminal,omitempty" mapstructure:"terminal"`
+	SecretStoreOptions    *secretstore.Options    `json:"secretStore,omitempty" yaml:"secretStore,omitempty" mapstructure:"secretStore"`
+	GitOpsOptions         *gitops.Options         `json:"gitops,omitempty" yaml:"gitops,omitempty" mapstructure:"gitops"`
+	DebugOptions          *debug.Options          `json:"debug,omitempty" yaml:"debug,omitempty" mapstructure:"debug"`
 }
 
 // newConfig creates a default non-empty Config
@@ -205,6 +211,9 @@ func New() *Config {
 		GatewayOptions:        gateway.NewGatewayOptions(),
 		GPUOptions:            gpu.NewGPUOptions(),
 		TerminalOptions:       terminal.NewTerminalOptions(),
+		SecretStoreOptions:    secretstore.NewSecretStoreOptions(),
+		GitOpsOptions:         gitops.NewGitOpsOptions(),
+		DebugOptions:          debug.NewOptions(),
 	}
 }
 
@@ -360,6 +369,14 @@ func (conf *Config) stripEmptyOptions() {
 	if conf.GPUOptions != nil && len(conf.GPUOptions.Kinds) == 0 {
 		conf.GPUOptions = nil
 	}
+
+	if conf.SecretStoreOptions != nil && conf.SecretStoreOptions.Endpoint == "" {
+		conf.SecretStoreOptions = nil
+	}
+
+	if conf.GitOpsOptions != nil && conf.GitOpsOptions.Repository == "" {
+		conf.GitOpsOptions = nil
+	}
 }
 
 // GetFromConfigMap returns KubeSphere ruuning config by the given ConfigMap.
@@ -30,7 +30,9 @@ import (
 	"kubesphere.io/kubesphere/pkg/apiserver/authentication"
 	"kubesphere.io/kubesphere/pkg/apiserver/authentication/oauth"
 	"kubesphere.io/kubesphere/pkg/apiserver/authorization"
+	"kubesphere.io/kubesphere/pkg/models/kubectl"
 	"kubesphere.io/kubesphere/pkg/models/terminal"
+	"kubesphere.io/kubesphere/pkg/server/debug"
 	"kubesphere.io/kubesphere/pkg/simple/client/alerting"
 	"kubesphere.io/kubesphere/pkg/simple/client/auditing"
 	"kubesphere.io/kubesphere/pkg/simple/client/cache"
@@ -38,6 +40,7 @@ import (
 	"kubesphere.io/kubesphere/pkg/simple/client/edgeruntime"
 	"kubesphere.io/kubesphere/pkg/simple/client/events"
 	"kubesphere.io/kubesphere/pkg/simple/client/gateway"
+	"kubesphere.io/kubesphere/pkg/simple/client/gitops"
 	"kubesphere.io/kubesphere/pkg/simple/client/gpu"
 	"kubesphere.io/kubesphere/pkg/simple/client/k8s"
 	"kubesphere.io/kubesphere/pkg/simple/client/kubeedge"
@@ -50,6 +53,7 @@ import (
 	"kubesphere.io/kubesphere/pkg/simple/client/notification"
 	"kubesphere.io/kubesphere/pkg/simple/client/openpitrix"
 	"kubesphere.io/kubesphere/pkg/simple/client/s3"
+	"kubesphere.io/kubesphere/pkg/simple/client/secretstore"
 	"kubesphere.io/kubesphere/pkg/simple/client/servicemesh"
 	"kubesphere.io/kubesphere/pkg/simple/client/sonarqube"
 )
@@ -150,6 +154,8 @@ func newTestConfig() (*Config, error) {
 			JwtSecret:                       "xxxxxx",
 			LoginHistoryMaximumEntries:      100,
 			MultipleLogin:                   false,
+			KubectlOptions:                  kubectl.NewOptions(),
+			LoginChallenge:                  authentication.NewLoginChallengeOptions(),
 			OAuthOptions: &oauth.Options{
 				Issuer:            oauth.DefaultIssuer,
 				IdentityProviders: []oauth.IdentityProviderOptions{},
@@ -163,6 +169,7 @@ func newTestConfig() (*Config, error) {
 				}},
 				AccessTokenMaxAge:            time.Hour * 24,
 				AccessTokenInactivityTimeout: 0,
+				SignKeyGracePeriod:           time.Hour * 2,
 			},
 		},
 		MultiClusterOptions: multicluster.NewOptions(),
@@ -172,9 +179,11 @@ func newTestConfig() (*Config, error) {
 			Version:     "6",
 		},
 		AuditingOptions: &auditing.Options{
-			Host:        "http://elasticsearch-logging-data.kubesphere-logging-system.svc:9200",
-			IndexPrefix: "ks-logstash-auditing",
-			Version:     "6",
+			Host:         "http://elasticsearch-logging-data.kubesphere-logging-system.svc:9200",
+			IndexPrefix:  "ks-logstash-auditing",
+			Version:      "6",
+			IgnoredUrls:  []string{"/healthz", "/readyz", "/metrics"},
+			IgnoredVerbs: []string{"watch"},
 		},
 		KubeEdgeOptions: &kubeedge.Options{
 			Endpoint: "http://edge-watcher.kubeedge.svc/api/",
@@ -196,6 +205,21 @@ func newTestConfig() (*Config, error) {
 			Image:   "alpine:3.15",
 			Timeout: 600,
 		},
+		GitOpsOptions: &gitops.Options{
+			Repository:   "https://github.com/example/platform-config.git",
+			Branch:       "main",
+			Path:         "clusters/production",
+			Username:     "bot",
+			Password:     "xxxxxx",
+			PollInterval: 5 * time.Minute,
+		},
+		SecretStoreOptions: &secretstore.Options{
+			Endpoint:   "https://vault.kubesphere-system.svc:8200",
+			Token:      "s.AAAAAAAAAAAAAAAAAAAAAAAA",
+			Mount:      "secret",
+			PathPrefix: "kubesphere",
+		},
+		DebugOptions: debug.NewOptions(),
 	}
 	return conf, nil
 }
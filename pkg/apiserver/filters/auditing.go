@@ -44,14 +44,20 @@ func WithAuditing(handler http.Handler, a auditing.Auditing) http.Handler {
 			return
 		}
 
-		// Auditing should igonre k8s request when k8s auditing is enabled.
-		if info.IsKubernetesRequest && a.K8sAuditingEnabled() {
+		// Auditing should igonre k8s request when k8s auditing is enabled,
+		// except for exec, attach and portforward whose streamed body
+		// neither side can audit, those are captured as dedicated events.
+		if info.IsKubernetesRequest && a.K8sAuditingEnabled() && !auditing.IsUpgradeSubresource(info.Subresource) {
 			handler.ServeHTTP(w, req)
 			return
 		}
 
 		e := a.LogRequestObject(req, info)
 		if e != nil {
+			// expose the event so handlers can complete it, e.g. the
+			// terminal handler links the session it starts.
+			req = req.WithContext(request.WithAuditEvent(req.Context(), &e.Event))
+
 			resp := auditing.NewResponseCapture(w)
 			handler.ServeHTTP(resp, req)
 
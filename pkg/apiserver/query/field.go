@@ -33,6 +33,7 @@ const (
 	FieldStatus              = "status"
 	FieldOwnerReference      = "ownerReference"
 	FieldOwnerKind           = "ownerKind"
+	FieldOwnerName           = "ownerName"
 
 	FieldType = "type"
 )
@@ -55,4 +56,5 @@ var ComparableFields = []Field{
 	FieldStatus,
 	FieldOwnerReference,
 	FieldOwnerKind,
+	FieldOwnerName,
 }
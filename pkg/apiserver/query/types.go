@@ -18,6 +18,7 @@ package query
 
 import (
 	"strconv"
+	"strings"
 
 	"github.com/emicklei/go-restful"
 	"k8s.io/apimachinery/pkg/labels"
@@ -33,13 +34,27 @@ const (
 	ParameterLimit         = "limit"
 	ParameterOrderBy       = "sortBy"
 	ParameterAscending     = "ascending"
+	ParameterFields        = "fields"
+	ParameterContinue      = "continue"
+	ParameterSearch        = "search"
+	ParameterCollation     = "collation"
+)
+
+const (
+	// CollationDefault keeps the byte-wise name ordering.
+	CollationDefault = "default"
+	// CollationNatural compares runs of digits by their numeric value, so
+	// app-2 sorts before app-10.
+	CollationNatural = "natural"
 )
 
 // Query represents api search terms
 type Query struct {
 	Pagination *Pagination
 
-	// sort result in which field, default to FieldCreationTimeStamp
+	// sort result in which field, default to FieldCreationTimeStamp. A
+	// leading-dot field path, e.g. .status.availableReplicas, sorts on
+	// the value at that path instead of the named fields.
 	SortBy Field
 
 	// sort result in ascending or descending order, default to descending
@@ -49,6 +64,24 @@ type Query struct {
 	Filters map[Field]Value
 
 	LabelSelector string
+
+	// Fields projects list items onto the selected dot separated field
+	// paths, e.g. metadata.name,status.phase. Empty means full objects.
+	Fields []string
+
+	// Continue resumes the list after the item a previous page ended
+	// with, it takes precedence over the page parameter.
+	Continue string
+
+	// Search matches the term against the searchable text of the objects,
+	// name, labels, annotations and container images, case-insensitively.
+	Search string
+
+	// Collation selects the name ordering when sorting by name, natural
+	// compares runs of digits numerically and any other non-default value
+	// is a BCP 47 language tag selecting that locale's collation. Empty
+	// keeps the byte-wise ordering.
+	Collation string
 }
 
 type Pagination struct {
@@ -140,8 +173,22 @@ func ParseQueryParameter(request *restful.Request) *Query {
 
 	query.LabelSelector = request.QueryParameter(ParameterLabelSelector)
 
+	query.Continue = request.QueryParameter(ParameterContinue)
+
+	query.Search = request.QueryParameter(ParameterSearch)
+
+	query.Collation = request.QueryParameter(ParameterCollation)
+
+	if fields := request.QueryParameter(ParameterFields); fields != "" {
+		for _, field := range strings.Split(fields, ",") {
+			if field = strings.TrimSpace(field); field != "" {
+				query.Fields = append(query.Fields, field)
+			}
+		}
+	}
+
 	for key, values := range request.Request.URL.Query() {
-		if !sliceutil.HasString([]string{ParameterPage, ParameterLimit, ParameterOrderBy, ParameterAscending, ParameterLabelSelector}, key) {
+		if !sliceutil.HasString([]string{ParameterPage, ParameterLimit, ParameterOrderBy, ParameterAscending, ParameterLabelSelector, ParameterFields, ParameterContinue, ParameterSearch, ParameterCollation}, key) {
 			// support multiple query condition
 			for _, value := range values {
 				query.Filters[Field(key)] = Value(value)
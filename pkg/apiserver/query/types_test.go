@@ -45,6 +45,19 @@ func TestParseQueryParameter(t *testing.T) {
 				},
 			},
 		},
+		{
+			"test field projection",
+			"name=foo&fields=metadata.name,status.phase&page=1&limit=10",
+			&Query{
+				Pagination: newPagination(10, 0),
+				SortBy:     FieldCreationTimeStamp,
+				Ascending:  false,
+				Filters: map[Field]Value{
+					FieldName: Value("foo"),
+				},
+				Fields: []string{"metadata.name", "status.phase"},
+			},
+		},
 		{
 			"test bad case",
 			"xxxx=xxxx&dsfsw=xxxx&page=abc&limit=add&ascending=ssss",
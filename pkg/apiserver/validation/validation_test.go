@@ -0,0 +1,86 @@
+/*
+Copyright 2022 The KubeSphere Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/emicklei/go-restful"
+)
+
+type sampleBody struct {
+	Name     string `json:"name"`
+	Replicas int    `json:"replicas,omitempty"`
+}
+
+func newTestContainer() *restful.Container {
+	accept := func(req *restful.Request, resp *restful.Response) {
+		resp.WriteHeader(http.StatusOK)
+	}
+
+	ws := new(restful.WebService)
+	ws.Path("/kapis/iam.kubesphere.io/v1alpha2").
+		Consumes(restful.MIME_JSON).
+		Produces(restful.MIME_JSON)
+	ws.Route(ws.POST("/samples").To(accept).Reads(sampleBody{}))
+	ws.Route(ws.POST("/untyped").To(accept))
+
+	other := new(restful.WebService)
+	other.Path("/kapis/other.kubesphere.io/v1").
+		Consumes(restful.MIME_JSON).
+		Produces(restful.MIME_JSON)
+	other.Route(other.POST("/samples").To(accept).Reads(sampleBody{}))
+
+	container := restful.NewContainer()
+	container.Add(ws)
+	container.Add(other)
+	container.Filter(NewBodyValidator(container.RegisteredWebServices()).Filter)
+	return container
+}
+
+func TestBodyValidator(t *testing.T) {
+	container := newTestContainer()
+
+	tests := []struct {
+		description  string
+		path         string
+		body         string
+		expectedCode int
+	}{
+		{"valid body passes", "/kapis/iam.kubesphere.io/v1alpha2/samples", `{"name":"foo","replicas":2}`, http.StatusOK},
+		{"missing required field", "/kapis/iam.kubesphere.io/v1alpha2/samples", `{"replicas":2}`, http.StatusBadRequest},
+		{"wrong field type", "/kapis/iam.kubesphere.io/v1alpha2/samples", `{"name":"foo","replicas":"two"}`, http.StatusBadRequest},
+		{"malformed json", "/kapis/iam.kubesphere.io/v1alpha2/samples", `{"name":`, http.StatusBadRequest},
+		{"route without declared body passes", "/kapis/iam.kubesphere.io/v1alpha2/untyped", `{"anything":true}`, http.StatusOK},
+		{"group outside the scope passes", "/kapis/other.kubesphere.io/v1/samples", `{"replicas":"two"}`, http.StatusOK},
+	}
+
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			request := httptest.NewRequest(http.MethodPost, test.path, strings.NewReader(test.body))
+			request.Header.Set("Content-Type", restful.MIME_JSON)
+			recorder := httptest.NewRecorder()
+			container.ServeHTTP(recorder, request)
+			if recorder.Code != test.expectedCode {
+				t.Errorf("expected status %d, got %d: %s", test.expectedCode, recorder.Code, recorder.Body.String())
+			}
+		})
+	}
+}
@@ -32,6 +32,7 @@ import (
 	devopsv1alpha3 "kubesphere.io/kubesphere/pkg/client/clientset/versioned/typed/devops/v1alpha3"
 	iamv1alpha2 "kubesphere.io/kubesphere/pkg/client/clientset/versioned/typed/iam/v1alpha2"
 	networkv1alpha1 "kubesphere.io/kubesphere/pkg/client/clientset/versioned/typed/network/v1alpha1"
+	notificationv1alpha1 "kubesphere.io/kubesphere/pkg/client/clientset/versioned/typed/notification/v1alpha1"
 	notificationv2beta1 "kubesphere.io/kubesphere/pkg/client/clientset/versioned/typed/notification/v2beta1"
 	notificationv2beta2 "kubesphere.io/kubesphere/pkg/client/clientset/versioned/typed/notification/v2beta2"
 	quotav1alpha2 "kubesphere.io/kubesphere/pkg/client/clientset/versioned/typed/quota/v1alpha2"
@@ -53,6 +54,7 @@ type Interface interface {
 	DevopsV1alpha3() devopsv1alpha3.DevopsV1alpha3Interface
 	IamV1alpha2() iamv1alpha2.IamV1alpha2Interface
 	NetworkV1alpha1() networkv1alpha1.NetworkV1alpha1Interface
+	NotificationV1alpha1() notificationv1alpha1.NotificationV1alpha1Interface
 	NotificationV2beta1() notificationv2beta1.NotificationV2beta1Interface
 	NotificationV2beta2() notificationv2beta2.NotificationV2beta2Interface
 	QuotaV1alpha2() quotav1alpha2.QuotaV1alpha2Interface
@@ -68,23 +70,24 @@ type Interface interface {
 // version included in a Clientset.
 type Clientset struct {
 	*discovery.DiscoveryClient
-	alertingV2beta1     *alertingv2beta1.AlertingV2beta1Client
-	applicationV1alpha1 *applicationv1alpha1.ApplicationV1alpha1Client
-	auditingV1alpha1    *auditingv1alpha1.AuditingV1alpha1Client
-	clusterV1alpha1     *clusterv1alpha1.ClusterV1alpha1Client
-	devopsV1alpha1      *devopsv1alpha1.DevopsV1alpha1Client
-	devopsV1alpha3      *devopsv1alpha3.DevopsV1alpha3Client
-	iamV1alpha2         *iamv1alpha2.IamV1alpha2Client
-	networkV1alpha1     *networkv1alpha1.NetworkV1alpha1Client
-	notificationV2beta1 *notificationv2beta1.NotificationV2beta1Client
-	notificationV2beta2 *notificationv2beta2.NotificationV2beta2Client
-	quotaV1alpha2       *quotav1alpha2.QuotaV1alpha2Client
-	servicemeshV1alpha2 *servicemeshv1alpha2.ServicemeshV1alpha2Client
-	storageV1alpha1     *storagev1alpha1.StorageV1alpha1Client
-	tenantV1alpha1      *tenantv1alpha1.TenantV1alpha1Client
-	tenantV1alpha2      *tenantv1alpha2.TenantV1alpha2Client
-	typesV1beta1        *typesv1beta1.TypesV1beta1Client
-	typesV1beta2        *typesv1beta2.TypesV1beta2Client
+	alertingV2beta1      *alertingv2beta1.AlertingV2beta1Client
+	applicationV1alpha1  *applicationv1alpha1.ApplicationV1alpha1Client
+	auditingV1alpha1     *auditingv1alpha1.AuditingV1alpha1Client
+	clusterV1alpha1      *clusterv1alpha1.ClusterV1alpha1Client
+	devopsV1alpha1       *devopsv1alpha1.DevopsV1alpha1Client
+	devopsV1alpha3       *devopsv1alpha3.DevopsV1alpha3Client
+	iamV1alpha2          *iamv1alpha2.IamV1alpha2Client
+	networkV1alpha1      *networkv1alpha1.NetworkV1alpha1Client
+	notificationV1alpha1 *notificationv1alpha1.NotificationV1alpha1Client
+	notificationV2beta1  *notificationv2beta1.NotificationV2beta1Client
+	notificationV2beta2  *notificationv2beta2.NotificationV2beta2Client
+	quotaV1alpha2        *quotav1alpha2.QuotaV1alpha2Client
+	servicemeshV1alpha2  *servicemeshv1alpha2.ServicemeshV1alpha2Client
+	storageV1alpha1      *storagev1alpha1.StorageV1alpha1Client
+	tenantV1alpha1       *tenantv1alpha1.TenantV1alpha1Client
+	tenantV1alpha2       *tenantv1alpha2.TenantV1alpha2Client
+	typesV1beta1         *typesv1beta1.TypesV1beta1Client
+	typesV1beta2         *typesv1beta2.TypesV1beta2Client
 }
 
 // AlertingV2beta1 retrieves the AlertingV2beta1Client
@@ -127,6 +130,11 @@ func (c *Clientset) NetworkV1alpha1() networkv1alpha1.NetworkV1alpha1Interface {
 	return c.networkV1alpha1
 }
 
+// NotificationV1alpha1 retrieves the NotificationV1alpha1Client
+func (c *Clientset) NotificationV1alpha1() notificationv1alpha1.NotificationV1alpha1Interface {
+	return c.notificationV1alpha1
+}
+
 // NotificationV2beta1 retrieves the NotificationV2beta1Client
 func (c *Clientset) NotificationV2beta1() notificationv2beta1.NotificationV2beta1Interface {
 	return c.notificationV2beta1
@@ -225,6 +233,10 @@ func NewForConfig(c *rest.Config) (*Clientset, error) {
 	if err != nil {
 		return nil, err
 	}
+	cs.notificationV1alpha1, err = notificationv1alpha1.NewForConfig(&configShallowCopy)
+	if err != nil {
+		return nil, err
+	}
 	cs.notificationV2beta1, err = notificationv2beta1.NewForConfig(&configShallowCopy)
 	if err != nil {
 		return nil, err
@@ -281,6 +293,7 @@ func NewForConfigOrDie(c *rest.Config) *Clientset {
 	cs.devopsV1alpha3 = devopsv1alpha3.NewForConfigOrDie(c)
 	cs.iamV1alpha2 = iamv1alpha2.NewForConfigOrDie(c)
 	cs.networkV1alpha1 = networkv1alpha1.NewForConfigOrDie(c)
+	cs.notificationV1alpha1 = notificationv1alpha1.NewForConfigOrDie(c)
 	cs.notificationV2beta1 = notificationv2beta1.NewForConfigOrDie(c)
 	cs.notificationV2beta2 = notificationv2beta2.NewForConfigOrDie(c)
 	cs.quotaV1alpha2 = quotav1alpha2.NewForConfigOrDie(c)
@@ -306,6 +319,7 @@ func New(c rest.Interface) *Clientset {
 	cs.devopsV1alpha3 = devopsv1alpha3.New(c)
 	cs.iamV1alpha2 = iamv1alpha2.New(c)
 	cs.networkV1alpha1 = networkv1alpha1.New(c)
+	cs.notificationV1alpha1 = notificationv1alpha1.New(c)
 	cs.notificationV2beta1 = notificationv2beta1.New(c)
 	cs.notificationV2beta2 = notificationv2beta2.New(c)
 	cs.quotaV1alpha2 = quotav1alpha2.New(c)
@@ -41,6 +41,8 @@ import (
 	fakeiamv1alpha2 "kubesphere.io/kubesphere/pkg/client/clientset/versioned/typed/iam/v1alpha2/fake"
 	networkv1alpha1 "kubesphere.io/kubesphere/pkg/client/clientset/versioned/typed/network/v1alpha1"
 	fakenetworkv1alpha1 "kubesphere.io/kubesphere/pkg/client/clientset/versioned/typed/network/v1alpha1/fake"
+	notificationv1alpha1 "kubesphere.io/kubesphere/pkg/client/clientset/versioned/typed/notification/v1alpha1"
+	fakenotificationv1alpha1 "kubesphere.io/kubesphere/pkg/client/clientset/versioned/typed/notification/v1alpha1/fake"
 	notificationv2beta1 "kubesphere.io/kubesphere/pkg/client/clientset/versioned/typed/notification/v2beta1"
 	fakenotificationv2beta1 "kubesphere.io/kubesphere/pkg/client/clientset/versioned/typed/notification/v2beta1/fake"
 	notificationv2beta2 "kubesphere.io/kubesphere/pkg/client/clientset/versioned/typed/notification/v2beta2"
@@ -148,6 +150,11 @@ func (c *Clientset) NetworkV1alpha1() networkv1alpha1.NetworkV1alpha1Interface {
 	return &fakenetworkv1alpha1.FakeNetworkV1alpha1{Fake: &c.Fake}
 }
 
+// NotificationV1alpha1 retrieves the NotificationV1alpha1Client
+func (c *Clientset) NotificationV1alpha1() notificationv1alpha1.NotificationV1alpha1Interface {
+	return &fakenotificationv1alpha1.FakeNotificationV1alpha1{Fake: &c.Fake}
+}
+
 // NotificationV2beta1 retrieves the NotificationV2beta1Client
 func (c *Clientset) NotificationV2beta1() notificationv2beta1.NotificationV2beta1Interface {
 	return &fakenotificationv2beta1.FakeNotificationV2beta1{Fake: &c.Fake}
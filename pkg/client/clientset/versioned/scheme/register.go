@@ -32,6 +32,7 @@ import (
 	devopsv1alpha3 "kubesphere.io/api/devops/v1alpha3"
 	iamv1alpha2 "kubesphere.io/api/iam/v1alpha2"
 	networkv1alpha1 "kubesphere.io/api/network/v1alpha1"
+	notificationv1alpha1 "kubesphere.io/api/notification/v1alpha1"
 	notificationv2beta1 "kubesphere.io/api/notification/v2beta1"
 	notificationv2beta2 "kubesphere.io/api/notification/v2beta2"
 	quotav1alpha2 "kubesphere.io/api/quota/v1alpha2"
@@ -55,6 +56,7 @@ var localSchemeBuilder = runtime.SchemeBuilder{
 	devopsv1alpha3.AddToScheme,
 	iamv1alpha2.AddToScheme,
 	networkv1alpha1.AddToScheme,
+	notificationv1alpha1.AddToScheme,
 	notificationv2beta1.AddToScheme,
 	notificationv2beta2.AddToScheme,
 	quotav1alpha2.AddToScheme,
@@ -44,6 +44,14 @@ func (c *FakeTypesV1beta1) FederatedConfigMaps(namespace string) v1beta1.Federat
 	return &FakeFederatedConfigMaps{c, namespace}
 }
 
+func (c *FakeTypesV1beta1) FederatedCronJobs(namespace string) v1beta1.FederatedCronJobInterface {
+	return &FakeFederatedCronJobs{c, namespace}
+}
+
+func (c *FakeTypesV1beta1) FederatedDaemonSets(namespace string) v1beta1.FederatedDaemonSetInterface {
+	return &FakeFederatedDaemonSets{c, namespace}
+}
+
 func (c *FakeTypesV1beta1) FederatedDeployments(namespace string) v1beta1.FederatedDeploymentInterface {
 	return &FakeFederatedDeployments{c, namespace}
 }
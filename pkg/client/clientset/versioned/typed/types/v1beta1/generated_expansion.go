@@ -26,6 +26,10 @@ type FederatedClusterRoleBindingExpansion interface{}
 
 type FederatedConfigMapExpansion interface{}
 
+type FederatedCronJobExpansion interface{}
+
+type FederatedDaemonSetExpansion interface{}
+
 type FederatedDeploymentExpansion interface{}
 
 type FederatedGroupExpansion interface{}
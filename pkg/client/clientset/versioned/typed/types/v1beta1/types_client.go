@@ -30,6 +30,8 @@ type TypesV1beta1Interface interface {
 	FederatedClusterRolesGetter
 	FederatedClusterRoleBindingsGetter
 	FederatedConfigMapsGetter
+	FederatedCronJobsGetter
+	FederatedDaemonSetsGetter
 	FederatedDeploymentsGetter
 	FederatedGroupsGetter
 	FederatedGroupBindingsGetter
@@ -64,6 +66,14 @@ func (c *TypesV1beta1Client) FederatedConfigMaps(namespace string) FederatedConf
 	return newFederatedConfigMaps(c, namespace)
 }
 
+func (c *TypesV1beta1Client) FederatedCronJobs(namespace string) FederatedCronJobInterface {
+	return newFederatedCronJobs(c, namespace)
+}
+
+func (c *TypesV1beta1Client) FederatedDaemonSets(namespace string) FederatedDaemonSetInterface {
+	return newFederatedDaemonSets(c, namespace)
+}
+
 func (c *TypesV1beta1Client) FederatedDeployments(namespace string) FederatedDeploymentInterface {
 	return newFederatedDeployments(c, namespace)
 }
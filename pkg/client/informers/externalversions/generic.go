@@ -31,6 +31,7 @@ import (
 	v1alpha3 "kubesphere.io/api/devops/v1alpha3"
 	v1alpha2 "kubesphere.io/api/iam/v1alpha2"
 	networkv1alpha1 "kubesphere.io/api/network/v1alpha1"
+	notificationv1alpha1 "kubesphere.io/api/notification/v1alpha1"
 	notificationv2beta1 "kubesphere.io/api/notification/v2beta1"
 	v2beta2 "kubesphere.io/api/notification/v2beta2"
 	quotav1alpha2 "kubesphere.io/api/quota/v1alpha2"
@@ -144,6 +145,12 @@ func (f *sharedInformerFactory) ForResource(resource schema.GroupVersionResource
 	case networkv1alpha1.SchemeGroupVersion.WithResource("namespacenetworkpolicies"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Network().V1alpha1().NamespaceNetworkPolicies().Informer()}, nil
 
+		// Group=notification.kubesphere.io, Version=v1alpha1
+	case notificationv1alpha1.SchemeGroupVersion.WithResource("notificationtemplates"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Notification().V1alpha1().NotificationTemplates().Informer()}, nil
+	case notificationv1alpha1.SchemeGroupVersion.WithResource("subscriptions"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Notification().V1alpha1().Subscriptions().Informer()}, nil
+
 		// Group=notification.kubesphere.io, Version=v2beta1
 	case notificationv2beta1.SchemeGroupVersion.WithResource("configs"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Notification().V2beta1().Configs().Informer()}, nil
@@ -195,6 +202,10 @@ func (f *sharedInformerFactory) ForResource(resource schema.GroupVersionResource
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Types().V1beta1().FederatedClusterRoleBindings().Informer()}, nil
 	case v1beta1.SchemeGroupVersion.WithResource("federatedconfigmaps"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Types().V1beta1().FederatedConfigMaps().Informer()}, nil
+	case v1beta1.SchemeGroupVersion.WithResource("federatedcronjobs"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Types().V1beta1().FederatedCronJobs().Informer()}, nil
+	case v1beta1.SchemeGroupVersion.WithResource("federateddaemonsets"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Types().V1beta1().FederatedDaemonSets().Informer()}, nil
 	case v1beta1.SchemeGroupVersion.WithResource("federateddeployments"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Types().V1beta1().FederatedDeployments().Informer()}, nil
 	case v1beta1.SchemeGroupVersion.WithResource("federatedgroups"):
@@ -32,6 +32,10 @@ type Interface interface {
 	FederatedClusterRoleBindings() FederatedClusterRoleBindingInformer
 	// FederatedConfigMaps returns a FederatedConfigMapInformer.
 	FederatedConfigMaps() FederatedConfigMapInformer
+	// FederatedCronJobs returns a FederatedCronJobInformer.
+	FederatedCronJobs() FederatedCronJobInformer
+	// FederatedDaemonSets returns a FederatedDaemonSetInformer.
+	FederatedDaemonSets() FederatedDaemonSetInformer
 	// FederatedDeployments returns a FederatedDeploymentInformer.
 	FederatedDeployments() FederatedDeploymentInformer
 	// FederatedGroups returns a FederatedGroupInformer.
@@ -87,6 +91,16 @@ func (v *version) FederatedConfigMaps() FederatedConfigMapInformer {
 	return &federatedConfigMapInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
 }
 
+// FederatedCronJobs returns a FederatedCronJobInformer.
+func (v *version) FederatedCronJobs() FederatedCronJobInformer {
+	return &federatedCronJobInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
+// FederatedDaemonSets returns a FederatedDaemonSetInformer.
+func (v *version) FederatedDaemonSets() FederatedDaemonSetInformer {
+	return &federatedDaemonSetInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
 // FederatedDeployments returns a FederatedDeploymentInformer.
 func (v *version) FederatedDeployments() FederatedDeploymentInformer {
 	return &federatedDeploymentInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
@@ -50,6 +50,22 @@ type FederatedConfigMapListerExpansion interface{}
 // FederatedConfigMapNamespaceLister.
 type FederatedConfigMapNamespaceListerExpansion interface{}
 
+// FederatedCronJobListerExpansion allows custom methods to be added to
+// FederatedCronJobLister.
+type FederatedCronJobListerExpansion interface{}
+
+// FederatedCronJobNamespaceListerExpansion allows custom methods to be added to
+// FederatedCronJobNamespaceLister.
+type FederatedCronJobNamespaceListerExpansion interface{}
+
+// FederatedDaemonSetListerExpansion allows custom methods to be added to
+// FederatedDaemonSetLister.
+type FederatedDaemonSetListerExpansion interface{}
+
+// FederatedDaemonSetNamespaceListerExpansion allows custom methods to be added to
+// FederatedDaemonSetNamespaceLister.
+type FederatedDaemonSetNamespaceListerExpansion interface{}
+
 // FederatedDeploymentListerExpansion allows custom methods to be added to
 // FederatedDeploymentLister.
 type FederatedDeploymentListerExpansion interface{}
@@ -54,6 +54,7 @@ const (
 	AuthenticationTag = "Authentication"
 	UserTag           = "User"
 	GroupTag          = "Group"
+	AccessRequestTag  = "Access Request"
 
 	WorkspaceMemberTag     = "Workspace Member"
 	DevOpsProjectMemberTag = "DevOps Project Member"
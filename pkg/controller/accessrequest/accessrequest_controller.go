@@ -0,0 +1,382 @@
+/*
+Copyright 2022 The KubeSphere Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package accessrequest
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	iamv1alpha2 "kubesphere.io/api/iam/v1alpha2"
+	notificationv1alpha1 "kubesphere.io/api/notification/v1alpha1"
+	tenantv1alpha1 "kubesphere.io/api/tenant/v1alpha1"
+
+	"kubesphere.io/kubesphere/pkg/controller/notificationdispatch"
+	"kubesphere.io/kubesphere/pkg/utils/sliceutil"
+)
+
+const (
+	controllerName = "accessrequest-controller"
+	finalizer      = "finalizers.kubesphere.io/accessrequests"
+
+	// decisionNotifiedAnnotation guards the decided notification so a
+	// decision is only published once.
+	decisionNotifiedAnnotation = "iam.kubesphere.io/decision-notified"
+)
+
+// Notifier publishes a notification event, the notification dispatch
+// reconciler satisfies it.
+type Notifier interface {
+	Publish(ctx context.Context, event notificationdispatch.Event)
+}
+
+// Reconciler drives the access request workflow: it resolves and records
+// the approvers of the requested scope while the request is pending, and
+// once a request is approved it maintains the granted binding, removing
+// it again when the optional expiry is reached or the request goes away.
+type Reconciler struct {
+	client.Client
+	Logger   logr.Logger
+	Recorder record.EventRecorder
+
+	// Notifier is optional, without it requests still move through the
+	// workflow but no notification goes out.
+	Notifier Notifier
+}
+
+func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) error {
+	if r.Client == nil {
+		r.Client = mgr.GetClient()
+	}
+	if r.Logger == nil {
+		r.Logger = ctrl.Log.WithName("controllers").WithName(controllerName)
+	}
+	if r.Recorder == nil {
+		r.Recorder = mgr.GetEventRecorderFor(controllerName)
+	}
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(controllerName).
+		For(&iamv1alpha2.AccessRequest{}).
+		Complete(r)
+}
+
+func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	accessRequest := &iamv1alpha2.AccessRequest{}
+	if err := r.Get(ctx, req.NamespacedName, accessRequest); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	if !accessRequest.DeletionTimestamp.IsZero() {
+		if sliceutil.HasString(accessRequest.Finalizers, finalizer) {
+			if err := r.removeBindings(ctx, accessRequest); err != nil {
+				return ctrl.Result{}, err
+			}
+			instanceCopy := accessRequest.DeepCopy()
+			instanceCopy.Finalizers = sliceutil.RemoveString(instanceCopy.Finalizers, func(item string) bool {
+				return item == finalizer
+			})
+			return ctrl.Result{}, r.Update(ctx, instanceCopy)
+		}
+		return ctrl.Result{}, nil
+	}
+
+	if !sliceutil.HasString(accessRequest.Finalizers, finalizer) {
+		instanceCopy := accessRequest.DeepCopy()
+		instanceCopy.Finalizers = append(instanceCopy.Finalizers, finalizer)
+		return ctrl.Result{}, r.Update(ctx, instanceCopy)
+	}
+
+	switch accessRequest.Status.State {
+	case "":
+		instanceCopy := accessRequest.DeepCopy()
+		instanceCopy.Status.State = iamv1alpha2.AccessRequestPending
+		return ctrl.Result{}, r.Update(ctx, instanceCopy)
+	case iamv1alpha2.AccessRequestPending:
+		return ctrl.Result{}, r.syncApprovers(ctx, accessRequest)
+	case iamv1alpha2.AccessRequestApproved:
+		return r.syncGrantedBinding(ctx, accessRequest)
+	case iamv1alpha2.AccessRequestDenied:
+		return ctrl.Result{}, r.notifyDecision(ctx, accessRequest)
+	default:
+		return ctrl.Result{}, nil
+	}
+}
+
+// syncApprovers records the users who may decide the request in an
+// annotation and notifies them, once per submission.
+func (r *Reconciler) syncApprovers(ctx context.Context, accessRequest *iamv1alpha2.AccessRequest) error {
+	if _, annotated := accessRequest.Annotations[iamv1alpha2.AccessRequestApproversAnnotation]; annotated {
+		return nil
+	}
+
+	approvers, err := r.resolveApprovers(ctx, accessRequest)
+	if err != nil {
+		return err
+	}
+
+	instanceCopy := accessRequest.DeepCopy()
+	if instanceCopy.Annotations == nil {
+		instanceCopy.Annotations = map[string]string{}
+	}
+	instanceCopy.Annotations[iamv1alpha2.AccessRequestApproversAnnotation] = strings.Join(approvers, ",")
+	patch := client.MergeFrom(accessRequest)
+	if err := r.Patch(ctx, instanceCopy, patch); err != nil {
+		return err
+	}
+
+	r.Recorder.Event(accessRequest, corev1.EventTypeNormal, "Submitted",
+		fmt.Sprintf("access request of user %s awaits approval by: %s", accessRequest.Spec.Username, strings.Join(approvers, ",")))
+
+	if r.Notifier != nil {
+		r.Notifier.Publish(ctx, notificationdispatch.Event{
+			Type: notificationv1alpha1.EventAccessRequestSubmitted,
+			Object: notificationdispatch.EventObject{
+				APIVersion: iamv1alpha2.SchemeGroupVersion.String(),
+				Kind:       iamv1alpha2.ResourceKindAccessRequest,
+				Namespace:  accessRequest.Spec.Namespace,
+				Name:       accessRequest.Name,
+			},
+			Timestamp: metav1.Now(),
+		})
+	}
+	return nil
+}
+
+// resolveApprovers returns the users bound to the admin role of the
+// requested scope: the workspace admin role for workspace requests, the
+// namespace admin role for project requests.
+func (r *Reconciler) resolveApprovers(ctx context.Context, accessRequest *iamv1alpha2.AccessRequest) ([]string, error) {
+	var approvers []string
+
+	appendUserSubjects := func(subjects []rbacv1.Subject) {
+		for _, subject := range subjects {
+			if subject.Kind == rbacv1.UserKind && !sliceutil.HasString(approvers, subject.Name) {
+				approvers = append(approvers, subject.Name)
+			}
+		}
+	}
+
+	if workspace := accessRequest.Spec.Workspace; workspace != "" {
+		workspaceAdmin := fmt.Sprintf("%s-admin", workspace)
+		var bindings iamv1alpha2.WorkspaceRoleBindingList
+		if err := r.List(ctx, &bindings, client.MatchingLabels{tenantv1alpha1.WorkspaceLabel: workspace}); err != nil {
+			return nil, err
+		}
+		for _, binding := range bindings.Items {
+			if binding.RoleRef.Name == workspaceAdmin {
+				appendUserSubjects(binding.Subjects)
+			}
+		}
+	} else if namespace := accessRequest.Spec.Namespace; namespace != "" {
+		var bindings rbacv1.RoleBindingList
+		if err := r.List(ctx, &bindings, client.InNamespace(namespace)); err != nil {
+			return nil, err
+		}
+		for _, binding := range bindings.Items {
+			if binding.RoleRef.Name == iamv1alpha2.NamespaceAdmin {
+				appendUserSubjects(binding.Subjects)
+			}
+		}
+	}
+
+	sort.Strings(approvers)
+	return approvers, nil
+}
+
+// syncGrantedBinding creates the binding an approved request grants and
+// removes it again once the expiration time is reached.
+func (r *Reconciler) syncGrantedBinding(ctx context.Context, accessRequest *iamv1alpha2.AccessRequest) (ctrl.Result, error) {
+	if err := r.notifyDecision(ctx, accessRequest); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	// derive the expiration time from the approval time on first sync
+	if accessRequest.Status.ExpirationTime == nil &&
+		accessRequest.Spec.ExpiresIn != nil && accessRequest.Status.ApprovalTime != nil {
+		instanceCopy := accessRequest.DeepCopy()
+		expirationTime := metav1.NewTime(accessRequest.Status.ApprovalTime.Add(accessRequest.Spec.ExpiresIn.Duration))
+		instanceCopy.Status.ExpirationTime = &expirationTime
+		return ctrl.Result{}, r.Update(ctx, instanceCopy)
+	}
+
+	if accessRequest.Status.ExpirationTime != nil {
+		remaining := time.Until(accessRequest.Status.ExpirationTime.Time)
+		if remaining <= 0 {
+			if err := r.removeBindings(ctx, accessRequest); err != nil {
+				return ctrl.Result{}, err
+			}
+			instanceCopy := accessRequest.DeepCopy()
+			instanceCopy.Status.State = iamv1alpha2.AccessRequestExpired
+			if err := r.Update(ctx, instanceCopy); err != nil {
+				return ctrl.Result{}, err
+			}
+			r.Recorder.Event(accessRequest, corev1.EventTypeNormal, "Expired",
+				fmt.Sprintf("access of user %s to role %s expired", accessRequest.Spec.Username, accessRequest.Spec.Role))
+			return ctrl.Result{}, nil
+		}
+		if err := r.ensureBinding(ctx, accessRequest); err != nil {
+			return ctrl.Result{}, err
+		}
+		return ctrl.Result{RequeueAfter: remaining}, nil
+	}
+
+	return ctrl.Result{}, r.ensureBinding(ctx, accessRequest)
+}
+
+func (r *Reconciler) ensureBinding(ctx context.Context, accessRequest *iamv1alpha2.AccessRequest) error {
+	username := accessRequest.Spec.Username
+	role := accessRequest.Spec.Role
+	subject := rbacv1.Subject{
+		Kind:     rbacv1.UserKind,
+		APIGroup: rbacv1.SchemeGroupVersion.Group,
+		Name:     username,
+	}
+
+	if workspace := accessRequest.Spec.Workspace; workspace != "" {
+		var bindings iamv1alpha2.WorkspaceRoleBindingList
+		if err := r.List(ctx, &bindings, client.MatchingLabels{iamv1alpha2.AccessRequestReferenceLabel: accessRequest.Name}); err != nil {
+			return err
+		}
+		if len(bindings.Items) > 0 {
+			return nil
+		}
+		binding := &iamv1alpha2.WorkspaceRoleBinding{
+			ObjectMeta: metav1.ObjectMeta{
+				GenerateName: fmt.Sprintf("%s-%s-", username, role),
+				Labels: map[string]string{
+					iamv1alpha2.UserReferenceLabel:          username,
+					iamv1alpha2.AccessRequestReferenceLabel: accessRequest.Name,
+					tenantv1alpha1.WorkspaceLabel:           workspace,
+				},
+			},
+			Subjects: []rbacv1.Subject{subject},
+			RoleRef: rbacv1.RoleRef{
+				APIGroup: iamv1alpha2.SchemeGroupVersion.Group,
+				Kind:     iamv1alpha2.ResourceKindWorkspaceRole,
+				Name:     role,
+			},
+		}
+		if err := r.Create(ctx, binding); err != nil {
+			return err
+		}
+	} else if namespace := accessRequest.Spec.Namespace; namespace != "" {
+		var bindings rbacv1.RoleBindingList
+		if err := r.List(ctx, &bindings, client.InNamespace(namespace),
+			client.MatchingLabels{iamv1alpha2.AccessRequestReferenceLabel: accessRequest.Name}); err != nil {
+			return err
+		}
+		if len(bindings.Items) > 0 {
+			return nil
+		}
+		binding := &rbacv1.RoleBinding{
+			ObjectMeta: metav1.ObjectMeta{
+				GenerateName: fmt.Sprintf("%s-%s-", username, role),
+				Namespace:    namespace,
+				Labels: map[string]string{
+					iamv1alpha2.UserReferenceLabel:          username,
+					iamv1alpha2.AccessRequestReferenceLabel: accessRequest.Name,
+				},
+			},
+			Subjects: []rbacv1.Subject{subject},
+			RoleRef: rbacv1.RoleRef{
+				APIGroup: rbacv1.SchemeGroupVersion.Group,
+				Kind:     iamv1alpha2.ResourceKindRole,
+				Name:     role,
+			},
+		}
+		if err := r.Create(ctx, binding); err != nil {
+			return err
+		}
+	}
+
+	r.Recorder.Event(accessRequest, corev1.EventTypeNormal, "Granted",
+		fmt.Sprintf("user %s was granted role %s", username, role))
+	return nil
+}
+
+// removeBindings deletes the bindings the request created.
+func (r *Reconciler) removeBindings(ctx context.Context, accessRequest *iamv1alpha2.AccessRequest) error {
+	selector := client.MatchingLabels{iamv1alpha2.AccessRequestReferenceLabel: accessRequest.Name}
+
+	if accessRequest.Spec.Workspace != "" {
+		var bindings iamv1alpha2.WorkspaceRoleBindingList
+		if err := r.List(ctx, &bindings, selector); err != nil {
+			return err
+		}
+		for i := range bindings.Items {
+			if err := r.Delete(ctx, &bindings.Items[i]); err != nil {
+				return client.IgnoreNotFound(err)
+			}
+		}
+	} else if accessRequest.Spec.Namespace != "" {
+		var bindings rbacv1.RoleBindingList
+		if err := r.List(ctx, &bindings, client.InNamespace(accessRequest.Spec.Namespace), selector); err != nil {
+			return err
+		}
+		for i := range bindings.Items {
+			if err := r.Delete(ctx, &bindings.Items[i]); err != nil {
+				return client.IgnoreNotFound(err)
+			}
+		}
+	}
+	return nil
+}
+
+// notifyDecision publishes the decided notification, once per request.
+func (r *Reconciler) notifyDecision(ctx context.Context, accessRequest *iamv1alpha2.AccessRequest) error {
+	if _, notified := accessRequest.Annotations[decisionNotifiedAnnotation]; notified {
+		return nil
+	}
+
+	instanceCopy := accessRequest.DeepCopy()
+	if instanceCopy.Annotations == nil {
+		instanceCopy.Annotations = map[string]string{}
+	}
+	instanceCopy.Annotations[decisionNotifiedAnnotation] = string(accessRequest.Status.State)
+	patch := client.MergeFrom(accessRequest)
+	if err := r.Patch(ctx, instanceCopy, patch); err != nil {
+		return err
+	}
+
+	r.Recorder.Event(accessRequest, corev1.EventTypeNormal, string(accessRequest.Status.State),
+		fmt.Sprintf("access request of user %s was %s by %s",
+			accessRequest.Spec.Username, strings.ToLower(string(accessRequest.Status.State)), accessRequest.Status.Approver))
+
+	if r.Notifier != nil {
+		r.Notifier.Publish(ctx, notificationdispatch.Event{
+			Type: notificationv1alpha1.EventAccessRequestDecided,
+			Object: notificationdispatch.EventObject{
+				APIVersion: iamv1alpha2.SchemeGroupVersion.String(),
+				Kind:       iamv1alpha2.ResourceKindAccessRequest,
+				Namespace:  accessRequest.Spec.Namespace,
+				Name:       accessRequest.Name,
+			},
+			Timestamp: metav1.Now(),
+		})
+	}
+	return nil
+}
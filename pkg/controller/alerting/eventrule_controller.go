@@ -0,0 +1,278 @@
+/*
+Copyright 2019 The KubeSphere Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package alerting
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	prommodel "github.com/prometheus/common/model"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	toolscache "k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	alertingv2beta1 "kubesphere.io/api/alerting/v2beta1"
+	notificationv1alpha1 "kubesphere.io/api/notification/v1alpha1"
+
+	"kubesphere.io/kubesphere/pkg/controller/notificationdispatch"
+)
+
+const (
+	eventRuleControllerName = "eventrule-controller"
+
+	// defaultEventRuleWindow is the sliding window used when spec.window is
+	// not set.
+	defaultEventRuleWindow = 5 * time.Minute
+)
+
+// Notifier dispatches a notification event, it is satisfied by the
+// notification dispatch reconciler.
+type Notifier interface {
+	Publish(ctx context.Context, event notificationdispatch.Event)
+}
+
+// compiledEventRule is an EventRule spec with its regular expressions
+// compiled and its occurrence timestamps within the sliding window.
+type compiledEventRule struct {
+	reason    *regexp.Regexp
+	kind      *regexp.Regexp
+	namespace *regexp.Regexp
+	eventType string
+	count     int
+	window    time.Duration
+
+	occurrences []time.Time
+}
+
+// EventRuleReconciler compiles EventRule objects and matches kubernetes
+// events against them as they are observed from the shared cache, firing
+// a notification when a rule crosses its rate threshold. Unlike the rule
+// group controllers it needs no prometheus, so it also runs on clusters
+// without the alerting stack.
+type EventRuleReconciler struct {
+	client.Client
+	Logger   logr.Logger
+	Recorder record.EventRecorder
+
+	// Notifier is optional, without it fired rules only update their
+	// status and emit a kubernetes event.
+	Notifier Notifier
+
+	mutex sync.Mutex
+	rules map[string]*compiledEventRule
+}
+
+func (r *EventRuleReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	if r.Client == nil {
+		r.Client = mgr.GetClient()
+	}
+	if r.Logger == nil {
+		r.Logger = ctrl.Log.WithName("controllers").WithName(eventRuleControllerName)
+	}
+	if r.Recorder == nil {
+		r.Recorder = mgr.GetEventRecorderFor(eventRuleControllerName)
+	}
+	r.rules = make(map[string]*compiledEventRule)
+
+	informer, err := mgr.GetCache().GetInformer(context.Background(), &corev1.Event{})
+	if err != nil {
+		return err
+	}
+	informer.AddEventHandler(toolscache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			if event, ok := obj.(*corev1.Event); ok {
+				r.observeEvent(event)
+			}
+		},
+		UpdateFunc: func(old, new interface{}) {
+			// kubernetes aggregates repeated events by bumping count, an
+			// update is another occurrence
+			if event, ok := new.(*corev1.Event); ok {
+				r.observeEvent(event)
+			}
+		},
+	})
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(eventRuleControllerName).
+		For(&alertingv2beta1.EventRule{}).
+		Complete(r)
+}
+
+// +kubebuilder:rbac:groups=alerting.kubesphere.io,resources=eventrules,verbs=get;list;watch
+// +kubebuilder:rbac:groups=alerting.kubesphere.io,resources=eventrules/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups="",resources=events,verbs=get;list;watch
+func (r *EventRuleReconciler) Reconcile(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+	rule := &alertingv2beta1.EventRule{}
+	if err := r.Get(ctx, req.NamespacedName, rule); err != nil {
+		if client.IgnoreNotFound(err) == nil {
+			r.forgetRule(req.Name)
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, err
+	}
+
+	compiled, err := compileEventRule(&rule.Spec)
+	if err != nil {
+		r.forgetRule(rule.Name)
+		r.Recorder.Event(rule, corev1.EventTypeWarning, "InvalidEventRule", err.Error())
+		return reconcile.Result{}, nil
+	}
+
+	r.mutex.Lock()
+	if previous := r.rules[rule.Name]; previous != nil {
+		compiled.occurrences = previous.occurrences
+	}
+	r.rules[rule.Name] = compiled
+	r.mutex.Unlock()
+	return reconcile.Result{}, nil
+}
+
+func (r *EventRuleReconciler) forgetRule(name string) {
+	r.mutex.Lock()
+	delete(r.rules, name)
+	r.mutex.Unlock()
+}
+
+func compileEventRule(spec *alertingv2beta1.EventRuleSpec) (*compiledEventRule, error) {
+	compiled := &compiledEventRule{
+		eventType: spec.Type,
+		count:     int(spec.Count),
+		window:    defaultEventRuleWindow,
+	}
+	if compiled.count < 1 {
+		compiled.count = 1
+	}
+	if spec.Window != "" {
+		window, err := prommodel.ParseDuration(string(spec.Window))
+		if err != nil {
+			return nil, fmt.Errorf("invalid window %q: %v", spec.Window, err)
+		}
+		compiled.window = time.Duration(window)
+	}
+
+	var err error
+	for _, expr := range []struct {
+		pattern string
+		target  **regexp.Regexp
+	}{
+		{spec.Reason, &compiled.reason},
+		{spec.Kind, &compiled.kind},
+		{spec.Namespace, &compiled.namespace},
+	} {
+		if expr.pattern == "" {
+			continue
+		}
+		if *expr.target, err = regexp.Compile(expr.pattern); err != nil {
+			return nil, fmt.Errorf("invalid expression %q: %v", expr.pattern, err)
+		}
+	}
+	return compiled, nil
+}
+
+// observeEvent records the event against every matching rule and fires
+// the rules whose threshold is reached, clearing their window so a noisy
+// condition alerts once per window instead of once per event.
+func (r *EventRuleReconciler) observeEvent(event *corev1.Event) {
+	now := time.Now()
+
+	r.mutex.Lock()
+	var fired []string
+	for name, rule := range r.rules {
+		if !rule.matches(event) {
+			continue
+		}
+		rule.occurrences = append(rule.occurrences, now)
+		rule.prune(now)
+		if len(rule.occurrences) >= rule.count {
+			rule.occurrences = nil
+			fired = append(fired, name)
+		}
+	}
+	r.mutex.Unlock()
+
+	for _, name := range fired {
+		r.fire(name, event)
+	}
+}
+
+func (c *compiledEventRule) matches(event *corev1.Event) bool {
+	if c.eventType != "" && event.Type != c.eventType {
+		return false
+	}
+	if c.reason != nil && !c.reason.MatchString(event.Reason) {
+		return false
+	}
+	if c.kind != nil && !c.kind.MatchString(event.InvolvedObject.Kind) {
+		return false
+	}
+	if c.namespace != nil && !c.namespace.MatchString(event.InvolvedObject.Namespace) {
+		return false
+	}
+	return true
+}
+
+// prune drops occurrences that fell out of the sliding window.
+func (c *compiledEventRule) prune(now time.Time) {
+	cutoff := now.Add(-c.window)
+	kept := c.occurrences[:0]
+	for _, occurred := range c.occurrences {
+		if occurred.After(cutoff) {
+			kept = append(kept, occurred)
+		}
+	}
+	c.occurrences = kept
+}
+
+// fire dispatches the notification of the rule and records the firing on
+// its status, both best effort.
+func (r *EventRuleReconciler) fire(name string, event *corev1.Event) {
+	ctx := context.Background()
+
+	rule := &alertingv2beta1.EventRule{}
+	if err := r.Get(ctx, types.NamespacedName{Name: name}, rule); err != nil {
+		return
+	}
+
+	r.Recorder.Eventf(rule, corev1.EventTypeNormal, "Fired", "matched %s event %s of %s/%s",
+		event.Reason, event.Type, event.InvolvedObject.Namespace, event.InvolvedObject.Name)
+
+	if r.Notifier != nil {
+		r.Notifier.Publish(ctx, notificationdispatch.Event{
+			Type: notificationv1alpha1.EventAlertFired,
+			Object: notificationdispatch.EventObject{
+				APIVersion: event.InvolvedObject.APIVersion,
+				Kind:       event.InvolvedObject.Kind,
+				Namespace:  event.InvolvedObject.Namespace,
+				Name:       event.InvolvedObject.Name,
+			},
+			Timestamp: metav1.Now(),
+		})
+	}
+
+	rule.Status.FiredCount++
+	rule.Status.LastFiredTime = &metav1.Time{Time: time.Now()}
+	if err := r.Status().Update(ctx, rule); err != nil {
+		r.Logger.V(4).Info("failed to update eventrule status", "eventrule", name, "error", err)
+	}
+}
@@ -38,9 +38,13 @@ import (
 	"k8s.io/client-go/tools/record"
 	"k8s.io/client-go/util/workqueue"
 	"k8s.io/klog"
+	runtimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	certificatesv1alpha1 "kubesphere.io/api/certificates/v1alpha1"
 
 	"kubesphere.io/kubesphere/pkg/constants"
 	"kubesphere.io/kubesphere/pkg/models/kubeconfig"
+	"kubesphere.io/kubesphere/pkg/utils/sliceutil"
 )
 
 const (
@@ -49,6 +53,8 @@ const (
 	// is csrSynced successfully
 	messageResourceSynced = "CertificateSigningRequest csrSynced successfully"
 	controllerName        = "csr-controller"
+	// approvedByPolicy is the Event 'reason' when a policy approves a csr
+	approvedByPolicy = "AutoApprovedByPolicy"
 )
 
 type Controller struct {
@@ -67,10 +73,13 @@ type Controller struct {
 	// Kubernetes API.
 	recorder           record.EventRecorder
 	kubeconfigOperator kubeconfig.Interface
+	// runtimeClient reads CSRApprovalPolicy objects, a nil client disables
+	// policy based approval.
+	runtimeClient runtimeclient.Client
 }
 
 func NewController(k8sClient kubernetes.Interface, csrInformer certificatesinformers.CertificateSigningRequestInformer,
-	configMapInformer corev1informers.ConfigMapInformer, config *rest.Config) *Controller {
+	configMapInformer corev1informers.ConfigMapInformer, config *rest.Config, runtimeClient runtimeclient.Client) *Controller {
 	// Create event broadcaster
 	// Add sample-controller types to the default Kubernetes Scheme so Events can be
 	// logged for sample-controller types.
@@ -89,6 +98,7 @@ func NewController(k8sClient kubernetes.Interface, csrInformer certificatesinfor
 		kubeconfigOperator: kubeconfig.NewOperator(k8sClient, configMapInformer.Lister(), config),
 		workqueue:          workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "CertificateSigningRequest"),
 		recorder:           recorder,
+		runtimeClient:      runtimeClient,
 	}
 	klog.Info("Setting up event handlers")
 	csrInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
@@ -234,12 +244,84 @@ func (c *Controller) reconcile(key string) error {
 				return err
 			}
 		}
+	} else if c.runtimeClient != nil && !decided(csr) {
+		if err := c.applyApprovalPolicies(csr); err != nil {
+			klog.Error(err)
+			return err
+		}
 	}
 
 	c.recorder.Event(csr, corev1.EventTypeNormal, successSynced, messageResourceSynced)
 	return nil
 }
 
+// applyApprovalPolicies approves the csr when a CSRApprovalPolicy matches
+// its requester, the first matching policy wins.
+func (c *Controller) applyApprovalPolicies(csr *certificatesv1.CertificateSigningRequest) error {
+	policies := &certificatesv1alpha1.CSRApprovalPolicyList{}
+	if err := c.runtimeClient.List(context.Background(), policies); err != nil {
+		return err
+	}
+
+	for i := range policies.Items {
+		policy := &policies.Items[i]
+		if !policyMatches(policy, csr) {
+			continue
+		}
+
+		csr = csr.DeepCopy()
+		csr.Status.Conditions = append(csr.Status.Conditions, certificatesv1.CertificateSigningRequestCondition{
+			Type:           certificatesv1.CertificateApproved,
+			Status:         corev1.ConditionTrue,
+			Reason:         approvedByPolicy,
+			Message:        fmt.Sprintf("This CSR was approved by CSRApprovalPolicy %s", policy.Name),
+			LastUpdateTime: metav1.Now(),
+		})
+		if _, err := c.k8sclient.CertificatesV1().CertificateSigningRequests().UpdateApproval(context.Background(), csr.Name, csr, metav1.UpdateOptions{}); err != nil {
+			return err
+		}
+		c.recorder.Eventf(csr, corev1.EventTypeNormal, approvedByPolicy, "Approved by CSRApprovalPolicy %s", policy.Name)
+
+		// the status update is best effort, losing a count must not requeue
+		// an already approved csr
+		policy.Status.ApprovedCount++
+		policy.Status.LastApprovedTime = &metav1.Time{Time: time.Now()}
+		if err := c.runtimeClient.Status().Update(context.Background(), policy); err != nil {
+			klog.Warningf("failed to update status of csrapprovalpolicy %s: %v", policy.Name, err)
+		}
+		return nil
+	}
+	return nil
+}
+
+// policyMatches reports whether the policy covers the requester and signer
+// of the csr.
+func policyMatches(policy *certificatesv1alpha1.CSRApprovalPolicy, csr *certificatesv1.CertificateSigningRequest) bool {
+	if len(policy.Spec.SignerNames) > 0 && !sliceutil.HasString(policy.Spec.SignerNames, csr.Spec.SignerName) {
+		return false
+	}
+	if sliceutil.HasString(policy.Spec.Requesters, csr.Spec.Username) {
+		return true
+	}
+	for _, group := range csr.Spec.Groups {
+		if sliceutil.HasString(policy.Spec.Groups, group) {
+			return true
+		}
+	}
+	return false
+}
+
+// decided reports whether the csr already carries an approval or denial
+// condition.
+func decided(csr *certificatesv1.CertificateSigningRequest) bool {
+	for _, condition := range csr.Status.Conditions {
+		if condition.Type == certificatesv1.CertificateApproved || condition.Type == certificatesv1.CertificateDenied {
+			return true
+		}
+	}
+	return false
+}
+
 func (c *Controller) Start(ctx context.Context) error {
 	return c.Run(4, ctx.Done())
 }
@@ -73,7 +73,7 @@ type Controller struct {
 
 func NewController(k8sClient kubernetes.Interface, clusterRoleBindingInformer rbacv1informers.ClusterRoleBindingInformer,
 	deploymentInformer appsv1informers.DeploymentInformer, podInformer coreinfomers.PodInformer,
-	userInformer iamv1alpha2informers.UserInformer, kubectlImage string) *Controller {
+	userInformer iamv1alpha2informers.UserInformer, kubectlOptions *kubectl.Options) *Controller {
 	// Create event broadcaster
 	// Add sample-controller types to the default Kubernetes Scheme so Events can be
 	// logged for sample-controller types.
@@ -91,7 +91,7 @@ func NewController(k8sClient kubernetes.Interface, clusterRoleBindingInformer rb
 		userSynced:                 userInformer.Informer().HasSynced,
 		deploymentSynced:           deploymentInformer.Informer().HasSynced,
 		podSynced:                  podInformer.Informer().HasSynced,
-		kubectlOperator:            kubectl.NewOperator(k8sClient, deploymentInformer, podInformer, userInformer, kubectlImage),
+		kubectlOperator:            kubectl.NewOperator(k8sClient, deploymentInformer, podInformer, userInformer, kubectlOptions),
 		workqueue:                  workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "ClusterRoleBinding"),
 		recorder:                   recorder,
 	}
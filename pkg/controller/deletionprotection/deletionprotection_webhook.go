@@ -0,0 +1,137 @@
+/*
+Copyright 2022 The KubeSphere Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deletionprotection
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	v1 "k8s.io/api/admission/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+const (
+	// DeletionProtectionAnnotation marks namespaces and critical workloads
+	// that must not be deleted while the protection is enabled, preventing
+	// accidental deletion of production projects from the console.
+	DeletionProtectionAnnotation = "kubesphere.io/deletion-protection"
+	// DeletionProtectionEnabled is the annotation value that enables the
+	// protection.
+	DeletionProtectionEnabled = "enabled"
+)
+
+// ValidatingHandler denies deletion of protected objects. The protection
+// annotation can only be removed by users holding the delete verb on the
+// resource at cluster scope, so project members cannot lift it themselves.
+type ValidatingHandler struct {
+	Client  client.Client
+	decoder *admission.Decoder
+}
+
+var _ admission.DecoderInjector = &ValidatingHandler{}
+
+// InjectDecoder injects the decoder into a ValidatingHandler.
+func (h *ValidatingHandler) InjectDecoder(d *admission.Decoder) error {
+	h.decoder = d
+	return nil
+}
+
+// Handle handles admission requests.
+func (h *ValidatingHandler) Handle(ctx context.Context, req admission.Request) admission.Response {
+
+	switch req.Operation {
+	case v1.Delete:
+		oldMeta, err := decodeMetadata(req.OldObject.Raw)
+		if err != nil {
+			return admission.Errored(http.StatusBadRequest, err)
+		}
+		if !protected(oldMeta) {
+			return admission.Allowed("")
+		}
+		return admission.Denied(fmt.Sprintf("%s %s is protected from deletion, remove the %s annotation first",
+			req.Resource.Resource, req.Name, DeletionProtectionAnnotation))
+	case v1.Update:
+		oldMeta, err := decodeMetadata(req.OldObject.Raw)
+		if err != nil {
+			return admission.Errored(http.StatusBadRequest, err)
+		}
+		newMeta, err := decodeMetadata(req.Object.Raw)
+		if err != nil {
+			return admission.Errored(http.StatusBadRequest, err)
+		}
+		if !protected(oldMeta) || protected(newMeta) {
+			return admission.Allowed("")
+		}
+
+		allowed, err := h.canRemoveProtection(ctx, req)
+		if err != nil {
+			return admission.Errored(http.StatusInternalServerError, err)
+		}
+		if !allowed {
+			return admission.Denied(fmt.Sprintf("only users allowed to delete %s at cluster scope can remove the %s annotation",
+				req.Resource.Resource, DeletionProtectionAnnotation))
+		}
+		return admission.Allowed("")
+	default:
+		return admission.Allowed("")
+	}
+}
+
+// canRemoveProtection checks whether the requesting user holds the delete
+// verb on the resource at cluster scope, the higher role required to lift
+// the protection.
+func (h *ValidatingHandler) canRemoveProtection(ctx context.Context, req admission.Request) (bool, error) {
+	sar := &authorizationv1.SubjectAccessReview{
+		Spec: authorizationv1.SubjectAccessReviewSpec{
+			User:   req.UserInfo.Username,
+			UID:    req.UserInfo.UID,
+			Groups: req.UserInfo.Groups,
+			ResourceAttributes: &authorizationv1.ResourceAttributes{
+				Verb:     "delete",
+				Group:    req.Resource.Group,
+				Version:  req.Resource.Version,
+				Resource: req.Resource.Resource,
+			},
+		},
+	}
+	if err := h.Client.Create(ctx, sar); err != nil {
+		return false, err
+	}
+	return sar.Status.Allowed, nil
+}
+
+// decodeMetadata extracts the object metadata from the raw admission
+// payload, which is all the protection check needs.
+func decodeMetadata(raw []byte) (*metav1.PartialObjectMetadata, error) {
+	meta := &metav1.PartialObjectMetadata{}
+	if len(raw) == 0 {
+		return meta, nil
+	}
+	if err := json.Unmarshal(raw, meta); err != nil {
+		return nil, err
+	}
+	return meta, nil
+}
+
+func protected(meta *metav1.PartialObjectMetadata) bool {
+	return meta.Annotations[DeletionProtectionAnnotation] == DeletionProtectionEnabled
+}
@@ -0,0 +1,257 @@
+/*
+Copyright 2022 The KubeSphere Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitopsconfig
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+
+	"gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	githttp "gopkg.in/src-d/go-git.v4/plumbing/transport/http"
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/apimachinery/pkg/util/yaml"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/klog"
+
+	"kubesphere.io/kubesphere/pkg/simple/client/gitops"
+)
+
+const (
+	// ManagedAnnotation marks objects owned by the gitops repository, the
+	// console renders them read-only.
+	ManagedAnnotation = "gitops.kubesphere.io/managed"
+	// RevisionAnnotation records the commit the object was last applied from.
+	RevisionAnnotation = "gitops.kubesphere.io/revision"
+)
+
+// managedResources maps the kinds the gitops mode is allowed to reconcile
+// to their resources, platform configuration only, never workloads.
+var managedResources = map[schema.GroupVersionKind]schema.GroupVersionResource{
+	{Group: "tenant.kubesphere.io", Version: "v1alpha1", Kind: "Workspace"}:             {Group: "tenant.kubesphere.io", Version: "v1alpha1", Resource: "workspaces"},
+	{Group: "tenant.kubesphere.io", Version: "v1alpha2", Kind: "WorkspaceTemplate"}:     {Group: "tenant.kubesphere.io", Version: "v1alpha2", Resource: "workspacetemplates"},
+	{Group: "iam.kubesphere.io", Version: "v1alpha2", Kind: "User"}:                     {Group: "iam.kubesphere.io", Version: "v1alpha2", Resource: "users"},
+	{Group: "iam.kubesphere.io", Version: "v1alpha2", Kind: "GlobalRole"}:               {Group: "iam.kubesphere.io", Version: "v1alpha2", Resource: "globalroles"},
+	{Group: "iam.kubesphere.io", Version: "v1alpha2", Kind: "WorkspaceRole"}:            {Group: "iam.kubesphere.io", Version: "v1alpha2", Resource: "workspaceroles"},
+	{Group: "iam.kubesphere.io", Version: "v1alpha2", Kind: "GlobalRoleBinding"}:        {Group: "iam.kubesphere.io", Version: "v1alpha2", Resource: "globalrolebindings"},
+	{Group: "iam.kubesphere.io", Version: "v1alpha2", Kind: "WorkspaceRoleBinding"}:     {Group: "iam.kubesphere.io", Version: "v1alpha2", Resource: "workspacerolebindings"},
+	{Group: "", Version: "v1", Kind: "ResourceQuota"}:                                   {Group: "", Version: "v1", Resource: "resourcequotas"},
+	{Group: "quota.kubesphere.io", Version: "v1alpha2", Kind: "ResourceQuota"}:          {Group: "quota.kubesphere.io", Version: "v1alpha2", Resource: "resourcequotas"},
+}
+
+// Controller reconciles workspaces, roles, users and quotas from a git
+// repository, so platform configuration lives as code and the API objects
+// follow the merged state of the repository.
+type Controller struct {
+	client  dynamic.Interface
+	options *gitops.Options
+}
+
+func NewController(client dynamic.Interface, options *gitops.Options) *Controller {
+	return &Controller{client: client, options: options}
+}
+
+func (c *Controller) Start(ctx context.Context) error {
+	wait.Until(func() {
+		if err := c.sync(ctx); err != nil {
+			klog.Errorf("failed to reconcile platform configuration from %s: %v", c.options.Repository, err)
+		}
+	}, c.options.PollInterval, ctx.Done())
+	return nil
+}
+
+func (c *Controller) sync(ctx context.Context) error {
+	worktree, revision, err := c.fetch()
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(worktree)
+
+	desired, err := loadManifests(filepath.Join(worktree, c.options.Path))
+	if err != nil {
+		return err
+	}
+
+	applied, drifted := 0, 0
+	for _, object := range desired {
+		changed, err := c.apply(ctx, object, revision)
+		if err != nil {
+			klog.Errorf("failed to apply %s %s from gitops repository: %v", object.GetKind(), object.GetName(), err)
+			continue
+		}
+		applied++
+		if changed {
+			drifted++
+		}
+	}
+	if drifted > 0 {
+		klog.Infof("gitops sync at %s: %d objects, %d drifted and reconciled", revision, applied, drifted)
+	} else {
+		klog.V(4).Infof("gitops sync at %s: %d objects, no drift", revision, applied)
+	}
+	return nil
+}
+
+// fetch shallow clones the branch into a temporary worktree the caller
+// removes, and returns the head commit.
+func (c *Controller) fetch() (string, string, error) {
+	worktree, err := os.MkdirTemp("", "gitops-")
+	if err != nil {
+		return "", "", err
+	}
+
+	cloneOptions := &git.CloneOptions{
+		URL:           c.options.Repository,
+		ReferenceName: plumbing.NewBranchReferenceName(c.options.Branch),
+		SingleBranch:  true,
+		Depth:         1,
+	}
+	if c.options.Username != "" || c.options.Password != "" {
+		cloneOptions.Auth = &githttp.BasicAuth{Username: c.options.Username, Password: c.options.Password}
+	}
+
+	repository, err := git.PlainClone(worktree, false, cloneOptions)
+	if err != nil {
+		os.RemoveAll(worktree)
+		return "", "", err
+	}
+	head, err := repository.Head()
+	if err != nil {
+		os.RemoveAll(worktree)
+		return "", "", err
+	}
+	return worktree, head.Hash().String(), nil
+}
+
+// loadManifests walks the manifest directory and decodes every yaml
+// document of a managed kind, other documents are skipped with a log line.
+func loadManifests(root string) ([]*unstructured.Unstructured, error) {
+	var objects []*unstructured.Unstructured
+	err := walkYAMLFiles(root, func(name string, reader io.Reader) error {
+		decoder := yaml.NewYAMLOrJSONDecoder(reader, 4096)
+		for {
+			object := &unstructured.Unstructured{}
+			if err := decoder.Decode(object); err != nil {
+				if err == io.EOF {
+					return nil
+				}
+				return fmt.Errorf("%s: %v", name, err)
+			}
+			if object.Object == nil {
+				continue
+			}
+			if _, ok := managedResources[object.GroupVersionKind()]; !ok {
+				klog.V(4).Infof("gitops: skipping %s %s in %s, the kind is not managed", object.GetKind(), object.GetName(), name)
+				continue
+			}
+			objects = append(objects, object)
+		}
+	})
+	return objects, err
+}
+
+func walkYAMLFiles(root string, visit func(name string, reader io.Reader) error) error {
+	entries, err := os.ReadDir(root)
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		name := filepath.Join(root, entry.Name())
+		if entry.IsDir() {
+			// the clone metadata is not configuration
+			if entry.Name() == git.GitDirName {
+				continue
+			}
+			if err := walkYAMLFiles(name, visit); err != nil {
+				return err
+			}
+			continue
+		}
+		if !strings.HasSuffix(entry.Name(), ".yaml") && !strings.HasSuffix(entry.Name(), ".yml") {
+			continue
+		}
+		file, err := os.Open(name)
+		if err != nil {
+			return err
+		}
+		err = visit(name, file)
+		file.Close()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// apply creates the object or reconciles the drifted fields of the live
+// object, it reports whether anything had to change.
+func (c *Controller) apply(ctx context.Context, desired *unstructured.Unstructured, revision string) (bool, error) {
+	gvr := managedResources[desired.GroupVersionKind()]
+
+	var resource dynamic.ResourceInterface = c.client.Resource(gvr)
+	if namespace := desired.GetNamespace(); namespace != "" {
+		resource = c.client.Resource(gvr).Namespace(namespace)
+	}
+
+	current, err := resource.Get(ctx, desired.GetName(), metav1.GetOptions{})
+	if err != nil {
+		if !k8serr.IsNotFound(err) {
+			return false, err
+		}
+		markManaged(desired, revision)
+		_, err = resource.Create(ctx, desired, metav1.CreateOptions{})
+		return true, err
+	}
+
+	drifted := false
+	for key, value := range desired.Object {
+		if key == "metadata" || key == "status" || key == "apiVersion" || key == "kind" {
+			continue
+		}
+		if !reflect.DeepEqual(current.Object[key], value) {
+			klog.Infof("gitops: %s %s drifted in %s", desired.GetKind(), desired.GetName(), key)
+			current.Object[key] = value
+			drifted = true
+		}
+	}
+	if !drifted && current.GetAnnotations()[ManagedAnnotation] == "true" {
+		return false, nil
+	}
+
+	markManaged(current, revision)
+	_, err = resource.Update(ctx, current, metav1.UpdateOptions{})
+	return drifted, err
+}
+
+func markManaged(object *unstructured.Unstructured, revision string) {
+	annotations := object.GetAnnotations()
+	if annotations == nil {
+		annotations = make(map[string]string)
+	}
+	annotations[ManagedAnnotation] = "true"
+	annotations[RevisionAnnotation] = revision
+	object.SetAnnotations(annotations)
+}
@@ -20,10 +20,12 @@ import (
 	"context"
 	"fmt"
 	"reflect"
+	"strings"
 
 	"k8s.io/apimachinery/pkg/util/validation"
 
 	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
@@ -225,6 +227,12 @@ func (c *Controller) reconcile(key string) error {
 		return nil
 	}
 
+	// Grant mapped workspace roles to the members of the group.
+	if err = c.ensureMappedWorkspaceRoleBindings(group); err != nil {
+		klog.Error(err)
+		return err
+	}
+
 	// synchronization through kubefed-controller when multi cluster is enabled
 	if c.multiClusterEnabled {
 		if err = c.multiClusterSync(group); err != nil {
@@ -237,6 +245,99 @@ func (c *Controller) reconcile(key string) error {
 	return nil
 }
 
+// ensureMappedWorkspaceRoleBindings creates a WorkspaceRoleBinding with the group
+// as subject for every workspace role listed in the workspace role mapping
+// annotation, so members of a synced IdP/LDAP group get the mapped workspace
+// membership without binding each group per workspace manually.
+func (c *Controller) ensureMappedWorkspaceRoleBindings(group *iam1alpha2.Group) error {
+	mapping, ok := group.Annotations[iam1alpha2.GroupWorkspaceRoleMappingAnnotation]
+	if !ok || len(mapping) == 0 {
+		return nil
+	}
+	if len(group.Name) > validation.LabelValueMaxLength {
+		// ignore invalid label value error
+		return nil
+	}
+
+	listOptions := metav1.ListOptions{
+		LabelSelector: labels.SelectorFromValidatedSet(labels.Set{iam1alpha2.GroupReferenceLabel: group.Name}).String(),
+	}
+	bindings, err := c.ksClient.IamV1alpha2().WorkspaceRoleBindings().List(context.Background(), listOptions)
+	if err != nil {
+		klog.Error(err)
+		return err
+	}
+
+	for _, roleName := range strings.Split(mapping, ",") {
+		roleName = strings.TrimSpace(roleName)
+		if roleName == "" {
+			continue
+		}
+
+		if mappedRoleBindingExists(bindings.Items, group.Name, roleName) {
+			continue
+		}
+
+		role, err := c.ksClient.IamV1alpha2().WorkspaceRoles().Get(context.Background(), roleName, metav1.GetOptions{})
+		if err != nil {
+			if errors.IsNotFound(err) {
+				utilruntime.HandleError(fmt.Errorf("mapped workspace role '%s' of group '%s' doesn't exist", roleName, group.Name))
+				continue
+			}
+			klog.Error(err)
+			return err
+		}
+		workspace := role.Labels[constants.WorkspaceLabelKey]
+		if workspace == "" {
+			utilruntime.HandleError(fmt.Errorf("mapped workspace role '%s' of group '%s' doesn't belong to any workspace", roleName, group.Name))
+			continue
+		}
+
+		roleBinding := &iam1alpha2.WorkspaceRoleBinding{
+			ObjectMeta: metav1.ObjectMeta{
+				GenerateName: fmt.Sprintf("%s-%s-", group.Name, roleName),
+				Labels: map[string]string{
+					iam1alpha2.GroupReferenceLabel: group.Name,
+					constants.WorkspaceLabelKey:    workspace,
+				},
+			},
+			Subjects: []rbacv1.Subject{
+				{
+					Kind:     rbacv1.GroupKind,
+					APIGroup: rbacv1.SchemeGroupVersion.Group,
+					Name:     group.Name,
+				},
+			},
+			RoleRef: rbacv1.RoleRef{
+				APIGroup: iam1alpha2.SchemeGroupVersion.Group,
+				Kind:     iam1alpha2.ResourceKindWorkspaceRole,
+				Name:     roleName,
+			},
+		}
+
+		if _, err = c.ksClient.IamV1alpha2().WorkspaceRoleBindings().Create(context.Background(), roleBinding, metav1.CreateOptions{}); err != nil {
+			klog.Error(err)
+			return err
+		}
+	}
+
+	return nil
+}
+
+func mappedRoleBindingExists(bindings []iam1alpha2.WorkspaceRoleBinding, group, roleName string) bool {
+	for _, binding := range bindings {
+		if binding.RoleRef.Name != roleName {
+			continue
+		}
+		for _, subject := range binding.Subjects {
+			if subject.Kind == rbacv1.GroupKind && subject.Name == group {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 func (c *Controller) deleteGroupBindings(group *iam1alpha2.Group) error {
 	if len(group.Name) > validation.LabelValueMaxLength {
 		// ignore invalid label value error
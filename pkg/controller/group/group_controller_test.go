@@ -17,10 +17,12 @@ limitations under the License.
 package group
 
 import (
+	"fmt"
 	"reflect"
 	"testing"
 	"time"
 
+	rbacv1 "k8s.io/api/rbac/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -271,6 +273,8 @@ func filterInformerActions(actions []core.Action) []core.Action {
 				action.Matches("list", "groups") ||
 				action.Matches("list", "namespaces") ||
 				action.Matches("get", "workspacetemplates") ||
+				action.Matches("get", "workspaceroles") ||
+				action.Matches("list", "workspacerolebindings") ||
 				action.Matches("list", "federatedgroups") ||
 				action.Matches("watch", "federatedgroups")) {
 			continue
@@ -356,6 +360,32 @@ func (f *fixture) expectUpdateGroupsDeleteAction(group *v1alpha2.Group) {
 	f.actions = append(f.actions, action)
 }
 
+func (f *fixture) expectCreateWorkspaceRoleBindingAction(group *v1alpha2.Group, role *v1alpha2.WorkspaceRole) {
+	roleBinding := &v1alpha2.WorkspaceRoleBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: fmt.Sprintf("%s-%s-", group.Name, role.Name),
+			Labels: map[string]string{
+				v1alpha2.GroupReferenceLabel: group.Name,
+				constants.WorkspaceLabelKey:  role.Labels[constants.WorkspaceLabelKey],
+			},
+		},
+		Subjects: []rbacv1.Subject{
+			{
+				Kind:     rbacv1.GroupKind,
+				APIGroup: rbacv1.SchemeGroupVersion.Group,
+				Name:     group.Name,
+			},
+		},
+		RoleRef: rbacv1.RoleRef{
+			APIGroup: v1alpha2.SchemeGroupVersion.Group,
+			Kind:     v1alpha2.ResourceKindWorkspaceRole,
+			Name:     role.Name,
+		},
+	}
+	action := core.NewCreateAction(schema.GroupVersionResource{Resource: "workspacerolebindings"}, "", roleBinding)
+	f.actions = append(f.actions, action)
+}
+
 func getKey(group *v1alpha2.Group, t *testing.T) string {
 	key, err := cache.DeletionHandlingMetaNamespaceKeyFunc(group)
 	if err != nil {
@@ -434,6 +464,27 @@ func TestFederetedGroupCreate(t *testing.T) {
 	f.run(getKey(group, t))
 }
 
+func TestGroupWorkspaceRoleMapping(t *testing.T) {
+	f := newFixture(t)
+
+	group := newUnmanagedGroup("test")
+	group.Annotations = map[string]string{v1alpha2.GroupWorkspaceRoleMappingAnnotation: "wsp-viewer"}
+
+	role := &v1alpha2.WorkspaceRole{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   "wsp-viewer",
+			Labels: map[string]string{constants.WorkspaceLabelKey: "wsp"},
+		},
+	}
+
+	f.groupLister = append(f.groupLister, group)
+	f.objects = append(f.objects, group, role)
+
+	f.expectCreateWorkspaceRoleBindingAction(group, role)
+	f.expectCreateFederatedGroupsAction(group)
+	f.run(getKey(group, t))
+}
+
 func TestFederetedGroupUpdate(t *testing.T) {
 	f := newFixture(t)
 
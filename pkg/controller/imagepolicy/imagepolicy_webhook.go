@@ -0,0 +1,127 @@
+/*
+Copyright 2022 The KubeSphere Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package imagepolicy
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	securityv1alpha1 "kubesphere.io/api/security/v1alpha1"
+	tenantv1alpha1 "kubesphere.io/api/tenant/v1alpha1"
+)
+
+// ValidatingHandler denies pods pulling images no applicable ImagePolicy
+// allows. Policies bound to a workspace constrain the namespaces of that
+// workspace, policies without one constrain the whole cluster. Namespaces
+// no policy applies to are unrestricted.
+type ValidatingHandler struct {
+	Client  client.Client
+	decoder *admission.Decoder
+}
+
+var _ admission.DecoderInjector = &ValidatingHandler{}
+
+// InjectDecoder injects the decoder into a ValidatingHandler.
+func (h *ValidatingHandler) InjectDecoder(d *admission.Decoder) error {
+	h.decoder = d
+	return nil
+}
+
+// Handle handles admission requests.
+func (h *ValidatingHandler) Handle(ctx context.Context, req admission.Request) admission.Response {
+	pod := &corev1.Pod{}
+	if err := h.decoder.Decode(req, pod); err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+
+	policies := &securityv1alpha1.ImagePolicyList{}
+	if err := h.Client.List(ctx, policies); err != nil {
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+	if len(policies.Items) == 0 {
+		return admission.Allowed("")
+	}
+
+	namespace := &corev1.Namespace{}
+	if err := h.Client.Get(ctx, types.NamespacedName{Name: req.Namespace}, namespace); err != nil {
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+	workspace := namespace.Labels[tenantv1alpha1.WorkspaceLabel]
+
+	for _, image := range PodImages(&pod.Spec) {
+		if !Allowed(policies.Items, workspace, image) {
+			return admission.Denied(fmt.Sprintf("image %s is not allowed by any image policy", image))
+		}
+	}
+
+	return admission.Allowed("")
+}
+
+// PodImages returns the images of all containers of the pod spec,
+// including init and ephemeral containers.
+func PodImages(spec *corev1.PodSpec) []string {
+	images := make([]string, 0, len(spec.InitContainers)+len(spec.Containers)+len(spec.EphemeralContainers))
+	for _, container := range spec.InitContainers {
+		images = append(images, container.Image)
+	}
+	for _, container := range spec.Containers {
+		images = append(images, container.Image)
+	}
+	for _, container := range spec.EphemeralContainers {
+		images = append(images, container.Image)
+	}
+	return images
+}
+
+// Allowed reports whether the image may run in a namespace of the given
+// workspace. An image is allowed when no policy applies, or when it
+// matches at least one allowlist entry of an applicable policy.
+func Allowed(policies []securityv1alpha1.ImagePolicy, workspace, image string) bool {
+	applicable := false
+	for _, policy := range policies {
+		if policy.Spec.Workspace != "" && policy.Spec.Workspace != workspace {
+			continue
+		}
+		applicable = true
+		for _, entry := range policy.Spec.AllowedImages {
+			if entryMatches(entry, image) {
+				return true
+			}
+		}
+	}
+	return !applicable
+}
+
+// entryMatches reports whether the allowlist entry covers the image. An
+// entry with a trailing * matches by plain prefix, otherwise it matches
+// the image itself or any repository, tag or digest below it.
+func entryMatches(entry, image string) bool {
+	if strings.HasSuffix(entry, "*") {
+		return strings.HasPrefix(image, strings.TrimSuffix(entry, "*"))
+	}
+	return image == entry ||
+		strings.HasPrefix(image, entry+"/") ||
+		strings.HasPrefix(image, entry+":") ||
+		strings.HasPrefix(image, entry+"@")
+}
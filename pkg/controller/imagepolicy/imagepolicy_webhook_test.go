@@ -0,0 +1,68 @@
+/*
+Copyright 2022 The KubeSphere Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package imagepolicy
+
+import (
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	securityv1alpha1 "kubesphere.io/api/security/v1alpha1"
+)
+
+func TestAllowed(t *testing.T) {
+	clusterPolicy := securityv1alpha1.ImagePolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "cluster-registry"},
+		Spec: securityv1alpha1.ImagePolicySpec{
+			AllowedImages: []string{"harbor.example.com"},
+		},
+	}
+	workspacePolicy := securityv1alpha1.ImagePolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "workspace-extra"},
+		Spec: securityv1alpha1.ImagePolicySpec{
+			Workspace:     "wksp",
+			AllowedImages: []string{"docker.io/library/nginx", "quay.io/ks*"},
+		},
+	}
+	policies := []securityv1alpha1.ImagePolicy{clusterPolicy, workspacePolicy}
+
+	tests := []struct {
+		description string
+		workspace   string
+		image       string
+		allowed     bool
+	}{
+		{"registry prefix match", "", "harbor.example.com/library/nginx:1.21", true},
+		{"registry mismatch", "", "docker.io/library/nginx:1.21", false},
+		{"workspace policy applies only in its workspace", "wksp", "docker.io/library/nginx:1.21", true},
+		{"repository entry matches tag", "wksp", "docker.io/library/nginx", true},
+		{"repository entry does not match sibling", "wksp", "docker.io/library/nginx-unprivileged", false},
+		{"trailing wildcard matches by prefix", "wksp", "quay.io/ks-ci/ks-apiserver", true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			if got := Allowed(policies, test.workspace, test.image); got != test.allowed {
+				t.Errorf("expected %v, got %v", test.allowed, got)
+			}
+		})
+	}
+
+	if !Allowed(nil, "", "anything") {
+		t.Errorf("expected images to be allowed when no policy exists")
+	}
+}
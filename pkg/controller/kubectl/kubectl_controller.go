@@ -0,0 +1,98 @@
+/*
+Copyright 2022 The KubeSphere Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubectl
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/wait"
+	appsv1informers "k8s.io/client-go/informers/apps/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/klog"
+
+	"kubesphere.io/kubesphere/pkg/constants"
+	"kubesphere.io/kubesphere/pkg/models/kubectl"
+)
+
+const (
+	kubectlDeployPrefix = "kubectl-"
+	resyncPeriod        = time.Minute
+)
+
+// Controller deletes kubectl terminal deployments which have been idle
+// beyond the configured timeout, the deployments are recreated on demand
+// by the clusterrolebinding controller.
+type Controller struct {
+	k8sClient          kubernetes.Interface
+	deploymentInformer appsv1informers.DeploymentInformer
+	options            *kubectl.Options
+}
+
+func NewController(k8sClient kubernetes.Interface, deploymentInformer appsv1informers.DeploymentInformer,
+	options *kubectl.Options) *Controller {
+	if options == nil {
+		options = kubectl.NewOptions()
+	}
+	return &Controller{k8sClient: k8sClient, deploymentInformer: deploymentInformer, options: options}
+}
+
+func (c *Controller) Start(ctx context.Context) error {
+	if c.options.IdleTimeout == 0 {
+		klog.V(4).Info("kubectl idle timeout is not set, skip kubectl pod cleanup")
+		return nil
+	}
+	wait.Until(c.reapIdleDeployments, resyncPeriod, ctx.Done())
+	return nil
+}
+
+func (c *Controller) reapIdleDeployments() {
+	deployments, err := c.deploymentInformer.Lister().Deployments(constants.KubeSphereControlNamespace).List(labels.Everything())
+	if err != nil {
+		klog.Errorf("failed to list kubectl deployments: %v", err)
+		return
+	}
+	for _, deployment := range deployments {
+		if !strings.HasPrefix(deployment.Name, kubectlDeployPrefix) {
+			continue
+		}
+		if _, ok := deployment.Labels[constants.UsernameLabelKey]; !ok {
+			continue
+		}
+		lastActivity := deployment.CreationTimestamp.Time
+		if value, ok := deployment.Annotations[kubectl.LastActivityAnnotation]; ok {
+			if parsed, err := time.Parse(time.RFC3339, value); err == nil {
+				lastActivity = parsed
+			} else {
+				klog.Warningf("invalid last activity annotation on deployment %s: %v", deployment.Name, err)
+			}
+		}
+		if time.Since(lastActivity) < c.options.IdleTimeout {
+			continue
+		}
+		klog.V(2).Infof("deleting kubectl deployment %s idle since %s", deployment.Name, lastActivity.Format(time.RFC3339))
+		err = c.k8sClient.AppsV1().Deployments(constants.KubeSphereControlNamespace).Delete(context.Background(),
+			deployment.Name, metav1.DeleteOptions{})
+		if err != nil && !errors.IsNotFound(err) {
+			klog.Errorf("failed to delete idle kubectl deployment %s: %v", deployment.Name, err)
+		}
+	}
+}
@@ -0,0 +1,356 @@
+/*
+Copyright 2022 The KubeSphere Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package notificationdispatch
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/tls"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/go-logr/logr"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	toolscache "k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/workqueue"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+
+	notificationv1alpha1 "kubesphere.io/api/notification/v1alpha1"
+)
+
+const (
+	controllerName = "notification-dispatch-controller"
+
+	// signatureHeader carries the hex encoded HMAC-SHA256 of the request
+	// body when the subscription references a signing secret.
+	signatureHeader = "X-KubeSphere-Signature"
+
+	// defaultMaxRetries bounds failed deliveries when the subscription does
+	// not set spec.maxRetries.
+	defaultMaxRetries = 3
+
+	deliveryTimeout = 10 * time.Second
+)
+
+// Event is a resource lifecycle event delivered to subscribed webhooks.
+type Event struct {
+	// Type of the event, e.g. workload.created.
+	Type notificationv1alpha1.EventType `json:"type"`
+	// Object identifies the resource the event is about.
+	Object EventObject `json:"object"`
+	// Timestamp records when the event was observed.
+	Timestamp metav1.Time `json:"timestamp"`
+}
+
+// EventObject identifies the resource an event is about.
+type EventObject struct {
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+	Namespace  string `json:"namespace"`
+	Name       string `json:"name"`
+}
+
+// delivery is one webhook call, an event fans out into one delivery per
+// matching subscription so retries are isolated per endpoint.
+type delivery struct {
+	subscription types.NamespacedName
+	event        Event
+}
+
+// Reconciler validates Subscription objects and dispatches resource
+// lifecycle events of their namespace to the registered webhooks, with
+// per-subscription retries and optional HMAC signing. Workload events are
+// observed from the shared cache, cross-process events such as
+// pipeline.finished and alert.fired enter through Publish.
+type Reconciler struct {
+	client.Client
+	Logger                  logr.Logger
+	Recorder                record.EventRecorder
+	MaxConcurrentReconciles int
+
+	httpClient         *http.Client
+	insecureHTTPClient *http.Client
+	queue              workqueue.RateLimitingInterface
+}
+
+func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) error {
+	if r.Client == nil {
+		r.Client = mgr.GetClient()
+	}
+	if r.Logger == nil {
+		r.Logger = ctrl.Log.WithName("controllers").WithName(controllerName)
+	}
+	if r.Recorder == nil {
+		r.Recorder = mgr.GetEventRecorderFor(controllerName)
+	}
+	if r.MaxConcurrentReconciles <= 0 {
+		r.MaxConcurrentReconciles = 1
+	}
+	if r.httpClient == nil {
+		r.httpClient = &http.Client{Timeout: deliveryTimeout}
+	}
+	if r.insecureHTTPClient == nil {
+		r.insecureHTTPClient = &http.Client{
+			Timeout: deliveryTimeout,
+			Transport: &http.Transport{
+				TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
+			},
+		}
+	}
+	if r.queue == nil {
+		r.queue = workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "NotificationDispatch")
+	}
+
+	for _, obj := range []client.Object{&appsv1.Deployment{}, &appsv1.StatefulSet{}} {
+		informer, err := mgr.GetCache().GetInformer(context.Background(), obj)
+		if err != nil {
+			return err
+		}
+		informer.AddEventHandler(toolscache.ResourceEventHandlerFuncs{
+			AddFunc: func(obj interface{}) {
+				r.observeWorkload(obj, notificationv1alpha1.EventWorkloadCreated)
+			},
+			DeleteFunc: func(obj interface{}) {
+				r.observeWorkload(obj, notificationv1alpha1.EventWorkloadDeleted)
+			},
+		})
+	}
+
+	if err := mgr.Add(manager.RunnableFunc(r.runDispatcher)); err != nil {
+		return err
+	}
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(controllerName).
+		WithOptions(controller.Options{
+			MaxConcurrentReconciles: r.MaxConcurrentReconciles,
+		}).
+		For(&notificationv1alpha1.Subscription{}).
+		Complete(r)
+}
+
+// +kubebuilder:rbac:groups=notification.kubesphere.io,resources=subscriptions,verbs=get;list;watch
+// +kubebuilder:rbac:groups=notification.kubesphere.io,resources=subscriptions/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=apps,resources=deployments;statefulsets,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch
+func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	subscription := &notificationv1alpha1.Subscription{}
+	if err := r.Get(ctx, req.NamespacedName, subscription); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	// Deliveries read the subscription from the cache when they run, the
+	// reconcile only surfaces configuration mistakes early.
+	if u, err := url.Parse(subscription.Spec.Webhook.URL); err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+		r.Recorder.Event(subscription, corev1.EventTypeWarning, "InvalidWebhookURL",
+			fmt.Sprintf("webhook url %q is not a valid http or https url", subscription.Spec.Webhook.URL))
+		return ctrl.Result{}, nil
+	}
+
+	if ref := subscription.Spec.Webhook.SecretRef; ref != nil {
+		secret := &corev1.Secret{}
+		if err := r.Get(ctx, types.NamespacedName{Namespace: subscription.Namespace, Name: ref.Name}, secret); err != nil {
+			r.Recorder.Event(subscription, corev1.EventTypeWarning, "SigningSecretNotFound", err.Error())
+		} else if _, ok := secret.Data[ref.Key]; !ok {
+			r.Recorder.Event(subscription, corev1.EventTypeWarning, "SigningSecretNotFound",
+				fmt.Sprintf("secret %s has no key %s", ref.Name, ref.Key))
+		}
+	}
+
+	return ctrl.Result{}, nil
+}
+
+// Publish fans the event out to the subscriptions of its namespace, it is
+// the entry point for events not observed from the shared cache.
+func (r *Reconciler) Publish(ctx context.Context, event Event) {
+	subscriptions := &notificationv1alpha1.SubscriptionList{}
+	if err := r.List(ctx, subscriptions, client.InNamespace(event.Object.Namespace)); err != nil {
+		r.Logger.Error(err, "failed to list subscriptions", "namespace", event.Object.Namespace)
+		return
+	}
+
+	for _, subscription := range subscriptions.Items {
+		if subscription.Spec.Paused || !subscribed(&subscription, event.Type) {
+			continue
+		}
+		r.queue.Add(delivery{
+			subscription: types.NamespacedName{Namespace: subscription.Namespace, Name: subscription.Name},
+			event:        event,
+		})
+	}
+}
+
+func (r *Reconciler) observeWorkload(obj interface{}, eventType notificationv1alpha1.EventType) {
+	if tombstone, ok := obj.(toolscache.DeletedFinalStateUnknown); ok {
+		obj = tombstone.Obj
+	}
+
+	var object EventObject
+	switch workload := obj.(type) {
+	case *appsv1.Deployment:
+		object = EventObject{APIVersion: "apps/v1", Kind: "Deployment", Namespace: workload.Namespace, Name: workload.Name}
+	case *appsv1.StatefulSet:
+		object = EventObject{APIVersion: "apps/v1", Kind: "StatefulSet", Namespace: workload.Namespace, Name: workload.Name}
+	default:
+		return
+	}
+
+	r.Publish(context.Background(), Event{
+		Type:      eventType,
+		Object:    object,
+		Timestamp: metav1.Now(),
+	})
+}
+
+// runDispatcher consumes the delivery queue until the manager stops.
+func (r *Reconciler) runDispatcher(ctx context.Context) error {
+	defer utilruntime.HandleCrash()
+	defer r.queue.ShutDown()
+
+	for i := 0; i < r.MaxConcurrentReconciles; i++ {
+		go func() {
+			for r.processNextDelivery(ctx) {
+			}
+		}()
+	}
+
+	<-ctx.Done()
+	return nil
+}
+
+func (r *Reconciler) processNextDelivery(ctx context.Context) bool {
+	item, shutdown := r.queue.Get()
+	if shutdown {
+		return false
+	}
+	defer r.queue.Done(item)
+
+	d := item.(delivery)
+	subscription := &notificationv1alpha1.Subscription{}
+	if err := r.Get(ctx, d.subscription, subscription); err != nil {
+		// The subscription disappeared after the event fanned out, the
+		// delivery is obsolete.
+		r.queue.Forget(item)
+		return true
+	}
+
+	err := r.deliver(ctx, subscription, d.event)
+	if err == nil {
+		r.queue.Forget(item)
+		r.updateStatus(ctx, d.subscription, func(status *notificationv1alpha1.SubscriptionStatus) {
+			status.DeliveredEvents++
+			status.LastDeliveryTime = &metav1.Time{Time: time.Now()}
+		})
+		return true
+	}
+
+	maxRetries := int32(defaultMaxRetries)
+	if subscription.Spec.MaxRetries != nil {
+		maxRetries = *subscription.Spec.MaxRetries
+	}
+	if r.queue.NumRequeues(item) < int(maxRetries) {
+		r.queue.AddRateLimited(item)
+		return true
+	}
+
+	r.queue.Forget(item)
+	r.Recorder.Event(subscription, corev1.EventTypeWarning, "DeliveryFailed",
+		fmt.Sprintf("dropped %s event after %d retries: %v", d.event.Type, maxRetries, err))
+	r.updateStatus(ctx, d.subscription, func(status *notificationv1alpha1.SubscriptionStatus) {
+		status.FailedEvents++
+		status.LastFailureReason = err.Error()
+	})
+	return true
+}
+
+// deliver posts the event to the webhook of the subscription, signing the
+// body when a signing secret is referenced.
+func (r *Reconciler) deliver(ctx context.Context, subscription *notificationv1alpha1.Subscription, event Event) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, subscription.Spec.Webhook.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Content-Type", "application/json")
+
+	if ref := subscription.Spec.Webhook.SecretRef; ref != nil {
+		secret := &corev1.Secret{}
+		if err := r.Get(ctx, types.NamespacedName{Namespace: subscription.Namespace, Name: ref.Name}, secret); err != nil {
+			return fmt.Errorf("failed to read signing secret: %v", err)
+		}
+		token, ok := secret.Data[ref.Key]
+		if !ok {
+			return fmt.Errorf("secret %s has no key %s", ref.Name, ref.Key)
+		}
+		mac := hmac.New(sha256.New, token)
+		mac.Write(body)
+		request.Header.Set(signatureHeader, "sha256="+hex.EncodeToString(mac.Sum(nil)))
+	}
+
+	httpClient := r.httpClient
+	if subscription.Spec.Webhook.InsecureSkipVerify {
+		httpClient = r.insecureHTTPClient
+	}
+	response, err := httpClient.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode < http.StatusOK || response.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("webhook returned status %s", response.Status)
+	}
+	return nil
+}
+
+func (r *Reconciler) updateStatus(ctx context.Context, name types.NamespacedName, mutate func(*notificationv1alpha1.SubscriptionStatus)) {
+	subscription := &notificationv1alpha1.Subscription{}
+	if err := r.Get(ctx, name, subscription); err != nil {
+		return
+	}
+	mutate(&subscription.Status)
+	if err := r.Status().Update(ctx, subscription); err != nil {
+		r.Logger.V(4).Info("failed to update subscription status", "subscription", name, "error", err)
+	}
+}
+
+func subscribed(subscription *notificationv1alpha1.Subscription, eventType notificationv1alpha1.EventType) bool {
+	for _, t := range subscription.Spec.Events {
+		if t == eventType {
+			return true
+		}
+	}
+	return false
+}
@@ -19,6 +19,8 @@ package helmapplication
 import (
 	"context"
 	"fmt"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/Masterminds/semver/v3"
@@ -31,8 +33,11 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	"kubesphere.io/api/application/v1alpha1"
+	iamv1alpha2 "kubesphere.io/api/iam/v1alpha2"
+	notificationv1alpha1 "kubesphere.io/api/notification/v1alpha1"
 
 	"kubesphere.io/kubesphere/pkg/constants"
+	"kubesphere.io/kubesphere/pkg/controller/notificationdispatch"
 	"kubesphere.io/kubesphere/pkg/utils/sliceutil"
 )
 
@@ -42,9 +47,19 @@ const (
 
 var _ reconcile.Reconciler = &ReconcileHelmApplicationVersion{}
 
+// Notifier publishes a notification event, the notification dispatch
+// reconciler satisfies it.
+type Notifier interface {
+	Publish(ctx context.Context, event notificationdispatch.Event)
+}
+
 // ReconcileHelmApplicationVersion reconciles a helm application version object
 type ReconcileHelmApplicationVersion struct {
 	client.Client
+
+	// Notifier is optional, without it submitted versions still get the
+	// pending reviewers annotation but no notification goes out.
+	Notifier Notifier
 }
 
 // Reconcile reads that state of the cluster for a helmapplicationversions object and makes changes based on the state read
@@ -112,6 +127,10 @@ func (r *ReconcileHelmApplicationVersion) Reconcile(ctx context.Context, request
 		return reconcile.Result{}, err
 	}
 
+	if err = r.syncReviewState(context.TODO(), appVersion); err != nil {
+		return reconcile.Result{}, err
+	}
+
 	if appVersion.Status.State == v1alpha1.StateActive {
 		// add labels to helm application version
 		// The label will exists forever, since this helmapplicationversion's state only can be active and suspend.
@@ -191,6 +210,97 @@ func updateHelmApplicationStatus(c client.Client, appId string, inAppStore bool)
 	return nil
 }
 
+// syncReviewState keeps the pending reviewers annotation in step with the
+// review state of the version. On submission the platform reviewers are
+// resolved from the global roles, recorded on the version and notified,
+// leaving the submitted state clears the annotation again.
+func (r *ReconcileHelmApplicationVersion) syncReviewState(ctx context.Context, appVersion *v1alpha1.HelmApplicationVersion) error {
+	_, annotated := appVersion.Annotations[v1alpha1.PendingReviewersAnnotationKey]
+
+	if appVersion.Status.State != v1alpha1.StateSubmitted {
+		if !annotated {
+			return nil
+		}
+		instanceCopy := appVersion.DeepCopy()
+		delete(instanceCopy.Annotations, v1alpha1.PendingReviewersAnnotationKey)
+		patch := client.MergeFrom(appVersion)
+		return r.Client.Patch(ctx, instanceCopy, patch)
+	}
+
+	if annotated {
+		// reviewers were already resolved and notified for this submission
+		return nil
+	}
+
+	reviewers, err := r.appReviewers(ctx)
+	if err != nil {
+		return err
+	}
+
+	instanceCopy := appVersion.DeepCopy()
+	if instanceCopy.Annotations == nil {
+		instanceCopy.Annotations = map[string]string{}
+	}
+	instanceCopy.Annotations[v1alpha1.PendingReviewersAnnotationKey] = strings.Join(reviewers, ",")
+	patch := client.MergeFrom(appVersion)
+	if err := r.Client.Patch(ctx, instanceCopy, patch); err != nil {
+		return err
+	}
+
+	klog.V(2).Infof("app version %s submitted for review, reviewers: %s", appVersion.Name, strings.Join(reviewers, ","))
+
+	if r.Notifier != nil {
+		r.Notifier.Publish(ctx, notificationdispatch.Event{
+			Type: notificationv1alpha1.EventAppVersionSubmitted,
+			Object: notificationdispatch.EventObject{
+				APIVersion: v1alpha1.SchemeGroupVersion.String(),
+				Kind:       v1alpha1.ResourceKindHelmApplicationVersion,
+				Name:       appVersion.Name,
+			},
+			Timestamp: metav1.Now(),
+		})
+	}
+
+	return nil
+}
+
+// appReviewers resolves the platform users who review app store
+// submissions: members of the platform-admin global role and of any
+// global role annotated as app reviewer.
+func (r *ReconcileHelmApplicationVersion) appReviewers(ctx context.Context) ([]string, error) {
+	var roles iamv1alpha2.GlobalRoleList
+	if err := r.List(ctx, &roles); err != nil {
+		return nil, err
+	}
+
+	reviewerRoles := map[string]bool{iamv1alpha2.PlatformAdmin: true}
+	for _, role := range roles.Items {
+		if role.Annotations[v1alpha1.AppReviewerRoleAnnotation] == "true" {
+			reviewerRoles[role.Name] = true
+		}
+	}
+
+	var bindings iamv1alpha2.GlobalRoleBindingList
+	if err := r.List(ctx, &bindings); err != nil {
+		return nil, err
+	}
+
+	var reviewers []string
+	for _, binding := range bindings.Items {
+		if !reviewerRoles[binding.RoleRef.Name] {
+			continue
+		}
+		for _, subject := range binding.Subjects {
+			if subject.Kind == iamv1alpha2.ResourceKindUser && !sliceutil.HasString(reviewers, subject.Name) {
+				reviewers = append(reviewers, subject.Name)
+			}
+		}
+	}
+	sort.Strings(reviewers)
+
+	return reviewers, nil
+}
+
 func (r *ReconcileHelmApplicationVersion) updateStatus(appVersion *v1alpha1.HelmApplicationVersion) error {
 	appVersion.Status = v1alpha1.HelmApplicationVersionStatus{
 		State: v1alpha1.StateDraft,
@@ -293,6 +293,22 @@ func (r *ReconcileHelmRelease) updateStatus(rls *v1alpha1.HelmRelease, currentSt
 		(currentState == v1alpha1.HelmStatusCreated || currentState == v1alpha1.HelmStatusUpgraded) {
 		rls.Status.Version = rls.Spec.Version
 		rls.Status.LastDeployed = &now
+
+		// record the values this version was deployed with, so upgrades
+		// can be audited and revisions diffed
+		revision := v1alpha1.HelmReleaseValuesRevision{
+			Version:  rls.Spec.Version,
+			Values:   rls.Spec.Values,
+			Operator: rls.Annotations[v1alpha1.HelmReleaseLastOperatorAnnotationKey],
+			Time:     now,
+		}
+		if revision.Operator == "" {
+			revision.Operator = rls.GetCreator()
+		}
+		rls.Status.ValuesHistory = append([]v1alpha1.HelmReleaseValuesRevision{revision}, rls.Status.ValuesHistory...)
+		if len(rls.Status.ValuesHistory) > v1alpha1.MaxValuesHistory {
+			rls.Status.ValuesHistory = rls.Status.ValuesHistory[:v1alpha1.MaxValuesHistory:v1alpha1.MaxValuesHistory]
+		}
 	}
 
 	rls.Status.State = currentState
@@ -42,6 +42,7 @@ import (
 	"kubesphere.io/api/application/v1alpha1"
 
 	"kubesphere.io/kubesphere/pkg/simple/client/openpitrix/helmrepoindex"
+	"kubesphere.io/kubesphere/pkg/simple/client/secretstore"
 	"kubesphere.io/kubesphere/pkg/utils/sliceutil"
 )
 
@@ -84,6 +85,10 @@ type ReconcileHelmRepo struct {
 	scheme   *runtime.Scheme
 	recorder record.EventRecorder
 	config   *rest.Config
+
+	// SecretStore resolves credential fields that are references into an
+	// external secret store, nil when no store is configured.
+	SecretStore secretstore.Interface
 }
 
 func (r *ReconcileHelmRepo) SetupWithManager(mgr ctrl.Manager) error {
@@ -267,9 +272,33 @@ func needReSyncNow(instance *v1alpha1.HelmRepo) (syncNow bool, after int) {
 	return false, after
 }
 
+// resolveCredential replaces secret store references in the repo credential
+// with the stored values, the instance itself keeps only the references.
+func (r *ReconcileHelmRepo) resolveCredential(instance *v1alpha1.HelmRepo) (*v1alpha1.HelmRepoCredential, error) {
+	cred := instance.Spec.Credential
+	if r.SecretStore == nil {
+		return &cred, nil
+	}
+
+	var err error
+	if cred.Username, err = r.SecretStore.Resolve(cred.Username); err != nil {
+		return nil, err
+	}
+	if cred.Password, err = r.SecretStore.Resolve(cred.Password); err != nil {
+		return nil, err
+	}
+	return &cred, nil
+}
+
 func (r *ReconcileHelmRepo) syncRepo(instance *v1alpha1.HelmRepo) error {
+	cred, err := r.resolveCredential(instance)
+	if err != nil {
+		klog.Errorf("resolve credential failed, repo: %s, err: %s", instance.GetTrueName(), err)
+		return err
+	}
+
 	// 1. load index from helm repo
-	index, err := helmrepoindex.LoadRepoIndex(context.TODO(), instance.Spec.Url, &instance.Spec.Credential)
+	index, err := helmrepoindex.LoadRepoIndex(context.TODO(), instance.Spec.Url, cred)
 
 	if err != nil {
 		klog.Errorf("load index failed, repo: %s, url: %s, err: %s", instance.GetTrueName(), instance.Spec.Url, err)
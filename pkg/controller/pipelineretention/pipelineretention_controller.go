@@ -0,0 +1,206 @@
+/*
+Copyright 2022 The KubeSphere Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pipelineretention
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	devopsv1alpha3 "kubesphere.io/api/devops/v1alpha3"
+
+	"kubesphere.io/kubesphere/pkg/simple/client/devops"
+	"kubesphere.io/kubesphere/pkg/simple/client/s3"
+)
+
+const (
+	controllerName = "pipelineretention-controller"
+
+	// checkInterval is how often the runs of a project with a retention
+	// policy are rechecked, runs only expire by count or age so a tighter
+	// loop buys nothing.
+	checkInterval = time.Hour
+)
+
+// retentionPolicy is the parsed retention annotations of a devops project,
+// zero values mean the corresponding limit is not set.
+type retentionPolicy struct {
+	maxRuns int
+	maxDays int
+	archive bool
+}
+
+// Reconciler cleans up expired pipeline runs of devops projects that
+// declare a retention policy by annotation, keeping at most N runs or M
+// days per pipeline. Logs of expired runs are archived to object storage
+// before deletion when the project asks for it; a run whose log cannot be
+// archived is kept.
+type Reconciler struct {
+	client.Client
+	Logger       logr.Logger
+	Recorder     record.EventRecorder
+	DevopsClient devops.Interface
+	S3Client     s3.Interface
+}
+
+func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) error {
+	if r.Client == nil {
+		r.Client = mgr.GetClient()
+	}
+	if r.Logger == nil {
+		r.Logger = ctrl.Log.WithName("controllers").WithName(controllerName)
+	}
+	if r.Recorder == nil {
+		r.Recorder = mgr.GetEventRecorderFor(controllerName)
+	}
+	if r.DevopsClient == nil {
+		return fmt.Errorf("failed to setup %s: devops client is required", controllerName)
+	}
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(controllerName).
+		For(&devopsv1alpha3.DevOpsProject{}).
+		Complete(r)
+}
+
+// +kubebuilder:rbac:groups=devops.kubesphere.io,resources=devopsprojects;pipelines,verbs=get;list;watch
+
+func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	project := &devopsv1alpha3.DevOpsProject{}
+	if err := r.Get(ctx, req.NamespacedName, project); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	policy, err := parseRetentionPolicy(project)
+	if err != nil {
+		r.Recorder.Event(project, corev1.EventTypeWarning, "InvalidRetentionPolicy", err.Error())
+		return ctrl.Result{}, nil
+	}
+	if policy == nil || project.Status.AdminNamespace == "" {
+		return ctrl.Result{}, nil
+	}
+
+	pipelines := &devopsv1alpha3.PipelineList{}
+	if err := r.List(ctx, pipelines, client.InNamespace(project.Status.AdminNamespace)); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	deleted := 0
+	for _, pipeline := range pipelines.Items {
+		n, err := r.cleanPipeline(project, pipeline.Name, policy)
+		if err != nil {
+			// the pipeline may not have reached jenkins yet, clean up the
+			// rest and come back on the next check
+			r.Logger.Error(err, "failed to clean expired runs", "devopsproject", project.Name, "pipeline", pipeline.Name)
+			continue
+		}
+		deleted += n
+	}
+	if deleted > 0 {
+		r.Recorder.Eventf(project, corev1.EventTypeNormal, "ExpiredRunsCleaned", "cleaned %d expired pipeline runs", deleted)
+	}
+
+	return ctrl.Result{RequeueAfter: checkInterval}, nil
+}
+
+// cleanPipeline deletes the completed runs of the pipeline that fall
+// outside the retention policy and returns how many it deleted.
+func (r *Reconciler) cleanPipeline(project *devopsv1alpha3.DevOpsProject, pipeline string, policy *retentionPolicy) (int, error) {
+	builds, err := r.DevopsClient.ListProjectPipelineBuilds(project.Status.AdminNamespace, pipeline)
+	if err != nil {
+		return 0, err
+	}
+	// newest first, so the first maxRuns completed builds are the kept ones
+	sort.Slice(builds, func(i, j int) bool { return builds[i].Number > builds[j].Number })
+
+	deleted := 0
+	completed := 0
+	for _, build := range builds {
+		if build.Building {
+			continue
+		}
+		completed++
+
+		expired := policy.maxRuns > 0 && completed > policy.maxRuns
+		if policy.maxDays > 0 && time.Since(time.Unix(build.Timestamp/1000, 0)) > time.Duration(policy.maxDays)*24*time.Hour {
+			expired = true
+		}
+		if !expired {
+			continue
+		}
+
+		if policy.archive {
+			if err := r.archiveRunLog(project, pipeline, build.Number); err != nil {
+				r.Logger.Error(err, "failed to archive run log, keeping the run", "devopsproject", project.Name, "pipeline", pipeline, "run", build.Number)
+				continue
+			}
+		}
+		if err := r.DevopsClient.DeleteProjectPipelineBuild(project.Status.AdminNamespace, pipeline, build.Number); err != nil {
+			r.Logger.Error(err, "failed to delete expired run", "devopsproject", project.Name, "pipeline", pipeline, "run", build.Number)
+			continue
+		}
+		deleted++
+	}
+	return deleted, nil
+}
+
+func (r *Reconciler) archiveRunLog(project *devopsv1alpha3.DevOpsProject, pipeline string, number int64) error {
+	if r.S3Client == nil {
+		return fmt.Errorf("no object storage configured to archive run logs")
+	}
+	log, err := r.DevopsClient.GetProjectPipelineBuildLog(project.Status.AdminNamespace, pipeline, number)
+	if err != nil {
+		return err
+	}
+	key := fmt.Sprintf("pipeline-run-logs/%s/%s/%d.log", project.Name, pipeline, number)
+	fileName := fmt.Sprintf("%s-%s-%d.log", project.Name, pipeline, number)
+	return r.S3Client.Upload(key, fileName, bytes.NewReader(log), len(log))
+}
+
+// parseRetentionPolicy returns the retention policy declared on the
+// project, nil when none of the retention annotations is set.
+func parseRetentionPolicy(project *devopsv1alpha3.DevOpsProject) (*retentionPolicy, error) {
+	policy := &retentionPolicy{
+		archive: project.Annotations[devopsv1alpha3.DevOpsProjectArchiveRunLogsAnnoKey] == "true",
+	}
+	if value, ok := project.Annotations[devopsv1alpha3.DevOpsProjectRetentionMaxRunsAnnoKey]; ok {
+		runs, err := strconv.Atoi(value)
+		if err != nil || runs < 1 {
+			return nil, fmt.Errorf("invalid annotation %s: %q must be a positive integer", devopsv1alpha3.DevOpsProjectRetentionMaxRunsAnnoKey, value)
+		}
+		policy.maxRuns = runs
+	}
+	if value, ok := project.Annotations[devopsv1alpha3.DevOpsProjectRetentionMaxDaysAnnoKey]; ok {
+		days, err := strconv.Atoi(value)
+		if err != nil || days < 1 {
+			return nil, fmt.Errorf("invalid annotation %s: %q must be a positive integer", devopsv1alpha3.DevOpsProjectRetentionMaxDaysAnnoKey, value)
+		}
+		policy.maxDays = days
+	}
+	if policy.maxRuns == 0 && policy.maxDays == 0 {
+		return nil, nil
+	}
+	return policy, nil
+}
@@ -0,0 +1,201 @@
+/*
+
+ Copyright 2021 The KubeSphere Authors.
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+
+*/
+
+package quota
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	devopsv1alpha3 "kubesphere.io/api/devops/v1alpha3"
+	quotav1alpha2 "kubesphere.io/api/quota/v1alpha2"
+	tenantv1alpha1 "kubesphere.io/api/tenant/v1alpha1"
+
+	modelstenant "kubesphere.io/kubesphere/pkg/models/tenant"
+)
+
+// DevOpsQuotaAdmission denies creation of devops projects, pipelines and
+// devops credentials once the workspace reaches the object count limits
+// declared on its resource quotas.
+type DevOpsQuotaAdmission struct {
+	client client.Client
+
+	decoder *webhook.AdmissionDecoder
+}
+
+func NewDevOpsQuotaAdmission(client client.Client, scheme *runtime.Scheme) (webhook.AdmissionHandler, error) {
+	decoder, err := admission.NewDecoder(scheme)
+	if err != nil {
+		return nil, err
+	}
+	return &DevOpsQuotaAdmission{
+		client:  client,
+		decoder: decoder,
+	}, nil
+}
+
+func (d *DevOpsQuotaAdmission) Handle(ctx context.Context, req webhook.AdmissionRequest) webhook.AdmissionResponse {
+	// quota only constrains resource creation
+	if req.Operation != admissionv1.Create || len(req.RequestSubResource) != 0 {
+		return webhook.Allowed("")
+	}
+	if req.DryRun != nil && *req.DryRun {
+		return webhook.Allowed("")
+	}
+
+	var workspace string
+	var key corev1.ResourceName
+	var resource string
+
+	switch req.Resource.Resource {
+	case devopsv1alpha3.ResourcePluralDevOpsProject:
+		project := &devopsv1alpha3.DevOpsProject{}
+		if err := d.decoder.Decode(req, project); err != nil {
+			return webhook.Errored(http.StatusBadRequest, err)
+		}
+		workspace = project.Labels[tenantv1alpha1.WorkspaceLabel]
+		key, resource = modelstenant.DevOpsProjectsQuotaKey, "devops projects"
+	case devopsv1alpha3.ResourcePluralPipeline:
+		workspace = d.workspaceOfNamespace(ctx, req.Namespace)
+		key, resource = modelstenant.DevOpsPipelinesQuotaKey, "pipelines"
+	case "secrets":
+		secret := &corev1.Secret{}
+		if err := d.decoder.Decode(req, secret); err != nil {
+			return webhook.Errored(http.StatusBadRequest, err)
+		}
+		// only devops credentials count against the quota
+		if !strings.HasPrefix(string(secret.Type), devopsv1alpha3.DevOpsCredentialPrefix) {
+			return webhook.Allowed("")
+		}
+		workspace = d.workspaceOfNamespace(ctx, req.Namespace)
+		key, resource = modelstenant.DevOpsCredentialsQuotaKey, "devops credentials"
+	default:
+		return webhook.Allowed("")
+	}
+
+	// resources outside any workspace are not constrained
+	if workspace == "" {
+		return webhook.Allowed("")
+	}
+
+	hard, err := d.hardLimit(ctx, workspace, key)
+	if err != nil {
+		klog.Error(err)
+		return webhook.Errored(http.StatusInternalServerError, err)
+	}
+	if hard == nil {
+		return webhook.Allowed("")
+	}
+
+	used, err := d.used(ctx, workspace, req.Resource.Resource)
+	if err != nil {
+		klog.Error(err)
+		return webhook.Errored(http.StatusInternalServerError, err)
+	}
+
+	if used >= *hard {
+		return webhook.Denied(fmt.Sprintf("workspace %s quota exceeded for %s: limited to %d, %d in use", workspace, resource, *hard, used))
+	}
+
+	return webhook.Allowed("")
+}
+
+// workspaceOfNamespace resolves the workspace a namespaced request belongs
+// to, empty when the namespace is not bound to a workspace.
+func (d *DevOpsQuotaAdmission) workspaceOfNamespace(ctx context.Context, namespace string) string {
+	ns := &corev1.Namespace{}
+	if err := d.client.Get(ctx, types.NamespacedName{Name: namespace}, ns); err != nil {
+		klog.Error(err)
+		return ""
+	}
+	return ns.Labels[tenantv1alpha1.WorkspaceLabel]
+}
+
+// hardLimit returns the most restrictive limit declared for the key across
+// the resource quotas of the workspace, nil when none declares it.
+func (d *DevOpsQuotaAdmission) hardLimit(ctx context.Context, workspace string, key corev1.ResourceName) (*int64, error) {
+	quotas := &quotav1alpha2.ResourceQuotaList{}
+	if err := d.client.List(ctx, quotas, client.MatchingLabels{tenantv1alpha1.WorkspaceLabel: workspace}); err != nil {
+		return nil, err
+	}
+	var hard *int64
+	for _, quota := range quotas.Items {
+		limit, ok := quota.Spec.Quota.Hard[key]
+		if !ok {
+			continue
+		}
+		value := limit.Value()
+		if hard == nil || value < *hard {
+			hard = &value
+		}
+	}
+	return hard, nil
+}
+
+// used counts the devops resources of the requested kind the workspace
+// already holds.
+func (d *DevOpsQuotaAdmission) used(ctx context.Context, workspace, resource string) (int64, error) {
+	workspaceSelector := client.MatchingLabels{tenantv1alpha1.WorkspaceLabel: workspace}
+
+	if resource == devopsv1alpha3.ResourcePluralDevOpsProject {
+		projects := &devopsv1alpha3.DevOpsProjectList{}
+		if err := d.client.List(ctx, projects, workspaceSelector); err != nil {
+			return 0, err
+		}
+		return int64(len(projects.Items)), nil
+	}
+
+	namespaces := &corev1.NamespaceList{}
+	if err := d.client.List(ctx, namespaces, workspaceSelector); err != nil {
+		return 0, err
+	}
+
+	var used int64
+	for _, namespace := range namespaces.Items {
+		switch resource {
+		case devopsv1alpha3.ResourcePluralPipeline:
+			pipelines := &devopsv1alpha3.PipelineList{}
+			if err := d.client.List(ctx, pipelines, client.InNamespace(namespace.Name)); err != nil {
+				return 0, err
+			}
+			used += int64(len(pipelines.Items))
+		case "secrets":
+			secrets := &corev1.SecretList{}
+			if err := d.client.List(ctx, secrets, client.InNamespace(namespace.Name)); err != nil {
+				return 0, err
+			}
+			for _, secret := range secrets.Items {
+				if strings.HasPrefix(string(secret.Type), devopsv1alpha3.DevOpsCredentialPrefix) {
+					used++
+				}
+			}
+		}
+	}
+	return used, nil
+}
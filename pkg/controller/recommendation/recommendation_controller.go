@@ -0,0 +1,121 @@
+/*
+Copyright 2022 The KubeSphere Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package recommendation
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/klog"
+
+	"kubesphere.io/kubesphere/pkg/models/recommendation"
+)
+
+// refreshInterval is how often the recommendations are recomputed, the
+// percentiles move slowly so a long interval is enough.
+const refreshInterval = 12 * time.Hour
+
+// Controller periodically refreshes the resource recommendation annotation
+// on deployments and statefulsets from the monitoring backend.
+type Controller struct {
+	client      kubernetes.Interface
+	recommender recommendation.Recommender
+}
+
+func NewController(client kubernetes.Interface, recommender recommendation.Recommender) *Controller {
+	return &Controller{client: client, recommender: recommender}
+}
+
+func (c *Controller) Start(ctx context.Context) error {
+	wait.Until(func() {
+		if err := c.refreshAll(ctx); err != nil {
+			klog.Errorf("failed to refresh workload recommendations: %v", err)
+		}
+	}, refreshInterval, ctx.Done())
+	return nil
+}
+
+func (c *Controller) refreshAll(ctx context.Context) error {
+	deployments, err := c.client.AppsV1().Deployments(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+	refreshed := 0
+	for _, deployment := range deployments.Items {
+		if err := c.refresh(ctx, "deployments", deployment.Namespace, deployment.Name); err != nil {
+			klog.V(4).Infof("skipping recommendation for deployment %s/%s: %v", deployment.Namespace, deployment.Name, err)
+			continue
+		}
+		refreshed++
+	}
+
+	statefulSets, err := c.client.AppsV1().StatefulSets(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+	for _, statefulSet := range statefulSets.Items {
+		if err := c.refresh(ctx, "statefulsets", statefulSet.Namespace, statefulSet.Name); err != nil {
+			klog.V(4).Infof("skipping recommendation for statefulset %s/%s: %v", statefulSet.Namespace, statefulSet.Name, err)
+			continue
+		}
+		refreshed++
+	}
+
+	klog.V(2).Infof("refreshed recommendations of %d workloads", refreshed)
+	return nil
+}
+
+func (c *Controller) refresh(ctx context.Context, kind, namespace, name string) error {
+	// pods of both deployments and statefulsets are prefixed with the
+	// workload name
+	result, err := c.recommender.Recommend(namespace, name+"-")
+	if err != nil {
+		return err
+	}
+	if result == nil {
+		// no usage data yet
+		return nil
+	}
+
+	value, err := json.Marshal(result)
+	if err != nil {
+		return err
+	}
+	annotations := map[string]map[string]map[string]string{
+		"metadata": {"annotations": {recommendation.ResourcesAnnotation: string(value)}},
+	}
+	patch, err := json.Marshal(annotations)
+	if err != nil {
+		return err
+	}
+
+	switch kind {
+	case "deployments":
+		_, err = c.client.AppsV1().Deployments(namespace).Patch(ctx, name, types.MergePatchType, patch, metav1.PatchOptions{})
+	case "statefulsets":
+		_, err = c.client.AppsV1().StatefulSets(namespace).Patch(ctx, name, types.MergePatchType, patch, metav1.PatchOptions{})
+	default:
+		err = fmt.Errorf("unsupported workload kind %s", kind)
+	}
+	return err
+}
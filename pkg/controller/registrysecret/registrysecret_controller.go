@@ -0,0 +1,196 @@
+/*
+Copyright 2022 The KubeSphere Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package registrysecret propagates image registry credentials to the
+// namespaces selected by their target-namespaces annotation, so one
+// workspace-level secret replaces manual duplication per project.
+package registrysecret
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	tenantv1alpha1 "kubesphere.io/api/tenant/v1alpha1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+
+	"kubesphere.io/kubesphere/pkg/models/registries"
+)
+
+const controllerName = "registrysecret-controller"
+
+// Reconciler copies a dockerconfigjson secret carrying the target-namespaces
+// annotation into the selected namespaces and removes copies whose namespace
+// was dropped from the selection.
+type Reconciler struct {
+	client.Client
+	Logger                  logr.Logger
+	Recorder                record.EventRecorder
+	MaxConcurrentReconciles int
+}
+
+func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) error {
+	if r.Client == nil {
+		r.Client = mgr.GetClient()
+	}
+	if r.Logger == nil {
+		r.Logger = ctrl.Log.WithName("controllers").WithName(controllerName)
+	}
+	if r.Recorder == nil {
+		r.Recorder = mgr.GetEventRecorderFor(controllerName)
+	}
+	if r.MaxConcurrentReconciles <= 0 {
+		r.MaxConcurrentReconciles = 1
+	}
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(controllerName).
+		WithOptions(controller.Options{
+			MaxConcurrentReconciles: r.MaxConcurrentReconciles,
+		}).
+		For(&corev1.Secret{}).
+		Complete(r)
+}
+
+func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := r.Logger.WithValues("secret", req.NamespacedName)
+
+	secret := &corev1.Secret{}
+	if err := r.Get(ctx, req.NamespacedName, secret); err != nil {
+		if errors.IsNotFound(err) {
+			// the source secret is gone, clean up every copy made from it
+			deleted := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: req.Namespace, Name: req.Name}}
+			return ctrl.Result{}, r.removeStaleCopies(ctx, deleted, nil)
+		}
+		return ctrl.Result{}, err
+	}
+
+	// only source registry secrets are reconciled, copies carry the source
+	// label instead of the annotation
+	if secret.Type != corev1.SecretTypeDockerConfigJson ||
+		secret.Annotations[registries.TargetNamespacesAnnotation] == "" ||
+		secret.Labels[registries.SourceSecretLabel] != "" {
+		return ctrl.Result{}, nil
+	}
+
+	targets, err := r.targetNamespaces(ctx, secret)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	for namespace := range targets {
+		if err := r.syncCopy(ctx, secret, namespace); err != nil {
+			logger.Error(err, "failed to sync registry secret copy", "namespace", namespace)
+			return ctrl.Result{}, err
+		}
+	}
+
+	if err := r.removeStaleCopies(ctx, secret, targets); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	return ctrl.Result{}, nil
+}
+
+// targetNamespaces resolves the annotation into the set of namespaces to
+// propagate to, the wildcard selects every namespace of the workspace the
+// secret is labeled with.
+func (r *Reconciler) targetNamespaces(ctx context.Context, secret *corev1.Secret) (map[string]bool, error) {
+	targets := make(map[string]bool)
+	annotation := secret.Annotations[registries.TargetNamespacesAnnotation]
+
+	if strings.TrimSpace(annotation) == registries.AllWorkspaceNamespaces {
+		workspace := secret.Labels[tenantv1alpha1.WorkspaceLabel]
+		if workspace == "" {
+			return nil, fmt.Errorf("secret %s/%s selects all workspace namespaces but has no workspace label", secret.Namespace, secret.Name)
+		}
+		var namespaces corev1.NamespaceList
+		if err := r.List(ctx, &namespaces, client.MatchingLabels{tenantv1alpha1.WorkspaceLabel: workspace}); err != nil {
+			return nil, err
+		}
+		for _, namespace := range namespaces.Items {
+			targets[namespace.Name] = true
+		}
+	} else {
+		for _, namespace := range strings.Split(annotation, ",") {
+			if namespace = strings.TrimSpace(namespace); namespace != "" {
+				targets[namespace] = true
+			}
+		}
+	}
+
+	delete(targets, secret.Namespace)
+	return targets, nil
+}
+
+func sourceKey(secret *corev1.Secret) string {
+	return fmt.Sprintf("%s.%s", secret.Namespace, secret.Name)
+}
+
+func (r *Reconciler) syncCopy(ctx context.Context, source *corev1.Secret, namespace string) error {
+	copied := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      source.Name,
+			Namespace: namespace,
+			Labels:    map[string]string{registries.SourceSecretLabel: sourceKey(source)},
+		},
+		Type: source.Type,
+		Data: source.Data,
+	}
+
+	existing := &corev1.Secret{}
+	err := r.Get(ctx, client.ObjectKey{Namespace: namespace, Name: source.Name}, existing)
+	if errors.IsNotFound(err) {
+		return r.Create(ctx, copied)
+	}
+	if err != nil {
+		return err
+	}
+
+	// never overwrite a secret the controller does not own
+	if existing.Labels[registries.SourceSecretLabel] != sourceKey(source) {
+		return fmt.Errorf("secret %s/%s already exists and is not managed by %s", namespace, source.Name, controllerName)
+	}
+
+	existing.Type = source.Type
+	existing.Data = source.Data
+	return r.Update(ctx, existing)
+}
+
+// removeStaleCopies deletes copies of the secret living in namespaces which
+// are no longer selected.
+func (r *Reconciler) removeStaleCopies(ctx context.Context, source *corev1.Secret, targets map[string]bool) error {
+	var copies corev1.SecretList
+	if err := r.List(ctx, &copies, client.MatchingLabels{registries.SourceSecretLabel: sourceKey(source)}); err != nil {
+		return err
+	}
+	for index := range copies.Items {
+		copied := &copies.Items[index]
+		if targets[copied.Namespace] {
+			continue
+		}
+		if err := r.Delete(ctx, copied); err != nil && !errors.IsNotFound(err) {
+			return err
+		}
+	}
+	return nil
+}
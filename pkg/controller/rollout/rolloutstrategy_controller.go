@@ -0,0 +1,415 @@
+/*
+Copyright 2022 The KubeSphere Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rollout
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/go-logr/logr"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+
+	rolloutv1alpha1 "kubesphere.io/api/rollout/v1alpha1"
+)
+
+const (
+	controllerName = "rolloutstrategy-controller"
+
+	// CanaryWeightAnnotation is set on the target workload so the
+	// workload APIs expose the rollout progress without extra queries.
+	CanaryWeightAnnotation = "rollout.kubesphere.io/canary-weight"
+	// PhaseAnnotation mirrors the rollout phase on the target workload.
+	PhaseAnnotation = "rollout.kubesphere.io/phase"
+
+	// nginx ingress canary annotations used for weight shifting.
+	canaryAnnotation       = "nginx.ingress.kubernetes.io/canary"
+	canaryWeightAnnotation = "nginx.ingress.kubernetes.io/canary-weight"
+
+	// canaryIngressSuffix is appended to the stable ingress name.
+	canaryIngressSuffix = "-canary"
+)
+
+// httpRouteGVK is the Gateway API route whose backend weights are shifted,
+// accessed unstructured so the controller does not depend on the Gateway
+// API types being installed.
+var httpRouteGVK = schema.GroupVersionKind{Group: "gateway.networking.k8s.io", Version: "v1alpha2", Kind: "HTTPRoute"}
+
+// Reconciler drives canary and blue-green rollouts without a service mesh
+// by shifting traffic weights through ingress-nginx canary annotations or
+// Gateway API HTTPRoute backends.
+type Reconciler struct {
+	client.Client
+	Logger                  logr.Logger
+	Recorder                record.EventRecorder
+	MaxConcurrentReconciles int
+
+	// now is replaceable for tests
+	now func() time.Time
+}
+
+func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) error {
+	if r.Client == nil {
+		r.Client = mgr.GetClient()
+	}
+	if r.Logger == nil {
+		r.Logger = ctrl.Log.WithName("controllers").WithName(controllerName)
+	}
+	if r.Recorder == nil {
+		r.Recorder = mgr.GetEventRecorderFor(controllerName)
+	}
+	if r.MaxConcurrentReconciles <= 0 {
+		r.MaxConcurrentReconciles = 1
+	}
+	if r.now == nil {
+		r.now = time.Now
+	}
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(controllerName).
+		WithOptions(controller.Options{
+			MaxConcurrentReconciles: r.MaxConcurrentReconciles,
+		}).
+		For(&rolloutv1alpha1.RolloutStrategy{}).
+		Complete(r)
+}
+
+// +kubebuilder:rbac:groups=rollout.kubesphere.io,resources=rolloutstrategies,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups=rollout.kubesphere.io,resources=rolloutstrategies/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=apps,resources=deployments,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups=networking.k8s.io,resources=ingresses,verbs=get;list;watch;create;update;patch
+// +kubebuilder:rbac:groups=gateway.networking.k8s.io,resources=httproutes,verbs=get;list;watch;update;patch
+func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := r.Logger.WithValues("rolloutstrategy", req.NamespacedName)
+
+	strategy := &rolloutv1alpha1.RolloutStrategy{}
+	if err := r.Get(ctx, req.NamespacedName, strategy); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	if strategy.Status.Phase == rolloutv1alpha1.RolloutSucceeded ||
+		strategy.Status.Phase == rolloutv1alpha1.RolloutFailed {
+		return ctrl.Result{}, nil
+	}
+
+	if strategy.Spec.Paused {
+		return ctrl.Result{}, r.updateStatus(ctx, strategy, rolloutv1alpha1.RolloutPaused,
+			strategy.Status.CanaryWeight, strategy.Status.CurrentStepIndex, "rollout paused")
+	}
+
+	workload, err := r.workload(ctx, strategy)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return ctrl.Result{}, r.fail(ctx, strategy, fmt.Sprintf("workload %s/%s not found",
+				strategy.Spec.WorkloadRef.Kind, strategy.Spec.WorkloadRef.Name))
+		}
+		return ctrl.Result{}, err
+	}
+
+	var requeueAfter time.Duration
+	switch strategy.Spec.Type {
+	case rolloutv1alpha1.BlueGreenRollout:
+		requeueAfter, err = r.reconcileBlueGreen(ctx, strategy, workload)
+	default:
+		requeueAfter, err = r.reconcileCanary(ctx, strategy, workload)
+	}
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	logger.V(4).Info("rollout reconciled", "phase", strategy.Status.Phase, "weight", strategy.Status.CanaryWeight)
+	if requeueAfter > 0 {
+		return ctrl.Result{RequeueAfter: requeueAfter}, nil
+	}
+	return ctrl.Result{}, nil
+}
+
+// reconcileCanary walks the steps of the strategy, holding each weight for
+// its pause duration. A step without a pause waits for manual promotion,
+// i.e. the user bumping status.currentStepIndex or editing the steps.
+func (r *Reconciler) reconcileCanary(ctx context.Context, strategy *rolloutv1alpha1.RolloutStrategy, workload *appsv1.Deployment) (time.Duration, error) {
+	stepIndex := strategy.Status.CurrentStepIndex
+	if int(stepIndex) >= len(strategy.Spec.Steps) {
+		// All steps done, send all traffic to the canary version.
+		if err := r.applyWeight(ctx, strategy, workload, 100); err != nil {
+			return 0, err
+		}
+		r.Recorder.Event(strategy, corev1.EventTypeNormal, "Promoted", "all traffic shifted to the canary version")
+		return 0, r.updateStatus(ctx, strategy, rolloutv1alpha1.RolloutSucceeded, 100, stepIndex, "rollout complete")
+	}
+
+	step := strategy.Spec.Steps[stepIndex]
+	if err := r.applyWeight(ctx, strategy, workload, step.Weight); err != nil {
+		return 0, err
+	}
+
+	if step.PauseSeconds == nil {
+		// Hold until the step is promoted manually.
+		return 0, r.updateStatus(ctx, strategy, rolloutv1alpha1.RolloutProgressing, step.Weight, stepIndex,
+			fmt.Sprintf("holding %d%% canary traffic, waiting for manual promotion", step.Weight))
+	}
+
+	pause := time.Duration(*step.PauseSeconds) * time.Second
+	if strategy.Status.LastTransitionTime != nil && strategy.Status.CanaryWeight == step.Weight {
+		elapsed := r.now().Sub(strategy.Status.LastTransitionTime.Time)
+		if elapsed < pause {
+			return pause - elapsed, nil
+		}
+		// Pause is over, move to the next step.
+		return time.Nanosecond, r.updateStatus(ctx, strategy, rolloutv1alpha1.RolloutProgressing,
+			step.Weight, stepIndex+1, fmt.Sprintf("step %d complete", stepIndex))
+	}
+	if err := r.updateStatus(ctx, strategy, rolloutv1alpha1.RolloutProgressing, step.Weight, stepIndex,
+		fmt.Sprintf("holding %d%% canary traffic for %s", step.Weight, pause)); err != nil {
+		return 0, err
+	}
+	return pause, nil
+}
+
+// reconcileBlueGreen keeps the new version dark until the workload is
+// fully ready, then shifts all traffic at once after the auto promotion
+// delay.
+func (r *Reconciler) reconcileBlueGreen(ctx context.Context, strategy *rolloutv1alpha1.RolloutStrategy, workload *appsv1.Deployment) (time.Duration, error) {
+	if !workloadReady(workload) {
+		if err := r.applyWeight(ctx, strategy, workload, 0); err != nil {
+			return 0, err
+		}
+		return 0, r.updateStatus(ctx, strategy, rolloutv1alpha1.RolloutProgressing, 0, 0,
+			"waiting for the new version to become ready")
+	}
+
+	if strategy.Spec.AutoPromotionSeconds == nil {
+		return 0, r.updateStatus(ctx, strategy, rolloutv1alpha1.RolloutProgressing, 0, 0,
+			"new version ready, waiting for manual promotion")
+	}
+
+	delay := time.Duration(*strategy.Spec.AutoPromotionSeconds) * time.Second
+	if strategy.Status.LastTransitionTime != nil {
+		if elapsed := r.now().Sub(strategy.Status.LastTransitionTime.Time); elapsed < delay {
+			return delay - elapsed, nil
+		}
+	}
+
+	if err := r.applyWeight(ctx, strategy, workload, 100); err != nil {
+		return 0, err
+	}
+	r.Recorder.Event(strategy, corev1.EventTypeNormal, "Promoted", "all traffic shifted to the new version")
+	return 0, r.updateStatus(ctx, strategy, rolloutv1alpha1.RolloutSucceeded, 100, 0, "rollout complete")
+}
+
+// applyWeight shifts the canary traffic weight through the configured
+// provider and mirrors the progress on the target workload.
+func (r *Reconciler) applyWeight(ctx context.Context, strategy *rolloutv1alpha1.RolloutStrategy, workload *appsv1.Deployment, weight int32) error {
+	var err error
+	switch strategy.Spec.TrafficRouting.Provider {
+	case rolloutv1alpha1.GatewayAPIProvider:
+		err = r.applyHTTPRouteWeight(ctx, strategy, weight)
+	default:
+		err = r.applyIngressWeight(ctx, strategy, weight)
+	}
+	if err != nil {
+		return err
+	}
+	return r.annotateWorkload(ctx, strategy, workload, weight)
+}
+
+// applyIngressWeight maintains a canary ingress next to the stable one
+// carrying the ingress-nginx canary weight annotations.
+func (r *Reconciler) applyIngressWeight(ctx context.Context, strategy *rolloutv1alpha1.RolloutStrategy, weight int32) error {
+	routing := strategy.Spec.TrafficRouting
+	if routing.Ingress == "" {
+		return fmt.Errorf("spec.trafficRouting.ingress is required for the Ingress provider")
+	}
+
+	stable := &networkingv1.Ingress{}
+	if err := r.Get(ctx, types.NamespacedName{Namespace: strategy.Namespace, Name: routing.Ingress}, stable); err != nil {
+		return err
+	}
+
+	canary := &networkingv1.Ingress{}
+	err := r.Get(ctx, types.NamespacedName{Namespace: strategy.Namespace, Name: routing.Ingress + canaryIngressSuffix}, canary)
+	if errors.IsNotFound(err) {
+		canary = canaryIngress(strategy, stable, weight)
+		return r.Create(ctx, canary)
+	}
+	if err != nil {
+		return err
+	}
+
+	if canary.Annotations[canaryWeightAnnotation] == strconv.Itoa(int(weight)) {
+		return nil
+	}
+	canary.Annotations[canaryWeightAnnotation] = strconv.Itoa(int(weight))
+	return r.Update(ctx, canary)
+}
+
+// canaryIngress derives the canary ingress from the stable one, pointing
+// every backend at the canary service.
+func canaryIngress(strategy *rolloutv1alpha1.RolloutStrategy, stable *networkingv1.Ingress, weight int32) *networkingv1.Ingress {
+	canary := &networkingv1.Ingress{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      stable.Name + canaryIngressSuffix,
+			Namespace: stable.Namespace,
+			Labels:    stable.Labels,
+			Annotations: map[string]string{
+				canaryAnnotation:       "true",
+				canaryWeightAnnotation: strconv.Itoa(int(weight)),
+			},
+			OwnerReferences: []metav1.OwnerReference{
+				*metav1.NewControllerRef(strategy, rolloutv1alpha1.SchemeGroupVersion.WithKind(rolloutv1alpha1.ResourceKindRolloutStrategy)),
+			},
+		},
+		Spec: *stable.Spec.DeepCopy(),
+	}
+	for ruleIndex := range canary.Spec.Rules {
+		rule := &canary.Spec.Rules[ruleIndex]
+		if rule.HTTP == nil {
+			continue
+		}
+		for pathIndex := range rule.HTTP.Paths {
+			backend := &rule.HTTP.Paths[pathIndex].Backend
+			if backend.Service != nil && backend.Service.Name == strategy.Spec.TrafficRouting.StableService {
+				backend.Service.Name = strategy.Spec.TrafficRouting.CanaryService
+			}
+		}
+	}
+	return canary
+}
+
+// applyHTTPRouteWeight updates the backend weights of the Gateway API
+// HTTPRoute so that the canary service receives the given share.
+func (r *Reconciler) applyHTTPRouteWeight(ctx context.Context, strategy *rolloutv1alpha1.RolloutStrategy, weight int32) error {
+	routing := strategy.Spec.TrafficRouting
+	if routing.HTTPRoute == "" {
+		return fmt.Errorf("spec.trafficRouting.httpRoute is required for the GatewayAPI provider")
+	}
+
+	route := &unstructured.Unstructured{}
+	route.SetGroupVersionKind(httpRouteGVK)
+	if err := r.Get(ctx, types.NamespacedName{Namespace: strategy.Namespace, Name: routing.HTTPRoute}, route); err != nil {
+		return err
+	}
+
+	rules, found, err := unstructured.NestedSlice(route.Object, "spec", "rules")
+	if err != nil || !found {
+		return fmt.Errorf("httproute %s has no rules: %v", routing.HTTPRoute, err)
+	}
+
+	changed := false
+	for _, ruleObject := range rules {
+		rule, ok := ruleObject.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		backendRefs, found, _ := unstructured.NestedSlice(rule, "backendRefs")
+		if !found {
+			continue
+		}
+		for _, backendObject := range backendRefs {
+			backend, ok := backendObject.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			name, _, _ := unstructured.NestedString(backend, "name")
+			switch name {
+			case routing.CanaryService:
+				backend["weight"] = int64(weight)
+				changed = true
+			case routing.StableService:
+				backend["weight"] = int64(100 - weight)
+				changed = true
+			}
+		}
+		_ = unstructured.SetNestedSlice(rule, backendRefs, "backendRefs")
+	}
+	if !changed {
+		return fmt.Errorf("httproute %s references neither the stable nor the canary service", routing.HTTPRoute)
+	}
+
+	if err := unstructured.SetNestedSlice(route.Object, rules, "spec", "rules"); err != nil {
+		return err
+	}
+	return r.Update(ctx, route)
+}
+
+// annotateWorkload mirrors the rollout progress on the target workload so
+// the existing workload APIs expose it.
+func (r *Reconciler) annotateWorkload(ctx context.Context, strategy *rolloutv1alpha1.RolloutStrategy, workload *appsv1.Deployment, weight int32) error {
+	phase := strategy.Status.Phase
+	if phase == "" {
+		phase = rolloutv1alpha1.RolloutProgressing
+	}
+	if workload.Annotations[CanaryWeightAnnotation] == strconv.Itoa(int(weight)) &&
+		workload.Annotations[PhaseAnnotation] == string(phase) {
+		return nil
+	}
+	if workload.Annotations == nil {
+		workload.Annotations = make(map[string]string)
+	}
+	workload.Annotations[CanaryWeightAnnotation] = strconv.Itoa(int(weight))
+	workload.Annotations[PhaseAnnotation] = string(phase)
+	return r.Update(ctx, workload)
+}
+
+func (r *Reconciler) workload(ctx context.Context, strategy *rolloutv1alpha1.RolloutStrategy) (*appsv1.Deployment, error) {
+	deployment := &appsv1.Deployment{}
+	err := r.Get(ctx, types.NamespacedName{Namespace: strategy.Namespace, Name: strategy.Spec.WorkloadRef.Name}, deployment)
+	return deployment, err
+}
+
+func workloadReady(deployment *appsv1.Deployment) bool {
+	desired := int32(1)
+	if deployment.Spec.Replicas != nil {
+		desired = *deployment.Spec.Replicas
+	}
+	return deployment.Status.UpdatedReplicas == desired &&
+		deployment.Status.ReadyReplicas == desired &&
+		deployment.Status.ObservedGeneration >= deployment.Generation
+}
+
+func (r *Reconciler) fail(ctx context.Context, strategy *rolloutv1alpha1.RolloutStrategy, message string) error {
+	r.Recorder.Event(strategy, corev1.EventTypeWarning, "RolloutFailed", message)
+	return r.updateStatus(ctx, strategy, rolloutv1alpha1.RolloutFailed,
+		strategy.Status.CanaryWeight, strategy.Status.CurrentStepIndex, message)
+}
+
+func (r *Reconciler) updateStatus(ctx context.Context, strategy *rolloutv1alpha1.RolloutStrategy,
+	phase rolloutv1alpha1.RolloutPhase, weight, stepIndex int32, message string) error {
+
+	if strategy.Status.Phase == phase && strategy.Status.CanaryWeight == weight &&
+		strategy.Status.CurrentStepIndex == stepIndex && strategy.Status.Message == message {
+		return nil
+	}
+	now := metav1.NewTime(r.now())
+	strategy.Status.Phase = phase
+	strategy.Status.CanaryWeight = weight
+	strategy.Status.CurrentStepIndex = stepIndex
+	strategy.Status.LastTransitionTime = &now
+	strategy.Status.Message = message
+	return r.Status().Update(ctx, strategy)
+}
@@ -0,0 +1,261 @@
+/*
+Copyright 2022 The KubeSphere Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scheduledscaling
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/robfig/cron/v3"
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv1 "k8s.io/api/autoscaling/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+
+	autoscalingv1alpha1 "kubesphere.io/api/autoscaling/v1alpha1"
+)
+
+const (
+	controllerName = "scheduledscaling-controller"
+
+	// excludeDateLayout is the day format of the exclusion calendar.
+	excludeDateLayout = "2006-01-02"
+
+	// missedRunLookback bounds how far a controller restart catches up, older
+	// missed runs are dropped the same way the cronjob controller does.
+	missedRunLookback = 24 * time.Hour
+)
+
+var cronParser = cron.NewParser(cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow)
+
+// Reconciler runs the schedules of ScheduledScaling objects, it wakes up at
+// the next fire time of each object and applies the target replica count to
+// the selected workloads.
+type Reconciler struct {
+	client.Client
+	Logger                  logr.Logger
+	Recorder                record.EventRecorder
+	MaxConcurrentReconciles int
+
+	// now is replaceable for tests
+	now func() time.Time
+}
+
+func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) error {
+	if r.Client == nil {
+		r.Client = mgr.GetClient()
+	}
+	if r.Logger == nil {
+		r.Logger = ctrl.Log.WithName("controllers").WithName(controllerName)
+	}
+	if r.Recorder == nil {
+		r.Recorder = mgr.GetEventRecorderFor(controllerName)
+	}
+	if r.MaxConcurrentReconciles <= 0 {
+		r.MaxConcurrentReconciles = 1
+	}
+	if r.now == nil {
+		r.now = time.Now
+	}
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(controllerName).
+		WithOptions(controller.Options{
+			MaxConcurrentReconciles: r.MaxConcurrentReconciles,
+		}).
+		For(&autoscalingv1alpha1.ScheduledScaling{}).
+		Complete(r)
+}
+
+// +kubebuilder:rbac:groups=autoscaling.kubesphere.io,resources=scheduledscalings,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups=autoscaling.kubesphere.io,resources=scheduledscalings/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=apps,resources=deployments;statefulsets,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups=autoscaling,resources=horizontalpodautoscalers,verbs=get;list;watch
+func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := r.Logger.WithValues("scheduledscaling", req.NamespacedName)
+
+	scaling := &autoscalingv1alpha1.ScheduledScaling{}
+	if err := r.Get(ctx, req.NamespacedName, scaling); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	if scaling.Spec.Paused {
+		return ctrl.Result{}, nil
+	}
+
+	location := time.UTC
+	if scaling.Spec.TimeZone != "" {
+		var err error
+		if location, err = time.LoadLocation(scaling.Spec.TimeZone); err != nil {
+			r.Recorder.Event(scaling, corev1.EventTypeWarning, "InvalidTimeZone", err.Error())
+			return ctrl.Result{}, nil
+		}
+	}
+
+	now := r.now().In(location)
+	due, next, err := r.dueSchedule(scaling, now)
+	if err != nil {
+		r.Recorder.Event(scaling, corev1.EventTypeWarning, "InvalidSchedule", err.Error())
+		return ctrl.Result{}, nil
+	}
+
+	if due != nil && !excluded(scaling.Spec.ExcludeDates, now) {
+		if err := r.applySchedule(ctx, scaling, due, now); err != nil {
+			return ctrl.Result{}, err
+		}
+		logger.V(4).Info("schedule applied", "cron", due.Cron, "targetReplicas", due.TargetReplicas)
+	}
+
+	if next.IsZero() {
+		return ctrl.Result{}, nil
+	}
+	return ctrl.Result{RequeueAfter: time.Until(next)}, nil
+}
+
+// dueSchedule returns the schedule that should fire now, if any, together
+// with the next wake-up time. When several schedules fire at the same
+// instant the last one in the list wins.
+func (r *Reconciler) dueSchedule(scaling *autoscalingv1alpha1.ScheduledScaling, now time.Time) (*autoscalingv1alpha1.ScalingSchedule, time.Time, error) {
+	earliestStart := now.Add(-missedRunLookback)
+	lastRun := scaling.CreationTimestamp.Time
+	if scaling.Status.LastScheduleTime != nil {
+		lastRun = scaling.Status.LastScheduleTime.Time
+	}
+	if lastRun.Before(earliestStart) {
+		lastRun = earliestStart
+	}
+
+	var due *autoscalingv1alpha1.ScalingSchedule
+	var dueTime, next time.Time
+	for index := range scaling.Spec.Schedules {
+		schedule := &scaling.Spec.Schedules[index]
+		expression, err := cronParser.Parse(schedule.Cron)
+		if err != nil {
+			return nil, time.Time{}, fmt.Errorf("invalid cron expression %q: %v", schedule.Cron, err)
+		}
+		// walk the fire times since the last run, the latest one not in the
+		// future is due now
+		for t := expression.Next(lastRun); !t.IsZero() && !t.After(now); t = expression.Next(t) {
+			if t.After(dueTime) || t.Equal(dueTime) {
+				due = schedule
+				dueTime = t
+			}
+		}
+		if upcoming := expression.Next(now); !upcoming.IsZero() && (next.IsZero() || upcoming.Before(next)) {
+			next = upcoming
+		}
+	}
+	return due, next, nil
+}
+
+func (r *Reconciler) applySchedule(ctx context.Context, scaling *autoscalingv1alpha1.ScheduledScaling, schedule *autoscalingv1alpha1.ScalingSchedule, now time.Time) error {
+	selector, err := metav1.LabelSelectorAsSelector(scaling.Spec.Selector)
+	if err != nil {
+		r.Recorder.Event(scaling, corev1.EventTypeWarning, "InvalidSelector", err.Error())
+		return nil
+	}
+
+	hpaTargets, err := r.hpaTargets(ctx, scaling.Namespace)
+	if err != nil {
+		return err
+	}
+	override := scaling.Spec.HPAConflictPolicy == autoscalingv1alpha1.HPAConflictOverride
+
+	var scaled, skipped []string
+
+	var deployments appsv1.DeploymentList
+	if err := r.List(ctx, &deployments, client.InNamespace(scaling.Namespace), client.MatchingLabelsSelector{Selector: selector}); err != nil {
+		return err
+	}
+	for index := range deployments.Items {
+		deployment := &deployments.Items[index]
+		name := fmt.Sprintf("Deployment/%s", deployment.Name)
+		if hpaTargets[name] && !override {
+			skipped = append(skipped, name)
+			continue
+		}
+		if deployment.Spec.Replicas == nil || *deployment.Spec.Replicas != schedule.TargetReplicas {
+			deployment.Spec.Replicas = &schedule.TargetReplicas
+			if err := r.Update(ctx, deployment); err != nil {
+				return err
+			}
+		}
+		scaled = append(scaled, name)
+	}
+
+	var statefulSets appsv1.StatefulSetList
+	if err := r.List(ctx, &statefulSets, client.InNamespace(scaling.Namespace), client.MatchingLabelsSelector{Selector: selector}); err != nil {
+		return err
+	}
+	for index := range statefulSets.Items {
+		statefulSet := &statefulSets.Items[index]
+		name := fmt.Sprintf("StatefulSet/%s", statefulSet.Name)
+		if hpaTargets[name] && !override {
+			skipped = append(skipped, name)
+			continue
+		}
+		if statefulSet.Spec.Replicas == nil || *statefulSet.Spec.Replicas != schedule.TargetReplicas {
+			statefulSet.Spec.Replicas = &schedule.TargetReplicas
+			if err := r.Update(ctx, statefulSet); err != nil {
+				return err
+			}
+		}
+		scaled = append(scaled, name)
+	}
+
+	scaleTime := metav1.NewTime(now)
+	scaling.Status.LastScheduleTime = &scaleTime
+	scaling.Status.LastAppliedReplicas = &schedule.TargetReplicas
+	scaling.Status.ScaledWorkloads = scaled
+	scaling.Status.SkippedWorkloads = skipped
+	if err := r.Status().Update(ctx, scaling); err != nil {
+		return err
+	}
+
+	r.Recorder.Eventf(scaling, corev1.EventTypeNormal, "Scaled",
+		"scaled %d workloads to %d replicas, %d skipped because of HPA conflicts", len(scaled), schedule.TargetReplicas, len(skipped))
+	return nil
+}
+
+// hpaTargets returns the workloads of the namespace managed by a
+// HorizontalPodAutoscaler, keyed as kind/name.
+func (r *Reconciler) hpaTargets(ctx context.Context, namespace string) (map[string]bool, error) {
+	var hpas autoscalingv1.HorizontalPodAutoscalerList
+	if err := r.List(ctx, &hpas, client.InNamespace(namespace)); err != nil {
+		return nil, err
+	}
+	targets := make(map[string]bool, len(hpas.Items))
+	for _, hpa := range hpas.Items {
+		targets[fmt.Sprintf("%s/%s", hpa.Spec.ScaleTargetRef.Kind, hpa.Spec.ScaleTargetRef.Name)] = true
+	}
+	return targets, nil
+}
+
+func excluded(dates []string, now time.Time) bool {
+	today := now.Format(excludeDateLayout)
+	for _, date := range dates {
+		if date == today {
+			return true
+		}
+	}
+	return false
+}
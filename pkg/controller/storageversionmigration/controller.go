@@ -0,0 +1,47 @@
+/*
+Copyright 2022 The KubeSphere Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package storageversionmigration
+
+import (
+	"context"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/klog"
+)
+
+const resyncPeriod = time.Hour
+
+// Controller periodically runs the storage version migrator, picking up CRDs
+// whose storage version changed after an upgrade. CRDs whose storedVersions
+// are already trimmed are skipped, so the steady-state pass is cheap.
+type Controller struct {
+	migrator *Migrator
+}
+
+func NewController(migrator *Migrator) *Controller {
+	return &Controller{migrator: migrator}
+}
+
+func (c *Controller) Start(ctx context.Context) error {
+	wait.Until(func() {
+		if err := c.migrator.MigrateAll(ctx); err != nil {
+			klog.Errorf("storage version migration failed: %v", err)
+		}
+	}, resyncPeriod, ctx.Done())
+	return nil
+}
@@ -0,0 +1,224 @@
+/*
+Copyright 2022 The KubeSphere Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package storageversionmigration rewrites stored objects of KubeSphere CRDs
+// at their current storage version, so old API versions can be dropped from
+// spec.versions once status.storedVersions no longer references them.
+package storageversionmigration
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apiextensionsclient "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/util/retry"
+	"k8s.io/klog"
+)
+
+const (
+	// ContinueAnnotation stores the list continue token of an in-flight
+	// migration on the CRD, so an interrupted run resumes where it stopped.
+	ContinueAnnotation = "migration.kubesphere.io/continue"
+
+	// kubeSphereGroupSuffix limits migrations to the CRD groups KubeSphere owns.
+	kubeSphereGroupSuffix = "kubesphere.io"
+
+	defaultBatchSize = 100
+)
+
+// Migrator rewrites every stored object of a CRD through the apiserver, which
+// persists it again at the current storage version, then trims
+// status.storedVersions down to that version.
+type Migrator struct {
+	dynamicClient       dynamic.Interface
+	apiextensionsClient apiextensionsclient.Interface
+	batchSize           int64
+}
+
+func NewMigrator(config *rest.Config, apiextensionsClient apiextensionsclient.Interface) (*Migrator, error) {
+	dynamicClient, err := dynamic.NewForConfig(config)
+	if err != nil {
+		return nil, err
+	}
+	return &Migrator{
+		dynamicClient:       dynamicClient,
+		apiextensionsClient: apiextensionsClient,
+		batchSize:           defaultBatchSize,
+	}, nil
+}
+
+// NeedsMigration reports whether the CRD still stores objects at a version
+// other than its current storage version.
+func NeedsMigration(crd *apiextensionsv1.CustomResourceDefinition) bool {
+	storageVersion := storageVersion(crd)
+	if storageVersion == "" {
+		return false
+	}
+	for _, stored := range crd.Status.StoredVersions {
+		if stored != storageVersion {
+			return true
+		}
+	}
+	return false
+}
+
+func storageVersion(crd *apiextensionsv1.CustomResourceDefinition) string {
+	for _, version := range crd.Spec.Versions {
+		if version.Storage {
+			return version.Name
+		}
+	}
+	return ""
+}
+
+// MigrateAll migrates every KubeSphere CRD that still has stored objects at
+// an outdated version, CRDs already migrated are skipped.
+func (m *Migrator) MigrateAll(ctx context.Context) error {
+	crds, err := m.apiextensionsClient.ApiextensionsV1().CustomResourceDefinitions().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for index := range crds.Items {
+		crd := &crds.Items[index]
+		if !strings.HasSuffix(crd.Spec.Group, kubeSphereGroupSuffix) || !NeedsMigration(crd) {
+			continue
+		}
+		if err := m.Migrate(ctx, crd); err != nil {
+			errs = append(errs, fmt.Errorf("failed to migrate %s: %v", crd.Name, err))
+		}
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
+// Migrate rewrites all stored objects of the CRD and trims its
+// status.storedVersions, the list continue token is checkpointed on the CRD
+// after every batch so the migration can resume after a restart.
+func (m *Migrator) Migrate(ctx context.Context, crd *apiextensionsv1.CustomResourceDefinition) error {
+	storageVersion := storageVersion(crd)
+	if storageVersion == "" {
+		return fmt.Errorf("%s has no storage version", crd.Name)
+	}
+
+	gvr := schema.GroupVersionResource{
+		Group:    crd.Spec.Group,
+		Version:  storageVersion,
+		Resource: crd.Spec.Names.Plural,
+	}
+
+	klog.V(0).Infof("Migrating stored objects of %s to %s", crd.Name, storageVersion)
+
+	migrated := 0
+	token := crd.Annotations[ContinueAnnotation]
+	for {
+		list, err := m.dynamicClient.Resource(gvr).List(ctx, metav1.ListOptions{Limit: m.batchSize, Continue: token})
+		if err != nil {
+			// the checkpointed token expired, start over from the beginning
+			if errors.IsResourceExpired(err) && token != "" {
+				token = ""
+				continue
+			}
+			return err
+		}
+
+		for index := range list.Items {
+			item := &list.Items[index]
+			if err := m.rewrite(ctx, gvr, item.GetNamespace(), item.GetName()); err != nil {
+				return err
+			}
+			migrated++
+		}
+
+		token = list.GetContinue()
+		if err := m.checkpoint(ctx, crd.Name, token); err != nil {
+			return err
+		}
+		if token == "" {
+			break
+		}
+	}
+
+	if err := m.trimStoredVersions(ctx, crd.Name, storageVersion); err != nil {
+		return err
+	}
+
+	klog.V(0).Infof("Migrated %d objects of %s to %s", migrated, crd.Name, storageVersion)
+	return nil
+}
+
+// rewrite updates the object without changing it, the apiserver persists it
+// again at the storage version. Objects deleted underneath are skipped.
+func (m *Migrator) rewrite(ctx context.Context, gvr schema.GroupVersionResource, namespace, name string) error {
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		item, err := m.dynamicClient.Resource(gvr).Namespace(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		_, err = m.dynamicClient.Resource(gvr).Namespace(namespace).Update(ctx, item, metav1.UpdateOptions{})
+		return err
+	})
+	if errors.IsNotFound(err) {
+		return nil
+	}
+	return err
+}
+
+// checkpoint persists the continue token on the CRD, an empty token removes
+// the annotation.
+func (m *Migrator) checkpoint(ctx context.Context, crdName, token string) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		crd, err := m.apiextensionsClient.ApiextensionsV1().CustomResourceDefinitions().Get(ctx, crdName, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		if token == "" {
+			if _, ok := crd.Annotations[ContinueAnnotation]; !ok {
+				return nil
+			}
+			delete(crd.Annotations, ContinueAnnotation)
+		} else {
+			if crd.Annotations == nil {
+				crd.Annotations = make(map[string]string)
+			}
+			crd.Annotations[ContinueAnnotation] = token
+		}
+		_, err = m.apiextensionsClient.ApiextensionsV1().CustomResourceDefinitions().Update(ctx, crd, metav1.UpdateOptions{})
+		return err
+	})
+}
+
+// trimStoredVersions replaces status.storedVersions with the storage version
+// only, all objects have been rewritten at that version by now.
+func (m *Migrator) trimStoredVersions(ctx context.Context, crdName, storageVersion string) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		crd, err := m.apiextensionsClient.ApiextensionsV1().CustomResourceDefinitions().Get(ctx, crdName, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		crd.Status.StoredVersions = []string{storageVersion}
+		_, err = m.apiextensionsClient.ApiextensionsV1().CustomResourceDefinitions().UpdateStatus(ctx, crd, metav1.UpdateOptions{})
+		return err
+	})
+}
@@ -0,0 +1,272 @@
+/*
+Copyright 2022 The KubeSphere Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package strategypromotion
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+
+	servicemeshv1alpha2 "kubesphere.io/api/servicemesh/v1alpha2"
+
+	"kubesphere.io/kubesphere/pkg/simple/client/monitoring"
+)
+
+const (
+	controllerName = "strategypromotion-controller"
+
+	// analysisHistoryLimit bounds the evaluations kept in status.
+	analysisHistoryLimit = 20
+
+	defaultIntervalSeconds = 60
+	defaultIterations      = 5
+	defaultFailureLimit    = 2
+
+	// canaryErrorRateQuery is the request error rate of the canary
+	// version in percent, from the istio request metrics.
+	canaryErrorRateQuery = `sum(rate(istio_requests_total{destination_workload_namespace="%s",destination_service_name="%s",destination_version="%s",response_code=~"5.."}[1m]))/sum(rate(istio_requests_total{destination_workload_namespace="%s",destination_service_name="%s",destination_version="%s"}[1m]))*100`
+	// canaryLatencyQuery is the 99th percentile request latency of the
+	// canary version in milliseconds.
+	canaryLatencyQuery = `histogram_quantile(0.99,sum(rate(istio_request_duration_milliseconds_bucket{destination_workload_namespace="%s",destination_service_name="%s",destination_version="%s"}[1m])) by (le))`
+)
+
+// Reconciler evaluates the success criteria of canary strategies against
+// the monitoring backend and promotes or rolls back the canary version
+// based on the observed metrics.
+type Reconciler struct {
+	client.Client
+	Monitoring              monitoring.Interface
+	Logger                  logr.Logger
+	Recorder                record.EventRecorder
+	MaxConcurrentReconciles int
+
+	// now is replaceable for tests
+	now func() time.Time
+}
+
+func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) error {
+	if r.Client == nil {
+		r.Client = mgr.GetClient()
+	}
+	if r.Logger == nil {
+		r.Logger = ctrl.Log.WithName("controllers").WithName(controllerName)
+	}
+	if r.Recorder == nil {
+		r.Recorder = mgr.GetEventRecorderFor(controllerName)
+	}
+	if r.MaxConcurrentReconciles <= 0 {
+		r.MaxConcurrentReconciles = 1
+	}
+	if r.now == nil {
+		r.now = time.Now
+	}
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(controllerName).
+		WithOptions(controller.Options{
+			MaxConcurrentReconciles: r.MaxConcurrentReconciles,
+		}).
+		For(&servicemeshv1alpha2.Strategy{}).
+		Complete(r)
+}
+
+// +kubebuilder:rbac:groups=servicemesh.kubesphere.io,resources=strategies,verbs=get;list;watch;update;patch
+func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := r.Logger.WithValues("strategy", req.NamespacedName)
+
+	strategy := &servicemeshv1alpha2.Strategy{}
+	if err := r.Get(ctx, req.NamespacedName, strategy); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	if strategy.Spec.Type != servicemeshv1alpha2.CanaryType || strategy.Spec.SuccessCriteria == nil {
+		return ctrl.Result{}, nil
+	}
+	if strategy.Spec.StrategyPolicy == servicemeshv1alpha2.PolicyPause {
+		return ctrl.Result{}, nil
+	}
+	// A governor version means all traffic has been shifted already,
+	// either by a finished analysis or manually.
+	if len(strategy.Spec.GovernorVersion) > 0 || strategy.Status.CompletionTime != nil {
+		return ctrl.Result{}, nil
+	}
+
+	canaryVersion := canaryVersion(strategy)
+	if canaryVersion == "" {
+		logger.V(4).Info("strategy defines no canary subset, skipping analysis")
+		return ctrl.Result{}, nil
+	}
+
+	record := r.evaluate(strategy, canaryVersion)
+	strategy.Status.AnalysisHistory = append(strategy.Status.AnalysisHistory, record)
+	if len(strategy.Status.AnalysisHistory) > analysisHistoryLimit {
+		strategy.Status.AnalysisHistory = strategy.Status.AnalysisHistory[len(strategy.Status.AnalysisHistory)-analysisHistoryLimit:]
+	}
+
+	criteria := strategy.Spec.SuccessCriteria
+	iterations := valueOrDefault(criteria.Iterations, defaultIterations)
+	failureLimit := valueOrDefault(criteria.FailureLimit, defaultFailureLimit)
+	passes, failures := trailingResults(strategy.Status.AnalysisHistory)
+
+	now := metav1.NewTime(r.now())
+	switch {
+	case passes >= iterations:
+		// The canary met the criteria long enough, promote it.
+		strategy.Spec.GovernorVersion = canaryVersion
+		strategy.Status.CompletionTime = &now
+		if err := r.Update(ctx, strategy); err != nil {
+			return ctrl.Result{}, err
+		}
+		r.Recorder.Eventf(strategy, corev1.EventTypeNormal, "Promoted",
+			"canary version %s met the success criteria for %d iterations, promoted", canaryVersion, passes)
+		return ctrl.Result{}, nil
+	case failures >= failureLimit:
+		// The canary failed too often, shift all traffic back to the
+		// principal version.
+		strategy.Spec.GovernorVersion = strategy.Spec.PrincipalVersion
+		strategy.Status.CompletionTime = &now
+		if err := r.Update(ctx, strategy); err != nil {
+			return ctrl.Result{}, err
+		}
+		r.Recorder.Eventf(strategy, corev1.EventTypeWarning, "RolledBack",
+			"canary version %s failed the success criteria %d times, rolled back: %s", canaryVersion, failures, record.Message)
+		return ctrl.Result{}, nil
+	default:
+		if err := r.Update(ctx, strategy); err != nil {
+			return ctrl.Result{}, err
+		}
+		interval := time.Duration(valueOrDefault(criteria.IntervalSeconds, defaultIntervalSeconds)) * time.Second
+		return ctrl.Result{RequeueAfter: interval}, nil
+	}
+}
+
+// evaluate queries the monitoring backend for the canary metrics and
+// checks them against the success criteria. Missing metrics, e.g. when
+// the canary receives no traffic yet, count as a failed evaluation.
+func (r *Reconciler) evaluate(strategy *servicemeshv1alpha2.Strategy, canaryVersion string) servicemeshv1alpha2.AnalysisRecord {
+	record := servicemeshv1alpha2.AnalysisRecord{
+		ProbeTime: metav1.NewTime(r.now()),
+		Passed:    true,
+	}
+
+	if len(strategy.Spec.Template.Spec.Hosts) == 0 {
+		record.Passed = false
+		record.Message = "strategy defines no hosts"
+		return record
+	}
+	host := strategy.Spec.Template.Spec.Hosts[0]
+	criteria := strategy.Spec.SuccessCriteria
+
+	if criteria.MaxErrorRatePercent != nil {
+		expr := fmt.Sprintf(canaryErrorRateQuery,
+			strategy.Namespace, host, canaryVersion, strategy.Namespace, host, canaryVersion)
+		errorRate, err := r.scalar(expr)
+		if err != nil {
+			record.Passed = false
+			record.Message = fmt.Sprintf("error rate unavailable: %v", err)
+			return record
+		}
+		record.ErrorRatePercent = fmt.Sprintf("%.2f", errorRate)
+		if errorRate > float64(*criteria.MaxErrorRatePercent) {
+			record.Passed = false
+			record.Message = fmt.Sprintf("error rate %.2f%% above threshold %d%%", errorRate, *criteria.MaxErrorRatePercent)
+			return record
+		}
+	}
+
+	if criteria.MaxLatencyMilliseconds != nil {
+		expr := fmt.Sprintf(canaryLatencyQuery, strategy.Namespace, host, canaryVersion)
+		latency, err := r.scalar(expr)
+		if err != nil {
+			record.Passed = false
+			record.Message = fmt.Sprintf("latency unavailable: %v", err)
+			return record
+		}
+		record.LatencyMilliseconds = fmt.Sprintf("%.0f", latency)
+		if latency > float64(*criteria.MaxLatencyMilliseconds) {
+			record.Passed = false
+			record.Message = fmt.Sprintf("p99 latency %.0fms above threshold %dms", latency, *criteria.MaxLatencyMilliseconds)
+			return record
+		}
+	}
+
+	return record
+}
+
+// scalar runs the expression and returns its single sample value.
+func (r *Reconciler) scalar(expr string) (float64, error) {
+	metric := r.Monitoring.GetMetric(expr, r.now())
+	if metric.Error != "" {
+		return 0, errors.New(metric.Error)
+	}
+	if len(metric.MetricValues) == 0 || metric.MetricValues[0].Sample == nil {
+		return 0, errors.New("no samples")
+	}
+	value := metric.MetricValues[0].Sample.Value()
+	if math.IsNaN(value) || math.IsInf(value, 0) {
+		return 0, errors.New("no samples")
+	}
+	return value, nil
+}
+
+// canaryVersion returns the subset of the strategy routes that is not the
+// principal version, i.e. the version under analysis.
+func canaryVersion(strategy *servicemeshv1alpha2.Strategy) string {
+	for _, httpRoute := range strategy.Spec.Template.Spec.Http {
+		for _, route := range httpRoute.Route {
+			if route.Destination != nil && route.Destination.Subset != strategy.Spec.PrincipalVersion {
+				return route.Destination.Subset
+			}
+		}
+	}
+	return ""
+}
+
+// trailingResults counts the consecutive passed and failed evaluations at
+// the end of the history, one of the two is always zero.
+func trailingResults(history []servicemeshv1alpha2.AnalysisRecord) (passes, failures int32) {
+	for i := len(history) - 1; i >= 0; i-- {
+		if history[i].Passed {
+			if failures > 0 {
+				break
+			}
+			passes++
+		} else {
+			if passes > 0 {
+				break
+			}
+			failures++
+		}
+	}
+	return passes, failures
+}
+
+func valueOrDefault(value *int32, fallback int32) int32 {
+	if value != nil {
+		return *value
+	}
+	return fallback
+}
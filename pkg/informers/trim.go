@@ -0,0 +1,232 @@
+/*
+Copyright 2022 The KubeSphere Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package informers
+
+import (
+	"context"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/informers/internalinterfaces"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog"
+)
+
+// lastAppliedConfigAnnotation holds the whole previous manifest of an
+// object applied with kubectl, often the largest single field.
+const lastAppliedConfigAnnotation = "kubectl.kubernetes.io/last-applied-configuration"
+
+// TrimCachedObjects strips managedFields and the last applied
+// configuration annotation from the cached objects of the given
+// resources, both duplicate most of the object and together dominate the
+// resident memory of the informer caches on large clusters. The client-go
+// of this tree predates informer transforms, so the trim is applied by
+// the lister watcher before objects enter the store; objects already in
+// the caches are never mutated, readers cannot race with the trim. It
+// must run before the informers of these resources are first requested,
+// later requests reuse the trimming informers registered here.
+func TrimCachedObjects(factory InformerFactory, gvrs ...schema.GroupVersionResource) {
+	for _, gvr := range gvrs {
+		resource, ok := trimmableResources[gvr]
+		if !ok {
+			klog.Warningf("trim cached objects of %s: no trimming informer registered", gvr)
+			continue
+		}
+		factory.KubernetesSharedInformerFactory().InformerFor(resource.example, resource.newInformer)
+	}
+}
+
+// trimmableResource registers a trimming informer constructor under the
+// example object the generated informers of the same resource use, so the
+// shared factory hands the trimming informer to every consumer.
+type trimmableResource struct {
+	example     runtime.Object
+	newInformer internalinterfaces.NewInformerFunc
+}
+
+// trimmableResources mirrors the list watchers of the generated informers
+// for the heavy kinds, wrapped so the trim runs before the store insert.
+var trimmableResources = map[schema.GroupVersionResource]trimmableResource{
+	{Group: "", Version: "v1", Resource: "pods"}: {
+		example: &corev1.Pod{},
+		newInformer: func(client kubernetes.Interface, resyncPeriod time.Duration) cache.SharedIndexInformer {
+			return newTrimmedInformer(&corev1.Pod{}, resyncPeriod, &cache.ListWatch{
+				ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
+					return client.CoreV1().Pods(metav1.NamespaceAll).List(context.TODO(), options)
+				},
+				WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+					return client.CoreV1().Pods(metav1.NamespaceAll).Watch(context.TODO(), options)
+				},
+			})
+		},
+	},
+	{Group: "", Version: "v1", Resource: "configmaps"}: {
+		example: &corev1.ConfigMap{},
+		newInformer: func(client kubernetes.Interface, resyncPeriod time.Duration) cache.SharedIndexInformer {
+			return newTrimmedInformer(&corev1.ConfigMap{}, resyncPeriod, &cache.ListWatch{
+				ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
+					return client.CoreV1().ConfigMaps(metav1.NamespaceAll).List(context.TODO(), options)
+				},
+				WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+					return client.CoreV1().ConfigMaps(metav1.NamespaceAll).Watch(context.TODO(), options)
+				},
+			})
+		},
+	},
+	{Group: "", Version: "v1", Resource: "secrets"}: {
+		example: &corev1.Secret{},
+		newInformer: func(client kubernetes.Interface, resyncPeriod time.Duration) cache.SharedIndexInformer {
+			return newTrimmedInformer(&corev1.Secret{}, resyncPeriod, &cache.ListWatch{
+				ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
+					return client.CoreV1().Secrets(metav1.NamespaceAll).List(context.TODO(), options)
+				},
+				WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+					return client.CoreV1().Secrets(metav1.NamespaceAll).Watch(context.TODO(), options)
+				},
+			})
+		},
+	},
+	{Group: "apps", Version: "v1", Resource: "deployments"}: {
+		example: &appsv1.Deployment{},
+		newInformer: func(client kubernetes.Interface, resyncPeriod time.Duration) cache.SharedIndexInformer {
+			return newTrimmedInformer(&appsv1.Deployment{}, resyncPeriod, &cache.ListWatch{
+				ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
+					return client.AppsV1().Deployments(metav1.NamespaceAll).List(context.TODO(), options)
+				},
+				WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+					return client.AppsV1().Deployments(metav1.NamespaceAll).Watch(context.TODO(), options)
+				},
+			})
+		},
+	},
+	{Group: "apps", Version: "v1", Resource: "daemonsets"}: {
+		example: &appsv1.DaemonSet{},
+		newInformer: func(client kubernetes.Interface, resyncPeriod time.Duration) cache.SharedIndexInformer {
+			return newTrimmedInformer(&appsv1.DaemonSet{}, resyncPeriod, &cache.ListWatch{
+				ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
+					return client.AppsV1().DaemonSets(metav1.NamespaceAll).List(context.TODO(), options)
+				},
+				WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+					return client.AppsV1().DaemonSets(metav1.NamespaceAll).Watch(context.TODO(), options)
+				},
+			})
+		},
+	},
+	{Group: "apps", Version: "v1", Resource: "statefulsets"}: {
+		example: &appsv1.StatefulSet{},
+		newInformer: func(client kubernetes.Interface, resyncPeriod time.Duration) cache.SharedIndexInformer {
+			return newTrimmedInformer(&appsv1.StatefulSet{}, resyncPeriod, &cache.ListWatch{
+				ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
+					return client.AppsV1().StatefulSets(metav1.NamespaceAll).List(context.TODO(), options)
+				},
+				WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+					return client.AppsV1().StatefulSets(metav1.NamespaceAll).Watch(context.TODO(), options)
+				},
+			})
+		},
+	},
+	{Group: "apps", Version: "v1", Resource: "replicasets"}: {
+		example: &appsv1.ReplicaSet{},
+		newInformer: func(client kubernetes.Interface, resyncPeriod time.Duration) cache.SharedIndexInformer {
+			return newTrimmedInformer(&appsv1.ReplicaSet{}, resyncPeriod, &cache.ListWatch{
+				ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
+					return client.AppsV1().ReplicaSets(metav1.NamespaceAll).List(context.TODO(), options)
+				},
+				WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+					return client.AppsV1().ReplicaSets(metav1.NamespaceAll).Watch(context.TODO(), options)
+				},
+			})
+		},
+	},
+	{Group: "batch", Version: "v1", Resource: "jobs"}: {
+		example: &batchv1.Job{},
+		newInformer: func(client kubernetes.Interface, resyncPeriod time.Duration) cache.SharedIndexInformer {
+			return newTrimmedInformer(&batchv1.Job{}, resyncPeriod, &cache.ListWatch{
+				ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
+					return client.BatchV1().Jobs(metav1.NamespaceAll).List(context.TODO(), options)
+				},
+				WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+					return client.BatchV1().Jobs(metav1.NamespaceAll).Watch(context.TODO(), options)
+				},
+			})
+		},
+	},
+}
+
+// newTrimmedInformer builds a shared informer equivalent to the generated
+// one, including the namespace index the listers rely on, backed by the
+// trimming lister watcher.
+func newTrimmedInformer(example runtime.Object, resyncPeriod time.Duration, lw cache.ListerWatcher) cache.SharedIndexInformer {
+	return cache.NewSharedIndexInformer(
+		trimmingListerWatcher{delegate: lw},
+		example,
+		resyncPeriod,
+		cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc},
+	)
+}
+
+// trimmingListerWatcher trims objects on their way into the informer, the
+// store only ever holds trimmed objects.
+type trimmingListerWatcher struct {
+	delegate cache.ListerWatcher
+}
+
+func (t trimmingListerWatcher) List(options metav1.ListOptions) (runtime.Object, error) {
+	list, err := t.delegate.List(options)
+	if err != nil {
+		return nil, err
+	}
+	if items, err := meta.ExtractList(list); err == nil {
+		for _, item := range items {
+			trim(item)
+		}
+	}
+	return list, nil
+}
+
+func (t trimmingListerWatcher) Watch(options metav1.ListOptions) (watch.Interface, error) {
+	w, err := t.delegate.Watch(options)
+	if err != nil {
+		return nil, err
+	}
+	return watch.Filter(w, func(event watch.Event) (watch.Event, bool) {
+		trim(event.Object)
+		return event, true
+	}), nil
+}
+
+func trim(obj interface{}) {
+	accessor, err := meta.Accessor(obj)
+	if err != nil {
+		return
+	}
+	accessor.SetManagedFields(nil)
+	if annotations := accessor.GetAnnotations(); annotations != nil {
+		if _, ok := annotations[lastAppliedConfigAnnotation]; ok {
+			delete(annotations, lastAppliedConfigAnnotation)
+			accessor.SetAnnotations(annotations)
+		}
+	}
+}
@@ -0,0 +1,94 @@
+/*
+Copyright 2022 The KubeSphere Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package informers
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	fakek8s "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/cache"
+)
+
+func TestTrim(t *testing.T) {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "nginx",
+			Namespace: "default",
+			Annotations: map[string]string{
+				lastAppliedConfigAnnotation: `{"apiVersion":"v1","kind":"Pod"}`,
+				"app":                       "nginx",
+			},
+			ManagedFields: []metav1.ManagedFieldsEntry{{Manager: "kubectl"}},
+		},
+	}
+
+	trim(pod)
+
+	if pod.ManagedFields != nil {
+		t.Error("expected managedFields to be stripped")
+	}
+	if _, ok := pod.Annotations[lastAppliedConfigAnnotation]; ok {
+		t.Error("expected the last applied configuration annotation to be stripped")
+	}
+	if pod.Annotations["app"] != "nginx" {
+		t.Error("expected other annotations to survive the trim")
+	}
+
+	// objects without annotations pass through
+	trim(&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "plain"}})
+}
+
+func TestTrimCachedObjects(t *testing.T) {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:          "nginx",
+			Namespace:     "default",
+			Annotations:   map[string]string{lastAppliedConfigAnnotation: "{}"},
+			ManagedFields: []metav1.ManagedFieldsEntry{{Manager: "kubectl"}},
+		},
+	}
+	client := fakek8s.NewSimpleClientset(pod)
+	factory := NewInformerFactories(client, nil, nil, nil, nil, nil)
+
+	TrimCachedObjects(factory, schema.GroupVersionResource{Group: "", Version: "v1", Resource: "pods"})
+
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	informer := factory.KubernetesSharedInformerFactory().Core().V1().Pods().Informer()
+	factory.Start(stopCh)
+	cache.WaitForCacheSync(stopCh, informer.HasSynced)
+
+	// the trim runs in the lister watcher, the store never holds an
+	// untrimmed object
+	object, exists, err := informer.GetStore().GetByKey("default/nginx")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !exists {
+		t.Fatal("expected the pod to be cached")
+	}
+	cached := object.(*corev1.Pod)
+	if cached.ManagedFields != nil {
+		t.Error("expected managedFields to be stripped from the cached pod")
+	}
+	if _, ok := cached.Annotations[lastAppliedConfigAnnotation]; ok {
+		t.Error("expected the last applied configuration annotation to be stripped from the cached pod")
+	}
+}
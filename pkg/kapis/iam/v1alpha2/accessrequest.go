@@ -0,0 +1,193 @@
+/*
+Copyright 2022 The KubeSphere Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha2
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/emicklei/go-restful"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	iamv1alpha2 "kubesphere.io/api/iam/v1alpha2"
+
+	"kubesphere.io/kubesphere/pkg/api"
+	"kubesphere.io/kubesphere/pkg/apiserver/authorization/authorizer"
+	apirequest "kubesphere.io/kubesphere/pkg/apiserver/request"
+)
+
+// AccessRequestDecision carries the optional comment an approver leaves
+// when approving or denying an access request.
+type AccessRequestDecision struct {
+	Comment string `json:"comment,omitempty"`
+}
+
+func (h *iamHandler) CreateAccessRequest(request *restful.Request, response *restful.Response) {
+	var accessRequest iamv1alpha2.AccessRequest
+	if err := request.ReadEntity(&accessRequest); err != nil {
+		api.HandleBadRequest(response, request, err)
+		return
+	}
+
+	if accessRequest.Spec.Role == "" {
+		api.HandleBadRequest(response, request, fmt.Errorf("role must not be empty"))
+		return
+	}
+	if (accessRequest.Spec.Workspace == "") == (accessRequest.Spec.Namespace == "") {
+		api.HandleBadRequest(response, request, fmt.Errorf("exactly one of workspace and namespace must be set"))
+		return
+	}
+
+	operator, ok := apirequest.UserFrom(request.Request.Context())
+	if !ok {
+		api.HandleInternalError(response, request, fmt.Errorf("cannot obtain user info"))
+		return
+	}
+
+	// Requests are always filed on behalf of the authenticated user.
+	accessRequest.Spec.Username = operator.GetName()
+	accessRequest.Name = ""
+	accessRequest.GenerateName = fmt.Sprintf("%s-", operator.GetName())
+	accessRequest.Status = iamv1alpha2.AccessRequestStatus{}
+
+	if err := h.client.Create(request.Request.Context(), &accessRequest); err != nil {
+		api.HandleError(response, request, err)
+		return
+	}
+
+	response.WriteEntity(accessRequest)
+}
+
+func (h *iamHandler) ListAccessRequests(request *restful.Request, response *restful.Response) {
+	accessRequests := &iamv1alpha2.AccessRequestList{}
+	if err := h.client.List(request.Request.Context(), accessRequests); err != nil {
+		api.HandleError(response, request, err)
+		return
+	}
+
+	username := request.QueryParameter("username")
+	state := request.QueryParameter("state")
+	result := api.ListResult{Items: make([]interface{}, 0)}
+	for i := range accessRequests.Items {
+		item := &accessRequests.Items[i]
+		if username != "" && item.Spec.Username != username {
+			continue
+		}
+		if state != "" && string(item.Status.State) != state {
+			continue
+		}
+		result.Items = append(result.Items, item)
+	}
+	result.TotalItems = len(result.Items)
+
+	response.WriteEntity(result)
+}
+
+func (h *iamHandler) DescribeAccessRequest(request *restful.Request, response *restful.Response) {
+	accessRequest := &iamv1alpha2.AccessRequest{}
+	if err := h.client.Get(request.Request.Context(), types.NamespacedName{Name: request.PathParameter("accessrequest")}, accessRequest); err != nil {
+		api.HandleError(response, request, err)
+		return
+	}
+
+	response.WriteEntity(accessRequest)
+}
+
+func (h *iamHandler) ApproveAccessRequest(request *restful.Request, response *restful.Response) {
+	h.decideAccessRequest(request, response, iamv1alpha2.AccessRequestApproved)
+}
+
+func (h *iamHandler) DenyAccessRequest(request *restful.Request, response *restful.Response) {
+	h.decideAccessRequest(request, response, iamv1alpha2.AccessRequestDenied)
+}
+
+func (h *iamHandler) decideAccessRequest(request *restful.Request, response *restful.Response, state iamv1alpha2.AccessRequestState) {
+	ctx := request.Request.Context()
+	name := request.PathParameter("accessrequest")
+
+	accessRequest := &iamv1alpha2.AccessRequest{}
+	if err := h.client.Get(ctx, types.NamespacedName{Name: name}, accessRequest); err != nil {
+		api.HandleError(response, request, err)
+		return
+	}
+
+	if accessRequest.Status.State != iamv1alpha2.AccessRequestPending {
+		api.HandleBadRequest(response, request, fmt.Errorf("access request %s has already been %s", name, strings.ToLower(string(accessRequest.Status.State))))
+		return
+	}
+
+	operator, ok := apirequest.UserFrom(ctx)
+	if !ok {
+		api.HandleInternalError(response, request, fmt.Errorf("cannot obtain user info"))
+		return
+	}
+
+	// Whoever may create role bindings of the requested scope may decide
+	// the request, approval grants nothing an approver could not grant by
+	// hand.
+	decideRecord := authorizer.AttributesRecord{
+		User:            operator,
+		Verb:            "create",
+		ResourceRequest: true,
+	}
+	if accessRequest.Spec.Workspace != "" {
+		decideRecord.Workspace = accessRequest.Spec.Workspace
+		decideRecord.Resource = iamv1alpha2.ResourcesPluralWorkspaceRoleBinding
+		decideRecord.ResourceScope = apirequest.WorkspaceScope
+	} else {
+		decideRecord.Namespace = accessRequest.Spec.Namespace
+		decideRecord.Resource = iamv1alpha2.ResourcesPluralRoleBinding
+		decideRecord.ResourceScope = apirequest.NamespaceScope
+	}
+
+	decision, _, err := h.authorizer.Authorize(decideRecord)
+	if err != nil {
+		api.HandleInternalError(response, request, err)
+		return
+	}
+	if decision != authorizer.DecisionAllow {
+		api.HandleForbidden(response, request, fmt.Errorf("user %s is not allowed to decide access request %s", operator.GetName(), name))
+		return
+	}
+
+	var body AccessRequestDecision
+	if err = request.ReadEntity(&body); err != nil && err.Error() != "EOF" {
+		api.HandleBadRequest(response, request, err)
+		return
+	}
+
+	now := metav1.Now()
+	accessRequest.Status.State = state
+	accessRequest.Status.Approver = operator.GetName()
+	accessRequest.Status.Comment = body.Comment
+	accessRequest.Status.ApprovalTime = &now
+	if err = h.client.Update(ctx, accessRequest); err != nil {
+		api.HandleError(response, request, err)
+		return
+	}
+
+	if e := apirequest.AuditEventFrom(ctx); e != nil {
+		if state == iamv1alpha2.AccessRequestApproved {
+			e.Verb = "approve"
+		} else {
+			e.Verb = "deny"
+		}
+	}
+
+	response.WriteEntity(accessRequest)
+}
@@ -29,6 +29,7 @@ import (
 	rbacv1 "k8s.io/api/rbac/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/klog"
+	runtimeclient "sigs.k8s.io/controller-runtime/pkg/client"
 
 	iamv1alpha2 "kubesphere.io/api/iam/v1alpha2"
 
@@ -36,6 +37,7 @@ import (
 	"kubesphere.io/kubesphere/pkg/apiserver/authorization/authorizer"
 	"kubesphere.io/kubesphere/pkg/apiserver/query"
 	apirequest "kubesphere.io/kubesphere/pkg/apiserver/request"
+	"kubesphere.io/kubesphere/pkg/models/iam/accessreview"
 	"kubesphere.io/kubesphere/pkg/models/iam/am"
 	"kubesphere.io/kubesphere/pkg/models/iam/group"
 	"kubesphere.io/kubesphere/pkg/models/iam/im"
@@ -58,18 +60,22 @@ type PasswordReset struct {
 }
 
 type iamHandler struct {
-	am         am.AccessManagementInterface
-	im         im.IdentityManagementInterface
-	group      group.GroupOperator
-	authorizer authorizer.Authorizer
+	am             am.AccessManagementInterface
+	im             im.IdentityManagementInterface
+	group          group.GroupOperator
+	authorizer     authorizer.Authorizer
+	accessReviewer accessreview.Reviewer
+	client         runtimeclient.Client
 }
 
-func newIAMHandler(im im.IdentityManagementInterface, am am.AccessManagementInterface, group group.GroupOperator, authorizer authorizer.Authorizer) *iamHandler {
+func newIAMHandler(im im.IdentityManagementInterface, am am.AccessManagementInterface, group group.GroupOperator, authorizer authorizer.Authorizer, accessReviewer accessreview.Reviewer, client runtimeclient.Client) *iamHandler {
 	return &iamHandler{
-		am:         am,
-		im:         im,
-		group:      group,
-		authorizer: authorizer,
+		am:             am,
+		im:             im,
+		group:          group,
+		authorizer:     authorizer,
+		accessReviewer: accessReviewer,
+		client:         client,
 	}
 }
 
@@ -448,7 +454,7 @@ func (h *iamHandler) UpdateWorkspaceRole(request *restful.Request, response *res
 		return
 	}
 
-	updated, err := h.am.CreateOrUpdateWorkspaceRole(workspace, &workspaceRole)
+	updated, err := h.am.CreateOrUpdateWorkspaceRole(workspace, &workspaceRole, api.DryRun(request)...)
 	if err != nil {
 		api.HandleError(response, request, err)
 		return
@@ -467,7 +473,7 @@ func (h *iamHandler) CreateWorkspaceRole(request *restful.Request, response *res
 		return
 	}
 
-	created, err := h.am.CreateOrUpdateWorkspaceRole(workspace, &workspaceRole)
+	created, err := h.am.CreateOrUpdateWorkspaceRole(workspace, &workspaceRole, api.DryRun(request)...)
 	if err != nil {
 		api.HandleError(response, request, err)
 		return
@@ -654,7 +660,7 @@ func (h *iamHandler) CreateGlobalRole(request *restful.Request, response *restfu
 		return
 	}
 
-	created, err := h.am.CreateOrUpdateGlobalRole(&globalRole)
+	created, err := h.am.CreateOrUpdateGlobalRole(&globalRole, api.DryRun(request)...)
 	if err != nil {
 		api.HandleError(response, request, err)
 		return
@@ -690,7 +696,7 @@ func (h *iamHandler) UpdateGlobalRole(request *restful.Request, response *restfu
 		return
 	}
 
-	updated, err := h.am.CreateOrUpdateGlobalRole(&globalRole)
+	updated, err := h.am.CreateOrUpdateGlobalRole(&globalRole, api.DryRun(request)...)
 	if err != nil {
 		api.HandleError(response, request, err)
 		return
@@ -717,7 +723,7 @@ func (h *iamHandler) CreateClusterRole(request *restful.Request, response *restf
 		return
 	}
 
-	created, err := h.am.CreateOrUpdateClusterRole(&clusterRole)
+	created, err := h.am.CreateOrUpdateClusterRole(&clusterRole, api.DryRun(request)...)
 	if err != nil {
 		api.HandleError(response, request, err)
 		return
@@ -755,7 +761,7 @@ func (h *iamHandler) UpdateClusterRole(request *restful.Request, response *restf
 		return
 	}
 
-	updated, err := h.am.CreateOrUpdateClusterRole(&clusterRole)
+	updated, err := h.am.CreateOrUpdateClusterRole(&clusterRole, api.DryRun(request)...)
 	if err != nil {
 		api.HandleError(response, request, err)
 		return
@@ -800,7 +806,7 @@ func (h *iamHandler) CreateNamespaceRole(request *restful.Request, response *res
 		return
 	}
 
-	created, err := h.am.CreateOrUpdateNamespaceRole(namespace, &role)
+	created, err := h.am.CreateOrUpdateNamespaceRole(namespace, &role, api.DryRun(request)...)
 	if err != nil {
 		api.HandleError(response, request, err)
 		return
@@ -848,7 +854,7 @@ func (h *iamHandler) UpdateNamespaceRole(request *restful.Request, response *res
 		return
 	}
 
-	updated, err := h.am.CreateOrUpdateNamespaceRole(namespace, &role)
+	updated, err := h.am.CreateOrUpdateNamespaceRole(namespace, &role, api.DryRun(request)...)
 	if err != nil {
 		api.HandleError(response, request, err)
 		return
@@ -1488,3 +1494,22 @@ func (h *iamHandler) DeleteGroupBinding(request *restful.Request, response *rest
 
 	response.WriteEntity(servererr.None)
 }
+
+func (h *iamHandler) ReviewResourceAccess(request *restful.Request, response *restful.Response) {
+	namespace := request.PathParameter("namespace")
+	resource := request.QueryParameter("resource")
+	name := request.QueryParameter("name")
+
+	if resource == "" {
+		api.HandleBadRequest(response, request, servererr.New("resource must be specified"))
+		return
+	}
+
+	result, err := h.accessReviewer.Review(namespace, resource, name)
+	if err != nil {
+		api.HandleInternalError(response, request, err)
+		return
+	}
+
+	response.WriteEntity(result)
+}
@@ -26,12 +26,14 @@ import (
 	rbacv1 "k8s.io/api/rbac/v1"
 	v1 "k8s.io/api/rbac/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	runtimeclient "sigs.k8s.io/controller-runtime/pkg/client"
 
 	iamv1alpha2 "kubesphere.io/api/iam/v1alpha2"
 
 	"kubesphere.io/kubesphere/pkg/api"
 	"kubesphere.io/kubesphere/pkg/apiserver/runtime"
 	"kubesphere.io/kubesphere/pkg/constants"
+	"kubesphere.io/kubesphere/pkg/models/iam/accessreview"
 	"kubesphere.io/kubesphere/pkg/models/iam/am"
 	"kubesphere.io/kubesphere/pkg/models/iam/group"
 	"kubesphere.io/kubesphere/pkg/models/iam/im"
@@ -44,9 +46,9 @@ const (
 
 var GroupVersion = schema.GroupVersion{Group: GroupName, Version: "v1alpha2"}
 
-func AddToContainer(container *restful.Container, im im.IdentityManagementInterface, am am.AccessManagementInterface, group group.GroupOperator, authorizer authorizer.Authorizer) error {
+func AddToContainer(container *restful.Container, im im.IdentityManagementInterface, am am.AccessManagementInterface, group group.GroupOperator, authorizer authorizer.Authorizer, accessReviewer accessreview.Reviewer, client runtimeclient.Client) error {
 	ws := runtime.NewWebService(GroupVersion)
-	handler := newIAMHandler(im, am, group, authorizer)
+	handler := newIAMHandler(im, am, group, authorizer, accessReviewer, client)
 
 	// users
 	ws.Route(ws.POST("/users").
@@ -198,6 +200,15 @@ func AddToContainer(container *restful.Container, im im.IdentityManagementInterf
 		Returns(http.StatusOK, api.StatusOK, errors.None).
 		Metadata(restfulspec.KeyOpenAPITags, []string{constants.NamespaceMemberTag}))
 
+	ws.Route(ws.GET("/namespaces/{namespace}/accessreviews").
+		To(handler.ReviewResourceAccess).
+		Doc("Review which subjects currently hold which verbs on the specified resource, along with recent audit events touching it.").
+		Param(ws.PathParameter("namespace", "namespace of the resource")).
+		Param(ws.QueryParameter("resource", "resource plural, e.g. deployments, secrets").Required(true)).
+		Param(ws.QueryParameter("name", "resource name, leave empty to review the whole resource type").Required(false)).
+		Returns(http.StatusOK, api.StatusOK, accessreview.AccessReview{}).
+		Metadata(restfulspec.KeyOpenAPITags, []string{constants.NamespaceMemberTag}))
+
 	ws.Route(ws.GET("/devops/{devops}/members").
 		To(handler.ListNamespaceMembers).
 		Doc("List all members in the specified devops project.").
@@ -238,6 +249,7 @@ func AddToContainer(container *restful.Container, im im.IdentityManagementInterf
 	ws.Route(ws.POST("/globalroles").
 		To(handler.CreateGlobalRole).
 		Doc("Create global role.").
+		Param(ws.QueryParameter("dryRun", "dryRun=All runs validation and admission without persisting the role")).
 		Reads(iamv1alpha2.GlobalRole{}).
 		Returns(http.StatusOK, api.StatusOK, iamv1alpha2.GlobalRole{}).
 		Metadata(restfulspec.KeyOpenAPITags, []string{constants.GlobalRoleTag}))
@@ -250,6 +262,7 @@ func AddToContainer(container *restful.Container, im im.IdentityManagementInterf
 	ws.Route(ws.PUT("/globalroles/{globalrole}").
 		To(handler.UpdateGlobalRole).
 		Doc("Update global role.").
+		Param(ws.QueryParameter("dryRun", "dryRun=All runs validation and admission without persisting the role")).
 		Param(ws.PathParameter("globalrole", "global role name")).
 		Reads(iamv1alpha2.GlobalRole{}).
 		Returns(http.StatusOK, api.StatusOK, iamv1alpha2.GlobalRole{}).
@@ -277,6 +290,7 @@ func AddToContainer(container *restful.Container, im im.IdentityManagementInterf
 	ws.Route(ws.POST("/clusterroles").
 		To(handler.CreateClusterRole).
 		Doc("Create cluster role.").
+		Param(ws.QueryParameter("dryRun", "dryRun=All runs validation and admission without persisting the role")).
 		Reads(rbacv1.ClusterRole{}).
 		Returns(http.StatusOK, api.StatusOK, rbacv1.ClusterRole{}).
 		Metadata(restfulspec.KeyOpenAPITags, []string{constants.ClusterRoleTag}))
@@ -289,6 +303,7 @@ func AddToContainer(container *restful.Container, im im.IdentityManagementInterf
 	ws.Route(ws.PUT("/clusterroles/{clusterrole}").
 		To(handler.UpdateClusterRole).
 		Doc("Update cluster role.").
+		Param(ws.QueryParameter("dryRun", "dryRun=All runs validation and admission without persisting the role")).
 		Param(ws.PathParameter("clusterrole", "cluster role name")).
 		Reads(rbacv1.ClusterRole{}).
 		Returns(http.StatusOK, api.StatusOK, rbacv1.ClusterRole{}).
@@ -316,6 +331,7 @@ func AddToContainer(container *restful.Container, im im.IdentityManagementInterf
 	ws.Route(ws.POST("/workspaces/{workspace}/workspaceroles").
 		To(handler.CreateWorkspaceRole).
 		Doc("Create workspace role.").
+		Param(ws.QueryParameter("dryRun", "dryRun=All runs validation and admission without persisting the role")).
 		Reads(iamv1alpha2.WorkspaceRole{}).
 		Returns(http.StatusOK, api.StatusOK, iamv1alpha2.WorkspaceRole{}).
 		Param(ws.PathParameter("workspace", "workspace name")).
@@ -338,6 +354,7 @@ func AddToContainer(container *restful.Container, im im.IdentityManagementInterf
 	ws.Route(ws.PUT("/workspaces/{workspace}/workspaceroles/{workspacerole}").
 		To(handler.UpdateWorkspaceRole).
 		Doc("Update workspace role.").
+		Param(ws.QueryParameter("dryRun", "dryRun=All runs validation and admission without persisting the role")).
 		Param(ws.PathParameter("workspace", "workspace name")).
 		Param(ws.PathParameter("workspacerole", "workspace role name")).
 		Reads(iamv1alpha2.WorkspaceRole{}).
@@ -349,6 +366,12 @@ func AddToContainer(container *restful.Container, im im.IdentityManagementInterf
 		Param(ws.PathParameter("workspace", "workspace name")).
 		Returns(http.StatusOK, api.StatusOK, api.ListResult{Items: []interface{}{iamv1alpha2.WorkspaceRole{}}}).
 		Metadata(restfulspec.KeyOpenAPITags, []string{constants.WorkspaceRoleTag}))
+	ws.Route(ws.GET("/workspaces/{workspace}/workspaceroleusage").
+		To(handler.ListWorkspaceRoleUsage).
+		Doc("Report per workspace role how many members hold it and when each member last logged in.").
+		Param(ws.PathParameter("workspace", "workspace name")).
+		Returns(http.StatusOK, api.StatusOK, api.ListResult{Items: []interface{}{WorkspaceRoleUsage{}}}).
+		Metadata(restfulspec.KeyOpenAPITags, []string{constants.WorkspaceRoleTag}))
 	ws.Route(ws.GET("/workspaces/{workspace}/workspaceroles/{workspacerole}").
 		To(handler.DescribeWorkspaceRole).
 		Doc("Retrieve workspace role details.").
@@ -361,6 +384,7 @@ func AddToContainer(container *restful.Container, im im.IdentityManagementInterf
 	ws.Route(ws.POST("/namespaces/{namespace}/roles").
 		To(handler.CreateNamespaceRole).
 		Doc("Create role in the specified namespace.").
+		Param(ws.QueryParameter("dryRun", "dryRun=All runs validation and admission without persisting the role")).
 		Reads(rbacv1.Role{}).
 		Param(ws.PathParameter("namespace", "namespace")).
 		Returns(http.StatusOK, api.StatusOK, rbacv1.Role{}).
@@ -375,6 +399,7 @@ func AddToContainer(container *restful.Container, im im.IdentityManagementInterf
 	ws.Route(ws.PUT("/namespaces/{namespace}/roles/{role}").
 		To(handler.UpdateNamespaceRole).
 		Doc("Update namespace role.").
+		Param(ws.QueryParameter("dryRun", "dryRun=All runs validation and admission without persisting the role")).
 		Param(ws.PathParameter("namespace", "namespace")).
 		Param(ws.PathParameter("role", "role name")).
 		Reads(rbacv1.Role{}).
@@ -604,6 +629,49 @@ func AddToContainer(container *restful.Container, im im.IdentityManagementInterf
 		Returns(http.StatusOK, api.StatusOK, errors.None).
 		Metadata(restfulspec.KeyOpenAPITags, []string{constants.GroupTag}))
 
+	// accessrequests
+	ws.Route(ws.POST("/accessrequests").
+		To(handler.CreateAccessRequest).
+		Doc("Request a workspace or namespace role for the authenticated user.").
+		Reads(iamv1alpha2.AccessRequest{}).
+		Returns(http.StatusOK, api.StatusOK, iamv1alpha2.AccessRequest{}).
+		Metadata(restfulspec.KeyOpenAPITags, []string{constants.AccessRequestTag}))
+	ws.Route(ws.GET("/accessrequests").
+		To(handler.ListAccessRequests).
+		Doc("List access requests.").
+		Param(ws.QueryParameter("username", "return only requests filed by this user").Required(false)).
+		Param(ws.QueryParameter("state", "return only requests in this state, e.g. Pending").Required(false)).
+		Returns(http.StatusOK, api.StatusOK, api.ListResult{Items: []interface{}{iamv1alpha2.AccessRequest{}}}).
+		Metadata(restfulspec.KeyOpenAPITags, []string{constants.AccessRequestTag}))
+	ws.Route(ws.GET("/accessrequests/{accessrequest}").
+		To(handler.DescribeAccessRequest).
+		Doc("Retrieve access request details.").
+		Param(ws.PathParameter("accessrequest", "access request name")).
+		Returns(http.StatusOK, api.StatusOK, iamv1alpha2.AccessRequest{}).
+		Metadata(restfulspec.KeyOpenAPITags, []string{constants.AccessRequestTag}))
+	ws.Route(ws.POST("/accessrequests/{accessrequest}/approve").
+		To(handler.ApproveAccessRequest).
+		Doc("Approve the access request, the requested binding is created by the controller.").
+		Param(ws.PathParameter("accessrequest", "access request name")).
+		Reads(AccessRequestDecision{}).
+		Returns(http.StatusOK, api.StatusOK, iamv1alpha2.AccessRequest{}).
+		Metadata(restfulspec.KeyOpenAPITags, []string{constants.AccessRequestTag}))
+	ws.Route(ws.POST("/accessrequests/{accessrequest}/deny").
+		To(handler.DenyAccessRequest).
+		Doc("Deny the access request.").
+		Param(ws.PathParameter("accessrequest", "access request name")).
+		Reads(AccessRequestDecision{}).
+		Returns(http.StatusOK, api.StatusOK, iamv1alpha2.AccessRequest{}).
+		Metadata(restfulspec.KeyOpenAPITags, []string{constants.AccessRequestTag}))
+
+	// rolemigrations
+	ws.Route(ws.POST("/rolemigrations").
+		To(handler.MigrateRoleBindings).
+		Doc("Move every binding of a deprecated global or workspace role over to its replacement, optionally as a dry run.").
+		Reads(RoleMigration{}).
+		Returns(http.StatusOK, api.StatusOK, RoleMigrationReport{}).
+		Metadata(restfulspec.KeyOpenAPITags, []string{constants.GlobalRoleTag}))
+
 	container.Add(ws)
 	return nil
 }
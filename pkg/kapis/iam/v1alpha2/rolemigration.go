@@ -0,0 +1,229 @@
+/*
+Copyright 2022 The KubeSphere Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha2
+
+import (
+	"fmt"
+
+	"github.com/emicklei/go-restful"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	iamv1alpha2 "kubesphere.io/api/iam/v1alpha2"
+
+	"kubesphere.io/kubesphere/pkg/api"
+)
+
+const (
+	globalScope    = "global"
+	workspaceScope = "workspace"
+)
+
+// RoleMigration asks to move every binding of a deprecated role over to
+// its replacement. NewRole may be left empty when the old role carries
+// the iam.kubesphere.io/replaced-by annotation.
+type RoleMigration struct {
+	// Scope is either global or workspace.
+	Scope     string `json:"scope"`
+	Workspace string `json:"workspace,omitempty"`
+	OldRole   string `json:"oldRole"`
+	NewRole   string `json:"newRole,omitempty"`
+	// DryRun reports the affected bindings without changing anything.
+	DryRun bool `json:"dryRun,omitempty"`
+}
+
+// RoleMigrationReport lists what a migration did, or with dry run what it
+// would do. Bindings with group subjects are skipped, groups keep their
+// roles until the group is re-bound by hand.
+type RoleMigrationReport struct {
+	Scope     string `json:"scope"`
+	Workspace string `json:"workspace,omitempty"`
+	OldRole   string `json:"oldRole"`
+	NewRole   string `json:"newRole"`
+	DryRun    bool   `json:"dryRun"`
+	// MigratedUsers hold the old role and were, or would be, re-bound to
+	// the new one.
+	MigratedUsers []string `json:"migratedUsers"`
+	// SkippedBindings reference the old role but carry no user subject.
+	SkippedBindings []string `json:"skippedBindings,omitempty"`
+}
+
+func (h *iamHandler) MigrateRoleBindings(request *restful.Request, response *restful.Response) {
+	var migration RoleMigration
+	if err := request.ReadEntity(&migration); err != nil {
+		api.HandleBadRequest(response, request, err)
+		return
+	}
+
+	if migration.OldRole == "" {
+		api.HandleBadRequest(response, request, fmt.Errorf("oldRole must not be empty"))
+		return
+	}
+	if migration.Scope != globalScope && migration.Scope != workspaceScope {
+		api.HandleBadRequest(response, request, fmt.Errorf("scope must be %s or %s", globalScope, workspaceScope))
+		return
+	}
+	if (migration.Scope == workspaceScope) != (migration.Workspace != "") {
+		api.HandleBadRequest(response, request, fmt.Errorf("workspace must be set exactly for the %s scope", workspaceScope))
+		return
+	}
+
+	oldRole, err := h.getRoleAnnotations(migration)
+	if err != nil {
+		api.HandleError(response, request, err)
+		return
+	}
+
+	// a deprecated role may name its successor itself
+	if migration.NewRole == "" {
+		migration.NewRole = oldRole[iamv1alpha2.RoleReplacementAnnotation]
+	}
+	if migration.NewRole == "" {
+		api.HandleBadRequest(response, request, fmt.Errorf("newRole must be set, role %s carries no %s annotation", migration.OldRole, iamv1alpha2.RoleReplacementAnnotation))
+		return
+	}
+	if migration.NewRole == migration.OldRole {
+		api.HandleBadRequest(response, request, fmt.Errorf("newRole must differ from oldRole"))
+		return
+	}
+
+	report, err := h.migrateRoleBindings(migration)
+	if err != nil {
+		api.HandleError(response, request, err)
+		return
+	}
+
+	response.WriteEntity(report)
+}
+
+func (h *iamHandler) getRoleAnnotations(migration RoleMigration) (map[string]string, error) {
+	if migration.Scope == globalScope {
+		role, err := h.am.GetGlobalRole(migration.OldRole)
+		if err != nil {
+			return nil, err
+		}
+		return role.Annotations, nil
+	}
+	role, err := h.am.GetWorkspaceRole(migration.Workspace, migration.OldRole)
+	if err != nil {
+		return nil, err
+	}
+	return role.Annotations, nil
+}
+
+func (h *iamHandler) migrateRoleBindings(migration RoleMigration) (*RoleMigrationReport, error) {
+	report := &RoleMigrationReport{
+		Scope:         migration.Scope,
+		Workspace:     migration.Workspace,
+		OldRole:       migration.OldRole,
+		NewRole:       migration.NewRole,
+		DryRun:        migration.DryRun,
+		MigratedUsers: make([]string, 0),
+	}
+
+	if migration.Scope == globalScope {
+		// ensure the target role exists before touching any binding
+		if _, err := h.am.GetGlobalRole(migration.NewRole); err != nil {
+			return nil, err
+		}
+		roleBindings, err := h.am.ListGlobalRoleBindings("")
+		if err != nil {
+			return nil, err
+		}
+		for _, roleBinding := range roleBindings {
+			if roleBinding.RoleRef.Name != migration.OldRole {
+				continue
+			}
+			users := userSubjects(roleBinding.Subjects)
+			if len(users) == 0 {
+				report.SkippedBindings = append(report.SkippedBindings, roleBinding.Name)
+				continue
+			}
+			for _, username := range users {
+				if !migration.DryRun {
+					if err := h.am.CreateGlobalRoleBinding(username, migration.NewRole); err != nil {
+						return nil, err
+					}
+				}
+				report.MigratedUsers = append(report.MigratedUsers, username)
+			}
+		}
+	} else {
+		if _, err := h.am.GetWorkspaceRole(migration.Workspace, migration.NewRole); err != nil {
+			return nil, err
+		}
+		roleBindings, err := h.am.ListWorkspaceRoleBindings("", nil, migration.Workspace)
+		if err != nil {
+			return nil, err
+		}
+		for _, roleBinding := range roleBindings {
+			if roleBinding.RoleRef.Name != migration.OldRole {
+				continue
+			}
+			users := userSubjects(roleBinding.Subjects)
+			if len(users) == 0 {
+				report.SkippedBindings = append(report.SkippedBindings, roleBinding.Name)
+				continue
+			}
+			for _, username := range users {
+				if !migration.DryRun {
+					if err := h.am.CreateUserWorkspaceRoleBinding(username, migration.Workspace, migration.NewRole); err != nil {
+						return nil, err
+					}
+				}
+				report.MigratedUsers = append(report.MigratedUsers, username)
+			}
+		}
+	}
+
+	if !migration.DryRun {
+		if err := h.markRoleDeprecated(migration); err != nil {
+			return nil, err
+		}
+	}
+
+	return report, nil
+}
+
+// markRoleDeprecated records on the old role that it has been migrated,
+// so that repeated migrations may omit newRole.
+func (h *iamHandler) markRoleDeprecated(migration RoleMigration) error {
+	annotations := map[string]string{
+		iamv1alpha2.RoleDeprecatedAnnotation:  "true",
+		iamv1alpha2.RoleReplacementAnnotation: migration.NewRole,
+	}
+	if migration.Scope == globalScope {
+		_, err := h.am.PatchGlobalRole(&iamv1alpha2.GlobalRole{
+			ObjectMeta: metav1.ObjectMeta{Name: migration.OldRole, Annotations: annotations},
+		})
+		return err
+	}
+	_, err := h.am.PatchWorkspaceRole(migration.Workspace, &iamv1alpha2.WorkspaceRole{
+		ObjectMeta: metav1.ObjectMeta{Name: migration.OldRole, Annotations: annotations},
+	})
+	return err
+}
+
+func userSubjects(subjects []rbacv1.Subject) []string {
+	users := make([]string, 0, len(subjects))
+	for _, subject := range subjects {
+		if subject.Kind == rbacv1.UserKind {
+			users = append(users, subject.Name)
+		}
+	}
+	return users
+}
@@ -0,0 +1,143 @@
+/*
+Copyright 2022 The KubeSphere Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha2
+
+import (
+	"sort"
+
+	"github.com/emicklei/go-restful"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog"
+
+	iamv1alpha2 "kubesphere.io/api/iam/v1alpha2"
+
+	"kubesphere.io/kubesphere/pkg/api"
+	"kubesphere.io/kubesphere/pkg/apiserver/query"
+	"kubesphere.io/kubesphere/pkg/utils/sliceutil"
+)
+
+// WorkspaceRoleUsage reports how many members hold a workspace role and
+// when each of them was last seen, so that admins can spot unused
+// privileged assignments.
+type WorkspaceRoleUsage struct {
+	Role        string                `json:"role"`
+	MemberCount int                   `json:"memberCount"`
+	Members     []WorkspaceRoleMember `json:"members"`
+}
+
+// WorkspaceRoleMember is a single member of a workspace role joined with
+// the member's most recent successful login record.
+type WorkspaceRoleMember struct {
+	Username      string       `json:"username"`
+	LastLoginTime *metav1.Time `json:"lastLoginTime,omitempty"`
+}
+
+func (h *iamHandler) ListWorkspaceRoleUsage(request *restful.Request, response *restful.Response) {
+	workspace := request.PathParameter("workspace")
+
+	roleBindings, err := h.am.ListWorkspaceRoleBindings("", nil, workspace)
+	if err != nil {
+		api.HandleInternalError(response, request, err)
+		return
+	}
+
+	members := make(map[string][]string)
+	for _, roleBinding := range roleBindings {
+		for _, subject := range roleBinding.Subjects {
+			if subject.Kind != rbacv1.UserKind {
+				continue
+			}
+			if !sliceutil.HasString(members[roleBinding.RoleRef.Name], subject.Name) {
+				members[roleBinding.RoleRef.Name] = append(members[roleBinding.RoleRef.Name], subject.Name)
+			}
+		}
+	}
+
+	// a member may hold several roles, look up the login time only once
+	lastLoginTimes := make(map[string]*metav1.Time)
+
+	result := api.ListResult{Items: make([]interface{}, 0)}
+	for _, role := range sortedKeys(members) {
+		usage := WorkspaceRoleUsage{
+			Role:        role,
+			MemberCount: len(members[role]),
+			Members:     make([]WorkspaceRoleMember, 0, len(members[role])),
+		}
+		for _, username := range members[role] {
+			lastLoginTime, ok := lastLoginTimes[username]
+			if !ok {
+				lastLoginTime = h.lastLoginTime(username)
+				lastLoginTimes[username] = lastLoginTime
+			}
+			usage.Members = append(usage.Members, WorkspaceRoleMember{
+				Username:      username,
+				LastLoginTime: lastLoginTime,
+			})
+		}
+		result.Items = append(result.Items, usage)
+	}
+	result.TotalItems = len(result.Items)
+
+	response.WriteEntity(result)
+}
+
+// lastLoginTime returns the creation time of the member's most recent
+// successful login record, falling back to the time recorded in the user
+// status when the records have already been pruned.
+func (h *iamHandler) lastLoginTime(username string) *metav1.Time {
+	var lastLoginTime *metav1.Time
+
+	records, err := h.im.ListLoginRecords(username, query.New())
+	if err != nil {
+		klog.Errorf("failed to list login records of user %s: %v", username, err)
+	} else {
+		for _, item := range records.Items {
+			record, ok := item.(*iamv1alpha2.LoginRecord)
+			if !ok || !record.Spec.Success {
+				continue
+			}
+			if lastLoginTime == nil || record.CreationTimestamp.After(lastLoginTime.Time) {
+				lastLoginTime = &record.CreationTimestamp
+			}
+		}
+	}
+
+	if lastLoginTime != nil {
+		return lastLoginTime
+	}
+
+	user, err := h.im.DescribeUser(username)
+	if err != nil {
+		if !errors.IsNotFound(err) {
+			klog.Errorf("failed to describe user %s: %v", username, err)
+		}
+		return nil
+	}
+
+	return user.Status.LastLoginTime
+}
+
+func sortedKeys(m map[string][]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
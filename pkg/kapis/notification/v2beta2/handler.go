@@ -22,6 +22,7 @@ import (
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/klog"
+	runtimeclient "sigs.k8s.io/controller-runtime/pkg/client"
 
 	"kubesphere.io/kubesphere/pkg/api"
 	"kubesphere.io/kubesphere/pkg/apiserver/query"
@@ -33,17 +34,20 @@ import (
 )
 
 type handler struct {
-	operator nmoperator.Operator
+	operator  nmoperator.Operator
+	templates nmoperator.TemplateOperator
 }
 
 func newNotificationHandler(
 	informers informers.InformerFactory,
 	k8sClient kubernetes.Interface,
 	ksClient kubesphere.Interface,
+	runtimeClient runtimeclient.Client,
 	options *notification.Options) *handler {
 
 	return &handler{
-		operator: nmoperator.NewOperator(informers, k8sClient, ksClient, options),
+		operator:  nmoperator.NewOperator(informers, k8sClient, ksClient, options),
+		templates: nmoperator.NewTemplateOperator(runtimeClient, options),
 	}
 }
 
@@ -159,6 +163,45 @@ func (h *handler) Verify(req *restful.Request, resp *restful.Response) {
 	h.operator.Verify(req, resp)
 }
 
+func (h *handler) TestSendReceiver(req *restful.Request, resp *restful.Response) {
+
+	user := req.PathParameter("user")
+	name := req.PathParameter("name")
+	channel := req.QueryParameter("type")
+
+	if channel != "" && !h.operator.IsKnownResource("receivers", nmoperator.V2beta2, channel) {
+		api.HandleBadRequest(resp, req, servererr.New("unknown receiver type %s", channel))
+		return
+	}
+
+	result, err := h.operator.TestSend(user, name, channel)
+	handleResponse(req, resp, result, err)
+}
+
+func (h *handler) PreviewTemplate(req *restful.Request, resp *restful.Response) {
+
+	renderRequest := &nmoperator.RenderRequest{}
+	if err := req.ReadEntity(renderRequest); err != nil {
+		api.HandleBadRequest(resp, req, err)
+		return
+	}
+
+	rendered, err := h.templates.Render(req.Request.Context(), renderRequest)
+	handleResponse(req, resp, rendered, err)
+}
+
+func (h *handler) TestSendTemplate(req *restful.Request, resp *restful.Response) {
+
+	renderRequest := &nmoperator.RenderRequest{}
+	if err := req.ReadEntity(renderRequest); err != nil {
+		api.HandleBadRequest(resp, req, err)
+		return
+	}
+
+	err := h.templates.TestSend(req.Request.Context(), renderRequest)
+	handleResponse(req, resp, servererr.None, err)
+}
+
 func handleResponse(req *restful.Request, resp *restful.Response, obj interface{}, err error) {
 
 	if err != nil {
@@ -25,6 +25,7 @@ import (
 	openapi "github.com/emicklei/go-restful-openapi"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/client-go/kubernetes"
+	runtimeclient "sigs.k8s.io/controller-runtime/pkg/client"
 
 	"kubesphere.io/kubesphere/pkg/api"
 	"kubesphere.io/kubesphere/pkg/apiserver/query"
@@ -32,6 +33,7 @@ import (
 	kubesphere "kubesphere.io/kubesphere/pkg/client/clientset/versioned"
 	"kubesphere.io/kubesphere/pkg/constants"
 	"kubesphere.io/kubesphere/pkg/informers"
+	nmoperator "kubesphere.io/kubesphere/pkg/models/notification"
 	"kubesphere.io/kubesphere/pkg/server/errors"
 	"kubesphere.io/kubesphere/pkg/simple/client/notification"
 )
@@ -48,10 +50,11 @@ func AddToContainer(
 	informers informers.InformerFactory,
 	k8sClient kubernetes.Interface,
 	ksClient kubesphere.Interface,
+	runtimeClient runtimeclient.Client,
 	options *notification.Options) error {
 
 	ws := runtime.NewWebService(GroupVersion)
-	h := newNotificationHandler(informers, k8sClient, ksClient, options)
+	h := newNotificationHandler(informers, k8sClient, ksClient, runtimeClient, options)
 
 	ws.Route(ws.POST("/configs/notification/verification").
 		Reads("").
@@ -64,6 +67,31 @@ func AddToContainer(
 		Returns(http.StatusOK, api.StatusOK, http.Response{}.Body)).
 		Doc("Provide validation for notification-manager information")
 
+	ws.Route(ws.POST("/receivers/{name}/testsend").
+		To(h.TestSendReceiver).
+		Param(ws.PathParameter("name", "receiver name")).
+		Param(ws.QueryParameter("type", "receiver type, known values include dingtalk, email, feishu, teams, pagerduty, slack, webhook, wechat, resolved from the receiver when unset").Required(false)).
+		Returns(http.StatusOK, api.StatusOK, nmoperator.TestSendResult{})).
+		Doc("Send a test message through the global receiver and return delivery diagnostics")
+	ws.Route(ws.POST("/users/{user}/receivers/{name}/testsend").
+		To(h.TestSendReceiver).
+		Param(ws.PathParameter("user", "user name")).
+		Param(ws.PathParameter("name", "receiver name")).
+		Param(ws.QueryParameter("type", "receiver type, known values include dingtalk, email, feishu, teams, pagerduty, slack, webhook, wechat, resolved from the receiver when unset").Required(false)).
+		Returns(http.StatusOK, api.StatusOK, nmoperator.TestSendResult{})).
+		Doc("Send a test message through the tenant receiver and return delivery diagnostics")
+
+	ws.Route(ws.POST("/templates/preview").
+		To(h.PreviewTemplate).
+		Reads(nmoperator.RenderRequest{}).
+		Returns(http.StatusOK, api.StatusOK, nmoperator.RenderResult{})).
+		Doc("Render a notification template with the given variables")
+	ws.Route(ws.POST("/templates/testsend").
+		To(h.TestSendTemplate).
+		Reads(nmoperator.RenderRequest{}).
+		Returns(http.StatusOK, api.StatusOK, errors.Error{})).
+		Doc("Render a notification template and send the result through the notification manager")
+
 	// apis for global notification config, receiver, and secret
 	ws.Route(ws.GET("/{resources}").
 		To(h.ListResource).
@@ -72,7 +100,7 @@ func AddToContainer(
 		Param(ws.PathParameter("resources", "known values include notificationmanagers, configs, receivers, secrets, routers, silences, configmaps")).
 		Param(ws.QueryParameter(query.ParameterName, "name used for filtering").Required(false)).
 		Param(ws.QueryParameter(query.ParameterLabelSelector, "label selector used for filtering").Required(false)).
-		Param(ws.QueryParameter("type", "config or receiver type, known values include dingtalk, email, feishu, slack, webhook, wechat").Required(false)).
+		Param(ws.QueryParameter("type", "config or receiver type, known values include dingtalk, email, feishu, teams, pagerduty, slack, webhook, wechat").Required(false)).
 		Param(ws.QueryParameter(query.ParameterPage, "page").Required(false).DataFormat("page=%d").DefaultValue("page=1")).
 		Param(ws.QueryParameter(query.ParameterLimit, "limit").Required(false)).
 		Param(ws.QueryParameter(query.ParameterAscending, "sort parameters, e.g. ascending=false").Required(false).DefaultValue("ascending=false")).
@@ -85,7 +113,7 @@ func AddToContainer(
 		Metadata(KeyOpenAPITags, []string{constants.NotificationTag}).
 		Param(ws.PathParameter("resources", "known values include notificationmanagers, configs, receivers, secrets, routers, silences, configmaps")).
 		Param(ws.PathParameter(query.ParameterName, "the name of the resource")).
-		Param(ws.QueryParameter("type", "config or receiver type, known values include dingtalk, feishu, email, slack, webhook, wechat").Required(false)).
+		Param(ws.QueryParameter("type", "config or receiver type, known values include dingtalk, feishu, teams, pagerduty, email, slack, webhook, wechat").Required(false)).
 		Returns(http.StatusOK, api.StatusOK, nil))
 
 	ws.Route(ws.POST("/{resources}").
@@ -128,7 +156,7 @@ func AddToContainer(
 		Param(ws.PathParameter("resources", "known values include configs, receivers, secrets, silences, configmaps")).
 		Param(ws.QueryParameter(query.ParameterName, "name used for filtering").Required(false)).
 		Param(ws.QueryParameter(query.ParameterLabelSelector, "label selector used for filtering").Required(false)).
-		Param(ws.QueryParameter("type", "config or receiver type, known values include dingtalk, email, feishu, slack, webhook, wechat").Required(false)).
+		Param(ws.QueryParameter("type", "config or receiver type, known values include dingtalk, email, feishu, teams, pagerduty, slack, webhook, wechat").Required(false)).
 		Param(ws.QueryParameter(query.ParameterPage, "page").Required(false).DataFormat("page=%d").DefaultValue("page=1")).
 		Param(ws.QueryParameter(query.ParameterLimit, "limit").Required(false)).
 		Param(ws.QueryParameter(query.ParameterAscending, "sort parameters, e.g. ascending=false").Required(false).DefaultValue("ascending=false")).
@@ -142,7 +170,7 @@ func AddToContainer(
 		Param(ws.PathParameter("user", "user name")).
 		Param(ws.PathParameter("resources", "known values include configs, receivers, secrets, silences, configmaps")).
 		Param(ws.PathParameter(query.ParameterName, "the name of the resource")).
-		Param(ws.QueryParameter("type", "config or receiver type, known values include dingtalk, email, feishu, slack, webhook, wechat").Required(false)).
+		Param(ws.QueryParameter("type", "config or receiver type, known values include dingtalk, email, feishu, teams, pagerduty, slack, webhook, wechat").Required(false)).
 		Returns(http.StatusOK, api.StatusOK, nil))
 
 	ws.Route(ws.POST("/users/{user}/{resources}").
@@ -127,6 +127,7 @@ type handler struct {
 	passwordAuthenticator auth.PasswordAuthenticator
 	oauthAuthenticator    auth.OAuthAuthenticator
 	loginRecorder         auth.LoginRecorder
+	loginChallenger       auth.LoginChallenger
 }
 
 func newHandler(im im.IdentityManagementInterface,
@@ -134,12 +135,14 @@ func newHandler(im im.IdentityManagementInterface,
 	passwordAuthenticator auth.PasswordAuthenticator,
 	oauthAuthenticator auth.OAuthAuthenticator,
 	loginRecorder auth.LoginRecorder,
+	loginChallenger auth.LoginChallenger,
 	options *authentication.Options) *handler {
 	return &handler{im: im,
 		tokenOperator:         tokenOperator,
 		passwordAuthenticator: passwordAuthenticator,
 		oauthAuthenticator:    oauthAuthenticator,
 		loginRecorder:         loginRecorder,
+		loginChallenger:       loginChallenger,
 		options:               options}
 }
 
@@ -205,7 +208,7 @@ func (h *handler) discovery(req *restful.Request, response *restful.Response) {
 
 func (h *handler) keys(req *restful.Request, response *restful.Response) {
 	jwks := jose.JSONWebKeySet{
-		Keys: []jose.JSONWebKey{*h.tokenOperator.Keys().SigningKeyPub},
+		Keys: h.tokenOperator.PublicKeys(),
 	}
 	response.WriteEntity(jwks)
 }
@@ -434,6 +437,21 @@ func (h *handler) token(req *restful.Request, response *restful.Response) {
 // The authorization server should take special care when enabling this
 // grant type and only allow it when other flows are not viable.
 func (h *handler) passwordGrant(username string, password string, req *restful.Request, response *restful.Response) {
+	// after repeated failed attempts the grant additionally requires a
+	// challenge token, e.g. a captcha response, validated by the
+	// configured verifier
+	if h.loginChallenger != nil && h.loginChallenger.Required(username) {
+		challengeToken, _ := req.BodyParameter("challenge_token")
+		if challengeToken == "" {
+			response.WriteHeaderAndEntity(http.StatusTooManyRequests, oauth.NewInvalidRequest(auth.ChallengeRequiredError))
+			return
+		}
+		if err := h.loginChallenger.Verify(req.Request.Context(), challengeToken); err != nil {
+			response.WriteHeaderAndEntity(http.StatusBadRequest, oauth.NewInvalidRequest(err))
+			return
+		}
+	}
+
 	authenticated, provider, err := h.passwordAuthenticator.Authenticate(req.Request.Context(), username, password)
 	if err != nil {
 		switch err {
@@ -508,6 +526,11 @@ func (h *handler) refreshTokenGrant(req *restful.Request, response *restful.Resp
 
 	verified, err := h.tokenOperator.Verify(refreshToken)
 	if err != nil {
+		// refresh tokens are one-time-use, redeeming a rotated out token
+		// revokes the whole session family it belonged to
+		if h.tokenOperator.RevokeOnRefreshTokenReuse(refreshToken) {
+			err = fmt.Errorf("refresh token has already been used")
+		}
 		response.WriteHeaderAndEntity(http.StatusBadRequest, oauth.NewInvalidGrant(err))
 		return
 	}
@@ -550,6 +573,13 @@ func (h *handler) refreshTokenGrant(req *restful.Request, response *restful.Resp
 		return
 	}
 
+	// rotate out the redeemed refresh token, under the name the session
+	// was cached with
+	if err := h.tokenOperator.RotateRefreshToken(verified.User.GetName(), refreshToken); err != nil {
+		response.WriteHeaderAndEntity(http.StatusInternalServerError, oauth.NewServerError(err))
+		return
+	}
+
 	response.WriteEntity(result)
 }
 
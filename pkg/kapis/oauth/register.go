@@ -44,6 +44,7 @@ func AddToContainer(c *restful.Container, im im.IdentityManagementInterface,
 	passwordAuthenticator auth.PasswordAuthenticator,
 	oauth2Authenticator auth.OAuthAuthenticator,
 	loginRecorder auth.LoginRecorder,
+	loginChallenger auth.LoginChallenger,
 	options *authentication.Options) error {
 
 	ws := &restful.WebService{}
@@ -51,7 +52,7 @@ func AddToContainer(c *restful.Container, im im.IdentityManagementInterface,
 		Consumes(restful.MIME_JSON).
 		Produces(restful.MIME_JSON)
 
-	handler := newHandler(im, tokenOperator, passwordAuthenticator, oauth2Authenticator, loginRecorder, options)
+	handler := newHandler(im, tokenOperator, passwordAuthenticator, oauth2Authenticator, loginRecorder, loginChallenger, options)
 
 	ws.Route(ws.GET("/.well-known/openid-configuration").To(handler.discovery).
 		Doc("The OpenID Provider's configuration information can be retrieved."))
@@ -116,6 +117,8 @@ func AddToContainer(c *restful.Container, im im.IdentityManagementInterface,
 		Param(ws.FormParameter("username", "The resource owner username.").Required(false)).
 		Param(ws.FormParameter("password", "The resource owner password.").Required(false)).
 		Param(ws.FormParameter("code", "Valid authorization code.").Required(false)).
+		Param(ws.FormParameter("challenge_token", "Challenge token validated by the configured verifier, "+
+			"required after repeated failed login attempts.").Required(false)).
 		To(handler.token).
 		Returns(http.StatusOK, http.StatusText(http.StatusOK), &oauth.Token{}).
 		Metadata(restfulspec.KeyOpenAPITags, []string{constants.AuthenticationTag}))
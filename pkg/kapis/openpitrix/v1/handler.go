@@ -35,6 +35,7 @@ import (
 	"kubesphere.io/api/application/v1alpha1"
 
 	"kubesphere.io/kubesphere/pkg/api"
+	"kubesphere.io/kubesphere/pkg/apiserver/auditing"
 	"kubesphere.io/kubesphere/pkg/apiserver/request"
 	"kubesphere.io/kubesphere/pkg/client/clientset/versioned"
 	"kubesphere.io/kubesphere/pkg/constants"
@@ -92,6 +93,13 @@ func (h *openpitrixHandler) CreateRepo(req *restful.Request, resp *restful.Respo
 	// trim credential from url
 	parsedUrl.User = nil
 
+	switch createRepoRequest.Visibility {
+	case "", v1alpha1.RepoVisibilityGlobal, v1alpha1.RepoVisibilityWorkspace, v1alpha1.RepoVisibilityPrivate:
+	default:
+		api.HandleBadRequest(resp, nil, fmt.Errorf("invalid visibility %s", createRepoRequest.Visibility))
+		return
+	}
+
 	syncPeriod := 0
 	// If SyncPeriod is empty, ignore it.
 	if createRepoRequest.SyncPeriod != "" {
@@ -119,6 +127,7 @@ func (h *openpitrixHandler) CreateRepo(req *restful.Request, resp *restful.Respo
 			Url:         parsedUrl.String(),
 			SyncPeriod:  syncPeriod,
 			Description: stringutils.ShortenString(createRepoRequest.Description, 512),
+			Visibility:  createRepoRequest.Visibility,
 		},
 	}
 
@@ -268,6 +277,10 @@ func (h *openpitrixHandler) ListRepos(req *restful.Request, resp *restful.Respon
 		conditions.Match[openpitrix.WorkspaceLabel] = req.PathParameter("workspace")
 	}
 
+	if user, _ := request.UserFrom(req.Request.Context()); user != nil {
+		conditions.Match[openpitrix.CurrentUser] = user.GetName()
+	}
+
 	result, err := h.openpitrix.ListRepos(conditions, orderBy, reverse, limit, offset)
 
 	if err != nil {
@@ -366,6 +379,11 @@ func (h *openpitrixHandler) CreateApp(req *restful.Request, resp *restful.Respon
 	}
 
 	validate, _ := strconv.ParseBool(req.QueryParameter("validate"))
+	// ?dryRun=All validates the package without persisting anything,
+	// same as ?validate=true
+	if len(api.DryRun(req)) > 0 {
+		validate = true
+	}
 
 	var result interface{}
 
@@ -487,6 +505,9 @@ func (h *openpitrixHandler) CreateAppVersion(req *restful.Request, resp *restful
 		createAppVersionRequest.Username = user.GetName()
 	}
 	validate, _ := strconv.ParseBool(req.QueryParameter("validate"))
+	if len(api.DryRun(req)) > 0 {
+		validate = true
+	}
 
 	var result interface{}
 
@@ -632,6 +653,24 @@ func (h *openpitrixHandler) GetAppVersionFiles(req *restful.Request, resp *restf
 	resp.WriteEntity(result)
 }
 
+func (h *openpitrixHandler) GetAppVersionExtras(req *restful.Request, resp *restful.Response) {
+	versionId := req.PathParameter("version")
+
+	result, err := h.openpitrix.GetAppVersionExtras(versionId)
+
+	if err != nil {
+		klog.Errorln(err)
+		if apierrors.IsNotFound(err) {
+			api.HandleNotFound(resp, nil, err)
+		} else {
+			api.HandleBadRequest(resp, nil, err)
+		}
+		return
+	}
+
+	resp.WriteEntity(result)
+}
+
 // app version audit
 func (h *openpitrixHandler) ListAppVersionAudits(req *restful.Request, resp *restful.Response) {
 	limit, offset := params.ParsePaging(req)
@@ -709,6 +748,34 @@ func (h *openpitrixHandler) DescribeApplication(req *restful.Request, resp *rest
 	resp.WriteEntity(app)
 }
 
+func (h *openpitrixHandler) DiffApplicationValues(req *restful.Request, resp *restful.Response) {
+	clusterName := req.PathParameter("cluster")
+	workspace := req.PathParameter("workspace")
+	applicationId := req.PathParameter("application")
+	namespace := req.PathParameter("namespace")
+
+	fromVersion, err := strconv.Atoi(req.QueryParameter("from"))
+	if err != nil {
+		api.HandleBadRequest(resp, nil, fmt.Errorf("invalid revision %q", req.QueryParameter("from")))
+		return
+	}
+	toVersion, err := strconv.Atoi(req.QueryParameter("to"))
+	if err != nil {
+		api.HandleBadRequest(resp, nil, fmt.Errorf("invalid revision %q", req.QueryParameter("to")))
+		return
+	}
+
+	diff, err := h.openpitrix.DiffApplicationValues(workspace, clusterName, namespace, applicationId, fromVersion, toVersion)
+
+	if err != nil {
+		klog.Errorln(err)
+		handleOpenpitrixError(resp, err)
+		return
+	}
+
+	resp.WriteEntity(diff)
+}
+
 func (h *openpitrixHandler) DeleteApplication(req *restful.Request, resp *restful.Response) {
 	clusterName := req.PathParameter("cluster")
 	workspace := req.PathParameter("workspace")
@@ -773,6 +840,21 @@ func (h *openpitrixHandler) UpgradeApplication(req *restful.Request, resp *restf
 		upgradeClusterRequest.Username = user.GetName()
 	}
 
+	// record which values keys the upgrade changes on the audit event,
+	// key names only, values of secrets must not end up in the audit log
+	if upgradeClusterRequest.Conf != "" {
+		if e := request.AuditEventFrom(req.Request.Context()); e != nil {
+			if oldValues, err := h.openpitrix.GetApplicationValues(applicationId); err == nil {
+				if keys := openpitrix.ChangedValuesKeys(oldValues, []byte(upgradeClusterRequest.Conf)); len(keys) > 0 {
+					if e.Annotations == nil {
+						e.Annotations = make(map[string]string)
+					}
+					e.Annotations[auditing.ValuesChangedKeysAnnotation] = strings.Join(keys, ",")
+				}
+			}
+		}
+	}
+
 	err = h.openpitrix.UpgradeApplication(upgradeClusterRequest)
 	if err != nil {
 		klog.Errorln(err)
@@ -836,6 +918,48 @@ func (h *openpitrixHandler) CreateApplication(req *restful.Request, resp *restfu
 	resp.WriteEntity(errors.None)
 }
 
+func (h *openpitrixHandler) ListImportableApplications(req *restful.Request, resp *restful.Response) {
+	clusterName := req.PathParameter("cluster")
+	namespace := req.PathParameter("namespace")
+
+	result, err := h.openpitrix.ListImportableApplications(clusterName, namespace)
+
+	if err != nil {
+		klog.Errorln(err)
+		api.HandleInternalError(resp, nil, err)
+		return
+	}
+
+	resp.WriteEntity(result)
+}
+
+func (h *openpitrixHandler) ImportApplication(req *restful.Request, resp *restful.Response) {
+	clusterName := req.PathParameter("cluster")
+	namespace := req.PathParameter("namespace")
+	workspace := req.PathParameter("workspace")
+	var importApplicationRequest openpitrix.ImportApplicationRequest
+	err := req.ReadEntity(&importApplicationRequest)
+	if err != nil {
+		klog.V(4).Infoln(err)
+		api.HandleBadRequest(resp, nil, err)
+		return
+	}
+	user, _ := request.UserFrom(req.Request.Context())
+	if user != nil {
+		importApplicationRequest.Username = user.GetName()
+	}
+
+	err = h.openpitrix.ImportApplication(workspace, clusterName, namespace, importApplicationRequest)
+
+	if err != nil {
+		klog.Errorln(err)
+		api.HandleInternalError(resp, nil, err)
+		return
+	}
+
+	resp.WriteEntity(errors.None)
+}
+
 func (h *openpitrixHandler) CreateCategory(req *restful.Request, resp *restful.Response) {
 	createCategoryRequest := &openpitrix.CreateCategoryRequest{}
 	err := req.ReadEntity(createCategoryRequest)
@@ -187,6 +187,7 @@ func AddToContainer(c *restful.Container, ksInfomrers informers.InformerFactory,
 	webservice.Route(webservice.POST("/workspaces/{workspace}/apps").
 		To(handler.CreateApp).
 		Doc("Create a new app template").
+		Param(webservice.QueryParameter("dryRun", "dryRun=All validates the package without persisting anything")).
 		Metadata(restfulspec.KeyOpenAPITags, []string{constants.OpenpitrixTag}).
 		Returns(http.StatusOK, api.StatusOK, openpitrix.CreateAppResponse{}).
 		Reads(openpitrix.CreateAppRequest{}).
@@ -283,6 +284,7 @@ func AddToContainer(c *restful.Container, ksInfomrers informers.InformerFactory,
 	webservice.Route(webservice.POST("/workspaces/{workspace}/apps/{app}/versions").
 		To(handler.CreateAppVersion).
 		Doc("Create a new app template version").
+		Param(webservice.QueryParameter("dryRun", "dryRun=All validates the package without persisting anything")).
 		Metadata(restfulspec.KeyOpenAPITags, []string{constants.OpenpitrixTag}).
 		Reads(openpitrix.CreateAppVersionRequest{}).
 		Param(webservice.QueryParameter("validate", "Validate format of package(pack by op tool)")).
@@ -387,6 +389,21 @@ func AddToContainer(c *restful.Container, ksInfomrers informers.InformerFactory,
 		Param(webservice.PathParameter("version", "app template version id")).
 		Param(webservice.PathParameter("app", "app template id")))
 
+	webservice.Route(webservice.GET("/apps/{app}/versions/{version}/extras").
+		To(handler.GetAppVersionExtras).
+		Doc("Get readme, icon and default values of the app template version").
+		Returns(http.StatusOK, api.StatusOK, openpitrix.GetAppVersionExtrasResponse{}).
+		Metadata(restfulspec.KeyOpenAPITags, []string{constants.OpenpitrixAppTemplateTag}).
+		Param(webservice.PathParameter("version", "app template version id")).
+		Param(webservice.PathParameter("app", "app template id")))
+	webservice.Route(webservice.GET("/workspaces/{workspace}/apps/{app}/versions/{version}/extras").
+		To(handler.GetAppVersionExtras).
+		Doc("Get readme, icon and default values of the app template version").
+		Returns(http.StatusOK, api.StatusOK, openpitrix.GetAppVersionExtrasResponse{}).
+		Metadata(restfulspec.KeyOpenAPITags, []string{constants.OpenpitrixAppTemplateTag}).
+		Param(webservice.PathParameter("version", "app template version id")).
+		Param(webservice.PathParameter("app", "app template id")))
+
 	// app version audits
 
 	webservice.Route(webservice.GET("/apps/{app}/audits").
@@ -581,6 +598,23 @@ func AddToContainer(c *restful.Container, ksInfomrers informers.InformerFactory,
 		Returns(http.StatusOK, api.StatusOK, errors.Error{}).
 		Param(webservice.PathParameter("namespace", "the name of the project").Required(true)))
 
+	webservice.Route(webservice.GET("/workspaces/{workspace}/clusters/{cluster}/namespaces/{namespace}/importable_applications").
+		To(handler.ListImportableApplications).
+		Doc("List helm releases in the cluster that are not managed by KubeSphere and can be imported").
+		Metadata(restfulspec.KeyOpenAPITags, []string{constants.OpenpitrixTag}).
+		Returns(http.StatusOK, api.StatusOK, models.PageableResponse{}).
+		Param(webservice.PathParameter("cluster", "the name of the cluster.").Required(true)).
+		Param(webservice.PathParameter("namespace", "the name of the project").Required(true)))
+
+	webservice.Route(webservice.POST("/workspaces/{workspace}/clusters/{cluster}/namespaces/{namespace}/importable_applications").
+		To(handler.ImportApplication).
+		Doc("Import an existing helm release as an application").
+		Metadata(restfulspec.KeyOpenAPITags, []string{constants.OpenpitrixTag}).
+		Reads(openpitrix.ImportApplicationRequest{}).
+		Returns(http.StatusOK, api.StatusOK, errors.Error{}).
+		Param(webservice.PathParameter("cluster", "the name of the cluster.").Required(true)).
+		Param(webservice.PathParameter("namespace", "the name of the project").Required(true)))
+
 	webservice.Route(webservice.GET("/workspaces/{workspace}/clusters/{cluster}/namespaces/{namespace}/applications/{application}").
 		To(handler.DescribeApplication).
 		Returns(http.StatusOK, api.StatusOK, openpitrix.Application{}).
@@ -598,6 +632,27 @@ func AddToContainer(c *restful.Container, ksInfomrers informers.InformerFactory,
 		Param(webservice.PathParameter("namespace", "the name of the project").Required(true)).
 		Param(webservice.PathParameter("application", "the id of the application").Required(true)))
 
+	webservice.Route(webservice.GET("/workspaces/{workspace}/clusters/{cluster}/namespaces/{namespace}/applications/{application}/valuesdiff").
+		To(handler.DiffApplicationValues).
+		Returns(http.StatusOK, api.StatusOK, openpitrix.ValuesDiff{}).
+		Metadata(restfulspec.KeyOpenAPITags, []string{constants.NamespaceResourcesTag}).
+		Doc("Diff the values of the application between two deployed revisions").
+		Param(webservice.PathParameter("cluster", "the name of the cluster.").Required(true)).
+		Param(webservice.PathParameter("namespace", "the name of the project").Required(true)).
+		Param(webservice.PathParameter("application", "the id of the application").Required(true)).
+		Param(webservice.QueryParameter("from", "the older revision").Required(true)).
+		Param(webservice.QueryParameter("to", "the newer revision").Required(true)))
+
+	webservice.Route(webservice.GET("/workspaces/{workspace}/namespaces/{namespace}/applications/{application}/valuesdiff").
+		To(handler.DiffApplicationValues).
+		Returns(http.StatusOK, api.StatusOK, openpitrix.ValuesDiff{}).
+		Metadata(restfulspec.KeyOpenAPITags, []string{constants.NamespaceResourcesTag}).
+		Doc("Diff the values of the application between two deployed revisions").
+		Param(webservice.PathParameter("namespace", "the name of the project").Required(true)).
+		Param(webservice.PathParameter("application", "the id of the application").Required(true)).
+		Param(webservice.QueryParameter("from", "the older revision").Required(true)).
+		Param(webservice.QueryParameter("to", "the newer revision").Required(true)))
+
 	webservice.Route(webservice.DELETE("/workspaces/{workspace}/namespaces/{namespace}/applications/{application}").
 		To(handler.DeleteApplication).
 		Doc("Delete the specified application").
@@ -19,22 +19,44 @@ package v1alpha2
 import (
 	"fmt"
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/emicklei/go-restful"
+	corev1 "k8s.io/api/core/v1"
 	k8serr "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/client-go/kubernetes"
 
+	requestctx "kubesphere.io/kubesphere/pkg/apiserver/request"
+	"kubesphere.io/kubesphere/pkg/models/certificates"
+	"kubesphere.io/kubesphere/pkg/models/nodes"
+	"kubesphere.io/kubesphere/pkg/models/revisions"
 	"kubesphere.io/kubesphere/pkg/models/workloads"
 	"kubesphere.io/kubesphere/pkg/server/errors"
 )
 
 type operationHandler struct {
-	jobRunner workloads.JobRunner
+	jobRunner    workloads.JobRunner
+	scaler       workloads.Scaler
+	rollbacker   revisions.Rollbacker
+	drainer      nodes.DrainOperator
+	nodeManager  nodes.NodeManager
+	nodeGroups   nodes.NodeGroupOperator
+	rollout      workloads.ImmutableRollout
+	csrApprovals certificates.ApprovalOperator
 }
 
 func newOperationHandler(client kubernetes.Interface) *operationHandler {
+	drainer := nodes.NewDrainOperator(client)
 	return &operationHandler{
-		jobRunner: workloads.NewJobRunner(client),
+		jobRunner:    workloads.NewJobRunner(client),
+		scaler:       workloads.NewScaler(client),
+		rollbacker:   revisions.NewRollbacker(client),
+		drainer:      drainer,
+		nodeManager:  nodes.NewNodeManager(client),
+		nodeGroups:   nodes.NewNodeGroupOperator(client, drainer),
+		rollout:      workloads.NewImmutableRollout(client),
+		csrApprovals: certificates.NewApprovalOperator(client),
 	}
 }
 
@@ -64,3 +86,352 @@ func (r *operationHandler) handleJobReRun(request *restful.Request, response *re
 
 	response.WriteAsJson(errors.None)
 }
+
+func (r *operationHandler) handleWorkloadScale(request *restful.Request, response *restful.Response) {
+	kind := request.PathParameter("kind")
+	namespace := request.PathParameter("namespace")
+	workload := request.PathParameter("workload")
+
+	replicas, err := strconv.ParseInt(request.QueryParameter("replicas"), 10, 32)
+	if err != nil {
+		response.WriteHeaderAndEntity(http.StatusBadRequest, errors.Wrap(fmt.Errorf("invalid replicas: %v", err)))
+		return
+	}
+	force, _ := strconv.ParseBool(request.QueryParameter("force"))
+
+	scale, err := r.scaler.Scale(kind, namespace, workload, int32(replicas), force)
+	if err != nil {
+		if k8serr.IsConflict(err) {
+			response.WriteHeaderAndEntity(http.StatusConflict, errors.Wrap(err))
+			return
+		}
+		if k8serr.IsNotFound(err) {
+			response.WriteHeaderAndEntity(http.StatusNotFound, errors.Wrap(err))
+			return
+		}
+		response.WriteHeaderAndEntity(http.StatusInternalServerError, errors.Wrap(err))
+		return
+	}
+
+	response.WriteAsJson(scale)
+}
+
+func (r *operationHandler) handleWorkloadRollback(request *restful.Request, response *restful.Response) {
+	kind := request.PathParameter("kind")
+	namespace := request.PathParameter("namespace")
+	workload := request.PathParameter("workload")
+
+	revision, err := strconv.ParseInt(request.QueryParameter("revision"), 10, 64)
+	if err != nil {
+		response.WriteHeaderAndEntity(http.StatusBadRequest, errors.Wrap(fmt.Errorf("invalid revision: %v", err)))
+		return
+	}
+
+	if err := r.rollbacker.Rollback(kind, namespace, workload, revision); err != nil {
+		if k8serr.IsNotFound(err) {
+			response.WriteHeaderAndEntity(http.StatusNotFound, errors.Wrap(err))
+			return
+		}
+		response.WriteHeaderAndEntity(http.StatusInternalServerError, errors.Wrap(err))
+		return
+	}
+
+	response.WriteAsJson(errors.None)
+}
+
+func (r *operationHandler) handleConfigMapRollout(request *restful.Request, response *restful.Response) {
+	namespace := request.PathParameter("namespace")
+	configMap := request.PathParameter("configmap")
+
+	result, err := r.rollout.RolloutConfigMap(namespace, configMap)
+	if err != nil {
+		if k8serr.IsNotFound(err) {
+			response.WriteHeaderAndEntity(http.StatusNotFound, errors.Wrap(err))
+			return
+		}
+		response.WriteHeaderAndEntity(http.StatusInternalServerError, errors.Wrap(err))
+		return
+	}
+
+	response.WriteAsJson(result)
+}
+
+func (r *operationHandler) handleSecretRollout(request *restful.Request, response *restful.Response) {
+	namespace := request.PathParameter("namespace")
+	secret := request.PathParameter("secret")
+
+	result, err := r.rollout.RolloutSecret(namespace, secret)
+	if err != nil {
+		if k8serr.IsNotFound(err) {
+			response.WriteHeaderAndEntity(http.StatusNotFound, errors.Wrap(err))
+			return
+		}
+		response.WriteHeaderAndEntity(http.StatusInternalServerError, errors.Wrap(err))
+		return
+	}
+
+	response.WriteAsJson(result)
+}
+
+func (r *operationHandler) handleNodeCordon(request *restful.Request, response *restful.Response) {
+	node := request.PathParameter("node")
+
+	unschedulable := true
+	if value := request.QueryParameter("unschedulable"); value != "" {
+		var err error
+		if unschedulable, err = strconv.ParseBool(value); err != nil {
+			response.WriteHeaderAndEntity(http.StatusBadRequest, errors.Wrap(fmt.Errorf("invalid unschedulable: %v", err)))
+			return
+		}
+	}
+
+	result, err := r.drainer.Cordon(node, unschedulable)
+	if err != nil {
+		if k8serr.IsNotFound(err) {
+			response.WriteHeaderAndEntity(http.StatusNotFound, errors.Wrap(err))
+			return
+		}
+		response.WriteHeaderAndEntity(http.StatusInternalServerError, errors.Wrap(err))
+		return
+	}
+
+	response.WriteAsJson(result)
+}
+
+func (r *operationHandler) handleNodeDrain(request *restful.Request, response *restful.Response) {
+	node := request.PathParameter("node")
+
+	options := nodes.DrainOptions{GracePeriodSeconds: -1}
+	if value := request.QueryParameter("gracePeriodSeconds"); value != "" {
+		gracePeriod, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			response.WriteHeaderAndEntity(http.StatusBadRequest, errors.Wrap(fmt.Errorf("invalid gracePeriodSeconds: %v", err)))
+			return
+		}
+		options.GracePeriodSeconds = gracePeriod
+	}
+	if value := request.QueryParameter("timeoutSeconds"); value != "" {
+		timeout, err := strconv.ParseInt(value, 10, 64)
+		if err != nil || timeout <= 0 {
+			response.WriteHeaderAndEntity(http.StatusBadRequest, errors.Wrap(fmt.Errorf("invalid timeoutSeconds: %s", value)))
+			return
+		}
+		options.Timeout = time.Duration(timeout) * time.Second
+	}
+	options.Force, _ = strconv.ParseBool(request.QueryParameter("force"))
+
+	status, err := r.drainer.Drain(node, options)
+	if err != nil {
+		if k8serr.IsNotFound(err) {
+			response.WriteHeaderAndEntity(http.StatusNotFound, errors.Wrap(err))
+			return
+		}
+		response.WriteHeaderAndEntity(http.StatusConflict, errors.Wrap(err))
+		return
+	}
+
+	response.WriteAsJson(status)
+}
+
+func (r *operationHandler) handleNodeDrainStatus(request *restful.Request, response *restful.Response) {
+	node := request.PathParameter("node")
+
+	status, err := r.drainer.DrainStatus(node)
+	if err != nil {
+		response.WriteHeaderAndEntity(http.StatusNotFound, errors.Wrap(err))
+		return
+	}
+
+	response.WriteAsJson(status)
+}
+
+func (r *operationHandler) handleNodeDrainAbort(request *restful.Request, response *restful.Response) {
+	node := request.PathParameter("node")
+
+	if err := r.drainer.AbortDrain(node); err != nil {
+		response.WriteHeaderAndEntity(http.StatusNotFound, errors.Wrap(err))
+		return
+	}
+
+	response.WriteAsJson(errors.None)
+}
+
+func (r *operationHandler) handleListNodeGroups(request *restful.Request, response *restful.Response) {
+	groups, err := r.nodeGroups.ListNodeGroups()
+	if err != nil {
+		response.WriteHeaderAndEntity(http.StatusInternalServerError, errors.Wrap(err))
+		return
+	}
+	response.WriteAsJson(groups)
+}
+
+func (r *operationHandler) handleGetNodeGroup(request *restful.Request, response *restful.Response) {
+	group, err := r.nodeGroups.GetNodeGroup(request.PathParameter("nodegroup"))
+	if err != nil {
+		response.WriteHeaderAndEntity(http.StatusNotFound, errors.Wrap(err))
+		return
+	}
+	response.WriteAsJson(group)
+}
+
+func (r *operationHandler) handleNodeGroupCordon(request *restful.Request, response *restful.Response) {
+	name := request.PathParameter("nodegroup")
+
+	unschedulable := true
+	if value := request.QueryParameter("unschedulable"); value != "" {
+		var err error
+		if unschedulable, err = strconv.ParseBool(value); err != nil {
+			response.WriteHeaderAndEntity(http.StatusBadRequest, errors.Wrap(fmt.Errorf("invalid unschedulable: %v", err)))
+			return
+		}
+	}
+
+	group, err := r.nodeGroups.CordonGroup(name, unschedulable)
+	if err != nil {
+		response.WriteHeaderAndEntity(http.StatusInternalServerError, errors.Wrap(err))
+		return
+	}
+	response.WriteAsJson(group)
+}
+
+func (r *operationHandler) handleNodeGroupTaintUpdate(request *restful.Request, response *restful.Response) {
+	name := request.PathParameter("nodegroup")
+
+	var taints []corev1.Taint
+	if err := request.ReadEntity(&taints); err != nil {
+		response.WriteHeaderAndEntity(http.StatusBadRequest, errors.Wrap(err))
+		return
+	}
+
+	warnings, err := r.nodeGroups.SetGroupTaints(name, taints)
+	if err != nil {
+		response.WriteHeaderAndEntity(http.StatusBadRequest, errors.Wrap(err))
+		return
+	}
+	response.WriteAsJson(map[string][]string{"warnings": warnings})
+}
+
+func (r *operationHandler) handleNodeGroupReboot(request *restful.Request, response *restful.Response) {
+	name := request.PathParameter("nodegroup")
+
+	options := nodes.DrainOptions{GracePeriodSeconds: -1}
+	if value := request.QueryParameter("timeoutSeconds"); value != "" {
+		timeout, err := strconv.ParseInt(value, 10, 64)
+		if err != nil || timeout <= 0 {
+			response.WriteHeaderAndEntity(http.StatusBadRequest, errors.Wrap(fmt.Errorf("invalid timeoutSeconds: %s", value)))
+			return
+		}
+		options.Timeout = time.Duration(timeout) * time.Second
+	}
+	options.Force, _ = strconv.ParseBool(request.QueryParameter("force"))
+
+	status, err := r.nodeGroups.RollingReboot(name, options)
+	if err != nil {
+		response.WriteHeaderAndEntity(http.StatusConflict, errors.Wrap(err))
+		return
+	}
+	response.WriteAsJson(status)
+}
+
+func (r *operationHandler) handleNodeGroupRebootStatus(request *restful.Request, response *restful.Response) {
+	status, err := r.nodeGroups.RebootStatus(request.PathParameter("nodegroup"))
+	if err != nil {
+		response.WriteHeaderAndEntity(http.StatusNotFound, errors.Wrap(err))
+		return
+	}
+	response.WriteAsJson(status)
+}
+
+func (r *operationHandler) handleNodeGroupRebootAbort(request *restful.Request, response *restful.Response) {
+	if err := r.nodeGroups.AbortReboot(request.PathParameter("nodegroup")); err != nil {
+		response.WriteHeaderAndEntity(http.StatusNotFound, errors.Wrap(err))
+		return
+	}
+	response.WriteAsJson(errors.None)
+}
+
+func (r *operationHandler) handleNodeLabelUpdate(request *restful.Request, response *restful.Response) {
+	node := request.PathParameter("node")
+
+	var update nodes.LabelUpdate
+	if err := request.ReadEntity(&update); err != nil {
+		response.WriteHeaderAndEntity(http.StatusBadRequest, errors.Wrap(err))
+		return
+	}
+
+	result, err := r.nodeManager.UpdateLabels(node, update)
+	if err != nil {
+		if k8serr.IsNotFound(err) {
+			response.WriteHeaderAndEntity(http.StatusNotFound, errors.Wrap(err))
+			return
+		}
+		response.WriteHeaderAndEntity(http.StatusBadRequest, errors.Wrap(err))
+		return
+	}
+
+	response.WriteAsJson(result)
+}
+
+func (r *operationHandler) handleNodeTaintUpdate(request *restful.Request, response *restful.Response) {
+	node := request.PathParameter("node")
+
+	var taints []corev1.Taint
+	if err := request.ReadEntity(&taints); err != nil {
+		response.WriteHeaderAndEntity(http.StatusBadRequest, errors.Wrap(err))
+		return
+	}
+
+	result, err := r.nodeManager.UpdateTaints(node, taints)
+	if err != nil {
+		if k8serr.IsNotFound(err) {
+			response.WriteHeaderAndEntity(http.StatusNotFound, errors.Wrap(err))
+			return
+		}
+		response.WriteHeaderAndEntity(http.StatusBadRequest, errors.Wrap(err))
+		return
+	}
+
+	response.WriteAsJson(result)
+}
+
+func (r *operationHandler) handleListPendingCSRs(request *restful.Request, response *restful.Response) {
+	pending, err := r.csrApprovals.ListPending()
+	if err != nil {
+		response.WriteHeaderAndEntity(http.StatusInternalServerError, errors.Wrap(err))
+		return
+	}
+	response.WriteAsJson(pending)
+}
+
+func (r *operationHandler) handleCSRApprove(request *restful.Request, response *restful.Response) {
+	r.handleCSRDecision(request, response, r.csrApprovals.Approve)
+}
+
+func (r *operationHandler) handleCSRDeny(request *restful.Request, response *restful.Response) {
+	r.handleCSRDecision(request, response, r.csrApprovals.Deny)
+}
+
+func (r *operationHandler) handleCSRDecision(request *restful.Request, response *restful.Response, decide func(name, approver, reason string) error) {
+	name := request.PathParameter("csr")
+	reason := request.QueryParameter("reason")
+
+	approver := "anonymous"
+	if user, ok := requestctx.UserFrom(request.Request.Context()); ok {
+		approver = user.GetName()
+	}
+
+	if err := decide(name, approver, reason); err != nil {
+		if k8serr.IsNotFound(err) {
+			response.WriteHeaderAndEntity(http.StatusNotFound, errors.Wrap(err))
+			return
+		}
+		if certificates.IsAlreadyDecided(err) {
+			response.WriteHeaderAndEntity(http.StatusBadRequest, errors.Wrap(err))
+			return
+		}
+		response.WriteHeaderAndEntity(http.StatusInternalServerError, errors.Wrap(err))
+		return
+	}
+
+	response.WriteAsJson(errors.None)
+}
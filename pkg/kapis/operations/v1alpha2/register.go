@@ -20,11 +20,16 @@ import (
 	"net/http"
 
 	"github.com/emicklei/go-restful"
+	autoscalingv1 "k8s.io/api/autoscaling/v1"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/client-go/kubernetes"
 
 	"kubesphere.io/kubesphere/pkg/api"
 	"kubesphere.io/kubesphere/pkg/apiserver/runtime"
+	"kubesphere.io/kubesphere/pkg/models/certificates"
+	"kubesphere.io/kubesphere/pkg/models/nodes"
+	"kubesphere.io/kubesphere/pkg/models/workloads"
 	"kubesphere.io/kubesphere/pkg/server/errors"
 )
 
@@ -50,6 +55,145 @@ func AddToContainer(c *restful.Container, client kubernetes.Interface) error {
 		Param(webservice.QueryParameter("resourceVersion", "version of job, rerun when the version matches").Required(true)).
 		Returns(http.StatusOK, api.StatusOK, errors.Error{}))
 
+	webservice.Route(webservice.POST("/namespaces/{namespace}/{kind}/{workload}/scale").
+		To(handler.handleWorkloadScale).
+		Doc("Scale the workload through the scale subresource, scaling an HPA-managed workload is rejected unless forced").
+		Param(webservice.PathParameter("namespace", "the name of the namespace the workload runs in")).
+		Param(webservice.PathParameter("kind", "the kind of the workload, one of deployments, statefulsets, replicasets")).
+		Param(webservice.PathParameter("workload", "workload name")).
+		Param(webservice.QueryParameter("replicas", "the desired number of replicas").Required(true)).
+		Param(webservice.QueryParameter("force", "scale even if the workload is managed by a HorizontalPodAutoscaler")).
+		Returns(http.StatusOK, api.StatusOK, autoscalingv1.Scale{}))
+
+	webservice.Route(webservice.POST("/namespaces/{namespace}/{kind}/{workload}/rollback").
+		To(handler.handleWorkloadRollback).
+		Doc("Roll the workload back to an earlier revision of its rollout history").
+		Param(webservice.PathParameter("namespace", "the name of the namespace the workload runs in")).
+		Param(webservice.PathParameter("kind", "the kind of the workload, one of deployments, statefulsets, daemonsets")).
+		Param(webservice.PathParameter("workload", "workload name")).
+		Param(webservice.QueryParameter("revision", "the revision to roll back to").Required(true)).
+		Returns(http.StatusOK, api.StatusOK, errors.Error{}))
+
+	webservice.Route(webservice.POST("/namespaces/{namespace}/configmaps/{configmap}/rollout").
+		To(handler.handleConfigMapRollout).
+		Doc("Create an immutable versioned copy of the configmap and rewrite referencing workloads to roll them").
+		Param(webservice.PathParameter("namespace", "the name of the namespace the configmap belongs to")).
+		Param(webservice.PathParameter("configmap", "configmap name")).
+		Returns(http.StatusOK, api.StatusOK, workloads.RolloutResult{}))
+
+	webservice.Route(webservice.POST("/namespaces/{namespace}/secrets/{secret}/rollout").
+		To(handler.handleSecretRollout).
+		Doc("Create an immutable versioned copy of the secret and rewrite referencing workloads to roll them").
+		Param(webservice.PathParameter("namespace", "the name of the namespace the secret belongs to")).
+		Param(webservice.PathParameter("secret", "secret name")).
+		Returns(http.StatusOK, api.StatusOK, workloads.RolloutResult{}))
+
+	webservice.Route(webservice.POST("/nodes/{node}/cordon").
+		To(handler.handleNodeCordon).
+		Doc("Mark the node unschedulable, or schedulable again").
+		Param(webservice.PathParameter("node", "node name")).
+		Param(webservice.QueryParameter("unschedulable", "the desired unschedulable state, defaults to true")).
+		Returns(http.StatusOK, api.StatusOK, corev1.Node{}))
+
+	webservice.Route(webservice.POST("/nodes/{node}/drain").
+		To(handler.handleNodeDrain).
+		Doc("Cordon the node and evict its pods honoring pod disruption budgets, the drain runs asynchronously").
+		Param(webservice.PathParameter("node", "node name")).
+		Param(webservice.QueryParameter("gracePeriodSeconds", "termination grace period applied to evicted pods, the pod setting is kept when omitted")).
+		Param(webservice.QueryParameter("timeoutSeconds", "abort the drain after this many seconds, defaults to 600")).
+		Param(webservice.QueryParameter("force", "evict pods not managed by a controller")).
+		Returns(http.StatusOK, api.StatusOK, nodes.DrainStatus{}))
+
+	webservice.Route(webservice.GET("/nodes/{node}/drain").
+		To(handler.handleNodeDrainStatus).
+		Doc("Get the progress of the latest drain of the node").
+		Param(webservice.PathParameter("node", "node name")).
+		Returns(http.StatusOK, api.StatusOK, nodes.DrainStatus{}))
+
+	webservice.Route(webservice.DELETE("/nodes/{node}/drain").
+		To(handler.handleNodeDrainAbort).
+		Doc("Abort a drain of the node that is still in progress").
+		Param(webservice.PathParameter("node", "node name")).
+		Returns(http.StatusOK, api.StatusOK, errors.Error{}))
+
+	webservice.Route(webservice.PUT("/nodes/{node}/labels").
+		To(handler.handleNodeLabelUpdate).
+		Doc("Add, overwrite or remove node labels, labels reserved for kubernetes are rejected").
+		Param(webservice.PathParameter("node", "node name")).
+		Reads(nodes.LabelUpdate{}).
+		Returns(http.StatusOK, api.StatusOK, corev1.Node{}))
+
+	webservice.Route(webservice.PUT("/nodes/{node}/taints").
+		To(handler.handleNodeTaintUpdate).
+		Doc("Replace the node taints, the response warns about running pods the new taints will evict or strand").
+		Param(webservice.PathParameter("node", "node name")).
+		Reads([]corev1.Taint{}).
+		Returns(http.StatusOK, api.StatusOK, nodes.TaintUpdateResult{}))
+
+	webservice.Route(webservice.GET("/nodegroups").
+		To(handler.handleListNodeGroups).
+		Doc("List node groups defined by the node group label with aggregate capacity and usage").
+		Returns(http.StatusOK, api.StatusOK, []nodes.NodeGroup{}))
+
+	webservice.Route(webservice.GET("/nodegroups/{nodegroup}").
+		To(handler.handleGetNodeGroup).
+		Doc("Get a node group with aggregate capacity and usage").
+		Param(webservice.PathParameter("nodegroup", "node group name")).
+		Returns(http.StatusOK, api.StatusOK, nodes.NodeGroup{}))
+
+	webservice.Route(webservice.POST("/nodegroups/{nodegroup}/cordon").
+		To(handler.handleNodeGroupCordon).
+		Doc("Mark all nodes of the group unschedulable, or schedulable again").
+		Param(webservice.PathParameter("nodegroup", "node group name")).
+		Param(webservice.QueryParameter("unschedulable", "the desired unschedulable state, defaults to true")).
+		Returns(http.StatusOK, api.StatusOK, nodes.NodeGroup{}))
+
+	webservice.Route(webservice.PUT("/nodegroups/{nodegroup}/taints").
+		To(handler.handleNodeGroupTaintUpdate).
+		Doc("Replace the taints on all nodes of the group").
+		Param(webservice.PathParameter("nodegroup", "node group name")).
+		Reads([]corev1.Taint{}).
+		Returns(http.StatusOK, api.StatusOK, map[string][]string{}))
+
+	webservice.Route(webservice.POST("/nodegroups/{nodegroup}/reboot").
+		To(handler.handleNodeGroupReboot).
+		Doc("Start a rolling reboot of the group, one node at a time is drained and handed to the node agent through the reboot annotation").
+		Param(webservice.PathParameter("nodegroup", "node group name")).
+		Param(webservice.QueryParameter("timeoutSeconds", "per-node timeout for drain and reboot, defaults to 600")).
+		Param(webservice.QueryParameter("force", "evict pods not managed by a controller")).
+		Returns(http.StatusOK, api.StatusOK, nodes.RebootStatus{}))
+
+	webservice.Route(webservice.GET("/nodegroups/{nodegroup}/reboot").
+		To(handler.handleNodeGroupRebootStatus).
+		Doc("Get the progress of the latest rolling reboot of the group").
+		Param(webservice.PathParameter("nodegroup", "node group name")).
+		Returns(http.StatusOK, api.StatusOK, nodes.RebootStatus{}))
+
+	webservice.Route(webservice.DELETE("/nodegroups/{nodegroup}/reboot").
+		To(handler.handleNodeGroupRebootAbort).
+		Doc("Abort a rolling reboot of the group that is still in progress").
+		Param(webservice.PathParameter("nodegroup", "node group name")).
+		Returns(http.StatusOK, api.StatusOK, errors.Error{}))
+
+	webservice.Route(webservice.GET("/certificatesigningrequests/pending").
+		To(handler.handleListPendingCSRs).
+		Doc("List certificate signing requests that have been neither approved nor denied, with the requester identity").
+		Returns(http.StatusOK, api.StatusOK, []certificates.PendingRequest{}))
+
+	webservice.Route(webservice.POST("/certificatesigningrequests/{csr}/approve").
+		To(handler.handleCSRApprove).
+		Doc("Approve a pending certificate signing request, recording the approver and reason on the request").
+		Param(webservice.PathParameter("csr", "certificate signing request name")).
+		Param(webservice.QueryParameter("reason", "why the request is approved")).
+		Returns(http.StatusOK, api.StatusOK, errors.Error{}))
+
+	webservice.Route(webservice.POST("/certificatesigningrequests/{csr}/deny").
+		To(handler.handleCSRDeny).
+		Doc("Deny a pending certificate signing request, recording the approver and reason on the request").
+		Param(webservice.PathParameter("csr", "certificate signing request name")).
+		Param(webservice.QueryParameter("reason", "why the request is denied")).
+		Returns(http.StatusOK, api.StatusOK, errors.Error{}))
+
 	c.Add(webservice)
 
 	return nil
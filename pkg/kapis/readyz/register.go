@@ -0,0 +1,67 @@
+/*
+Copyright 2022 The KubeSphere Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package readyz
+
+import (
+	"net/http"
+
+	"github.com/emicklei/go-restful"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"kubesphere.io/kubesphere/pkg/apiserver/authentication/identityprovider"
+	"kubesphere.io/kubesphere/pkg/apiserver/runtime"
+)
+
+// Readiness reports the health of the external dependencies of the
+// apiserver, currently the configured identity providers.
+type Readiness struct {
+	Status            string                            `json:"status"`
+	IdentityProviders []identityprovider.ProviderStatus `json:"identityProviders,omitempty"`
+}
+
+const (
+	statusOK       = "ok"
+	statusDegraded = "degraded"
+)
+
+func AddToContainer(container *restful.Container) error {
+	webservice := runtime.NewWebService(schema.GroupVersion{})
+
+	webservice.Route(webservice.GET("/readyz").
+		To(func(request *restful.Request, response *restful.Response) {
+			readiness := Readiness{
+				Status:            statusOK,
+				IdentityProviders: identityprovider.Status(),
+			}
+			for _, provider := range readiness.IdentityProviders {
+				if !provider.Ready {
+					readiness.Status = statusDegraded
+					break
+				}
+			}
+			if readiness.Status != statusOK {
+				response.WriteHeaderAndJson(http.StatusServiceUnavailable, readiness, restful.MIME_JSON)
+				return
+			}
+			response.WriteAsJson(readiness)
+		})).
+		Doc("KubeSphere dependency readiness")
+
+	container.Add(webservice)
+
+	return nil
+}
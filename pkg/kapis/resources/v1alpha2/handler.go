@@ -29,6 +29,7 @@ import (
 	"k8s.io/klog"
 
 	"kubesphere.io/kubesphere/pkg/api"
+	"kubesphere.io/kubesphere/pkg/apiserver/authentication/oauth"
 	"kubesphere.io/kubesphere/pkg/informers"
 	"kubesphere.io/kubesphere/pkg/models/components"
 	"kubesphere.io/kubesphere/pkg/models/git"
@@ -40,6 +41,7 @@ import (
 	"kubesphere.io/kubesphere/pkg/models/resources/v1alpha2/resource"
 	"kubesphere.io/kubesphere/pkg/models/revisions"
 	"kubesphere.io/kubesphere/pkg/models/routers"
+	"kubesphere.io/kubesphere/pkg/models/workloads"
 	"kubesphere.io/kubesphere/pkg/server/errors"
 	"kubesphere.io/kubesphere/pkg/server/params"
 )
@@ -54,22 +56,26 @@ type resourceHandler struct {
 	registryGetter      registries.RegistryGetter
 	kubeconfigOperator  kubeconfig.Interface
 	kubectlOperator     kubectl.Interface
+	secretManager       registries.SecretManager
+	pdbAnalyzer         workloads.PDBAnalyzer
 }
 
-func newResourceHandler(k8sClient kubernetes.Interface, factory informers.InformerFactory, masterURL string) *resourceHandler {
+func newResourceHandler(k8sClient kubernetes.Interface, factory informers.InformerFactory, masterURL string, oauthOptions *oauth.Options) *resourceHandler {
 
 	return &resourceHandler{
 		resourcesGetter:     resource.NewResourceGetter(factory),
-		componentsGetter:    components.NewComponentsGetter(factory.KubernetesSharedInformerFactory()),
+		componentsGetter:    components.NewComponentsGetter(factory.KubernetesSharedInformerFactory(), factory.KubeSphereSharedInformerFactory()),
 		resourceQuotaGetter: quotas.NewResourceQuotaGetter(factory.KubernetesSharedInformerFactory()),
 		revisionGetter:      revisions.NewRevisionGetter(factory.KubernetesSharedInformerFactory()),
 		routerOperator:      routers.NewRouterOperator(k8sClient, factory.KubernetesSharedInformerFactory()),
 		gitVerifier:         git.NewGitVerifier(factory.KubernetesSharedInformerFactory()),
 		registryGetter:      registries.NewRegistryGetter(factory.KubernetesSharedInformerFactory()),
-		kubeconfigOperator:  kubeconfig.NewReadOnlyOperator(factory.KubernetesSharedInformerFactory().Core().V1().ConfigMaps().Lister(), masterURL),
-		kubectlOperator: kubectl.NewOperator(nil, factory.KubernetesSharedInformerFactory().Apps().V1().Deployments(),
+		kubeconfigOperator:  kubeconfig.NewReadOnlyOperator(factory.KubernetesSharedInformerFactory().Core().V1().ConfigMaps().Lister(), masterURL, oauthOptions),
+		kubectlOperator: kubectl.NewOperator(k8sClient, factory.KubernetesSharedInformerFactory().Apps().V1().Deployments(),
 			factory.KubernetesSharedInformerFactory().Core().V1().Pods(),
-			factory.KubeSphereSharedInformerFactory().Iam().V1alpha2().Users(), ""),
+			factory.KubeSphereSharedInformerFactory().Iam().V1alpha2().Users(), nil),
+		secretManager: registries.NewSecretManager(k8sClient, registries.NewRegistryGetter(factory.KubernetesSharedInformerFactory())),
+		pdbAnalyzer:   workloads.NewPDBAnalyzer(factory.KubernetesSharedInformerFactory()),
 	}
 }
 
@@ -206,6 +212,53 @@ func (r *resourceHandler) handleGetStatefulSetRevision(request *restful.Request,
 	response.WriteAsJson(result)
 }
 
+func (r *resourceHandler) handleListDaemonSetRevisions(request *restful.Request, response *restful.Response) {
+	daemonset := request.PathParameter("daemonset")
+	namespace := request.PathParameter("namespace")
+
+	result, err := r.revisionGetter.ListDaemonSetRevisions(namespace, daemonset)
+	if err != nil {
+		response.WriteHeaderAndEntity(http.StatusInternalServerError, errors.Wrap(err))
+		return
+	}
+	response.WriteAsJson(result)
+}
+
+func (r *resourceHandler) handleListDeploymentRevisions(request *restful.Request, response *restful.Response) {
+	deploy := request.PathParameter("deployment")
+	namespace := request.PathParameter("namespace")
+
+	result, err := r.revisionGetter.ListDeploymentRevisions(namespace, deploy)
+	if err != nil {
+		response.WriteHeaderAndEntity(http.StatusInternalServerError, errors.Wrap(err))
+		return
+	}
+	response.WriteAsJson(result)
+}
+
+func (r *resourceHandler) handleListStatefulSetRevisions(request *restful.Request, response *restful.Response) {
+	statefulset := request.PathParameter("statefulset")
+	namespace := request.PathParameter("namespace")
+
+	result, err := r.revisionGetter.ListStatefulSetRevisions(namespace, statefulset)
+	if err != nil {
+		response.WriteHeaderAndEntity(http.StatusInternalServerError, errors.Wrap(err))
+		return
+	}
+	response.WriteAsJson(result)
+}
+
+func (r *resourceHandler) handleAnalyzePDBGaps(request *restful.Request, response *restful.Response) {
+	namespace := request.PathParameter("namespace")
+
+	result, err := r.pdbAnalyzer.AnalyzePDBGaps(namespace)
+	if err != nil {
+		response.WriteHeaderAndEntity(http.StatusInternalServerError, errors.Wrap(err))
+		return
+	}
+	response.WriteAsJson(result)
+}
+
 // Get ingress controller service for specified namespace
 func (r *resourceHandler) handleGetRouter(request *restful.Request, response *restful.Response) {
 	namespace := request.PathParameter("namespace")
@@ -319,6 +372,28 @@ func (r *resourceHandler) handleVerifyRegistryCredential(request *restful.Reques
 	response.WriteHeader(http.StatusOK)
 }
 
+func (r *resourceHandler) handleCreateRegistrySecret(request *restful.Request, response *restful.Response) {
+	namespace := request.PathParameter("namespace")
+
+	var spec registries.RegistrySecretSpec
+	if err := request.ReadEntity(&spec); err != nil {
+		api.HandleBadRequest(response, nil, err)
+		return
+	}
+
+	secret, err := r.secretManager.CreateRegistrySecret(namespace, &spec)
+	if err != nil {
+		if k8serr.IsAlreadyExists(err) {
+			api.HandleConflict(response, request, err)
+			return
+		}
+		api.HandleBadRequest(response, nil, err)
+		return
+	}
+
+	response.WriteAsJson(secret)
+}
+
 func (r *resourceHandler) handleGetRegistryEntry(request *restful.Request, response *restful.Response) {
 	imageName := request.QueryParameter("image")
 	namespace := request.QueryParameter("namespace")
@@ -398,3 +473,23 @@ func (r *resourceHandler) GetKubeconfig(request *restful.Request, response *rest
 
 	response.Write([]byte(kubectlConfig))
 }
+
+// GetOIDCKubeconfig returns a kubeconfig which authenticates through the OIDC
+// exec credential plugin (device-code flow) instead of embedded client certs.
+func (r *resourceHandler) GetOIDCKubeconfig(request *restful.Request, response *restful.Response) {
+	user := request.PathParameter("user")
+
+	kubectlConfig, err := r.kubeconfigOperator.GetOIDCKubeConfig(user)
+
+	if err != nil {
+		klog.Error(err)
+		if k8serr.IsNotFound(err) {
+			response.WriteHeaderAndJson(http.StatusNotFound, errors.Wrap(err), restful.MIME_JSON)
+		} else {
+			response.WriteHeaderAndJson(http.StatusInternalServerError, errors.Wrap(err), restful.MIME_JSON)
+		}
+		return
+	}
+
+	response.Write([]byte(kubectlConfig))
+}
@@ -28,12 +28,15 @@ import (
 
 	"kubesphere.io/kubesphere/pkg/api"
 	"kubesphere.io/kubesphere/pkg/api/resource/v1alpha2"
+	"kubesphere.io/kubesphere/pkg/apiserver/authentication/oauth"
 	"kubesphere.io/kubesphere/pkg/apiserver/runtime"
 	"kubesphere.io/kubesphere/pkg/constants"
 	"kubesphere.io/kubesphere/pkg/informers"
 	"kubesphere.io/kubesphere/pkg/models"
 	gitmodel "kubesphere.io/kubesphere/pkg/models/git"
 	registriesmodel "kubesphere.io/kubesphere/pkg/models/registries"
+	"kubesphere.io/kubesphere/pkg/models/revisions"
+	"kubesphere.io/kubesphere/pkg/models/workloads"
 	"kubesphere.io/kubesphere/pkg/server/errors"
 	"kubesphere.io/kubesphere/pkg/server/params"
 )
@@ -44,9 +47,9 @@ const (
 
 var GroupVersion = schema.GroupVersion{Group: GroupName, Version: "v1alpha2"}
 
-func AddToContainer(c *restful.Container, k8sClient kubernetes.Interface, factory informers.InformerFactory, masterURL string) error {
+func AddToContainer(c *restful.Container, k8sClient kubernetes.Interface, factory informers.InformerFactory, masterURL string, oauthOptions *oauth.Options) error {
 	webservice := runtime.NewWebService(GroupVersion)
-	handler := newResourceHandler(k8sClient, factory, masterURL)
+	handler := newResourceHandler(k8sClient, factory, masterURL, oauthOptions)
 
 	webservice.Route(webservice.GET("/namespaces/{namespace}/{resources}").
 		To(handler.handleListNamespaceResources).
@@ -99,6 +102,14 @@ func AddToContainer(c *restful.Container, k8sClient kubernetes.Interface, factor
 		Returns(http.StatusOK, api.StatusOK, "").
 		Metadata(restfulspec.KeyOpenAPITags, []string{constants.ToolboxTag}))
 
+	webservice.Route(webservice.GET("/users/{user}/kubeconfig/oidc").
+		Produces("text/plain", restful.MIME_JSON).
+		To(handler.GetOIDCKubeconfig).
+		Doc("get users' kubeconfig with the OIDC exec credential plugin").
+		Param(webservice.PathParameter("user", "username")).
+		Returns(http.StatusOK, api.StatusOK, "").
+		Metadata(restfulspec.KeyOpenAPITags, []string{constants.ToolboxTag}))
+
 	webservice.Route(webservice.GET("/components").
 		To(handler.handleGetComponents).
 		Metadata(restfulspec.KeyOpenAPITags, []string{constants.ComponentStatusTag}).
@@ -137,6 +148,13 @@ func AddToContainer(c *restful.Container, k8sClient kubernetes.Interface, factor
 		Doc("verify if a user has access to the docker registry").
 		Reads(api.RegistryCredential{}).
 		Returns(http.StatusOK, api.StatusOK, errors.Error{}))
+	webservice.Route(webservice.POST("/namespaces/{namespace}/registrysecrets").
+		To(handler.handleCreateRegistrySecret).
+		Metadata(restfulspec.KeyOpenAPITags, []string{constants.RegistryTag}).
+		Doc("Create a dockerconfigjson secret after testing connectivity against the registry, the secret is propagated to the namespaces it selects").
+		Param(webservice.PathParameter("namespace", "the namespace the secret is created in")).
+		Reads(registriesmodel.RegistrySecretSpec{}).
+		Returns(http.StatusOK, api.StatusOK, corev1.Secret{}))
 	webservice.Route(webservice.GET("/registry/blob").
 		Deprecate().
 		To(handler.handleGetRegistryEntry).
@@ -190,6 +208,35 @@ func AddToContainer(c *restful.Container, k8sClient kubernetes.Interface, factor
 		Param(webservice.PathParameter("revision", "the revision of the statefulset")).
 		Returns(http.StatusOK, api.StatusOK, appsv1.StatefulSet{}))
 
+	webservice.Route(webservice.GET("/namespaces/{namespace}/daemonsets/{daemonset}/revisions").
+		To(handler.handleListDaemonSetRevisions).
+		Metadata(restfulspec.KeyOpenAPITags, []string{constants.NamespaceResourcesTag}).
+		Doc("List the rollout history of the daemonset with per-revision pod template diffs").
+		Param(webservice.PathParameter("daemonset", "the name of the daemonset")).
+		Param(webservice.PathParameter("namespace", "the namespace of the daemonset")).
+		Returns(http.StatusOK, api.StatusOK, []revisions.WorkloadRevision{}))
+	webservice.Route(webservice.GET("/namespaces/{namespace}/deployments/{deployment}/revisions").
+		To(handler.handleListDeploymentRevisions).
+		Metadata(restfulspec.KeyOpenAPITags, []string{constants.NamespaceResourcesTag}).
+		Doc("List the rollout history of the deployment with per-revision pod template diffs").
+		Param(webservice.PathParameter("deployment", "the name of deployment")).
+		Param(webservice.PathParameter("namespace", "the namespace of the deployment")).
+		Returns(http.StatusOK, api.StatusOK, []revisions.WorkloadRevision{}))
+	webservice.Route(webservice.GET("/namespaces/{namespace}/statefulsets/{statefulset}/revisions").
+		To(handler.handleListStatefulSetRevisions).
+		Metadata(restfulspec.KeyOpenAPITags, []string{constants.NamespaceResourcesTag}).
+		Doc("List the rollout history of the statefulset with per-revision pod template diffs").
+		Param(webservice.PathParameter("statefulset", "the name of the statefulset")).
+		Param(webservice.PathParameter("namespace", "the namespace of the statefulset")).
+		Returns(http.StatusOK, api.StatusOK, []revisions.WorkloadRevision{}))
+
+	webservice.Route(webservice.GET("/namespaces/{namespace}/poddisruptionbudgets/gaps").
+		To(handler.handleAnalyzePDBGaps).
+		Metadata(restfulspec.KeyOpenAPITags, []string{constants.NamespaceResourcesTag}).
+		Doc("Find workloads with multiple replicas but no pod disruption budget, or budgets that never allow a disruption").
+		Param(webservice.PathParameter("namespace", "the namespace to analyze")).
+		Returns(http.StatusOK, api.StatusOK, []workloads.PDBGap{}))
+
 	webservice.Route(webservice.GET("/namespaces/{namespace}/router").
 		Deprecate().
 		To(handler.handleGetRouter).
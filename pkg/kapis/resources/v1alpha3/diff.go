@@ -0,0 +1,186 @@
+/*
+Copyright 2022 The KubeSphere Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha3
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/emicklei/go-restful"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/yaml"
+
+	"kubesphere.io/kubesphere/pkg/api"
+	resourcev1alpha3 "kubesphere.io/kubesphere/pkg/models/resources/v1alpha3/resource"
+)
+
+const (
+	diffEntryAdded    = "added"
+	diffEntryRemoved  = "removed"
+	diffEntryModified = "modified"
+)
+
+// DiffEntry records one difference between the live object and the
+// proposed state at a dot separated field path.
+type DiffEntry struct {
+	Path string `json:"path"`
+	// Type is one of added, removed or modified, seen from the proposed
+	// state: added means the path only exists in the proposal.
+	Type     string      `json:"type"`
+	Live     interface{} `json:"live,omitempty"`
+	Proposed interface{} `json:"proposed,omitempty"`
+}
+
+// DiffResult is the structured diff of a live object against a proposed
+// manifest or another live object of the same kind.
+type DiffResult struct {
+	Resource  string      `json:"resource"`
+	Namespace string      `json:"namespace,omitempty"`
+	Name      string      `json:"name"`
+	Entries   []DiffEntry `json:"entries"`
+}
+
+// handleDiffResource diffs the live object against the manifest in the
+// request body, so the console shows the changes before they are applied.
+// Without a body the compareTo parameter names another object of the same
+// kind to diff against, e.g. two controller revisions of a workload.
+func (h *Handler) handleDiffResource(request *restful.Request, response *restful.Response) {
+	resourceType := request.PathParameter("resources")
+	namespace := request.PathParameter("namespace")
+	name := request.PathParameter("name")
+
+	live, err := h.resourceGetterV1alpha3.Get(resourceType, namespace, name)
+	if err != nil {
+		if err == resourcev1alpha3.ErrResourceNotSupported || errors.IsNotFound(err) {
+			api.HandleNotFound(response, request, err)
+			return
+		}
+		api.HandleInternalError(response, request, err)
+		return
+	}
+	liveState, err := runtime.DefaultUnstructuredConverter.ToUnstructured(live)
+	if err != nil {
+		api.HandleInternalError(response, request, err)
+		return
+	}
+
+	proposedState, err := h.proposedState(request, resourceType, namespace)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			api.HandleNotFound(response, request, err)
+			return
+		}
+		api.HandleBadRequest(response, request, err)
+		return
+	}
+
+	pruneServerFields(liveState)
+	pruneServerFields(proposedState)
+
+	var entries []DiffEntry
+	diffValues("", liveState, proposedState, &entries)
+	response.WriteEntity(DiffResult{
+		Resource:  resourceType,
+		Namespace: namespace,
+		Name:      name,
+		Entries:   entries,
+	})
+}
+
+// proposedState resolves the state the live object is diffed against,
+// either the manifest carried in the request body or the live object the
+// compareTo parameter names.
+func (h *Handler) proposedState(request *restful.Request, resourceType, namespace string) (map[string]interface{}, error) {
+	body, err := io.ReadAll(request.Request.Body)
+	if err != nil {
+		return nil, err
+	}
+	if len(bytes.TrimSpace(body)) > 0 {
+		var proposed map[string]interface{}
+		if err := yaml.Unmarshal(body, &proposed); err != nil {
+			return nil, fmt.Errorf("invalid manifest: %s", err)
+		}
+		return proposed, nil
+	}
+
+	compareTo := request.QueryParameter("compareTo")
+	if compareTo == "" {
+		return nil, fmt.Errorf("either a manifest body or the compareTo parameter is required")
+	}
+	other, err := h.resourceGetterV1alpha3.Get(resourceType, namespace, compareTo)
+	if err != nil {
+		return nil, err
+	}
+	return runtime.DefaultUnstructuredConverter.ToUnstructured(other)
+}
+
+// pruneServerFields drops the fields the server populates on every
+// object, diffing them against a manifest only produces noise.
+func pruneServerFields(object map[string]interface{}) {
+	delete(object, "status")
+	delete(object, "apiVersion")
+	delete(object, "kind")
+	if metadata, ok := object["metadata"].(map[string]interface{}); ok {
+		for _, field := range []string{"creationTimestamp", "generation", "managedFields", "resourceVersion", "selfLink", "uid"} {
+			delete(metadata, field)
+		}
+	}
+}
+
+// diffValues walks both values in lockstep, maps recurse per key while
+// any other pair is compared wholesale.
+func diffValues(path string, live, proposed interface{}, entries *[]DiffEntry) {
+	liveMap, liveOK := live.(map[string]interface{})
+	proposedMap, proposedOK := proposed.(map[string]interface{})
+	if liveOK && proposedOK {
+		keys := make([]string, 0, len(liveMap)+len(proposedMap))
+		for key := range liveMap {
+			keys = append(keys, key)
+		}
+		for key := range proposedMap {
+			if _, ok := liveMap[key]; !ok {
+				keys = append(keys, key)
+			}
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			child := key
+			if path != "" {
+				child = strings.Join([]string{path, key}, ".")
+			}
+			liveValue, inLive := liveMap[key]
+			proposedValue, inProposed := proposedMap[key]
+			switch {
+			case !inLive:
+				*entries = append(*entries, DiffEntry{Path: child, Type: diffEntryAdded, Proposed: proposedValue})
+			case !inProposed:
+				*entries = append(*entries, DiffEntry{Path: child, Type: diffEntryRemoved, Live: liveValue})
+			default:
+				diffValues(child, liveValue, proposedValue, entries)
+			}
+		}
+		return
+	}
+	if !reflect.DeepEqual(live, proposed) {
+		*entries = append(*entries, DiffEntry{Path: path, Type: diffEntryModified, Live: live, Proposed: proposed})
+	}
+}
@@ -0,0 +1,92 @@
+/*
+Copyright 2022 The KubeSphere Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha3
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDiffValues(t *testing.T) {
+	live := map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"name":   "nginx",
+			"labels": map[string]interface{}{"app": "nginx", "tier": "web"},
+		},
+		"spec": map[string]interface{}{
+			"replicas": int64(2),
+		},
+	}
+	proposed := map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"name":   "nginx",
+			"labels": map[string]interface{}{"app": "nginx"},
+		},
+		"spec": map[string]interface{}{
+			"replicas": int64(3),
+			"paused":   true,
+		},
+	}
+
+	var entries []DiffEntry
+	diffValues("", live, proposed, &entries)
+
+	expected := []DiffEntry{
+		{Path: "metadata.labels.tier", Type: diffEntryRemoved, Live: "web"},
+		{Path: "spec.paused", Type: diffEntryAdded, Proposed: true},
+		{Path: "spec.replicas", Type: diffEntryModified, Live: int64(2), Proposed: int64(3)},
+	}
+	if !reflect.DeepEqual(expected, entries) {
+		t.Errorf("expected %v, got %v", expected, entries)
+	}
+
+	// identical objects produce no entries
+	entries = nil
+	diffValues("", live, live, &entries)
+	if len(entries) != 0 {
+		t.Errorf("expected no entries, got %v", entries)
+	}
+}
+
+func TestPruneServerFields(t *testing.T) {
+	object := map[string]interface{}{
+		"apiVersion": "apps/v1",
+		"kind":       "Deployment",
+		"metadata": map[string]interface{}{
+			"name":            "nginx",
+			"resourceVersion": "42",
+			"uid":             "a8a8d6cf",
+		},
+		"spec":   map[string]interface{}{},
+		"status": map[string]interface{}{},
+	}
+	pruneServerFields(object)
+
+	if _, ok := object["status"]; ok {
+		t.Error("expected status to be pruned")
+	}
+	if _, ok := object["apiVersion"]; ok {
+		t.Error("expected apiVersion to be pruned")
+	}
+	metadata := object["metadata"].(map[string]interface{})
+	if _, ok := metadata["resourceVersion"]; ok {
+		t.Error("expected resourceVersion to be pruned")
+	}
+	if metadata["name"] != "nginx" {
+		t.Error("expected the name to survive pruning")
+	}
+}
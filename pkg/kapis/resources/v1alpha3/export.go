@@ -0,0 +1,155 @@
+/*
+Copyright 2022 The KubeSphere Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha3
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/emicklei/go-restful"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/klog"
+	"sigs.k8s.io/yaml"
+
+	"kubesphere.io/kubesphere/pkg/api"
+	"kubesphere.io/kubesphere/pkg/apiserver/query"
+	resourcev1alpha3 "kubesphere.io/kubesphere/pkg/models/resources/v1alpha3/resource"
+)
+
+const (
+	parameterExport = "export"
+
+	exportFormatCSV  = "csv"
+	exportFormatYAML = "yaml"
+	exportFormatJSON = "json"
+)
+
+// defaultExportColumns are the csv columns used when the request does not
+// select fields of its own.
+var defaultExportColumns = []string{"metadata.namespace", "metadata.name", "metadata.creationTimestamp"}
+
+// handleExportResources streams the whole filtered list as a downloadable
+// attachment, the page window does not apply. The fields parameter selects
+// the csv columns, json and yaml exports carry the items as they are.
+func (h *Handler) handleExportResources(request *restful.Request, response *restful.Response, format string) {
+	q := query.ParseQueryParameter(request)
+	delete(q.Filters, parameterExport)
+	resourceType := request.PathParameter("resources")
+	namespace := request.PathParameter("namespace")
+
+	q.Pagination = query.NoPagination
+	q.Continue = ""
+	columns := q.Fields
+	// csv extracts its columns itself, the other formats keep the
+	// field projection of the query
+	if format == exportFormatCSV {
+		q.Fields = nil
+	}
+
+	result, err := h.resourceGetterV1alpha3.List(resourceType, namespace, q)
+	if err != nil {
+		if err == resourcev1alpha3.ErrResourceNotSupported {
+			api.HandleNotFound(response, request, err)
+			return
+		}
+		klog.Errorf("%s, resource type: %s", err, resourceType)
+		api.HandleInternalError(response, request, err)
+		return
+	}
+
+	response.AddHeader("Content-Disposition", fmt.Sprintf("attachment; filename=%q", fmt.Sprintf("%s.%s", resourceType, format)))
+
+	switch format {
+	case exportFormatJSON:
+		response.AddHeader(restful.HEADER_ContentType, restful.MIME_JSON)
+		data, err := json.Marshal(result.Items)
+		if err != nil {
+			api.HandleInternalError(response, request, err)
+			return
+		}
+		writeExport(response, data)
+	case exportFormatYAML:
+		response.AddHeader(restful.HEADER_ContentType, "application/yaml")
+		data, err := yaml.Marshal(result.Items)
+		if err != nil {
+			api.HandleInternalError(response, request, err)
+			return
+		}
+		writeExport(response, data)
+	case exportFormatCSV:
+		response.AddHeader(restful.HEADER_ContentType, "text/csv")
+		if err := writeCSV(response, result.Items, columns); err != nil {
+			klog.Errorf("write csv export: %s", err)
+		}
+	default:
+		api.HandleBadRequest(response, request, fmt.Errorf("unsupported export format %s", format))
+	}
+}
+
+func writeExport(response *restful.Response, data []byte) {
+	if _, err := response.Write(data); err != nil {
+		klog.Errorf("write export: %s", err)
+	}
+}
+
+// writeCSV renders one row per item with one cell per dot separated
+// column path, cells whose path the item misses are left empty.
+func writeCSV(w io.Writer, items []interface{}, columns []string) error {
+	if len(columns) == 0 {
+		columns = defaultExportColumns
+	}
+	writer := csv.NewWriter(w)
+	if err := writer.Write(columns); err != nil {
+		return err
+	}
+	for _, item := range items {
+		content, err := itemContent(item)
+		if err != nil {
+			return err
+		}
+		row := make([]string, len(columns))
+		for i, column := range columns {
+			value, found, err := unstructured.NestedFieldNoCopy(content, strings.Split(column, ".")...)
+			if err != nil || !found {
+				continue
+			}
+			row[i] = fmt.Sprintf("%v", value)
+		}
+		if err := writer.Write(row); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// itemContent flattens the item into its generic form through json, it
+// works the same for typed objects and projected unstructured ones.
+func itemContent(item interface{}) (map[string]interface{}, error) {
+	data, err := json.Marshal(item)
+	if err != nil {
+		return nil, err
+	}
+	content := map[string]interface{}{}
+	if err := json.Unmarshal(data, &content); err != nil {
+		return nil, err
+	}
+	return content, nil
+}
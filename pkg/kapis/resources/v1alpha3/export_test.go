@@ -0,0 +1,71 @@
+/*
+Copyright 2022 The KubeSphere Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha3
+
+import (
+	"bytes"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestWriteCSV(t *testing.T) {
+	items := []interface{}{
+		&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "nginx-1", Namespace: "default"},
+			Status:     corev1.PodStatus{Phase: corev1.PodRunning},
+		},
+		&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "redis-1", Namespace: "kube-system"},
+		},
+	}
+
+	var buffer bytes.Buffer
+	if err := writeCSV(&buffer, items, []string{"metadata.name", "status.phase"}); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := "metadata.name,status.phase\n" +
+		"nginx-1,Running\n" +
+		"redis-1,\n"
+	if buffer.String() != expected {
+		t.Errorf("expected csv\n%s\ngot\n%s", expected, buffer.String())
+	}
+}
+
+func TestWriteCSVDefaultColumns(t *testing.T) {
+	items := []interface{}{
+		&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "settings", Namespace: "default"}},
+	}
+
+	var buffer bytes.Buffer
+	if err := writeCSV(&buffer, items, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	lines := bytes.Split(bytes.TrimSpace(buffer.Bytes()), []byte("\n"))
+	if len(lines) != 2 {
+		t.Fatalf("expected header and one row, got %q", buffer.String())
+	}
+	if string(lines[0]) != "metadata.namespace,metadata.name,metadata.creationTimestamp" {
+		t.Errorf("unexpected header %q", lines[0])
+	}
+	if !bytes.HasPrefix(lines[1], []byte("default,settings,")) {
+		t.Errorf("unexpected row %q", lines[1])
+	}
+}
@@ -19,19 +19,28 @@ package v1alpha3
 import (
 	"fmt"
 	"net/http"
+	"strconv"
 	"strings"
 
 	"github.com/emicklei/go-restful"
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/klog"
+	runtimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	securityv1alpha1 "kubesphere.io/api/security/v1alpha1"
+	tenantv1alpha1 "kubesphere.io/api/tenant/v1alpha1"
 
 	"kubesphere.io/kubesphere/pkg/api"
+	"kubesphere.io/kubesphere/pkg/apiserver/authorization/authorizer"
 	"kubesphere.io/kubesphere/pkg/apiserver/query"
+	requestctx "kubesphere.io/kubesphere/pkg/apiserver/request"
+	"kubesphere.io/kubesphere/pkg/controller/imagepolicy"
 	"kubesphere.io/kubesphere/pkg/models/components"
 	v2 "kubesphere.io/kubesphere/pkg/models/registries/v2"
 	"kubesphere.io/kubesphere/pkg/models/resources/v1alpha2"
 	resourcev1alpha2 "kubesphere.io/kubesphere/pkg/models/resources/v1alpha2/resource"
+	"kubesphere.io/kubesphere/pkg/models/resources/v1alpha3/customresourcedefinition"
 	resourcev1alpha3 "kubesphere.io/kubesphere/pkg/models/resources/v1alpha3/resource"
 	"kubesphere.io/kubesphere/pkg/server/params"
 )
@@ -41,22 +50,113 @@ type Handler struct {
 	resourcesGetterV1alpha2 *resourcev1alpha2.ResourceGetter
 	componentsGetter        components.ComponentsGetter
 	registryHelper          v2.RegistryHelper
+	crdInstances            customresourcedefinition.InstanceLister
+	authorizer              authorizer.Authorizer
+	// runtimeReader reads resources no informer getter covers, e.g.
+	// image policies, nil when only the api docs are generated
+	runtimeReader runtimeclient.Reader
 }
 
-func New(resourceGetterV1alpha3 *resourcev1alpha3.ResourceGetter, resourcesGetterV1alpha2 *resourcev1alpha2.ResourceGetter, componentsGetter components.ComponentsGetter) *Handler {
+func New(resourceGetterV1alpha3 *resourcev1alpha3.ResourceGetter, resourcesGetterV1alpha2 *resourcev1alpha2.ResourceGetter, componentsGetter components.ComponentsGetter, crdInstances customresourcedefinition.InstanceLister, authorizer authorizer.Authorizer, runtimeReader runtimeclient.Reader) *Handler {
 	return &Handler{
 		resourceGetterV1alpha3:  resourceGetterV1alpha3,
 		resourcesGetterV1alpha2: resourcesGetterV1alpha2,
 		componentsGetter:        componentsGetter,
 		registryHelper:          v2.NewRegistryHelper(),
+		crdInstances:            crdInstances,
+		authorizer:              authorizer,
+		runtimeReader:           runtimeReader,
 	}
 }
 
+const (
+	// defaultSearchLimit is the default cap of search matches per resource
+	// kind, the search powers a suggestion box so only a handful of
+	// matches per kind is useful.
+	defaultSearchLimit = 5
+	maxSearchLimit     = 50
+)
+
+// handleSearch queries all registered resource kinds, cluster scoped and
+// namespaced, for objects whose name or labels contain the keyword. Kinds
+// and namespaces the user is not allowed to list are left out.
+func (h *Handler) handleSearch(request *restful.Request, response *restful.Response) {
+	keyword := request.QueryParameter(query.ParameterName)
+	if keyword == "" {
+		api.HandleBadRequest(response, request, fmt.Errorf("keyword must not be empty"))
+		return
+	}
+
+	limit := defaultSearchLimit
+	if value, err := strconv.Atoi(request.QueryParameter(query.ParameterLimit)); err == nil && value > 0 {
+		limit = value
+	}
+	if limit > maxSearchLimit {
+		limit = maxSearchLimit
+	}
+
+	user, ok := requestctx.UserFrom(request.Request.Context())
+	if !ok {
+		api.HandleForbidden(response, request, fmt.Errorf("cannot obtain user info"))
+		return
+	}
+
+	filter := resourcev1alpha3.NewAuthorizingFilter(user, h.authorizer)
+	response.WriteEntity(h.resourceGetterV1alpha3.Search(request.Request.Context(), keyword, limit, filter))
+}
+
+// authorizingFilter builds the per-item RBAC filter for the request user,
+// nil when no user or authorizer is available, e.g. when only the api
+// docs are generated, so such lists stay unfiltered.
+func (h *Handler) authorizingFilter(request *restful.Request) resourcev1alpha3.ListFilter {
+	if h.authorizer == nil {
+		return nil
+	}
+	user, ok := requestctx.UserFrom(request.Request.Context())
+	if !ok {
+		return nil
+	}
+	return resourcev1alpha3.NewAuthorizingFilter(user, h.authorizer)
+}
+
+// handleListCustomResources lists instances of the CRD as a table, the
+// dynamic client reaches resources no informer getter is registered for.
+func (h *Handler) handleListCustomResources(request *restful.Request, response *restful.Response) {
+	if h.crdInstances == nil {
+		api.HandleBadRequest(response, request, fmt.Errorf("custom resource listing is not available"))
+		return
+	}
+
+	crd := request.PathParameter("crd")
+	namespace := request.PathParameter("namespace")
+	q := query.ParseQueryParameter(request)
+
+	result, err := h.crdInstances.ListInstances(crd, namespace, q)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			api.HandleNotFound(response, request, err)
+			return
+		}
+		klog.Error(err)
+		api.HandleError(response, request, err)
+		return
+	}
+
+	response.WriteEntity(result)
+}
+
 func (h *Handler) handleGetResources(request *restful.Request, response *restful.Response) {
 	namespace := request.PathParameter("namespace")
 	resourceType := request.PathParameter("resources")
 	name := request.PathParameter("name")
 
+	// include=owners,dependents expands the ownership relations of the
+	// object into a tree instead of returning it alone
+	if include := request.QueryParameter("include"); include != "" {
+		h.handleGetResourceTree(request, response, resourceType, namespace, name, include)
+		return
+	}
+
 	// use informers to retrieve resources
 	result, err := h.resourceGetterV1alpha3.Get(resourceType, namespace, name)
 	if err == nil {
@@ -88,6 +188,11 @@ func (h *Handler) handleGetResources(request *restful.Request, response *restful
 
 // handleListResources retrieves resources
 func (h *Handler) handleListResources(request *restful.Request, response *restful.Response) {
+	if format := strings.ToLower(request.QueryParameter(parameterExport)); format != "" {
+		h.handleExportResources(request, response, format)
+		return
+	}
+
 	query := query.ParseQueryParameter(request)
 	resourceType := request.PathParameter("resources")
 	namespace := request.PathParameter("namespace")
@@ -118,6 +223,175 @@ func (h *Handler) handleListResources(request *restful.Request, response *restfu
 	response.WriteEntity(result)
 }
 
+// handleGetResourceTree resolves the object and the ownership relations
+// the include parameter selects, so the console renders chains like
+// deployment-replicaset-pod without several round trips.
+func (h *Handler) handleGetResourceTree(request *restful.Request, response *restful.Response, resourceType, namespace, name, include string) {
+	var includeOwners, includeDependents bool
+	for _, relation := range strings.Split(include, ",") {
+		switch strings.TrimSpace(relation) {
+		case "owners":
+			includeOwners = true
+		case "dependents":
+			includeDependents = true
+		default:
+			api.HandleBadRequest(response, request, fmt.Errorf("invalid include value %q, expected owners or dependents", relation))
+			return
+		}
+	}
+
+	tree, err := h.resourceGetterV1alpha3.GetTree(resourceType, namespace, name, includeOwners, includeDependents)
+	if err != nil {
+		if err == resourcev1alpha3.ErrResourceNotSupported || errors.IsNotFound(err) {
+			api.HandleNotFound(response, request, err)
+			return
+		}
+		klog.Errorf("%s, resource type: %s", err, resourceType)
+		api.HandleError(response, request, err)
+		return
+	}
+
+	response.WriteEntity(tree)
+}
+
+// handleListWorkspacedResources lists a namespaced resource across every
+// namespace of the workspace, so workspace level pages do not fan out one
+// request per namespace. Namespaces the user may not list are left out.
+func (h *Handler) handleListWorkspacedResources(request *restful.Request, response *restful.Response) {
+	q := query.ParseQueryParameter(request)
+	resourceType := request.PathParameter("resources")
+	workspace := request.PathParameter("workspace")
+
+	result, err := h.resourceGetterV1alpha3.ListInWorkspace(resourceType, workspace, q, h.authorizingFilter(request))
+	if err != nil {
+		if err == resourcev1alpha3.ErrResourceNotSupported {
+			api.HandleNotFound(response, request, err)
+			return
+		}
+		klog.Errorf("%s, resource type: %s", err, resourceType)
+		api.HandleError(response, request, err)
+		return
+	}
+
+	response.WriteEntity(result)
+}
+
+// handleListMultiResources lists several resource kinds in one request,
+// e.g. resources=deployments,statefulsets,daemonsets, so overview pages
+// do not have to fan out one request per kind. Items the user may not
+// list are left out.
+func (h *Handler) handleListMultiResources(request *restful.Request, response *restful.Response) {
+	resourcesParam := request.QueryParameter("resources")
+	if resourcesParam == "" {
+		api.HandleBadRequest(response, request, fmt.Errorf("resources must not be empty"))
+		return
+	}
+	resourceTypes := make([]string, 0)
+	for _, resourceType := range strings.Split(resourcesParam, ",") {
+		if resourceType = strings.TrimSpace(resourceType); resourceType != "" {
+			resourceTypes = append(resourceTypes, resourceType)
+		}
+	}
+
+	q := query.ParseQueryParameter(request)
+	// the resources parameter selects the kinds, it is not a filter
+	delete(q.Filters, "resources")
+	namespace := request.PathParameter("namespace")
+
+	result, err := h.resourceGetterV1alpha3.ListMulti(request.Request.Context(), resourceTypes, namespace, q, h.authorizingFilter(request))
+	if err != nil {
+		if err == resourcev1alpha3.ErrResourceNotSupported {
+			api.HandleNotFound(response, request, err)
+			return
+		}
+		klog.Error(err)
+		api.HandleError(response, request, err)
+		return
+	}
+
+	response.WriteEntity(result)
+}
+
+// ImagePolicyViolation is a running container whose image no applicable
+// image policy allows.
+type ImagePolicyViolation struct {
+	Namespace string `json:"namespace"`
+	Workspace string `json:"workspace,omitempty"`
+	Pod       string `json:"pod"`
+	Image     string `json:"image"`
+}
+
+// handleListImagePolicyViolations lists running pods whose images the
+// image policies no longer allow, policies only take effect on admission
+// so workloads started before a policy keep running in violation.
+func (h *Handler) handleListImagePolicyViolations(request *restful.Request, response *restful.Response) {
+	if h.runtimeReader == nil {
+		api.HandleBadRequest(response, request, fmt.Errorf("image policy violation listing is not available"))
+		return
+	}
+
+	policies := &securityv1alpha1.ImagePolicyList{}
+	if err := h.runtimeReader.List(request.Request.Context(), policies); err != nil {
+		klog.Error(err)
+		api.HandleError(response, request, err)
+		return
+	}
+
+	result := api.ListResult{Items: make([]interface{}, 0)}
+	if len(policies.Items) == 0 {
+		response.WriteEntity(result)
+		return
+	}
+
+	pods, err := h.resourceGetterV1alpha3.List("pods", "", query.New())
+	if err != nil {
+		klog.Error(err)
+		api.HandleError(response, request, err)
+		return
+	}
+
+	// namespaces map onto workspaces, resolve each namespace only once
+	workspaces := make(map[string]string)
+	for _, item := range pods.Items {
+		pod, ok := item.(*v1.Pod)
+		if !ok || pod.Status.Phase == v1.PodSucceeded || pod.Status.Phase == v1.PodFailed {
+			continue
+		}
+		workspace, ok := workspaces[pod.Namespace]
+		if !ok {
+			workspace = h.workspaceOfNamespace(pod.Namespace)
+			workspaces[pod.Namespace] = workspace
+		}
+		for _, image := range imagepolicy.PodImages(&pod.Spec) {
+			if imagepolicy.Allowed(policies.Items, workspace, image) {
+				continue
+			}
+			result.Items = append(result.Items, ImagePolicyViolation{
+				Namespace: pod.Namespace,
+				Workspace: workspace,
+				Pod:       pod.Name,
+				Image:     image,
+			})
+		}
+	}
+	result.TotalItems = len(result.Items)
+
+	response.WriteEntity(result)
+}
+
+func (h *Handler) workspaceOfNamespace(name string) string {
+	obj, err := h.resourceGetterV1alpha3.Get("namespaces", "", name)
+	if err != nil {
+		klog.Warningf("failed to get namespace %s: %v", name, err)
+		return ""
+	}
+	namespace, ok := obj.(*v1.Namespace)
+	if !ok {
+		return ""
+	}
+	return namespace.Labels[tenantv1alpha1.WorkspaceLabel]
+}
+
 func (h *Handler) fallback(resourceType string, namespace string, q *query.Query) (*api.ListResult, error) {
 	orderBy := string(q.SortBy)
 	limit, offset := q.Pagination.Limit, q.Pagination.Offset
@@ -275,6 +549,116 @@ func (h *Handler) handleGetRepositoryTags(request *restful.Request, response *re
 	response.WriteHeaderAndJson(http.StatusOK, tags, restful.MIME_JSON)
 }
 
+// handleListRepositories lists all repositories of the given registry through
+// the catalog API, no paging.
+func (h *Handler) handleListRepositories(request *restful.Request, response *restful.Response) {
+	secretName := request.QueryParameter("secret")
+	namespace := request.PathParameter("namespace")
+	registry := request.QueryParameter("registry")
+	var secret *v1.Secret
+
+	if len(registry) == 0 {
+		api.HandleBadRequest(response, request, fmt.Errorf("empty registry name"))
+		return
+	}
+
+	if len(secretName) != 0 {
+		object, err := h.resourceGetterV1alpha3.Get("secrets", namespace, secretName)
+		if errors.IsNotFound(err) {
+			api.HandleNotFound(response, request, err)
+		}
+		secret = object.(*v1.Secret)
+	}
+
+	catalog, err := h.registryHelper.ListRepositories(secret, registry)
+	if err != nil {
+		canonicalizeRegistryError(request, response, err)
+		return
+	}
+
+	response.WriteHeaderAndJson(http.StatusOK, catalog, restful.MIME_JSON)
+}
+
+// handleGetImageDigest resolves the manifest digest of the given image reference.
+func (h *Handler) handleGetImageDigest(request *restful.Request, response *restful.Response) {
+	secretName := request.QueryParameter("secret")
+	namespace := request.PathParameter("namespace")
+	image := request.QueryParameter("image")
+	var secret *v1.Secret
+
+	if len(image) == 0 {
+		api.HandleBadRequest(response, request, fmt.Errorf("empty image name"))
+		return
+	}
+
+	if len(secretName) != 0 {
+		object, err := h.resourceGetterV1alpha3.Get("secrets", namespace, secretName)
+		if errors.IsNotFound(err) {
+			api.HandleNotFound(response, request, err)
+		}
+		secret = object.(*v1.Secret)
+	}
+
+	digest, err := h.registryHelper.ImageDigest(secret, image)
+	if err != nil {
+		canonicalizeRegistryError(request, response, err)
+		return
+	}
+
+	response.WriteHeaderAndJson(http.StatusOK, digest, restful.MIME_JSON)
+}
+
+// handleDiagnoseImagePull checks the images of containers stuck in an image
+// pull error against the registry, using the pull secrets of the pod, and
+// returns an actionable reason per container.
+func (h *Handler) handleDiagnoseImagePull(request *restful.Request, response *restful.Response) {
+	namespace := request.PathParameter("namespace")
+	podName := request.PathParameter("pod")
+
+	object, err := h.resourceGetterV1alpha3.Get("pods", namespace, podName)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			api.HandleNotFound(response, request, err)
+			return
+		}
+		api.HandleInternalError(response, request, err)
+		return
+	}
+	pod := object.(*v1.Pod)
+
+	var secrets []*v1.Secret
+	for _, reference := range pod.Spec.ImagePullSecrets {
+		secretObject, err := h.resourceGetterV1alpha3.Get("secrets", namespace, reference.Name)
+		if err != nil {
+			continue
+		}
+		secrets = append(secrets, secretObject.(*v1.Secret))
+	}
+
+	nodeArchitecture := ""
+	if pod.Spec.NodeName != "" {
+		if nodeObject, err := h.resourceGetterV1alpha3.Get("nodes", "", pod.Spec.NodeName); err == nil {
+			nodeArchitecture = nodeObject.(*v1.Node).Status.NodeInfo.Architecture
+		}
+	}
+
+	diagnoses := make([]v2.ImagePullDiagnosis, 0)
+	for _, status := range append(pod.Status.InitContainerStatuses, pod.Status.ContainerStatuses...) {
+		if status.State.Waiting == nil {
+			continue
+		}
+		reason := status.State.Waiting.Reason
+		if reason != "ImagePullBackOff" && reason != "ErrImagePull" && reason != "InvalidImageName" {
+			continue
+		}
+		diagnosis := h.registryHelper.DiagnoseImage(secrets, status.Image, nodeArchitecture)
+		diagnosis.Container = status.Name
+		diagnoses = append(diagnoses, diagnosis)
+	}
+
+	response.WriteHeaderAndJson(http.StatusOK, diagnoses, restful.MIME_JSON)
+}
+
 func canonicalizeRegistryError(request *restful.Request, response *restful.Response, err error) {
 	if strings.Contains(err.Error(), "Unauthorized") {
 		api.HandleUnauthorized(response, request, err)
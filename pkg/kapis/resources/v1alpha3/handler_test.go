@@ -43,6 +43,8 @@ import (
 	"kubesphere.io/kubesphere/pkg/informers"
 	"kubesphere.io/kubesphere/pkg/models/components"
 	resourcev1alpha2 "kubesphere.io/kubesphere/pkg/models/resources/v1alpha2/resource"
+	modelsv1alpha3 "kubesphere.io/kubesphere/pkg/models/resources/v1alpha3"
+	"kubesphere.io/kubesphere/pkg/models/resources/v1alpha3/namespace"
 	"kubesphere.io/kubesphere/pkg/models/resources/v1alpha3/resource"
 	resourcev1alpha3 "kubesphere.io/kubesphere/pkg/models/resources/v1alpha3/resource"
 )
@@ -71,7 +73,7 @@ func TestResourceV1alpha2Fallback(t *testing.T) {
 			},
 			expectedError: nil,
 			expected: &api.ListResult{
-				Items:      []interface{}{kubesphereNamespace, defaultNamespace},
+				Items:      []interface{}{withUsage(kubesphereNamespace), withUsage(defaultNamespace)},
 				TotalItems: 2,
 			},
 		},
@@ -90,7 +92,7 @@ func TestResourceV1alpha2Fallback(t *testing.T) {
 			},
 			expectedError: nil,
 			expected: &api.ListResult{
-				Items:      []interface{}{secretFoo2, secretFoo1},
+				Items:      []interface{}{unreferenced(secretFoo2), unreferenced(secretFoo1)},
 				TotalItems: 2,
 			},
 		},
@@ -113,6 +115,25 @@ func TestResourceV1alpha2Fallback(t *testing.T) {
 	}
 }
 
+// unreferenced is what the secret getter returns for a secret no pod references
+func unreferenced(secret *corev1.Secret) *corev1.Secret {
+	annotated := secret.DeepCopy()
+	annotated.Annotations = map[string]string{modelsv1alpha3.SafeToDeleteAnnotation: "true"}
+	return annotated
+}
+
+// the namespace getter annotates returned namespaces with their cached usage
+func withUsage(ns *corev1.Namespace) *corev1.Namespace {
+	annotated := ns.DeepCopy()
+	annotated.Annotations = map[string]string{
+		namespace.PodCountAnnotation:       "0",
+		namespace.CPURequestsAnnotation:    "0",
+		namespace.MemoryRequestsAnnotation: "0",
+		namespace.PVCStorageAnnotation:     "0",
+	}
+	return annotated
+}
+
 func listResources(namespace, resourceType string, query *query.Query, h *Handler) (*api.ListResult, error) {
 
 	result, err := h.resourceGetterV1alpha3.List(resourceType, namespace, query)
@@ -243,7 +264,7 @@ func prepare() (*Handler, error) {
 		}
 	}
 
-	handler := New(resourcev1alpha3.NewResourceGetter(fakeInformerFactory, nil), resourcev1alpha2.NewResourceGetter(fakeInformerFactory), components.NewComponentsGetter(fakeInformerFactory.KubernetesSharedInformerFactory()))
+	handler := New(resourcev1alpha3.NewResourceGetter(fakeInformerFactory, nil), resourcev1alpha2.NewResourceGetter(fakeInformerFactory), components.NewComponentsGetter(fakeInformerFactory.KubernetesSharedInformerFactory(), fakeInformerFactory.KubeSphereSharedInformerFactory()), nil, nil, nil)
 
 	return handler, nil
 }
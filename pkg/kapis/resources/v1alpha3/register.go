@@ -21,17 +21,26 @@ import (
 	restfulspec "github.com/emicklei/go-restful-openapi"
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/dynamic"
 	"sigs.k8s.io/controller-runtime/pkg/cache"
 
 	"kubesphere.io/kubesphere/pkg/api"
 	"kubesphere.io/kubesphere/pkg/api/resource/v1alpha2"
+	"kubesphere.io/kubesphere/pkg/apiserver/authorization/authorizer"
 	"kubesphere.io/kubesphere/pkg/apiserver/query"
 	"kubesphere.io/kubesphere/pkg/apiserver/runtime"
 	"kubesphere.io/kubesphere/pkg/informers"
 	"kubesphere.io/kubesphere/pkg/models/components"
 	v2 "kubesphere.io/kubesphere/pkg/models/registries/v2"
 	resourcev1alpha2 "kubesphere.io/kubesphere/pkg/models/resources/v1alpha2/resource"
+	"kubesphere.io/kubesphere/pkg/models/resources/v1alpha3/cached"
+	"kubesphere.io/kubesphere/pkg/models/resources/v1alpha3/cronjob"
+	"kubesphere.io/kubesphere/pkg/models/resources/v1alpha3/customresourcedefinition"
+	"kubesphere.io/kubesphere/pkg/models/resources/v1alpha3/ingress"
 	resourcev1alpha3 "kubesphere.io/kubesphere/pkg/models/resources/v1alpha3/resource"
+	simplecache "kubesphere.io/kubesphere/pkg/simple/client/cache"
+	"kubesphere.io/kubesphere/pkg/simple/client/monitoring"
 
 	"net/http"
 )
@@ -52,10 +61,39 @@ func Resource(resource string) schema.GroupResource {
 	return GroupVersion.WithResource(resource).GroupResource()
 }
 
-func AddToContainer(c *restful.Container, informerFactory informers.InformerFactory, cache cache.Cache) error {
+func AddToContainer(c *restful.Container, informerFactory informers.InformerFactory, cache cache.Cache, listSnapshotCache simplecache.Interface, dynamicClient dynamic.Interface, authorizer authorizer.Authorizer, monitoringClient monitoring.Interface, discoveryClient discovery.DiscoveryInterface) error {
 
 	webservice := runtime.NewWebService(GroupVersion)
-	handler := New(resourcev1alpha3.NewResourceGetter(informerFactory, cache), resourcev1alpha2.NewResourceGetter(informerFactory), components.NewComponentsGetter(informerFactory.KubernetesSharedInformerFactory()))
+	resourceGetter := resourcev1alpha3.NewResourceGetter(informerFactory, cache)
+	// back the heavy getters with the shared cache layer when a distributed
+	// cache backend is available, so multiple ks-apiserver replicas can
+	// share list snapshots instead of each holding full caches.
+	if listSnapshotCache != nil {
+		resourceGetter.EnableSharedCache(listSnapshotCache, cached.DefaultTTL)
+	}
+	// the inverted indexes resolving the search query parameter ride on the
+	// shared informers of the heavy kinds
+	resourceGetter.EnableSearchIndex(informerFactory)
+	// webhook getters report recent rejection counts when a monitoring
+	// backend is configured
+	if monitoringClient != nil {
+		resourceGetter.EnableWebhookMetrics(monitoringClient)
+	}
+	// modern clusters serve ingress under networking.k8s.io/v1, fall back
+	// to v1beta1 when discovery shows the cluster stopped there
+	if discoveryClient != nil && !ingress.ServesV1(discoveryClient) {
+		resourceGetter.UseLegacyIngress(informerFactory)
+	}
+	// same for cronjob, which moved to batch/v1 in kubernetes 1.21
+	if discoveryClient != nil && !cronjob.ServesV1(discoveryClient) {
+		resourceGetter.UseLegacyCronJob(informerFactory)
+	}
+	// the dynamic client is nil when only the api docs are generated
+	var crdInstances customresourcedefinition.InstanceLister
+	if dynamicClient != nil {
+		crdInstances = customresourcedefinition.NewInstanceLister(informerFactory.ApiExtensionSharedInformerFactory(), dynamicClient)
+	}
+	handler := New(resourceGetter, resourcev1alpha2.NewResourceGetter(informerFactory), components.NewComponentsGetter(informerFactory.KubernetesSharedInformerFactory(), informerFactory.KubeSphereSharedInformerFactory()), crdInstances, authorizer, cache)
 
 	webservice.Route(webservice.GET("/{resources}").
 		To(handler.handleListResources).
@@ -67,6 +105,11 @@ func AddToContainer(c *restful.Container, informerFactory informers.InformerFact
 		Param(webservice.QueryParameter(query.ParameterLimit, "limit").Required(false)).
 		Param(webservice.QueryParameter(query.ParameterAscending, "sort parameters, e.g. reverse=true").Required(false).DefaultValue("ascending=false")).
 		Param(webservice.QueryParameter(query.ParameterOrderBy, "sort parameters, e.g. orderBy=createTime")).
+		Param(webservice.QueryParameter(query.ParameterCollation, "name ordering when sorting by name, natural compares runs of digits numerically so app-2 sorts before app-10, a BCP 47 language tag such as en selects that locale's collation").Required(false)).
+		Param(webservice.QueryParameter(query.ParameterFields, "field paths the returned items are projected onto, e.g. fields=metadata.name,status.phase").Required(false)).
+		Param(webservice.QueryParameter(query.ParameterContinue, "cursor returned by a previous page, resumes the list after the item that page ended with").Required(false)).
+		Param(webservice.QueryParameter(query.ParameterSearch, "term matched against the name, labels, annotations and container images of the items, case-insensitively").Required(false)).
+		Param(webservice.QueryParameter("export", "export the whole filtered list as a downloadable attachment instead of a page, one of csv, yaml, json; the fields parameter selects the csv columns").Required(false)).
 		Returns(http.StatusOK, ok, api.ListResult{}))
 
 	webservice.Route(webservice.GET("/{resources}/{name}").
@@ -75,6 +118,7 @@ func AddToContainer(c *restful.Container, informerFactory informers.InformerFact
 		Doc("Cluster level resource").
 		Param(webservice.PathParameter("resources", "cluster level resource type, e.g. pods,jobs,configmaps,services.")).
 		Param(webservice.PathParameter("name", "the name of the clustered resources")).
+		Param(webservice.QueryParameter("include", "ownership relations to expand into a tree, a comma separated subset of owners and dependents").Required(false)).
 		Returns(http.StatusOK, api.StatusOK, nil))
 
 	webservice.Route(webservice.GET("/namespaces/{namespace}/{resources}").
@@ -88,7 +132,30 @@ func AddToContainer(c *restful.Container, informerFactory informers.InformerFact
 		Param(webservice.QueryParameter(query.ParameterLimit, "limit").Required(false)).
 		Param(webservice.QueryParameter(query.ParameterAscending, "sort parameters, e.g. reverse=true").Required(false).DefaultValue("ascending=false")).
 		Param(webservice.QueryParameter(query.ParameterOrderBy, "sort parameters, e.g. orderBy=createTime")).
+		Param(webservice.QueryParameter(query.ParameterCollation, "name ordering when sorting by name, natural compares runs of digits numerically so app-2 sorts before app-10, a BCP 47 language tag such as en selects that locale's collation").Required(false)).
 		Param(webservice.QueryParameter(query.ParameterFieldSelector, "field selector used for filtering, you can use the = , == and != operators with field selectors( = and == mean the same thing), e.g. fieldSelector=type=kubernetes.io/dockerconfigjson, multiple separated by comma").Required(false)).
+		Param(webservice.QueryParameter("redact", "redact=true masks the data values of listed secrets, only the keys remain visible; ignored by other resource types").Required(false)).
+		Param(webservice.QueryParameter(query.ParameterFields, "field paths the returned items are projected onto, e.g. fields=metadata.name,status.phase").Required(false)).
+		Param(webservice.QueryParameter(query.ParameterContinue, "cursor returned by a previous page, resumes the list after the item that page ended with").Required(false)).
+		Param(webservice.QueryParameter(query.ParameterSearch, "term matched against the name, labels, annotations and container images of the items, case-insensitively").Required(false)).
+		Param(webservice.QueryParameter("export", "export the whole filtered list as a downloadable attachment instead of a page, one of csv, yaml, json; the fields parameter selects the csv columns").Required(false)).
+		Returns(http.StatusOK, ok, api.ListResult{}))
+
+	webservice.Route(webservice.GET("/workspaces/{workspace}/{resources}").
+		To(handler.handleListWorkspacedResources).
+		Metadata(restfulspec.KeyOpenAPITags, []string{tagNamespacedResource}).
+		Doc("Workspace level resource query, merges the resource across all namespaces of the workspace").
+		Param(webservice.PathParameter("workspace", "the name of the workspace")).
+		Param(webservice.PathParameter("resources", "namespace level resource type, e.g. pods,jobs,configmaps,services.")).
+		Param(webservice.QueryParameter(query.ParameterName, "name used to do filtering").Required(false)).
+		Param(webservice.QueryParameter(query.ParameterPage, "page").Required(false).DataFormat("page=%d").DefaultValue("page=1")).
+		Param(webservice.QueryParameter(query.ParameterLimit, "limit").Required(false)).
+		Param(webservice.QueryParameter(query.ParameterAscending, "sort parameters, e.g. reverse=true").Required(false).DefaultValue("ascending=false")).
+		Param(webservice.QueryParameter(query.ParameterOrderBy, "sort parameters, e.g. orderBy=createTime")).
+		Param(webservice.QueryParameter(query.ParameterCollation, "name ordering when sorting by name, natural compares runs of digits numerically so app-2 sorts before app-10, a BCP 47 language tag such as en selects that locale's collation").Required(false)).
+		Param(webservice.QueryParameter(query.ParameterFields, "field paths the returned items are projected onto, e.g. fields=metadata.name,status.phase").Required(false)).
+		Param(webservice.QueryParameter(query.ParameterContinue, "cursor returned by a previous page, resumes the list after the item that page ended with").Required(false)).
+		Param(webservice.QueryParameter(query.ParameterSearch, "term matched against the name, labels, annotations and container images of the items, case-insensitively").Required(false)).
 		Returns(http.StatusOK, ok, api.ListResult{}))
 
 	webservice.Route(webservice.GET("/namespaces/{namespace}/{resources}/{name}").
@@ -98,8 +165,67 @@ func AddToContainer(c *restful.Container, informerFactory informers.InformerFact
 		Param(webservice.PathParameter("namespace", "the name of the project")).
 		Param(webservice.PathParameter("resources", "namespace level resource type, e.g. pods,jobs,configmaps,services.")).
 		Param(webservice.PathParameter("name", "the name of resource")).
+		Param(webservice.QueryParameter("include", "ownership relations to expand into a tree, a comma separated subset of owners and dependents").Required(false)).
 		Returns(http.StatusOK, ok, api.ListResult{}))
 
+	webservice.Route(webservice.POST("/{resources}/{name}/diff").
+		To(handler.handleDiffResource).
+		Metadata(restfulspec.KeyOpenAPITags, []string{tagClusteredResource}).
+		Doc("Diff the live cluster level resource against the manifest in the request body, or against the object named by compareTo when the body is empty.").
+		Param(webservice.PathParameter("resources", "cluster level resource type, e.g. nodes,clusterroles.")).
+		Param(webservice.PathParameter("name", "the name of the resource")).
+		Param(webservice.QueryParameter("compareTo", "name of another object of the same kind to diff against, e.g. another controller revision; ignored when a manifest body is given").Required(false)).
+		Returns(http.StatusOK, ok, DiffResult{}))
+
+	webservice.Route(webservice.POST("/namespaces/{namespace}/{resources}/{name}/diff").
+		To(handler.handleDiffResource).
+		Metadata(restfulspec.KeyOpenAPITags, []string{tagNamespacedResource}).
+		Doc("Diff the live namespaced resource against the manifest in the request body, or against the object named by compareTo when the body is empty.").
+		Param(webservice.PathParameter("namespace", "the name of the project")).
+		Param(webservice.PathParameter("resources", "namespace level resource type, e.g. deployments,configmaps.")).
+		Param(webservice.PathParameter("name", "the name of the resource")).
+		Param(webservice.QueryParameter("compareTo", "name of another object of the same kind to diff against, e.g. another controller revision; ignored when a manifest body is given").Required(false)).
+		Returns(http.StatusOK, ok, DiffResult{}))
+
+	webservice.Route(webservice.GET("/multiresources").
+		To(handler.handleListMultiResources).
+		Metadata(restfulspec.KeyOpenAPITags, []string{tagClusteredResource}).
+		Doc("List several cluster level resource kinds in one request").
+		Param(webservice.QueryParameter("resources", "comma separated resource types, e.g. resources=persistentvolumes,storageclasses").Required(true)).
+		Param(webservice.QueryParameter(query.ParameterName, "name used to do filtering").Required(false)).
+		Param(webservice.QueryParameter(query.ParameterPage, "page").Required(false).DataFormat("page=%d").DefaultValue("page=1")).
+		Param(webservice.QueryParameter(query.ParameterLimit, "limit").Required(false)).
+		Param(webservice.QueryParameter(query.ParameterAscending, "sort parameters, e.g. reverse=true").Required(false).DefaultValue("ascending=false")).
+		Param(webservice.QueryParameter(query.ParameterOrderBy, "sort parameters, e.g. orderBy=createTime")).
+		Returns(http.StatusOK, ok, map[string]api.ListResult{}))
+
+	webservice.Route(webservice.GET("/namespaces/{namespace}/multiresources").
+		To(handler.handleListMultiResources).
+		Metadata(restfulspec.KeyOpenAPITags, []string{tagNamespacedResource}).
+		Doc("List several namespace level resource kinds in one request").
+		Param(webservice.PathParameter("namespace", "the name of the project")).
+		Param(webservice.QueryParameter("resources", "comma separated resource types, e.g. resources=deployments,statefulsets,daemonsets").Required(true)).
+		Param(webservice.QueryParameter(query.ParameterName, "name used to do filtering").Required(false)).
+		Param(webservice.QueryParameter(query.ParameterPage, "page").Required(false).DataFormat("page=%d").DefaultValue("page=1")).
+		Param(webservice.QueryParameter(query.ParameterLimit, "limit").Required(false)).
+		Param(webservice.QueryParameter(query.ParameterAscending, "sort parameters, e.g. reverse=true").Required(false).DefaultValue("ascending=false")).
+		Param(webservice.QueryParameter(query.ParameterOrderBy, "sort parameters, e.g. orderBy=createTime")).
+		Returns(http.StatusOK, ok, map[string]api.ListResult{}))
+
+	webservice.Route(webservice.GET("/imagepolicyviolations").
+		To(handler.handleListImagePolicyViolations).
+		Metadata(restfulspec.KeyOpenAPITags, []string{tagClusteredResource}).
+		Doc("List running pods whose images the image policies no longer allow").
+		Returns(http.StatusOK, ok, api.ListResult{}))
+
+	webservice.Route(webservice.GET("/search").
+		To(handler.handleSearch).
+		Metadata(restfulspec.KeyOpenAPITags, []string{tagClusteredResource}).
+		Doc("Search all resource kinds for objects whose name or labels contain the keyword, kinds and namespaces the user may not list are left out.").
+		Param(webservice.QueryParameter(query.ParameterName, "keyword matched against resource names and labels").Required(true)).
+		Param(webservice.QueryParameter(query.ParameterLimit, "max matches per resource kind, defaults to 5").Required(false)).
+		Returns(http.StatusOK, ok, []resourcev1alpha3.SearchResult{}))
+
 	webservice.Route(webservice.GET("/components").
 		To(handler.handleGetComponents).
 		Metadata(restfulspec.KeyOpenAPITags, []string{tagComponentStatus}).
@@ -145,6 +271,57 @@ func AddToContainer(c *restful.Container, informerFactory informers.InformerFact
 		Doc("List repository tags, this is an experimental API, use it by your own caution.").
 		Returns(http.StatusOK, ok, v2.RepositoryTags{}))
 
+	webservice.Route(webservice.GET("/namespaces/{namespace}/repositories").
+		To(handler.handleListRepositories).
+		Param(webservice.PathParameter("namespace", "Namespace of the image repository secret.").Required(true)).
+		Param(webservice.QueryParameter("registry", "Registry to query, e.g. harbor.devops.io:30280.").Required(true)).
+		Param(webservice.QueryParameter("secret", "Secret name of the image repository credential, left empty means anonymous fetch.").Required(false)).
+		Metadata(restfulspec.KeyOpenAPITags, []string{tagNamespacedResource}).
+		Doc("List all repositories of the registry, this is an experimental API, use it by your own caution.").
+		Returns(http.StatusOK, ok, v2.RepositoryCatalog{}))
+
+	webservice.Route(webservice.GET("/namespaces/{namespace}/imagedigest").
+		To(handler.handleGetImageDigest).
+		Param(webservice.PathParameter("namespace", "Namespace of the image repository secret.").Required(true)).
+		Param(webservice.QueryParameter("image", "Image reference to resolve, e.g. kubesphere/ks-apiserver:v3.1.1.").Required(true)).
+		Param(webservice.QueryParameter("secret", "Secret name of the image repository credential, left empty means anonymous fetch.").Required(false)).
+		Metadata(restfulspec.KeyOpenAPITags, []string{tagNamespacedResource}).
+		Doc("Resolve the manifest digest of an image reference.").
+		Returns(http.StatusOK, ok, v2.ImageDigest{}))
+
+	webservice.Route(webservice.GET("/namespaces/{namespace}/pods/{pod}/imagepulldiagnosis").
+		To(handler.handleDiagnoseImagePull).
+		Param(webservice.PathParameter("namespace", "Namespace of the pod.").Required(true)).
+		Param(webservice.PathParameter("pod", "Name of the pod stuck in an image pull error.").Required(true)).
+		Metadata(restfulspec.KeyOpenAPITags, []string{tagNamespacedResource}).
+		Doc("Diagnose the containers of the pod that fail to pull their image by checking the image against the registry with the pull secrets of the pod.").
+		Returns(http.StatusOK, ok, []v2.ImagePullDiagnosis{}))
+
+	webservice.Route(webservice.GET("/customresourcedefinitions/{crd}/instances").
+		To(handler.handleListCustomResources).
+		Metadata(restfulspec.KeyOpenAPITags, []string{tagClusteredResource}).
+		Doc("List instances of the custom resource definition as a table with its schema-driven printer columns.").
+		Param(webservice.PathParameter("crd", "name of the custom resource definition, e.g. workspaces.tenant.kubesphere.io")).
+		Param(webservice.QueryParameter(query.ParameterName, "name used to do filtering").Required(false)).
+		Param(webservice.QueryParameter(query.ParameterPage, "page").Required(false).DataFormat("page=%d").DefaultValue("page=1")).
+		Param(webservice.QueryParameter(query.ParameterLimit, "limit").Required(false)).
+		Param(webservice.QueryParameter(query.ParameterAscending, "sort parameters, e.g. reverse=true").Required(false).DefaultValue("ascending=false")).
+		Param(webservice.QueryParameter(query.ParameterOrderBy, "sort parameters, e.g. orderBy=createTime")).
+		Returns(http.StatusOK, ok, customresourcedefinition.CustomResourceList{}))
+
+	webservice.Route(webservice.GET("/namespaces/{namespace}/customresourcedefinitions/{crd}/instances").
+		To(handler.handleListCustomResources).
+		Metadata(restfulspec.KeyOpenAPITags, []string{tagNamespacedResource}).
+		Doc("List instances of the namespaced custom resource definition in the namespace as a table with its schema-driven printer columns.").
+		Param(webservice.PathParameter("namespace", "the name of the project")).
+		Param(webservice.PathParameter("crd", "name of the custom resource definition, e.g. applications.app.k8s.io")).
+		Param(webservice.QueryParameter(query.ParameterName, "name used to do filtering").Required(false)).
+		Param(webservice.QueryParameter(query.ParameterPage, "page").Required(false).DataFormat("page=%d").DefaultValue("page=1")).
+		Param(webservice.QueryParameter(query.ParameterLimit, "limit").Required(false)).
+		Param(webservice.QueryParameter(query.ParameterAscending, "sort parameters, e.g. reverse=true").Required(false).DefaultValue("ascending=false")).
+		Param(webservice.QueryParameter(query.ParameterOrderBy, "sort parameters, e.g. orderBy=createTime")).
+		Returns(http.StatusOK, ok, customresourcedefinition.CustomResourceList{}))
+
 	c.Add(webservice)
 
 	return nil
@@ -219,7 +219,7 @@ func (h *tenantHandler) CreateWorkspaceTemplate(req *restful.Request, resp *rest
 		api.HandleForbidden(resp, req, err)
 	}
 
-	created, err := h.tenant.CreateWorkspaceTemplate(requestUser, &workspace)
+	created, err := h.tenant.CreateWorkspaceTemplate(requestUser, &workspace, api.DryRun(req)...)
 
 	if err != nil {
 		klog.Error(err)
@@ -289,7 +289,7 @@ func (h *tenantHandler) UpdateWorkspaceTemplate(req *restful.Request, resp *rest
 		api.HandleForbidden(resp, req, err)
 	}
 
-	updated, err := h.tenant.UpdateWorkspaceTemplate(requestUser, &workspace)
+	updated, err := h.tenant.UpdateWorkspaceTemplate(requestUser, &workspace, api.DryRun(req)...)
 
 	if err != nil {
 		klog.Error(err)
@@ -678,3 +678,19 @@ func (h *tenantHandler) DescribeWorkspaceResourceQuota(r *restful.Request, respo
 
 	response.WriteEntity(resourceQuota)
 }
+
+func (h *tenantHandler) DescribeWorkspaceDevOpsQuota(r *restful.Request, response *restful.Response) {
+	workspaceName := r.PathParameter("workspace")
+
+	quota, err := h.tenant.DescribeDevOpsQuota(workspaceName)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			api.HandleNotFound(response, r, err)
+			return
+		}
+		api.HandleInternalError(response, r, err)
+		return
+	}
+
+	response.WriteEntity(quota)
+}
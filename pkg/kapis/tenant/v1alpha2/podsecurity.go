@@ -0,0 +1,104 @@
+/*
+Copyright 2022 The KubeSphere Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha2
+
+import (
+	"github.com/emicklei/go-restful"
+	"k8s.io/apimachinery/pkg/api/errors"
+
+	"kubesphere.io/kubesphere/pkg/api"
+	"kubesphere.io/kubesphere/pkg/models/tenant"
+)
+
+func (h *tenantHandler) DescribeNamespacePodSecurity(request *restful.Request, response *restful.Response) {
+	workspaceName := request.PathParameter("workspace")
+	namespaceName := request.PathParameter("namespace")
+
+	podSecurity, err := h.tenant.DescribeNamespacePodSecurity(workspaceName, namespaceName)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			api.HandleNotFound(response, request, err)
+			return
+		}
+		api.HandleInternalError(response, request, err)
+		return
+	}
+
+	response.WriteEntity(podSecurity)
+}
+
+func (h *tenantHandler) UpdateNamespacePodSecurity(request *restful.Request, response *restful.Response) {
+	workspaceName := request.PathParameter("workspace")
+	namespaceName := request.PathParameter("namespace")
+
+	var podSecurity tenant.PodSecurity
+	if err := request.ReadEntity(&podSecurity); err != nil {
+		api.HandleBadRequest(response, request, err)
+		return
+	}
+
+	result, err := h.tenant.UpdateNamespacePodSecurity(workspaceName, namespaceName, &podSecurity)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			api.HandleNotFound(response, request, err)
+			return
+		}
+		api.HandleError(response, request, err)
+		return
+	}
+
+	response.WriteEntity(result)
+}
+
+func (h *tenantHandler) UpdateWorkspacePodSecurity(request *restful.Request, response *restful.Response) {
+	workspaceName := request.PathParameter("workspace")
+
+	var podSecurity tenant.PodSecurity
+	if err := request.ReadEntity(&podSecurity); err != nil {
+		api.HandleBadRequest(response, request, err)
+		return
+	}
+
+	updated, err := h.tenant.UpdateWorkspacePodSecurity(workspaceName, &podSecurity)
+	if err != nil {
+		api.HandleError(response, request, err)
+		return
+	}
+
+	response.WriteEntity(updated)
+}
+
+func (h *tenantHandler) PodSecurityDryRun(request *restful.Request, response *restful.Response) {
+	workspaceName := request.PathParameter("workspace")
+	namespaceName := request.PathParameter("namespace")
+	level := request.QueryParameter("level")
+	if level == "" {
+		level = tenant.PodSecurityLevelBaseline
+	}
+
+	report, err := h.tenant.PodSecurityDryRun(workspaceName, namespaceName, level)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			api.HandleNotFound(response, request, err)
+			return
+		}
+		api.HandleError(response, request, err)
+		return
+	}
+
+	response.WriteEntity(report)
+}
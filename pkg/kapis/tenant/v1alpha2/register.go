@@ -45,6 +45,7 @@ import (
 	"kubesphere.io/kubesphere/pkg/models/monitoring"
 	"kubesphere.io/kubesphere/pkg/models/openpitrix"
 	resourcev1alpha3 "kubesphere.io/kubesphere/pkg/models/resources/v1alpha3/resource"
+	"kubesphere.io/kubesphere/pkg/models/tenant"
 	"kubesphere.io/kubesphere/pkg/server/errors"
 	"kubesphere.io/kubesphere/pkg/simple/client/auditing"
 	"kubesphere.io/kubesphere/pkg/simple/client/events"
@@ -80,6 +81,7 @@ func AddToContainer(c *restful.Container, factory informers.InformerFactory, k8s
 
 	ws.Route(ws.POST("/workspaces").
 		To(handler.CreateWorkspaceTemplate).
+		Param(ws.QueryParameter("dryRun", "dryRun=All runs validation and admission without persisting the workspace")).
 		Reads(tenantv1alpha2.WorkspaceTemplate{}).
 		Returns(http.StatusOK, api.StatusOK, tenantv1alpha2.WorkspaceTemplate{}).
 		Doc("Create workspace.").
@@ -95,6 +97,7 @@ func AddToContainer(c *restful.Container, factory informers.InformerFactory, k8s
 	ws.Route(ws.PUT("/workspaces/{workspace}").
 		To(handler.UpdateWorkspaceTemplate).
 		Param(ws.PathParameter("workspace", "workspace name")).
+		Param(ws.QueryParameter("dryRun", "dryRun=All runs validation and admission without persisting the workspace")).
 		Reads(tenantv1alpha2.WorkspaceTemplate{}).
 		Returns(http.StatusOK, api.StatusOK, tenantv1alpha2.WorkspaceTemplate{}).
 		Doc("Update workspace.").
@@ -179,6 +182,40 @@ func AddToContainer(c *restful.Container, factory informers.InformerFactory, k8s
 		Returns(http.StatusOK, api.StatusOK, corev1.Namespace{}).
 		Metadata(restfulspec.KeyOpenAPITags, []string{constants.NamespaceTag}))
 
+	ws.Route(ws.GET("/workspaces/{workspace}/namespaces/{namespace}/podsecurity").
+		To(handler.DescribeNamespacePodSecurity).
+		Param(ws.PathParameter("workspace", "workspace name")).
+		Param(ws.PathParameter("namespace", "project name")).
+		Doc("Retrieve the pod security standard levels of the namespace.").
+		Returns(http.StatusOK, api.StatusOK, tenant.PodSecurity{}).
+		Metadata(restfulspec.KeyOpenAPITags, []string{constants.NamespaceTag}))
+
+	ws.Route(ws.PUT("/workspaces/{workspace}/namespaces/{namespace}/podsecurity").
+		To(handler.UpdateNamespacePodSecurity).
+		Param(ws.PathParameter("workspace", "workspace name")).
+		Param(ws.PathParameter("namespace", "project name")).
+		Doc("Update the pod security standard levels of the namespace, an empty level removes the label.").
+		Reads(tenant.PodSecurity{}).
+		Returns(http.StatusOK, api.StatusOK, tenant.PodSecurity{}).
+		Metadata(restfulspec.KeyOpenAPITags, []string{constants.NamespaceTag}))
+
+	ws.Route(ws.PUT("/workspaces/{workspace}/podsecurity").
+		To(handler.UpdateWorkspacePodSecurity).
+		Param(ws.PathParameter("workspace", "workspace name")).
+		Doc("Apply the pod security standard levels to every namespace of the workspace.").
+		Reads(tenant.PodSecurity{}).
+		Returns(http.StatusOK, api.StatusOK, []string{}).
+		Metadata(restfulspec.KeyOpenAPITags, []string{constants.WorkspaceTag}))
+
+	ws.Route(ws.GET("/workspaces/{workspace}/namespaces/{namespace}/podsecuritydryrun").
+		To(handler.PodSecurityDryRun).
+		Param(ws.PathParameter("workspace", "workspace name")).
+		Param(ws.PathParameter("namespace", "project name")).
+		Param(ws.QueryParameter("level", "pod security level to evaluate, defaults to baseline").Required(false)).
+		Doc("Report which existing pods enforcing the level would block, without changing anything.").
+		Returns(http.StatusOK, api.StatusOK, tenant.PodSecurityDryRunReport{}).
+		Metadata(restfulspec.KeyOpenAPITags, []string{constants.NamespaceTag}))
+
 	ws.Route(ws.DELETE("/workspaces/{workspace}/namespaces/{namespace}").
 		To(handler.DeleteNamespace).
 		Param(ws.PathParameter("workspace", "workspace name")).
@@ -376,6 +413,13 @@ func AddToContainer(c *restful.Container, factory informers.InformerFactory, k8s
 		Doc("Describe resource quota.").
 		Metadata(restfulspec.KeyOpenAPITags, []string{constants.WorkspaceTag}))
 
+	ws.Route(ws.GET("/workspaces/{workspace}/devopsquota").
+		To(handler.DescribeWorkspaceDevOpsQuota).
+		Param(ws.PathParameter("workspace", "workspace name")).
+		Returns(http.StatusOK, api.StatusOK, tenant.DevOpsQuota{}).
+		Doc("Describe devops resource usage and quota of the workspace.").
+		Metadata(restfulspec.KeyOpenAPITags, []string{constants.WorkspaceTag}))
+
 	c.Add(ws)
 	return nil
 }
@@ -19,18 +19,26 @@ package v1alpha2
 import (
 	"errors"
 	"net/http"
+	"strings"
 
 	"kubesphere.io/kubesphere/pkg/api"
+	"kubesphere.io/kubesphere/pkg/apiserver/auditing"
 	"kubesphere.io/kubesphere/pkg/apiserver/authorization/authorizer"
 	requestctx "kubesphere.io/kubesphere/pkg/apiserver/request"
 
 	"github.com/emicklei/go-restful"
+	"github.com/google/uuid"
 	"github.com/gorilla/websocket"
+	"k8s.io/apiserver/pkg/authentication/user"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 	"k8s.io/klog"
 
+	"kubesphere.io/kubesphere/pkg/constants"
+	"kubesphere.io/kubesphere/pkg/models/filebrowser"
+	"kubesphere.io/kubesphere/pkg/models/kubectl"
 	"kubesphere.io/kubesphere/pkg/models/terminal"
+	servererrors "kubesphere.io/kubesphere/pkg/server/errors"
 )
 
 var upgrader = websocket.Upgrader{
@@ -41,25 +49,28 @@ var upgrader = websocket.Upgrader{
 }
 
 type terminalHandler struct {
-	terminaler terminal.Interface
-	authorizer authorizer.Authorizer
+	terminaler     terminal.Interface
+	fileBrowser    filebrowser.Interface
+	authorizer     authorizer.Authorizer
+	kubectlOptions *kubectl.Options
 }
 
-func newTerminalHandler(client kubernetes.Interface, authorizer authorizer.Authorizer, config *rest.Config, options *terminal.Options) *terminalHandler {
+func newTerminalHandler(client kubernetes.Interface, authorizer authorizer.Authorizer, config *rest.Config, options *terminal.Options, kubectlOptions *kubectl.Options) *terminalHandler {
+	if kubectlOptions == nil {
+		kubectlOptions = kubectl.NewOptions()
+	}
 	return &terminalHandler{
-		authorizer: authorizer,
-		terminaler: terminal.NewTerminaler(client, config, options),
+		authorizer:     authorizer,
+		terminaler:     terminal.NewTerminaler(client, config, options),
+		fileBrowser:    filebrowser.New(client, config),
+		kubectlOptions: kubectlOptions,
 	}
 }
 
-func (t *terminalHandler) handleTerminalSession(request *restful.Request, response *restful.Response) {
-	namespace := request.PathParameter("namespace")
-	podName := request.PathParameter("pod")
-	containerName := request.QueryParameter("container")
-	shell := request.QueryParameter("shell")
-
-	user, _ := requestctx.UserFrom(request.Request.Context())
-
+// authorizePodExec checks whether the user may exec into pods of the
+// namespace, which also gates file access through the exec subresource. It
+// writes the error response itself and reports whether to continue.
+func (t *terminalHandler) authorizePodExec(user user.Info, namespace string, request *restful.Request, response *restful.Response) bool {
 	createPodsExec := authorizer.AttributesRecord{
 		User:            user,
 		Verb:            "create",
@@ -73,23 +84,158 @@ func (t *terminalHandler) handleTerminalSession(request *restful.Request, respon
 	decision, reason, err := t.authorizer.Authorize(createPodsExec)
 	if err != nil {
 		api.HandleInternalError(response, request, err)
-		return
+		return false
 	}
 
 	if decision != authorizer.DecisionAllow {
 		api.HandleForbidden(response, request, errors.New(reason))
+		return false
+	}
+	return true
+}
+
+// auditSession links the audit event of the request, when auditing is
+// enabled, to the terminal session it starts by a generated session id.
+// Session recordings carry the same id, so an audit trail leads to the
+// recording of what was typed.
+func auditSession(request *restful.Request, container string) string {
+	sessionID := uuid.New().String()
+	if e := requestctx.AuditEventFrom(request.Request.Context()); e != nil {
+		if e.Annotations == nil {
+			e.Annotations = make(map[string]string)
+		}
+		e.Annotations[auditing.SessionIDAnnotation] = sessionID
+		if container != "" {
+			e.Annotations[auditing.ContainerAnnotation] = container
+		}
+	}
+	return sessionID
+}
+
+func (t *terminalHandler) handleTerminalSession(request *restful.Request, response *restful.Response) {
+	namespace := request.PathParameter("namespace")
+	podName := request.PathParameter("pod")
+	containerName := request.QueryParameter("container")
+	shell := request.QueryParameter("shell")
+
+	user, _ := requestctx.UserFrom(request.Request.Context())
+
+	if !t.authorizePodExec(user, namespace, request, response) {
 		return
 	}
 
+	sessionID := auditSession(request, containerName)
+	klog.V(2).Infof("terminal session %s: user %s execs into pod %s/%s", sessionID, user.GetName(), namespace, podName)
+
 	conn, err := upgrader.Upgrade(response.ResponseWriter, request.Request, nil)
 	if err != nil {
 		klog.Warning(err)
 		return
 	}
 
+	// kubectl terminal pods are shared per user, apply the per-user
+	// concurrent session limit before attaching.
+	if namespace == constants.KubeSphereControlNamespace && strings.HasPrefix(podName, "kubectl-") {
+		username := user.GetName()
+		if err := kubectl.AcquireSession(username, t.kubectlOptions.MaxSessionsPerUser); err != nil {
+			klog.Warning(err)
+			conn.Close()
+			return
+		}
+		defer kubectl.ReleaseSession(username)
+	}
+
 	t.terminaler.HandleSession(shell, namespace, podName, containerName, conn)
 }
 
+func (t *terminalHandler) handleDebugSession(request *restful.Request, response *restful.Response) {
+	namespace := request.PathParameter("namespace")
+	podName := request.PathParameter("pod")
+	targetContainer := request.QueryParameter("container")
+	image := request.QueryParameter("image")
+
+	user, _ := requestctx.UserFrom(request.Request.Context())
+
+	// attaching a debug container grants the same access as exec
+	if !t.authorizePodExec(user, namespace, request, response) {
+		return
+	}
+
+	sessionID := auditSession(request, targetContainer)
+	klog.V(2).Infof("terminal session %s: user %s debugs pod %s/%s", sessionID, user.GetName(), namespace, podName)
+
+	conn, err := upgrader.Upgrade(response.ResponseWriter, request.Request, nil)
+	if err != nil {
+		klog.Warning(err)
+		return
+	}
+
+	t.terminaler.HandleDebugSession(user.GetName(), namespace, podName, targetContainer, image, conn)
+}
+
+func (t *terminalHandler) handleListFiles(request *restful.Request, response *restful.Response) {
+	namespace := request.PathParameter("namespace")
+	podName := request.PathParameter("pod")
+	containerName := request.QueryParameter("container")
+	path := request.QueryParameter("path")
+
+	user, _ := requestctx.UserFrom(request.Request.Context())
+	if !t.authorizePodExec(user, namespace, request, response) {
+		return
+	}
+
+	files, err := t.fileBrowser.ListFiles(namespace, podName, containerName, path)
+	if err != nil {
+		api.HandleBadRequest(response, request, err)
+		return
+	}
+
+	response.WriteAsJson(files)
+}
+
+func (t *terminalHandler) handleDownloadFile(request *restful.Request, response *restful.Response) {
+	namespace := request.PathParameter("namespace")
+	podName := request.PathParameter("pod")
+	containerName := request.QueryParameter("container")
+	path := request.QueryParameter("path")
+
+	user, _ := requestctx.UserFrom(request.Request.Context())
+	if !t.authorizePodExec(user, namespace, request, response) {
+		return
+	}
+
+	klog.V(2).Infof("user %s downloads %s from pod %s/%s", user.GetName(), path, namespace, podName)
+
+	response.AddHeader("Content-Type", "application/x-tar")
+	if err := t.fileBrowser.DownloadFile(namespace, podName, containerName, path, filebrowser.DefaultMaxFileSize, response.ResponseWriter); err != nil {
+		api.HandleBadRequest(response, request, err)
+		return
+	}
+}
+
+func (t *terminalHandler) handleUploadFile(request *restful.Request, response *restful.Response) {
+	namespace := request.PathParameter("namespace")
+	podName := request.PathParameter("pod")
+	containerName := request.QueryParameter("container")
+	path := request.QueryParameter("path")
+	fileName := request.QueryParameter("file")
+
+	user, _ := requestctx.UserFrom(request.Request.Context())
+	if !t.authorizePodExec(user, namespace, request, response) {
+		return
+	}
+
+	klog.V(2).Infof("user %s uploads %s to %s in pod %s/%s", user.GetName(), fileName, path, namespace, podName)
+
+	err := t.fileBrowser.UploadFile(namespace, podName, containerName, path, fileName, request.Request.Body, filebrowser.DefaultMaxFileSize)
+	if err != nil {
+		api.HandleBadRequest(response, request, err)
+		return
+	}
+
+	response.WriteAsJson(servererrors.None)
+}
+
 func (t *terminalHandler) handleShellAccessToNode(request *restful.Request, response *restful.Response) {
 	nodename := request.PathParameter("nodename")
 
@@ -115,6 +261,9 @@ func (t *terminalHandler) handleShellAccessToNode(request *restful.Request, resp
 		return
 	}
 
+	sessionID := auditSession(request, "")
+	klog.V(2).Infof("terminal session %s: user %s opens a shell on node %s", sessionID, user.GetName(), nodename)
+
 	conn, err := upgrader.Upgrade(response.ResponseWriter, request.Request, nil)
 	if err != nil {
 		klog.Warning(err)
@@ -28,6 +28,8 @@ import (
 	"kubesphere.io/kubesphere/pkg/apiserver/runtime"
 	"kubesphere.io/kubesphere/pkg/constants"
 	"kubesphere.io/kubesphere/pkg/models"
+	"kubesphere.io/kubesphere/pkg/models/filebrowser"
+	"kubesphere.io/kubesphere/pkg/models/kubectl"
 	"kubesphere.io/kubesphere/pkg/models/terminal"
 )
 
@@ -37,11 +39,11 @@ const (
 
 var GroupVersion = schema.GroupVersion{Group: GroupName, Version: "v1alpha2"}
 
-func AddToContainer(c *restful.Container, client kubernetes.Interface, authorizer authorizer.Authorizer, config *rest.Config, options *terminal.Options) error {
+func AddToContainer(c *restful.Container, client kubernetes.Interface, authorizer authorizer.Authorizer, config *rest.Config, options *terminal.Options, kubectlOptions *kubectl.Options) error {
 
 	webservice := runtime.NewWebService(GroupVersion)
 
-	handler := newTerminalHandler(client, authorizer, config, options)
+	handler := newTerminalHandler(client, authorizer, config, options, kubectlOptions)
 
 	webservice.Route(webservice.GET("/namespaces/{namespace}/pods/{pod}/exec").
 		To(handler.handleTerminalSession).
@@ -51,6 +53,46 @@ func AddToContainer(c *restful.Container, client kubernetes.Interface, authorize
 		Metadata(restfulspec.KeyOpenAPITags, []string{constants.TerminalTag}).
 		Writes(models.PodInfo{}))
 
+	webservice.Route(webservice.GET("/namespaces/{namespace}/pods/{pod}/debug").
+		To(handler.handleDebugSession).
+		Param(webservice.PathParameter("namespace", "namespace of which the pod located in")).
+		Param(webservice.PathParameter("pod", "name of the pod")).
+		Param(webservice.QueryParameter("container", "name of the container to debug, shares its process namespace when set")).
+		Param(webservice.QueryParameter("image", "image of the ephemeral debug container, must be in the allow-list, defaults to the configured terminal image")).
+		Doc("attach an ephemeral debug container to the pod and create a terminal session").
+		Metadata(restfulspec.KeyOpenAPITags, []string{constants.TerminalTag}).
+		Writes(models.PodInfo{}))
+
+	webservice.Route(webservice.GET("/namespaces/{namespace}/pods/{pod}/files").
+		To(handler.handleListFiles).
+		Param(webservice.PathParameter("namespace", "namespace of which the pod located in")).
+		Param(webservice.PathParameter("pod", "name of the pod")).
+		Param(webservice.QueryParameter("container", "name of the container, defaults to the first container")).
+		Param(webservice.QueryParameter("path", "directory to list, defaults to /")).
+		Doc("list files of a directory inside a running container").
+		Metadata(restfulspec.KeyOpenAPITags, []string{constants.TerminalTag}).
+		Writes([]filebrowser.FileInfo{}))
+
+	webservice.Route(webservice.GET("/namespaces/{namespace}/pods/{pod}/files/download").
+		Produces("application/x-tar", restful.MIME_JSON).
+		To(handler.handleDownloadFile).
+		Param(webservice.PathParameter("namespace", "namespace of which the pod located in")).
+		Param(webservice.PathParameter("pod", "name of the pod")).
+		Param(webservice.QueryParameter("container", "name of the container, defaults to the first container")).
+		Param(webservice.QueryParameter("path", "file or directory to download").Required(true)).
+		Doc("download a file or directory from a running container as a tar archive").
+		Metadata(restfulspec.KeyOpenAPITags, []string{constants.TerminalTag}))
+
+	webservice.Route(webservice.POST("/namespaces/{namespace}/pods/{pod}/files/upload").
+		To(handler.handleUploadFile).
+		Param(webservice.PathParameter("namespace", "namespace of which the pod located in")).
+		Param(webservice.PathParameter("pod", "name of the pod")).
+		Param(webservice.QueryParameter("container", "name of the container, defaults to the first container")).
+		Param(webservice.QueryParameter("path", "destination directory inside the container").Required(true)).
+		Param(webservice.QueryParameter("file", "name the uploaded file is stored as").Required(true)).
+		Doc("upload the request body as a file into a running container").
+		Metadata(restfulspec.KeyOpenAPITags, []string{constants.TerminalTag}))
+
 	//Add new Route to support shell access to the node
 	webservice.Route(webservice.GET("/nodes/{nodename}/exec").
 		To(handler.handleShellAccessToNode).
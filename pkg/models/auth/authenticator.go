@@ -40,9 +40,11 @@ import (
 )
 
 var (
-	RateLimitExceededError  = fmt.Errorf("auth rate limit exceeded")
-	IncorrectPasswordError  = fmt.Errorf("incorrect password")
-	AccountIsNotActiveError = fmt.Errorf("account is not active")
+	RateLimitExceededError     = fmt.Errorf("auth rate limit exceeded")
+	IncorrectPasswordError     = fmt.Errorf("incorrect password")
+	AccountIsNotActiveError    = fmt.Errorf("account is not active")
+	ChallengeRequiredError     = fmt.Errorf("challenge token required")
+	InvalidChallengeTokenError = fmt.Errorf("invalid challenge token")
 )
 
 // PasswordAuthenticator is an interface implemented by authenticator which take a
@@ -0,0 +1,126 @@
+/*
+
+ Copyright 2022 The KubeSphere Authors.
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+
+*/
+
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/klog"
+
+	iamv1alpha2 "kubesphere.io/api/iam/v1alpha2"
+
+	"kubesphere.io/kubesphere/pkg/apiserver/authentication"
+	iamv1alpha2listers "kubesphere.io/kubesphere/pkg/client/listers/iam/v1alpha2"
+)
+
+// LoginChallenger is the pluggable hook guarding the password grant
+// against brute force attacks. Once a user accumulates enough failed
+// attempts the grant additionally requires a challenge token, e.g. a
+// captcha response or proof of work, validated by an external verifier.
+type LoginChallenger interface {
+	// Required reports whether the next login attempt of the user must
+	// present a challenge token.
+	Required(username string) bool
+	// Verify validates the challenge token with the configured verifier.
+	Verify(ctx context.Context, challengeToken string) error
+}
+
+type loginChallenger struct {
+	loginRecordLister iamv1alpha2listers.LoginRecordLister
+	options           *authentication.LoginChallengeOptions
+	rateLimiterWindow time.Duration
+	client            *http.Client
+}
+
+// NewLoginChallenger returns nil when the challenge hook is disabled, the
+// password grant then proceeds unguarded as before.
+func NewLoginChallenger(loginRecordLister iamv1alpha2listers.LoginRecordLister, options *authentication.Options) LoginChallenger {
+	if options.LoginChallenge == nil || !options.LoginChallenge.Enabled {
+		return nil
+	}
+	return &loginChallenger{
+		loginRecordLister: loginRecordLister,
+		options:           options.LoginChallenge,
+		rateLimiterWindow: options.AuthenticateRateLimiterDuration,
+		client:            &http.Client{Timeout: options.LoginChallenge.Timeout},
+	}
+}
+
+func (l *loginChallenger) Required(username string) bool {
+	selector := labels.SelectorFromSet(labels.Set{iamv1alpha2.UserReferenceLabel: username})
+	records, err := l.loginRecordLister.List(selector)
+	if err != nil {
+		klog.Error(err)
+		return false
+	}
+	failed := 0
+	for _, record := range records {
+		if record.Spec.Success {
+			continue
+		}
+		if time.Since(record.CreationTimestamp.Time) > l.rateLimiterWindow {
+			continue
+		}
+		failed++
+	}
+	return failed >= l.options.FailedLoginThreshold
+}
+
+// challengeVerification is the response the verifier replies with, the
+// shape follows the common captcha siteverify endpoints.
+type challengeVerification struct {
+	Success bool   `json:"success"`
+	Reason  string `json:"reason,omitempty"`
+}
+
+func (l *loginChallenger) Verify(ctx context.Context, challengeToken string) error {
+	form := url.Values{"token": {challengeToken}}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, l.options.VerifyURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	resp, err := l.client.Do(req)
+	if err != nil {
+		klog.Error(err)
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("challenge verifier returned status %s", resp.Status)
+	}
+	var verification challengeVerification
+	if err = json.NewDecoder(resp.Body).Decode(&verification); err != nil {
+		return err
+	}
+	if !verification.Success {
+		if verification.Reason != "" {
+			return fmt.Errorf("%s: %s", InvalidChallengeTokenError, verification.Reason)
+		}
+		return InvalidChallengeTokenError
+	}
+	return nil
+}
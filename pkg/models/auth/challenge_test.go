@@ -0,0 +1,117 @@
+/*
+
+ Copyright 2022 The KubeSphere Authors.
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+
+*/
+
+package auth
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	iamv1alpha2 "kubesphere.io/api/iam/v1alpha2"
+
+	"kubesphere.io/kubesphere/pkg/apiserver/authentication"
+	fakeks "kubesphere.io/kubesphere/pkg/client/clientset/versioned/fake"
+	ksinformers "kubesphere.io/kubesphere/pkg/client/informers/externalversions"
+)
+
+func failedLoginRecord(name, username string, age time.Duration) *iamv1alpha2.LoginRecord {
+	return &iamv1alpha2.LoginRecord{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              name,
+			Labels:            map[string]string{iamv1alpha2.UserReferenceLabel: username},
+			CreationTimestamp: metav1.NewTime(time.Now().Add(-age)),
+		},
+		Spec: iamv1alpha2.LoginRecordSpec{
+			Type:    iamv1alpha2.Token,
+			Success: false,
+			Reason:  IncorrectPasswordError.Error(),
+		},
+	}
+}
+
+func TestLoginChallengerRequired(t *testing.T) {
+	options := authentication.NewOptions()
+	options.LoginChallenge.Enabled = true
+	options.LoginChallenge.FailedLoginThreshold = 2
+	options.LoginChallenge.VerifyURL = "http://localhost/verify"
+
+	ksClient := fakeks.NewSimpleClientset()
+	ksInformerFactory := ksinformers.NewSharedInformerFactory(ksClient, 0)
+	informer := ksInformerFactory.Iam().V1alpha2().LoginRecords().Informer()
+	if err := informer.GetIndexer().Add(failedLoginRecord("admin-1", "admin", time.Minute)); err != nil {
+		t.Fatal(err)
+	}
+	if err := informer.GetIndexer().Add(failedLoginRecord("admin-2", "admin", time.Minute)); err != nil {
+		t.Fatal(err)
+	}
+	// records outside the rate limiter window are ignored
+	if err := informer.GetIndexer().Add(failedLoginRecord("alice-1", "alice", time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+
+	challenger := NewLoginChallenger(ksInformerFactory.Iam().V1alpha2().LoginRecords().Lister(), options)
+	if challenger == nil {
+		t.Fatal("expected challenger to be created")
+	}
+	if !challenger.Required("admin") {
+		t.Error("expected challenge to be required for admin")
+	}
+	if challenger.Required("alice") {
+		t.Error("expected no challenge for stale failures")
+	}
+	if challenger.Required("bob") {
+		t.Error("expected no challenge without failed attempts")
+	}
+
+	options.LoginChallenge.Enabled = false
+	if NewLoginChallenger(nil, options) != nil {
+		t.Error("expected nil challenger when disabled")
+	}
+}
+
+func TestLoginChallengerVerify(t *testing.T) {
+	verifier := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		if r.PostFormValue("token") == "valid" {
+			fmt.Fprint(w, `{"success":true}`)
+			return
+		}
+		fmt.Fprint(w, `{"success":false,"reason":"token expired"}`)
+	}))
+	defer verifier.Close()
+
+	options := authentication.NewOptions()
+	options.LoginChallenge.Enabled = true
+	options.LoginChallenge.VerifyURL = verifier.URL
+
+	challenger := NewLoginChallenger(nil, options)
+	if err := challenger.Verify(context.Background(), "valid"); err != nil {
+		t.Errorf("expected valid token to verify, got %v", err)
+	}
+	if err := challenger.Verify(context.Background(), "bogus"); err == nil {
+		t.Error("expected invalid token to be rejected")
+	}
+}
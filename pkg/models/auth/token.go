@@ -21,8 +21,11 @@ package auth
 import (
 	"errors"
 	"fmt"
+	"strings"
 	"time"
 
+	"gopkg.in/square/go-jose.v2"
+
 	"kubesphere.io/kubesphere/pkg/apiserver/authentication"
 
 	"k8s.io/klog"
@@ -43,6 +46,15 @@ type TokenManagementInterface interface {
 	RevokeAllUserTokens(username string) error
 	// Keys hold encryption and signing keys.
 	Keys() *token.Keys
+	// PublicKeys lists the public keys of all signing key generations
+	// still validating tokens.
+	PublicKeys() []jose.JSONWebKey
+	// RotateRefreshToken makes the redeemed refresh token one-time-use,
+	// remembering it as used for the rest of its lifetime
+	RotateRefreshToken(username, refreshToken string) error
+	// RevokeOnRefreshTokenReuse reports whether the refresh token was
+	// already redeemed, revoking the whole session family when it was
+	RevokeOnRefreshTokenReuse(refreshToken string) bool
 }
 
 type tokenOperator struct {
@@ -123,6 +135,56 @@ func (t *tokenOperator) Keys() *token.Keys {
 	return t.issuer.Keys()
 }
 
+func (t *tokenOperator) PublicKeys() []jose.JSONWebKey {
+	return t.issuer.PublicKeys()
+}
+
+// RotateRefreshToken revokes the redeemed refresh token and remembers it as
+// used for the rest of its lifetime, so a later redemption of the same token
+// can be told apart from an expired one. Rotation needs the token cache and
+// is skipped when tokens never expire.
+func (t *tokenOperator) RotateRefreshToken(username, refreshToken string) error {
+	if t.options.OAuthOptions.AccessTokenMaxAge == 0 {
+		return nil
+	}
+	if err := t.cache.Del(fmt.Sprintf("kubesphere:user:%s:token:%s", username, refreshToken)); err != nil {
+		klog.Error(err)
+		return err
+	}
+	key := fmt.Sprintf("kubesphere:user:%s:used-refresh-token:%s", username, refreshToken)
+	lifetime := t.options.OAuthOptions.AccessTokenMaxAge + t.options.OAuthOptions.AccessTokenInactivityTimeout
+	if err := t.cache.Set(key, refreshToken, lifetime); err != nil {
+		klog.Error(err)
+		return err
+	}
+	return nil
+}
+
+// RevokeOnRefreshTokenReuse reports whether the refresh token was already
+// redeemed. Refresh tokens are one-time-use, presenting a rotated out token
+// means either the client or an attacker holds a stolen copy, and the two
+// cannot be told apart, so every outstanding token of the user is revoked.
+func (t *tokenOperator) RevokeOnRefreshTokenReuse(refreshToken string) bool {
+	pattern := fmt.Sprintf("kubesphere:user:*:used-refresh-token:%s", refreshToken)
+	keys, err := t.cache.Keys(pattern)
+	if err != nil {
+		klog.Error(err)
+		return false
+	}
+	if len(keys) == 0 {
+		return false
+	}
+	// the username is embedded in the cache key
+	if parts := strings.Split(keys[0], ":"); len(parts) > 2 {
+		username := parts[2]
+		klog.Warningf("refresh token reuse detected for user %s, revoking all tokens", username)
+		if err := t.RevokeAllUserTokens(username); err != nil {
+			klog.Error(err)
+		}
+	}
+	return true
+}
+
 // tokenCacheValidate verify that the token is in the cache
 func (t *tokenOperator) tokenCacheValidate(username, token string) error {
 	key := fmt.Sprintf("kubesphere:user:%s:token:%s", username, token)
@@ -0,0 +1,96 @@
+/*
+Copyright 2022 The KubeSphere Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package auth
+
+import (
+	"testing"
+
+	"k8s.io/apiserver/pkg/authentication/user"
+
+	"kubesphere.io/kubesphere/pkg/apiserver/authentication"
+	"kubesphere.io/kubesphere/pkg/apiserver/authentication/oauth"
+	"kubesphere.io/kubesphere/pkg/apiserver/authentication/token"
+	"kubesphere.io/kubesphere/pkg/simple/client/cache"
+)
+
+func TestRefreshTokenRotation(t *testing.T) {
+	options := authentication.NewOptions()
+	options.JwtSecret = "kubesphere"
+	options.OAuthOptions = oauth.NewOptions()
+
+	issuer, err := token.NewIssuer(options)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cacheClient, err := cache.NewInMemoryCache(nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	operator := NewTokenOperator(cacheClient, issuer, options)
+
+	admin := &user.DefaultInfo{Name: "admin"}
+	accessToken, err := operator.IssueTo(&token.IssueRequest{
+		User:      admin,
+		Claims:    token.Claims{TokenType: token.AccessToken},
+		ExpiresIn: options.OAuthOptions.AccessTokenMaxAge,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	refreshToken, err := operator.IssueTo(&token.IssueRequest{
+		User:      admin,
+		Claims:    token.Claims{TokenType: token.RefreshToken},
+		ExpiresIn: options.OAuthOptions.AccessTokenMaxAge + options.OAuthOptions.AccessTokenInactivityTimeout,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err = operator.Verify(refreshToken); err != nil {
+		t.Fatal(err)
+	}
+	if operator.RevokeOnRefreshTokenReuse(refreshToken) {
+		t.Fatal("expected no reuse before the token was rotated")
+	}
+
+	// first redemption rotates the token out
+	if err = operator.RotateRefreshToken(admin.Name, refreshToken); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = operator.Verify(refreshToken); err == nil {
+		t.Error("expected rotated out refresh token to be rejected")
+	}
+
+	// a second redemption is reuse and revokes the session family
+	if !operator.RevokeOnRefreshTokenReuse(refreshToken) {
+		t.Fatal("expected reuse of a rotated out refresh token to be detected")
+	}
+	if _, err = operator.Verify(accessToken); err == nil {
+		t.Error("expected the access token to be revoked after reuse detection")
+	}
+
+	// an unrelated garbage token is not reuse
+	if operator.RevokeOnRefreshTokenReuse("unknown") {
+		t.Error("expected no reuse detection for unknown tokens")
+	}
+
+	// rotation is a no-op when tokens never expire and are not cached
+	options.OAuthOptions.AccessTokenMaxAge = 0
+	if err = operator.RotateRefreshToken(admin.Name, refreshToken); err != nil {
+		t.Errorf("expected rotation to be skipped without a token cache, got %v", err)
+	}
+}
@@ -0,0 +1,145 @@
+/*
+Copyright 2022 The KubeSphere Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certificates
+
+import (
+	"context"
+	"fmt"
+
+	certificatesv1 "k8s.io/api/certificates/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	approvedByOperatorReason = "ApprovedByOperator"
+	deniedByOperatorReason   = "DeniedByOperator"
+)
+
+// PendingRequest is a certificate signing request awaiting a decision,
+// reduced to what an approver needs to judge it.
+type PendingRequest struct {
+	Name              string                    `json:"name"`
+	Requester         string                    `json:"requester"`
+	Groups            []string                  `json:"groups,omitempty"`
+	SignerName        string                    `json:"signerName"`
+	Usages            []certificatesv1.KeyUsage `json:"usages,omitempty"`
+	CreationTimestamp metav1.Time               `json:"creationTimestamp"`
+}
+
+// ApprovalOperator lists pending certificate signing requests and records
+// approve or deny decisions on them.
+type ApprovalOperator interface {
+	ListPending() ([]PendingRequest, error)
+	Approve(name, approver, reason string) error
+	Deny(name, approver, reason string) error
+}
+
+type approvalOperator struct {
+	client kubernetes.Interface
+}
+
+func NewApprovalOperator(client kubernetes.Interface) ApprovalOperator {
+	return &approvalOperator{client: client}
+}
+
+func (o *approvalOperator) ListPending() ([]PendingRequest, error) {
+	requests, err := o.client.CertificatesV1().CertificateSigningRequests().List(context.Background(), metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	pending := make([]PendingRequest, 0)
+	for _, request := range requests.Items {
+		if decided(&request) {
+			continue
+		}
+		pending = append(pending, PendingRequest{
+			Name:              request.Name,
+			Requester:         request.Spec.Username,
+			Groups:            request.Spec.Groups,
+			SignerName:        request.Spec.SignerName,
+			Usages:            request.Spec.Usages,
+			CreationTimestamp: request.CreationTimestamp,
+		})
+	}
+	return pending, nil
+}
+
+func (o *approvalOperator) Approve(name, approver, reason string) error {
+	return o.decide(name, approver, reason, certificatesv1.CertificateApproved)
+}
+
+func (o *approvalOperator) Deny(name, approver, reason string) error {
+	return o.decide(name, approver, reason, certificatesv1.CertificateDenied)
+}
+
+func (o *approvalOperator) decide(name, approver, reason string, decision certificatesv1.RequestConditionType) error {
+	request, err := o.client.CertificatesV1().CertificateSigningRequests().Get(context.Background(), name, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	if decided(request) {
+		return alreadyDecidedError{name: name}
+	}
+
+	conditionReason := approvedByOperatorReason
+	if decision == certificatesv1.CertificateDenied {
+		conditionReason = deniedByOperatorReason
+	}
+	message := fmt.Sprintf("decided by %s", approver)
+	if reason != "" {
+		message = fmt.Sprintf("%s: %s", message, reason)
+	}
+	request.Status.Conditions = append(request.Status.Conditions, certificatesv1.CertificateSigningRequestCondition{
+		Type:           decision,
+		Status:         corev1.ConditionTrue,
+		Reason:         conditionReason,
+		Message:        message,
+		LastUpdateTime: metav1.Now(),
+	})
+
+	_, err = o.client.CertificatesV1().CertificateSigningRequests().UpdateApproval(context.Background(), name, request, metav1.UpdateOptions{})
+	return err
+}
+
+type alreadyDecidedError struct {
+	name string
+}
+
+func (e alreadyDecidedError) Error() string {
+	return fmt.Sprintf("certificate signing request %s has already been decided", e.name)
+}
+
+// IsAlreadyDecided reports whether err means the request already carried
+// a decision when a new one was attempted.
+func IsAlreadyDecided(err error) bool {
+	_, ok := err.(alreadyDecidedError)
+	return ok
+}
+
+// decided reports whether the request already carries an approval or
+// denial condition, such requests are immutable for approvers.
+func decided(request *certificatesv1.CertificateSigningRequest) bool {
+	for _, condition := range request.Status.Conditions {
+		if condition.Type == certificatesv1.CertificateApproved || condition.Type == certificatesv1.CertificateDenied {
+			return true
+		}
+	}
+	return false
+}
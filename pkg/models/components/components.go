@@ -18,16 +18,23 @@ package components
 
 import (
 	"fmt"
+	"strings"
 
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/client-go/informers"
 	"k8s.io/klog"
+	clusterv1alpha1 "kubesphere.io/api/cluster/v1alpha1"
 
 	"kubesphere.io/kubesphere/pkg/api/resource/v1alpha2"
+	ksinformers "kubesphere.io/kubesphere/pkg/client/informers/externalversions"
 	"kubesphere.io/kubesphere/pkg/constants"
 )
 
+// versionLabel is the recommended kubernetes label carrying the component
+// version, image tags are used as a fallback when it is absent.
+const versionLabel = "app.kubernetes.io/version"
+
 type ComponentsGetter interface {
 	GetComponentStatus(name string) (v1alpha2.ComponentStatus, error)
 	GetSystemHealthStatus() (v1alpha2.HealthStatus, error)
@@ -36,10 +43,13 @@ type ComponentsGetter interface {
 
 type componentsGetter struct {
 	informers informers.SharedInformerFactory
+
+	// ksInformers is optional, member cluster agent status is skipped when nil
+	ksInformers ksinformers.SharedInformerFactory
 }
 
-func NewComponentsGetter(informers informers.SharedInformerFactory) ComponentsGetter {
-	return &componentsGetter{informers: informers}
+func NewComponentsGetter(informers informers.SharedInformerFactory, ksInformers ksinformers.SharedInformerFactory) ComponentsGetter {
+	return &componentsGetter{informers: informers, ksInformers: ksInformers}
 }
 
 func (c *componentsGetter) GetComponentStatus(name string) (v1alpha2.ComponentStatus, error) {
@@ -77,12 +87,7 @@ func (c *componentsGetter) GetComponentStatus(name string) (v1alpha2.ComponentSt
 		HealthyBackends: 0,
 		TotalBackends:   0,
 	}
-	for _, pod := range pods {
-		component.TotalBackends++
-		if pod.Status.Phase == corev1.PodRunning && isAllContainersReady(pod) {
-			component.HealthyBackends++
-		}
-	}
+	fillBackendStatus(&component, pods)
 	return component, nil
 }
 
@@ -95,6 +100,44 @@ func isAllContainersReady(pod *corev1.Pod) bool {
 	return true
 }
 
+// fillBackendStatus aggregates readiness, restarts and the reported version
+// of the backing pods into the component status.
+func fillBackendStatus(component *v1alpha2.ComponentStatus, pods []*corev1.Pod) {
+	for _, pod := range pods {
+		component.TotalBackends++
+		if pod.Status.Phase == corev1.PodRunning && isAllContainersReady(pod) {
+			component.HealthyBackends++
+		}
+
+		if component.Version == "" {
+			component.Version = podVersion(pod)
+		}
+
+		for _, containerStatus := range pod.Status.ContainerStatuses {
+			component.RestartCount += containerStatus.RestartCount
+			if terminated := containerStatus.LastTerminationState.Terminated; terminated != nil &&
+				terminated.FinishedAt.After(component.LatestRestartTime) {
+				component.LatestRestartTime = terminated.FinishedAt.Time
+			}
+		}
+	}
+}
+
+// podVersion extracts the component version from the recommended version
+// label, falling back to the image tag of the first container.
+func podVersion(pod *corev1.Pod) string {
+	if version := pod.Labels[versionLabel]; version != "" {
+		return version
+	}
+	if len(pod.Spec.Containers) > 0 {
+		image := pod.Spec.Containers[0].Image
+		if index := strings.LastIndex(image, ":"); index > strings.LastIndex(image, "/") {
+			return image[index+1:]
+		}
+	}
+	return ""
+}
+
 func (c *componentsGetter) GetSystemHealthStatus() (v1alpha2.HealthStatus, error) {
 
 	status := v1alpha2.HealthStatus{}
@@ -107,6 +150,9 @@ func (c *componentsGetter) GetSystemHealthStatus() (v1alpha2.HealthStatus, error
 
 	status.KubeSphereComponents = components
 
+	// get member cluster agents status
+	status.MemberClusterStatus = c.getMemberClusterStatus()
+
 	// get node status
 	nodes, err := c.informers.Core().V1().Nodes().Lister().List(labels.Everything())
 	if err != nil {
@@ -132,6 +178,44 @@ func (c *componentsGetter) GetSystemHealthStatus() (v1alpha2.HealthStatus, error
 
 }
 
+// getMemberClusterStatus reports the readiness of every member cluster agent,
+// the host cluster itself is left out.
+func (c *componentsGetter) getMemberClusterStatus() []v1alpha2.ClusterAgentStatus {
+	if c.ksInformers == nil {
+		return nil
+	}
+
+	clusters, err := c.ksInformers.Cluster().V1alpha1().Clusters().Lister().List(labels.Everything())
+	if err != nil {
+		klog.Errorln(err)
+		return nil
+	}
+
+	var memberClusters []v1alpha2.ClusterAgentStatus
+	for _, cluster := range clusters {
+		if _, ok := cluster.Labels[clusterv1alpha1.HostCluster]; ok {
+			continue
+		}
+
+		agentStatus := v1alpha2.ClusterAgentStatus{
+			Cluster:           cluster.Name,
+			KubernetesVersion: cluster.Status.KubernetesVersion,
+		}
+
+		for _, condition := range cluster.Status.Conditions {
+			if condition.Type == clusterv1alpha1.ClusterReady {
+				agentStatus.Ready = condition.Status == corev1.ConditionTrue
+				agentStatus.LastTransitionTime = condition.LastTransitionTime.Time
+				break
+			}
+		}
+
+		memberClusters = append(memberClusters, agentStatus)
+	}
+
+	return memberClusters
+}
+
 func (c *componentsGetter) GetAllComponentsStatus() ([]v1alpha2.ComponentStatus, error) {
 
 	components := make([]v1alpha2.ComponentStatus, 0)
@@ -170,12 +254,7 @@ func (c *componentsGetter) GetAllComponentsStatus() ([]v1alpha2.ComponentStatus,
 				continue
 			}
 
-			for _, pod := range pods {
-				component.TotalBackends++
-				if pod.Status.Phase == corev1.PodRunning && isAllContainersReady(pod) {
-					component.HealthyBackends++
-				}
-			}
+			fillBackendStatus(&component, pods)
 
 			components = append(components, component)
 		}
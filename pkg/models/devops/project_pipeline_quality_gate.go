@@ -0,0 +1,83 @@
+/*
+Copyright 2022 The KubeSphere Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package devops
+
+import (
+	sonargo "github.com/kubesphere/sonargo/sonar"
+	"k8s.io/klog"
+
+	devopsv1alpha3 "kubesphere.io/api/devops/v1alpha3"
+
+	"kubesphere.io/kubesphere/pkg/simple/client/devops"
+	"kubesphere.io/kubesphere/pkg/simple/client/sonarqube"
+)
+
+// Quality gate statuses reported by sonarqube, see
+// api/qualitygates/project_status for details.
+const (
+	QualityGateStatusOK    = "OK"
+	QualityGateStatusError = "ERROR"
+)
+
+// PipelineQualityGate is the per-run quality gate summary shown by the console,
+// it carries the raw gate status plus whether the project policy blocks the
+// deploy stage of the run.
+type PipelineQualityGate struct {
+	Status       string                                     `json:"status"`
+	BlockDeploy  bool                                       `json:"blockDeploy"`
+	ProjectGates []*sonargo.QualitygatesProjectStatusObject `json:"projectGates,omitempty"`
+}
+
+// GetPipelineQualityGate retrieves the quality gate status of the last build of
+// the given pipeline and evaluates it against the project-level policy.
+func (g *pipelineSonarGetter) GetPipelineQualityGate(project *devopsv1alpha3.DevOpsProject, pipelineId string) (*PipelineQualityGate, error) {
+	build, err := g.GetProjectPipelineBuildByType(project.Name, pipelineId, devops.LastBuild)
+	if err != nil {
+		klog.Errorf("%+v", err)
+		return nil, err
+	}
+
+	gate := &PipelineQualityGate{}
+	for _, action := range build.Actions {
+		if action.ClassName != sonarqube.SonarAnalysisActionClass {
+			continue
+		}
+		projectStatus, err := g.GetQualityGateByTaskId(action.SonarTaskId)
+		if err != nil {
+			klog.Errorf("%+v", err)
+			return nil, err
+		}
+		gate.ProjectGates = append(gate.ProjectGates, projectStatus)
+		if projectStatus.ProjectStatus != nil && projectStatus.ProjectStatus.Status != QualityGateStatusOK {
+			gate.Status = QualityGateStatusError
+		}
+	}
+	if gate.Status == "" && len(gate.ProjectGates) > 0 {
+		gate.Status = QualityGateStatusOK
+	}
+	gate.BlockDeploy = gate.Status == QualityGateStatusError && qualityGateBlocksDeploy(project)
+	return gate, nil
+}
+
+// qualityGateBlocksDeploy reports whether the project opted in to block the
+// deploy stage when the quality gate fails.
+func qualityGateBlocksDeploy(project *devopsv1alpha3.DevOpsProject) bool {
+	if project == nil {
+		return false
+	}
+	return project.Annotations[devopsv1alpha3.DevOpsProjectQualityGatePolicyAnnoKey] == devopsv1alpha3.QualityGatePolicyBlockDeploy
+}
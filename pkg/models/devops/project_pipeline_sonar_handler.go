@@ -22,6 +22,8 @@ import (
 	"github.com/emicklei/go-restful"
 	"k8s.io/klog"
 
+	devopsv1alpha3 "kubesphere.io/api/devops/v1alpha3"
+
 	"kubesphere.io/kubesphere/pkg/server/errors"
 	"kubesphere.io/kubesphere/pkg/simple/client/devops"
 	"kubesphere.io/kubesphere/pkg/simple/client/sonarqube"
@@ -30,6 +32,7 @@ import (
 type PipelineSonarGetter interface {
 	GetPipelineSonar(projectId, pipelineId string) ([]*sonarqube.SonarStatus, error)
 	GetMultiBranchPipelineSonar(projectId, pipelineId, branchId string) ([]*sonarqube.SonarStatus, error)
+	GetPipelineQualityGate(project *devopsv1alpha3.DevOpsProject, pipelineId string) (*PipelineQualityGate, error)
 }
 type pipelineSonarGetter struct {
 	devops.BuildGetter
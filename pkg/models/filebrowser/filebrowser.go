@@ -0,0 +1,215 @@
+/*
+Copyright 2022 The KubeSphere Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package filebrowser lists and copies files of running containers over the
+// exec subresource with kubectl cp semantics, no agent is required in the
+// container beyond a tar binary.
+package filebrowser
+
+import (
+	"archive/tar"
+	"bytes"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/remotecommand"
+)
+
+// DefaultMaxFileSize bounds uploads and downloads, large transfers should go
+// through a volume instead of the apiserver.
+const DefaultMaxFileSize = int64(100 << 20)
+
+// FileInfo describes one entry of a container directory listing.
+type FileInfo struct {
+	Name  string `json:"name" description:"file name"`
+	Mode  string `json:"mode" description:"permission string as printed by ls -l"`
+	Size  int64  `json:"size" description:"file size in bytes"`
+	IsDir bool   `json:"isDir" description:"whether the entry is a directory"`
+}
+
+type Interface interface {
+	// ListFiles lists the entries of a directory inside the container.
+	ListFiles(namespace, pod, container, path string) ([]FileInfo, error)
+
+	// DownloadFile streams the file or directory as a tar archive, the
+	// transfer is aborted once it exceeds the size limit.
+	DownloadFile(namespace, pod, container, path string, sizeLimit int64, writer io.Writer) error
+
+	// UploadFile stores the content as a file inside the destination
+	// directory of the container.
+	UploadFile(namespace, pod, container, destDir, fileName string, content io.Reader, sizeLimit int64) error
+}
+
+type fileBrowser struct {
+	client kubernetes.Interface
+	config *rest.Config
+}
+
+func New(client kubernetes.Interface, config *rest.Config) Interface {
+	return &fileBrowser{client: client, config: config}
+}
+
+func (f *fileBrowser) ListFiles(namespace, pod, container, path string) ([]FileInfo, error) {
+	if path == "" {
+		path = "/"
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd := []string{"ls", "-l", "-a", path}
+	if err := f.exec(namespace, pod, container, cmd, nil, &stdout, &stderr); err != nil {
+		return nil, fmt.Errorf("%v: %s", err, strings.TrimSpace(stderr.String()))
+	}
+
+	return parseListing(stdout.String()), nil
+}
+
+func (f *fileBrowser) DownloadFile(namespace, pod, container, path string, sizeLimit int64, writer io.Writer) error {
+	if path == "" {
+		return fmt.Errorf("path must not be empty")
+	}
+	if sizeLimit <= 0 {
+		sizeLimit = DefaultMaxFileSize
+	}
+
+	var stderr bytes.Buffer
+	limited := &limitWriter{writer: writer, remaining: sizeLimit}
+	cmd := []string{"tar", "cf", "-", path}
+	if err := f.exec(namespace, pod, container, cmd, nil, limited, &stderr); err != nil {
+		if limited.exceeded {
+			return fmt.Errorf("file %s exceeds the size limit of %d bytes", path, sizeLimit)
+		}
+		return fmt.Errorf("%v: %s", err, strings.TrimSpace(stderr.String()))
+	}
+	return nil
+}
+
+func (f *fileBrowser) UploadFile(namespace, pod, container, destDir, fileName string, content io.Reader, sizeLimit int64) error {
+	if destDir == "" || fileName == "" {
+		return fmt.Errorf("path and file name must not be empty")
+	}
+	if strings.Contains(fileName, "/") {
+		return fmt.Errorf("file name must not contain path separators")
+	}
+	if sizeLimit <= 0 {
+		sizeLimit = DefaultMaxFileSize
+	}
+
+	data, err := io.ReadAll(io.LimitReader(content, sizeLimit+1))
+	if err != nil {
+		return err
+	}
+	if int64(len(data)) > sizeLimit {
+		return fmt.Errorf("file %s exceeds the size limit of %d bytes", fileName, sizeLimit)
+	}
+
+	// wrap the content into a single entry archive, the container side only
+	// needs a tar binary the same way kubectl cp does
+	var archive bytes.Buffer
+	tarWriter := tar.NewWriter(&archive)
+	if err := tarWriter.WriteHeader(&tar.Header{Name: fileName, Mode: 0644, Size: int64(len(data))}); err != nil {
+		return err
+	}
+	if _, err := tarWriter.Write(data); err != nil {
+		return err
+	}
+	if err := tarWriter.Close(); err != nil {
+		return err
+	}
+
+	var stderr bytes.Buffer
+	cmd := []string{"tar", "xmf", "-", "-C", destDir}
+	if err := f.exec(namespace, pod, container, cmd, &archive, io.Discard, &stderr); err != nil {
+		return fmt.Errorf("%v: %s", err, strings.TrimSpace(stderr.String()))
+	}
+	return nil
+}
+
+func (f *fileBrowser) exec(namespace, pod, container string, cmd []string, stdin io.Reader, stdout, stderr io.Writer) error {
+	req := f.client.CoreV1().RESTClient().Post().
+		Resource("pods").
+		Name(pod).
+		Namespace(namespace).
+		SubResource("exec")
+	req.VersionedParams(&v1.PodExecOptions{
+		Container: container,
+		Command:   cmd,
+		Stdin:     stdin != nil,
+		Stdout:    true,
+		Stderr:    true,
+	}, scheme.ParameterCodec)
+
+	executor, err := remotecommand.NewSPDYExecutor(f.config, "POST", req.URL())
+	if err != nil {
+		return err
+	}
+
+	return executor.Stream(remotecommand.StreamOptions{
+		Stdin:  stdin,
+		Stdout: stdout,
+		Stderr: stderr,
+	})
+}
+
+// parseListing extracts the entries from ls -l output, which both coreutils
+// and busybox agree on closely enough for permissions, size and name.
+func parseListing(output string) []FileInfo {
+	files := make([]FileInfo, 0)
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.Fields(line)
+		// permissions, links, owner, group, size, month, day, time, name
+		if len(fields) < 9 || len(fields[0]) < 10 {
+			continue
+		}
+		size, err := strconv.ParseInt(fields[4], 10, 64)
+		if err != nil {
+			continue
+		}
+		name := strings.Join(fields[8:], " ")
+		if name == "." || name == ".." {
+			continue
+		}
+		files = append(files, FileInfo{
+			Name:  name,
+			Mode:  fields[0],
+			Size:  size,
+			IsDir: fields[0][0] == 'd',
+		})
+	}
+	return files
+}
+
+// limitWriter aborts the stream once more than the allowed number of bytes
+// passed through.
+type limitWriter struct {
+	writer    io.Writer
+	remaining int64
+	exceeded  bool
+}
+
+func (l *limitWriter) Write(p []byte) (int, error) {
+	if int64(len(p)) > l.remaining {
+		l.exceeded = true
+		return 0, fmt.Errorf("size limit exceeded")
+	}
+	l.remaining -= int64(len(p))
+	return l.writer.Write(p)
+}
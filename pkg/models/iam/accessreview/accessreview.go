@@ -0,0 +1,146 @@
+/*
+Copyright 2022 KubeSphere Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package accessreview
+
+import (
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/klog"
+
+	auditingv1alpha1 "kubesphere.io/kubesphere/pkg/api/auditing/v1alpha1"
+	"kubesphere.io/kubesphere/pkg/apiserver/authorization/rbac"
+	"kubesphere.io/kubesphere/pkg/models/auditing"
+	"kubesphere.io/kubesphere/pkg/models/iam/am"
+	auditingclient "kubesphere.io/kubesphere/pkg/simple/client/auditing"
+)
+
+// standardVerbs are evaluated against every role when building a review.
+var standardVerbs = []string{"get", "list", "watch", "create", "update", "patch", "delete"}
+
+// defaultEventsSize limits how many recent audit events a review includes.
+const defaultEventsSize = 10
+
+// SubjectAccess records the verbs a role binding subject currently holds
+// on the reviewed resource, and the role that grants them.
+type SubjectAccess struct {
+	Subject rbacv1.Subject `json:"subject"`
+	RoleRef rbacv1.RoleRef `json:"roleRef"`
+	Verbs   []string       `json:"verbs"`
+}
+
+// AccessReview combines who can currently touch a resource with who
+// recently did, so that operators can audit both in one request.
+type AccessReview struct {
+	Namespace string          `json:"namespace"`
+	Resource  string          `json:"resource"`
+	Name      string          `json:"name,omitempty"`
+	Subjects  []SubjectAccess `json:"subjects"`
+	// RecentEvents are the latest audit events touching the resource,
+	// omitted when the auditing backend is not configured.
+	RecentEvents *auditingv1alpha1.APIResponse `json:"recentEvents,omitempty"`
+}
+
+type Reviewer interface {
+	// Review resolves which subjects hold which verbs on the resource
+	// through the role bindings in its namespace, and appends recent
+	// audit events touching it.
+	Review(namespace, resource, name string) (*AccessReview, error)
+}
+
+type reviewer struct {
+	am     am.AccessManagementInterface
+	events auditing.Interface
+}
+
+func NewReviewer(am am.AccessManagementInterface, auditingClient auditingclient.Client) Reviewer {
+	r := &reviewer{am: am}
+	if auditingClient != nil {
+		r.events = auditing.NewEventsOperator(auditingClient)
+	}
+	return r
+}
+
+func (r *reviewer) Review(namespace, resource, name string) (*AccessReview, error) {
+
+	roleBindings, err := r.am.ListRoleBindings("", nil, namespace)
+	if err != nil {
+		klog.Error(err)
+		return nil, err
+	}
+
+	review := &AccessReview{
+		Namespace: namespace,
+		Resource:  resource,
+		Name:      name,
+		Subjects:  make([]SubjectAccess, 0),
+	}
+
+	for _, roleBinding := range roleBindings {
+		_, rules, err := r.am.GetRoleReferenceRules(roleBinding.RoleRef, namespace)
+		if err != nil {
+			// A dangling role reference should not fail the whole review.
+			klog.Warningf("resolve role reference %s of role binding %s/%s failed: %v",
+				roleBinding.RoleRef.Name, namespace, roleBinding.Name, err)
+			continue
+		}
+
+		verbs := allowedVerbs(rules, resource, name)
+		if len(verbs) == 0 {
+			continue
+		}
+
+		for _, subject := range roleBinding.Subjects {
+			review.Subjects = append(review.Subjects, SubjectAccess{
+				Subject: subject,
+				RoleRef: roleBinding.RoleRef,
+				Verbs:   verbs,
+			})
+		}
+	}
+
+	if r.events != nil {
+		queryParam := &auditingv1alpha1.Query{
+			ObjectRefNamespaceFilter: namespace,
+			ObjectRefResourceFilter:  resource,
+			ObjectRefNameFilter:      name,
+			Size:                     defaultEventsSize,
+		}
+		recentEvents, err := r.events.Events(queryParam, nil)
+		if err != nil {
+			// The review is still useful without the history.
+			klog.Errorf("query audit events of %s/%s failed: %v", namespace, resource, err)
+		} else {
+			review.RecentEvents = recentEvents
+		}
+	}
+
+	return review, nil
+}
+
+// allowedVerbs returns the standard verbs the rules allow on the resource.
+func allowedVerbs(rules []rbacv1.PolicyRule, resource, name string) []string {
+	verbs := make([]string, 0)
+	for _, verb := range standardVerbs {
+		for i := range rules {
+			rule := &rules[i]
+			if rbac.VerbMatches(rule, verb) && rbac.ResourceMatches(rule, resource, "") && rbac.ResourceNameMatches(rule, name) {
+				verbs = append(verbs, verb)
+				break
+			}
+		}
+	}
+	return verbs
+}
@@ -68,17 +68,17 @@ type AccessManagementInterface interface {
 	GetGlobalRole(globalRole string) (*iamv1alpha2.GlobalRole, error)
 	GetWorkspaceRole(workspace string, name string) (*iamv1alpha2.WorkspaceRole, error)
 	CreateGlobalRoleBinding(username string, globalRole string) error
-	CreateOrUpdateWorkspaceRole(workspace string, workspaceRole *iamv1alpha2.WorkspaceRole) (*iamv1alpha2.WorkspaceRole, error)
+	CreateOrUpdateWorkspaceRole(workspace string, workspaceRole *iamv1alpha2.WorkspaceRole, dryRun ...string) (*iamv1alpha2.WorkspaceRole, error)
 	PatchWorkspaceRole(workspace string, workspaceRole *iamv1alpha2.WorkspaceRole) (*iamv1alpha2.WorkspaceRole, error)
-	CreateOrUpdateGlobalRole(globalRole *iamv1alpha2.GlobalRole) (*iamv1alpha2.GlobalRole, error)
+	CreateOrUpdateGlobalRole(globalRole *iamv1alpha2.GlobalRole, dryRun ...string) (*iamv1alpha2.GlobalRole, error)
 	PatchGlobalRole(globalRole *iamv1alpha2.GlobalRole) (*iamv1alpha2.GlobalRole, error)
 	DeleteWorkspaceRole(workspace string, name string) error
 	DeleteGlobalRole(name string) error
-	CreateOrUpdateClusterRole(clusterRole *rbacv1.ClusterRole) (*rbacv1.ClusterRole, error)
+	CreateOrUpdateClusterRole(clusterRole *rbacv1.ClusterRole, dryRun ...string) (*rbacv1.ClusterRole, error)
 	DeleteClusterRole(name string) error
 	GetClusterRole(name string) (*rbacv1.ClusterRole, error)
 	GetNamespaceRole(namespace string, name string) (*rbacv1.Role, error)
-	CreateOrUpdateNamespaceRole(namespace string, role *rbacv1.Role) (*rbacv1.Role, error)
+	CreateOrUpdateNamespaceRole(namespace string, role *rbacv1.Role, dryRun ...string) (*rbacv1.Role, error)
 	DeleteNamespaceRole(namespace string, name string) error
 	CreateUserWorkspaceRoleBinding(username string, workspace string, role string) error
 	RemoveUserFromWorkspace(username string, workspace string) error
@@ -437,7 +437,7 @@ func (am *amOperator) CreateGlobalRoleBinding(username string, role string) erro
 	return nil
 }
 
-func (am *amOperator) CreateOrUpdateWorkspaceRole(workspace string, workspaceRole *iamv1alpha2.WorkspaceRole) (*iamv1alpha2.WorkspaceRole, error) {
+func (am *amOperator) CreateOrUpdateWorkspaceRole(workspace string, workspaceRole *iamv1alpha2.WorkspaceRole, dryRun ...string) (*iamv1alpha2.WorkspaceRole, error) {
 	if workspaceRole.Labels == nil {
 		workspaceRole.Labels = make(map[string]string, 0)
 	}
@@ -456,9 +456,9 @@ func (am *amOperator) CreateOrUpdateWorkspaceRole(workspace string, workspaceRol
 	var created *iamv1alpha2.WorkspaceRole
 	var err error
 	if workspaceRole.ResourceVersion != "" {
-		created, err = am.ksclient.IamV1alpha2().WorkspaceRoles().Update(context.Background(), workspaceRole, metav1.UpdateOptions{})
+		created, err = am.ksclient.IamV1alpha2().WorkspaceRoles().Update(context.Background(), workspaceRole, metav1.UpdateOptions{DryRun: dryRun})
 	} else {
-		created, err = am.ksclient.IamV1alpha2().WorkspaceRoles().Create(context.Background(), workspaceRole, metav1.CreateOptions{})
+		created, err = am.ksclient.IamV1alpha2().WorkspaceRoles().Create(context.Background(), workspaceRole, metav1.CreateOptions{DryRun: dryRun})
 	}
 
 	return created, err
@@ -828,7 +828,7 @@ func (am *amOperator) RemoveUserFromCluster(username string) error {
 	return nil
 }
 
-func (am *amOperator) CreateOrUpdateGlobalRole(globalRole *iamv1alpha2.GlobalRole) (*iamv1alpha2.GlobalRole, error) {
+func (am *amOperator) CreateOrUpdateGlobalRole(globalRole *iamv1alpha2.GlobalRole, dryRun ...string) (*iamv1alpha2.GlobalRole, error) {
 	globalRole.Rules = make([]rbacv1.PolicyRule, 0)
 	if aggregateRoles := am.getAggregateRoles(globalRole.ObjectMeta); aggregateRoles != nil {
 		for _, roleName := range aggregateRoles {
@@ -843,14 +843,14 @@ func (am *amOperator) CreateOrUpdateGlobalRole(globalRole *iamv1alpha2.GlobalRol
 	var created *iamv1alpha2.GlobalRole
 	var err error
 	if globalRole.ResourceVersion != "" {
-		created, err = am.ksclient.IamV1alpha2().GlobalRoles().Update(context.Background(), globalRole, metav1.UpdateOptions{})
+		created, err = am.ksclient.IamV1alpha2().GlobalRoles().Update(context.Background(), globalRole, metav1.UpdateOptions{DryRun: dryRun})
 	} else {
-		created, err = am.ksclient.IamV1alpha2().GlobalRoles().Create(context.Background(), globalRole, metav1.CreateOptions{})
+		created, err = am.ksclient.IamV1alpha2().GlobalRoles().Create(context.Background(), globalRole, metav1.CreateOptions{DryRun: dryRun})
 	}
 	return created, err
 }
 
-func (am *amOperator) CreateOrUpdateClusterRole(clusterRole *rbacv1.ClusterRole) (*rbacv1.ClusterRole, error) {
+func (am *amOperator) CreateOrUpdateClusterRole(clusterRole *rbacv1.ClusterRole, dryRun ...string) (*rbacv1.ClusterRole, error) {
 	clusterRole.Rules = make([]rbacv1.PolicyRule, 0)
 	if aggregateRoles := am.getAggregateRoles(clusterRole.ObjectMeta); aggregateRoles != nil {
 		for _, roleName := range aggregateRoles {
@@ -865,14 +865,14 @@ func (am *amOperator) CreateOrUpdateClusterRole(clusterRole *rbacv1.ClusterRole)
 	var created *rbacv1.ClusterRole
 	var err error
 	if clusterRole.ResourceVersion != "" {
-		created, err = am.k8sclient.RbacV1().ClusterRoles().Update(context.Background(), clusterRole, metav1.UpdateOptions{})
+		created, err = am.k8sclient.RbacV1().ClusterRoles().Update(context.Background(), clusterRole, metav1.UpdateOptions{DryRun: dryRun})
 	} else {
-		created, err = am.k8sclient.RbacV1().ClusterRoles().Create(context.Background(), clusterRole, metav1.CreateOptions{})
+		created, err = am.k8sclient.RbacV1().ClusterRoles().Create(context.Background(), clusterRole, metav1.CreateOptions{DryRun: dryRun})
 	}
 	return created, err
 }
 
-func (am *amOperator) CreateOrUpdateNamespaceRole(namespace string, role *rbacv1.Role) (*rbacv1.Role, error) {
+func (am *amOperator) CreateOrUpdateNamespaceRole(namespace string, role *rbacv1.Role, dryRun ...string) (*rbacv1.Role, error) {
 	role.Rules = make([]rbacv1.PolicyRule, 0)
 	role.Namespace = namespace
 	if aggregateRoles := am.getAggregateRoles(role.ObjectMeta); aggregateRoles != nil {
@@ -888,9 +888,9 @@ func (am *amOperator) CreateOrUpdateNamespaceRole(namespace string, role *rbacv1
 	var created *rbacv1.Role
 	var err error
 	if role.ResourceVersion != "" {
-		created, err = am.k8sclient.RbacV1().Roles(namespace).Update(context.Background(), role, metav1.UpdateOptions{})
+		created, err = am.k8sclient.RbacV1().Roles(namespace).Update(context.Background(), role, metav1.UpdateOptions{DryRun: dryRun})
 	} else {
-		created, err = am.k8sclient.RbacV1().Roles(namespace).Create(context.Background(), role, metav1.CreateOptions{})
+		created, err = am.k8sclient.RbacV1().Roles(namespace).Create(context.Background(), role, metav1.CreateOptions{DryRun: dryRun})
 	}
 
 	return created, err
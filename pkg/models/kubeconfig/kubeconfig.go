@@ -43,6 +43,7 @@ import (
 
 	iamv1alpha2 "kubesphere.io/api/iam/v1alpha2"
 
+	"kubesphere.io/kubesphere/pkg/apiserver/authentication/oauth"
 	"kubesphere.io/kubesphere/pkg/client/clientset/versioned/scheme"
 	"kubesphere.io/kubesphere/pkg/constants"
 	"kubesphere.io/kubesphere/pkg/utils/pkiutil"
@@ -59,10 +60,17 @@ const (
 	configMapAPIVersion  = "v1"
 	privateKeyAnnotation = "kubesphere.io/private-key"
 	residual             = 72 * time.Hour
+
+	oauthClientID         = "kubesphere"
+	oidcPluginInstallHint = "kubectl oidc-login is required to authenticate to the cluster, " +
+		"see https://github.com/int128/kubelogin for installation instructions"
 )
 
 type Interface interface {
 	GetKubeConfig(username string) (string, error)
+	// GetOIDCKubeConfig returns kubeconfig data which authenticates through
+	// the OIDC exec credential plugin instead of embedded client certs.
+	GetOIDCKubeConfig(username string) (string, error)
 	CreateKubeConfig(user *iamv1alpha2.User) error
 	UpdateKubeconfig(username string, csr *certificatesv1.CertificateSigningRequest) error
 }
@@ -72,14 +80,15 @@ type operator struct {
 	configMapLister corev1listers.ConfigMapLister
 	config          *rest.Config
 	masterURL       string
+	oauthOptions    *oauth.Options
 }
 
 func NewOperator(k8sClient kubernetes.Interface, configMapLister corev1listers.ConfigMapLister, config *rest.Config) Interface {
 	return &operator{k8sClient: k8sClient, configMapLister: configMapLister, config: config}
 }
 
-func NewReadOnlyOperator(configMapLister corev1listers.ConfigMapLister, masterURL string) Interface {
-	return &operator{configMapLister: configMapLister, masterURL: masterURL}
+func NewReadOnlyOperator(configMapLister corev1listers.ConfigMapLister, masterURL string, oauthOptions *oauth.Options) Interface {
+	return &operator{configMapLister: configMapLister, masterURL: masterURL, oauthOptions: oauthOptions}
 }
 
 // CreateKubeConfig Create kubeconfig configmap in KubeSphereControlNamespace for the specified user
@@ -205,6 +214,65 @@ func (o *operator) GetKubeConfig(username string) (string, error) {
 	return string(data), nil
 }
 
+// GetOIDCKubeConfig returns kubeconfig data for the specified user with the
+// OIDC exec credential plugin (device-code flow) as the auth provider, so CLI
+// access tokens follow the same revocation and expiry rules as console sessions.
+func (o *operator) GetOIDCKubeConfig(username string) (string, error) {
+	if o.oauthOptions == nil || o.oauthOptions.Issuer == "" {
+		return "", fmt.Errorf("oauth issuer is not configured")
+	}
+
+	configName := fmt.Sprintf(kubeconfigNameFormat, username)
+	configMap, err := o.configMapLister.ConfigMaps(constants.KubeSphereControlNamespace).Get(configName)
+	if err != nil {
+		klog.Errorln(err)
+		return "", err
+	}
+
+	data := []byte(configMap.Data[kubeconfigFileName])
+	kubeconfig, err := clientcmd.Load(data)
+	if err != nil {
+		klog.Errorln(err)
+		return "", err
+	}
+
+	masterURL := o.masterURL
+	// server host override
+	if cluster := kubeconfig.Clusters[defaultClusterName]; cluster != nil && masterURL != "" {
+		cluster.Server = masterURL
+	}
+
+	clientID := oauthClientID
+	if len(o.oauthOptions.Clients) > 0 {
+		clientID = o.oauthOptions.Clients[0].Name
+	}
+
+	kubeconfig.AuthInfos = map[string]*clientcmdapi.AuthInfo{
+		username: {
+			Exec: &clientcmdapi.ExecConfig{
+				APIVersion: "client.authentication.k8s.io/v1beta1",
+				Command:    "kubectl",
+				Args: []string{
+					"oidc-login",
+					"get-token",
+					fmt.Sprintf("--oidc-issuer-url=%s", o.oauthOptions.Issuer),
+					fmt.Sprintf("--oidc-client-id=%s", clientID),
+					"--grant-type=device-code",
+				},
+				InstallHint: oidcPluginInstallHint,
+			},
+		},
+	}
+
+	data, err = clientcmd.Write(*kubeconfig)
+	if err != nil {
+		klog.Errorln(err)
+		return "", err
+	}
+
+	return string(data), nil
+}
+
 func (o *operator) createCSR(username string) error {
 	csrConfig := &certutil.Config{
 		CommonName:   username,
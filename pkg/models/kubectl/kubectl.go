@@ -20,12 +20,17 @@ import (
 	"context"
 	"fmt"
 	"math/rand"
+	"sync"
+	"sync/atomic"
+	"time"
 
 	appsv1 "k8s.io/api/apps/v1"
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
 	appsv1informers "k8s.io/client-go/informers/apps/v1"
 	coreinfomers "k8s.io/client-go/informers/core/v1"
 	"k8s.io/client-go/kubernetes"
@@ -33,6 +38,8 @@ import (
 	"k8s.io/klog"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
+	iamv1alpha2 "kubesphere.io/api/iam/v1alpha2"
+
 	iamv1alpha2informers "kubesphere.io/kubesphere/pkg/client/informers/externalversions/iam/v1alpha2"
 	"kubesphere.io/kubesphere/pkg/models"
 
@@ -42,8 +49,15 @@ import (
 const (
 	namespace        = constants.KubeSphereControlNamespace
 	deployNameFormat = "kubectl-%s"
+
+	// LastActivityAnnotation records when a kubectl pod was last attached,
+	// the idle cleanup controller deletes pods idle beyond the configured timeout.
+	LastActivityAnnotation = "kubectl.kubesphere.io/last-activity"
 )
 
+// userSessionCounter tracks concurrent kubectl terminal sessions per user.
+var userSessionCounter sync.Map
+
 type Interface interface {
 	GetKubectlPod(username string) (models.PodInfo, error)
 	CreateKubectlDeploy(username string, owner metav1.Object) error
@@ -54,13 +68,16 @@ type operator struct {
 	deploymentInformer appsv1informers.DeploymentInformer
 	podInformer        coreinfomers.PodInformer
 	userInformer       iamv1alpha2informers.UserInformer
-	kubectlImage       string
+	options            *Options
 }
 
 func NewOperator(k8sClient kubernetes.Interface, deploymentInformer appsv1informers.DeploymentInformer,
-	podInformer coreinfomers.PodInformer, userInformer iamv1alpha2informers.UserInformer, kubectlImage string) Interface {
+	podInformer coreinfomers.PodInformer, userInformer iamv1alpha2informers.UserInformer, options *Options) Interface {
+	if options == nil {
+		options = NewOptions()
+	}
 	return &operator{k8sClient: k8sClient, deploymentInformer: deploymentInformer, podInformer: podInformer,
-		userInformer: userInformer, kubectlImage: kubectlImage}
+		userInformer: userInformer, options: options}
 }
 
 func (o *operator) GetKubectlPod(username string) (models.PodInfo, error) {
@@ -71,6 +88,8 @@ func (o *operator) GetKubectlPod(username string) (models.PodInfo, error) {
 		return models.PodInfo{}, err
 	}
 
+	o.updateLastActivity(deployName)
+
 	selectors := deploy.Spec.Selector.MatchLabels
 	labelSelector := labels.Set(selectors).AsSelector()
 	pods, err := o.podInformer.Lister().Pods(namespace).List(labelSelector)
@@ -91,6 +110,41 @@ func (o *operator) GetKubectlPod(username string) (models.PodInfo, error) {
 
 }
 
+// updateLastActivity refreshes the last-activity annotation of the kubectl
+// deployment so the idle cleanup controller won't delete a pod in use.
+func (o *operator) updateLastActivity(deployName string) {
+	if o.k8sClient == nil {
+		return
+	}
+	patch := fmt.Sprintf(`{"metadata":{"annotations":{"%s":"%s"}}}`, LastActivityAnnotation, time.Now().UTC().Format(time.RFC3339))
+	_, err := o.k8sClient.AppsV1().Deployments(namespace).Patch(context.Background(), deployName,
+		types.MergePatchType, []byte(patch), metav1.PatchOptions{})
+	if err != nil && !errors.IsNotFound(err) {
+		klog.Warningf("failed to update last activity of kubectl deploy %s: %v", deployName, err)
+	}
+}
+
+// AcquireSession reserves a kubectl terminal session slot for the user,
+// it returns an error once the user reached maxSessions concurrent sessions.
+// Zero maxSessions means no limit. A successful acquisition must be paired
+// with ReleaseSession when the session ends.
+func AcquireSession(username string, maxSessions int) error {
+	counter, _ := userSessionCounter.LoadOrStore(username, new(int64))
+	count := atomic.AddInt64(counter.(*int64), 1)
+	if maxSessions > 0 && count > int64(maxSessions) {
+		atomic.AddInt64(counter.(*int64), -1)
+		return fmt.Errorf("user %s exceeds the maximum of %d concurrent kubectl sessions", username, maxSessions)
+	}
+	return nil
+}
+
+// ReleaseSession releases a session slot acquired by AcquireSession.
+func ReleaseSession(username string) {
+	if counter, ok := userSessionCounter.Load(username); ok {
+		atomic.AddInt64(counter.(*int64), -1)
+	}
+}
+
 func selectCorrectPod(namespace string, pods []*v1.Pod) (kubectlPod *v1.Pod, err error) {
 
 	var kubectlPodList []*v1.Pod
@@ -114,7 +168,7 @@ func selectCorrectPod(namespace string, pods []*v1.Pod) (kubectlPod *v1.Pod, err
 func (o *operator) CreateKubectlDeploy(username string, owner metav1.Object) error {
 	deployName := fmt.Sprintf(deployNameFormat, username)
 
-	_, err := o.userInformer.Lister().Get(username)
+	user, err := o.userInformer.Lister().Get(username)
 	if err != nil {
 		klog.Error(err)
 		// ignore if user not exist
@@ -129,6 +183,9 @@ func (o *operator) CreateKubectlDeploy(username string, owner metav1.Object) err
 	deployment := &appsv1.Deployment{
 		ObjectMeta: metav1.ObjectMeta{
 			Name: deployName,
+			Annotations: map[string]string{
+				LastActivityAnnotation: time.Now().UTC().Format(time.RFC3339),
+			},
 		},
 		Spec: appsv1.DeploymentSpec{
 			Replicas: &replica,
@@ -142,8 +199,9 @@ func (o *operator) CreateKubectlDeploy(username string, owner metav1.Object) err
 				Spec: v1.PodSpec{
 					Containers: []v1.Container{
 						{
-							Name:  "kubectl",
-							Image: o.kubectlImage,
+							Name:      "kubectl",
+							Image:     o.imageFor(user),
+							Resources: o.containerResources(),
 							VolumeMounts: []v1.VolumeMount{
 								{
 									Name:      "host-time",
@@ -186,3 +244,31 @@ func (o *operator) CreateKubectlDeploy(username string, owner metav1.Object) err
 
 	return nil
 }
+
+// imageFor returns the kubectl image configured for the global role of the
+// user, falling back to the default image.
+func (o *operator) imageFor(user *iamv1alpha2.User) string {
+	if role := user.Annotations[iamv1alpha2.GlobalRoleAnnotation]; role != "" {
+		if image, ok := o.options.RoleImages[role]; ok {
+			return image
+		}
+	}
+	return o.options.Image
+}
+
+// containerResources builds the resource limits of the kubectl container
+// from the options, invalid quantities have been rejected by options validation.
+func (o *operator) containerResources() v1.ResourceRequirements {
+	limits := v1.ResourceList{}
+	if o.options.CPULimit != "" {
+		if quantity, err := resource.ParseQuantity(o.options.CPULimit); err == nil {
+			limits[v1.ResourceCPU] = quantity
+		}
+	}
+	if o.options.MemoryLimit != "" {
+		if quantity, err := resource.ParseQuantity(o.options.MemoryLimit); err == nil {
+			limits[v1.ResourceMemory] = quantity
+		}
+	}
+	return v1.ResourceRequirements{Limits: limits}
+}
@@ -0,0 +1,78 @@
+/*
+Copyright 2022 The KubeSphere Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubectl
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/pflag"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// Options configures the kubectl pods created for users who have admin
+// access to the cluster.
+type Options struct {
+	// Image is the default image of the kubectl pod.
+	Image string `json:"image,omitempty" yaml:"image,omitempty"`
+	// RoleImages overrides Image for users bound to the given global role,
+	// keyed by global role name.
+	RoleImages map[string]string `json:"roleImages,omitempty" yaml:"roleImages,omitempty"`
+	// CPULimit and MemoryLimit bound the kubectl container,
+	// empty means no limit.
+	CPULimit    string `json:"cpuLimit,omitempty" yaml:"cpuLimit,omitempty"`
+	MemoryLimit string `json:"memoryLimit,omitempty" yaml:"memoryLimit,omitempty"`
+	// IdleTimeout is how long a kubectl pod may stay idle before it gets
+	// deleted by the cleanup controller, zero disables the cleanup.
+	IdleTimeout time.Duration `json:"idleTimeout,omitempty" yaml:"idleTimeout,omitempty"`
+	// MaxSessionsPerUser limits concurrent kubectl terminal sessions of a
+	// single user, zero means no limit.
+	MaxSessionsPerUser int `json:"maxSessionsPerUser,omitempty" yaml:"maxSessionsPerUser,omitempty"`
+}
+
+func NewOptions() *Options {
+	return &Options{
+		Image: "kubesphere/kubectl:v1.0.0",
+	}
+}
+
+func (o *Options) Validate() []error {
+	var errs []error
+	if o.CPULimit != "" {
+		if _, err := resource.ParseQuantity(o.CPULimit); err != nil {
+			errs = append(errs, fmt.Errorf("invalid kubectl cpu limit %s: %v", o.CPULimit, err))
+		}
+	}
+	if o.MemoryLimit != "" {
+		if _, err := resource.ParseQuantity(o.MemoryLimit); err != nil {
+			errs = append(errs, fmt.Errorf("invalid kubectl memory limit %s: %v", o.MemoryLimit, err))
+		}
+	}
+	if o.IdleTimeout < 0 {
+		errs = append(errs, fmt.Errorf("kubectl idle timeout must not be negative"))
+	}
+	if o.MaxSessionsPerUser < 0 {
+		errs = append(errs, fmt.Errorf("kubectl max sessions per user must not be negative"))
+	}
+	return errs
+}
+
+func (o *Options) AddFlags(fs *pflag.FlagSet, s *Options) {
+	fs.StringVar(&o.Image, "kubectl-image", s.Image, "Setup the image used by kubectl terminal pod")
+	fs.DurationVar(&o.IdleTimeout, "kubectl-idle-timeout", s.IdleTimeout, "How long a kubectl terminal pod may stay idle before it gets deleted, 0 disables the cleanup.")
+	fs.IntVar(&o.MaxSessionsPerUser, "kubectl-max-sessions-per-user", s.MaxSessionsPerUser, "Maximum concurrent kubectl terminal sessions of a single user, 0 means no limit.")
+}
@@ -0,0 +1,281 @@
+/*
+Copyright 2022 The KubeSphere Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package nodes orchestrates node maintenance server-side, draining evicts
+// pods through the eviction API so PodDisruptionBudgets are honored instead
+// of deleting pods one by one from the console.
+package nodes
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	policyv1beta1 "k8s.io/api/policy/v1beta1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/klog"
+)
+
+// drain phases
+const (
+	DrainPhaseDraining  = "Draining"
+	DrainPhaseSucceeded = "Succeeded"
+	DrainPhaseFailed    = "Failed"
+	DrainPhaseAborted   = "Aborted"
+)
+
+const evictionRetryInterval = 5 * time.Second
+
+// DrainOptions control which pods a drain may disrupt and how fast.
+type DrainOptions struct {
+	// GracePeriodSeconds overrides the pod terminationGracePeriodSeconds,
+	// negative values keep the pod's own setting.
+	GracePeriodSeconds int64 `json:"gracePeriodSeconds,omitempty" description:"termination grace period, the pod setting is kept when negative"`
+	// Force evicts pods which are not managed by a controller.
+	Force bool `json:"force,omitempty" description:"evict pods not managed by a controller"`
+	// Timeout aborts the drain when evictions do not finish in time.
+	Timeout time.Duration `json:"timeout,omitempty" description:"abort the drain after this duration, defaults to 10m"`
+}
+
+// DrainStatus reports the progress of a running or finished drain.
+type DrainStatus struct {
+	Node      string    `json:"node" description:"node being drained"`
+	Phase     string    `json:"phase" description:"one of Draining, Succeeded, Failed, Aborted"`
+	Total     int       `json:"total" description:"number of pods selected for eviction"`
+	Evicted   int       `json:"evicted" description:"number of pods already evicted"`
+	Pending   []string  `json:"pending,omitempty" description:"pods still waiting for eviction, namespace/name"`
+	Message   string    `json:"message,omitempty" description:"failure reason if any"`
+	StartedAt time.Time `json:"startedAt" description:"time the drain started"`
+}
+
+// DrainOperator cordons nodes and drains them with PDB-aware evictions,
+// drains run asynchronously and can be observed and aborted. Progress is
+// tracked in the serving replica only.
+type DrainOperator interface {
+	Cordon(nodeName string, unschedulable bool) (*corev1.Node, error)
+	Drain(nodeName string, options DrainOptions) (*DrainStatus, error)
+	DrainStatus(nodeName string) (*DrainStatus, error)
+	AbortDrain(nodeName string) error
+}
+
+type drainOperator struct {
+	client kubernetes.Interface
+
+	mutex  sync.Mutex
+	drains map[string]*drainState
+}
+
+type drainState struct {
+	status *DrainStatus
+	cancel context.CancelFunc
+}
+
+func NewDrainOperator(client kubernetes.Interface) DrainOperator {
+	return &drainOperator{client: client, drains: make(map[string]*drainState)}
+}
+
+func (d *drainOperator) Cordon(nodeName string, unschedulable bool) (*corev1.Node, error) {
+	patch := fmt.Sprintf(`{"spec":{"unschedulable":%t}}`, unschedulable)
+	return d.client.CoreV1().Nodes().Patch(context.Background(), nodeName,
+		types.StrategicMergePatchType, []byte(patch), metav1.PatchOptions{})
+}
+
+func (d *drainOperator) Drain(nodeName string, options DrainOptions) (*DrainStatus, error) {
+	if options.Timeout <= 0 {
+		options.Timeout = 10 * time.Minute
+	}
+
+	pods, err := d.podsToEvict(nodeName, options)
+	if err != nil {
+		return nil, err
+	}
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	if state, ok := d.drains[nodeName]; ok && state.status.Phase == DrainPhaseDraining {
+		return nil, fmt.Errorf("node %s is already being drained", nodeName)
+	}
+
+	// draining implies the node must not accept new pods
+	if _, err := d.Cordon(nodeName, true); err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), options.Timeout)
+	status := &DrainStatus{
+		Node:      nodeName,
+		Phase:     DrainPhaseDraining,
+		Total:     len(pods),
+		Pending:   podNames(pods),
+		StartedAt: time.Now(),
+	}
+	d.drains[nodeName] = &drainState{status: status, cancel: cancel}
+
+	go d.evictPods(ctx, nodeName, pods, options)
+
+	return d.copyStatus(status), nil
+}
+
+func (d *drainOperator) DrainStatus(nodeName string) (*DrainStatus, error) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	state, ok := d.drains[nodeName]
+	if !ok {
+		return nil, fmt.Errorf("no drain recorded for node %s", nodeName)
+	}
+	return d.copyStatus(state.status), nil
+}
+
+func (d *drainOperator) AbortDrain(nodeName string) error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	state, ok := d.drains[nodeName]
+	if !ok || state.status.Phase != DrainPhaseDraining {
+		return fmt.Errorf("no drain in progress for node %s", nodeName)
+	}
+	state.status.Phase = DrainPhaseAborted
+	state.cancel()
+	return nil
+}
+
+// podsToEvict selects the pods a drain has to move away, daemonset and
+// mirror pods are always left alone.
+func (d *drainOperator) podsToEvict(nodeName string, options DrainOptions) ([]corev1.Pod, error) {
+	podList, err := d.client.CoreV1().Pods(metav1.NamespaceAll).List(context.Background(), metav1.ListOptions{
+		FieldSelector: fields.OneTermEqualSelector("spec.nodeName", nodeName).String(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var pods []corev1.Pod
+	for _, pod := range podList.Items {
+		if pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+			continue
+		}
+		if _, mirror := pod.Annotations[corev1.MirrorPodAnnotationKey]; mirror {
+			continue
+		}
+		controller := metav1.GetControllerOf(&pod)
+		if controller != nil && controller.Kind == "DaemonSet" {
+			continue
+		}
+		if controller == nil && !options.Force {
+			return nil, fmt.Errorf("pod %s/%s is not managed by a controller, use force to evict it anyway", pod.Namespace, pod.Name)
+		}
+		pods = append(pods, pod)
+	}
+	return pods, nil
+}
+
+// evictPods evicts the pods one by one, evictions blocked by a
+// PodDisruptionBudget are retried until the drain times out or is aborted.
+func (d *drainOperator) evictPods(ctx context.Context, nodeName string, pods []corev1.Pod, options DrainOptions) {
+	for _, pod := range pods {
+		if err := d.evictPod(ctx, pod, options); err != nil {
+			d.finishDrain(ctx, nodeName, err)
+			return
+		}
+		d.markEvicted(nodeName, pod)
+	}
+	d.finishDrain(ctx, nodeName, nil)
+}
+
+func (d *drainOperator) evictPod(ctx context.Context, pod corev1.Pod, options DrainOptions) error {
+	eviction := &policyv1beta1.Eviction{
+		ObjectMeta: metav1.ObjectMeta{Namespace: pod.Namespace, Name: pod.Name},
+	}
+	if options.GracePeriodSeconds >= 0 {
+		gracePeriod := options.GracePeriodSeconds
+		eviction.DeleteOptions = &metav1.DeleteOptions{GracePeriodSeconds: &gracePeriod}
+	}
+
+	for {
+		err := d.client.PolicyV1beta1().Evictions(pod.Namespace).Evict(ctx, eviction)
+		if err == nil || apierrors.IsNotFound(err) {
+			return nil
+		}
+		// the eviction API answers 429 while a PodDisruptionBudget blocks
+		// the disruption, wait and retry
+		if !apierrors.IsTooManyRequests(err) {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("eviction of pod %s/%s gave up: %v", pod.Namespace, pod.Name, ctx.Err())
+		case <-time.After(evictionRetryInterval):
+		}
+	}
+}
+
+func (d *drainOperator) markEvicted(nodeName string, pod corev1.Pod) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	if state, ok := d.drains[nodeName]; ok {
+		state.status.Evicted++
+		name := fmt.Sprintf("%s/%s", pod.Namespace, pod.Name)
+		for index, pending := range state.status.Pending {
+			if pending == name {
+				state.status.Pending = append(state.status.Pending[:index], state.status.Pending[index+1:]...)
+				break
+			}
+		}
+	}
+}
+
+func (d *drainOperator) finishDrain(ctx context.Context, nodeName string, err error) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	state, ok := d.drains[nodeName]
+	if !ok {
+		return
+	}
+	state.cancel()
+
+	// an abort already set the final phase
+	if state.status.Phase != DrainPhaseDraining {
+		return
+	}
+	if err != nil {
+		state.status.Phase = DrainPhaseFailed
+		state.status.Message = err.Error()
+		klog.Errorf("drain of node %s failed: %v", nodeName, err)
+		return
+	}
+	state.status.Phase = DrainPhaseSucceeded
+	klog.V(2).Infof("drain of node %s finished, %d pods evicted", nodeName, state.status.Evicted)
+}
+
+func (d *drainOperator) copyStatus(status *DrainStatus) *DrainStatus {
+	copied := *status
+	copied.Pending = append([]string(nil), status.Pending...)
+	return &copied
+}
+
+func podNames(pods []corev1.Pod) []string {
+	names := make([]string, 0, len(pods))
+	for _, pod := range pods {
+		names = append(names, fmt.Sprintf("%s/%s", pod.Namespace, pod.Name))
+	}
+	return names
+}
@@ -0,0 +1,228 @@
+/*
+Copyright 2022 The KubeSphere Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nodes
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/util/retry"
+	"k8s.io/klog"
+)
+
+// protectedLabelPrefixes are reserved for kubernetes itself, labels under
+// them are set by the kubelet or the control plane and must not be edited
+// from the console.
+var protectedLabelPrefixes = []string{"kubernetes.io/", "k8s.io/"}
+
+// LabelUpdate describes a partial change of the node labels, keys in Set are
+// added or overwritten and keys in Remove are deleted.
+type LabelUpdate struct {
+	Set    map[string]string `json:"set,omitempty" description:"labels to add or overwrite"`
+	Remove []string          `json:"remove,omitempty" description:"label keys to delete"`
+}
+
+// TaintUpdateResult carries the node after a taint update together with
+// warnings about running pods the new taints will disrupt or strand.
+type TaintUpdateResult struct {
+	Node     *corev1.Node `json:"node" description:"the node after the update"`
+	Warnings []string     `json:"warnings,omitempty" description:"running pods affected by the new taints"`
+}
+
+// NodeManager edits node labels and taints with validation, so the console
+// does not have to issue raw merge patches against the node object.
+type NodeManager interface {
+	UpdateLabels(nodeName string, update LabelUpdate) (*corev1.Node, error)
+	UpdateTaints(nodeName string, taints []corev1.Taint) (*TaintUpdateResult, error)
+}
+
+type nodeManager struct {
+	client kubernetes.Interface
+}
+
+func NewNodeManager(client kubernetes.Interface) NodeManager {
+	return &nodeManager{client: client}
+}
+
+func (n *nodeManager) UpdateLabels(nodeName string, update LabelUpdate) (*corev1.Node, error) {
+	if err := validateLabelUpdate(update); err != nil {
+		return nil, err
+	}
+
+	var updated *corev1.Node
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		node, err := n.client.CoreV1().Nodes().Get(context.Background(), nodeName, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		if node.Labels == nil {
+			node.Labels = make(map[string]string)
+		}
+		for key, value := range update.Set {
+			node.Labels[key] = value
+		}
+		for _, key := range update.Remove {
+			delete(node.Labels, key)
+		}
+		updated, err = n.client.CoreV1().Nodes().Update(context.Background(), node, metav1.UpdateOptions{})
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	klog.V(2).Infof("labels of node %s updated, %d set, %d removed", nodeName, len(update.Set), len(update.Remove))
+	return updated, nil
+}
+
+func (n *nodeManager) UpdateTaints(nodeName string, taints []corev1.Taint) (*TaintUpdateResult, error) {
+	if err := validateTaints(taints); err != nil {
+		return nil, err
+	}
+
+	var updated *corev1.Node
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		node, err := n.client.CoreV1().Nodes().Get(context.Background(), nodeName, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		node.Spec.Taints = taints
+		updated, err = n.client.CoreV1().Nodes().Update(context.Background(), node, metav1.UpdateOptions{})
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	warnings, err := n.taintWarnings(nodeName, taints)
+	if err != nil {
+		// the update itself went through, degrade to a generic warning
+		warnings = []string{fmt.Sprintf("failed to inspect running pods: %v", err)}
+	}
+
+	klog.V(2).Infof("taints of node %s updated to %d entries, %d warnings", nodeName, len(taints), len(warnings))
+	return &TaintUpdateResult{Node: updated, Warnings: warnings}, nil
+}
+
+// taintWarnings reports the running pods which do not tolerate the new
+// NoSchedule and NoExecute taints, NoExecute will evict them and NoSchedule
+// strands them once they restart.
+func (n *nodeManager) taintWarnings(nodeName string, taints []corev1.Taint) ([]string, error) {
+	podList, err := n.client.CoreV1().Pods(metav1.NamespaceAll).List(context.Background(), metav1.ListOptions{
+		FieldSelector: fields.OneTermEqualSelector("spec.nodeName", nodeName).String(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var warnings []string
+	for index := range taints {
+		taint := &taints[index]
+		if taint.Effect == corev1.TaintEffectPreferNoSchedule {
+			continue
+		}
+		var intolerant []string
+		for _, pod := range podList.Items {
+			if pod.Status.Phase != corev1.PodRunning || toleratesTaint(pod.Spec.Tolerations, taint) {
+				continue
+			}
+			intolerant = append(intolerant, fmt.Sprintf("%s/%s", pod.Namespace, pod.Name))
+		}
+		if len(intolerant) == 0 {
+			continue
+		}
+		verb := "will not be rescheduled onto the node"
+		if taint.Effect == corev1.TaintEffectNoExecute {
+			verb = "will be evicted"
+		}
+		warnings = append(warnings, fmt.Sprintf("%d running pods do not tolerate taint %s and %s: %s",
+			len(intolerant), taint.ToString(), verb, strings.Join(intolerant, ", ")))
+	}
+	return warnings, nil
+}
+
+func toleratesTaint(tolerations []corev1.Toleration, taint *corev1.Taint) bool {
+	for index := range tolerations {
+		if tolerations[index].ToleratesTaint(taint) {
+			return true
+		}
+	}
+	return false
+}
+
+func validateLabelUpdate(update LabelUpdate) error {
+	if len(update.Set) == 0 && len(update.Remove) == 0 {
+		return fmt.Errorf("label update must set or remove at least one label")
+	}
+	for key, value := range update.Set {
+		if err := validateEditableLabelKey(key); err != nil {
+			return err
+		}
+		if messages := validation.IsValidLabelValue(value); len(messages) > 0 {
+			return fmt.Errorf("invalid value of label %s: %s", key, strings.Join(messages, ", "))
+		}
+	}
+	for _, key := range update.Remove {
+		if err := validateEditableLabelKey(key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func validateEditableLabelKey(key string) error {
+	if messages := validation.IsQualifiedName(key); len(messages) > 0 {
+		return fmt.Errorf("invalid label key %s: %s", key, strings.Join(messages, ", "))
+	}
+	for _, prefix := range protectedLabelPrefixes {
+		if strings.HasPrefix(key, prefix) || strings.Contains(key, "."+strings.TrimSuffix(prefix, "/")+"/") {
+			return fmt.Errorf("label %s is reserved for kubernetes and cannot be edited", key)
+		}
+	}
+	return nil
+}
+
+func validateTaints(taints []corev1.Taint) error {
+	seen := make(map[string]bool)
+	for _, taint := range taints {
+		if messages := validation.IsQualifiedName(taint.Key); len(messages) > 0 {
+			return fmt.Errorf("invalid taint key %s: %s", taint.Key, strings.Join(messages, ", "))
+		}
+		if taint.Value != "" {
+			if messages := validation.IsValidLabelValue(taint.Value); len(messages) > 0 {
+				return fmt.Errorf("invalid value of taint %s: %s", taint.Key, strings.Join(messages, ", "))
+			}
+		}
+		switch taint.Effect {
+		case corev1.TaintEffectNoSchedule, corev1.TaintEffectPreferNoSchedule, corev1.TaintEffectNoExecute:
+		default:
+			return fmt.Errorf("invalid taint effect %q, must be one of NoSchedule, PreferNoSchedule, NoExecute", taint.Effect)
+		}
+		key := fmt.Sprintf("%s:%s", taint.Key, taint.Effect)
+		if seen[key] {
+			return fmt.Errorf("duplicate taint %s with effect %s", taint.Key, taint.Effect)
+		}
+		seen[key] = true
+	}
+	return nil
+}
@@ -0,0 +1,437 @@
+/*
+Copyright 2022 The KubeSphere Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nodes
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/klog"
+)
+
+// NodeGroupLabel assigns a node to a named pool, nodes sharing the label
+// value form one group.
+const NodeGroupLabel = "node.kubesphere.io/group"
+
+// RebootRequestedAnnotation asks the node agent to reboot the machine, a
+// rolling reboot sets it after the node is drained and waits until the agent
+// removes it again before moving on.
+const RebootRequestedAnnotation = "node.kubesphere.io/reboot-requested"
+
+const rebootPollInterval = 5 * time.Second
+
+// NodeGroup aggregates the nodes sharing one pool label value.
+type NodeGroup struct {
+	Name               string              `json:"name" description:"value of the group label"`
+	Nodes              []string            `json:"nodes" description:"names of the member nodes"`
+	ReadyNodes         int                 `json:"readyNodes" description:"number of nodes with a Ready condition"`
+	UnschedulableNodes int                 `json:"unschedulableNodes" description:"number of cordoned nodes"`
+	Capacity           corev1.ResourceList `json:"capacity,omitempty" description:"summed node capacity"`
+	Allocatable        corev1.ResourceList `json:"allocatable,omitempty" description:"summed allocatable resources"`
+	Requests           corev1.ResourceList `json:"requests,omitempty" description:"summed resource requests of non-terminal pods"`
+}
+
+// RebootStatus reports the progress of a rolling reboot of a node group.
+type RebootStatus struct {
+	Group     string    `json:"group" description:"node group being rebooted"`
+	Phase     string    `json:"phase" description:"one of Draining, Succeeded, Failed, Aborted"`
+	Current   string    `json:"current,omitempty" description:"node currently being drained and rebooted"`
+	Completed []string  `json:"completed,omitempty" description:"nodes already rebooted"`
+	Pending   []string  `json:"pending,omitempty" description:"nodes not reached yet"`
+	Message   string    `json:"message,omitempty" description:"failure reason if any"`
+	StartedAt time.Time `json:"startedAt" description:"time the rolling reboot started"`
+}
+
+// NodeGroupOperator reads node pools and applies group-level operations. A
+// rolling reboot drains one node at a time, requests the reboot through the
+// annotation contract and waits for the node to come back before moving on.
+type NodeGroupOperator interface {
+	ListNodeGroups() ([]NodeGroup, error)
+	GetNodeGroup(name string) (*NodeGroup, error)
+	CordonGroup(name string, unschedulable bool) (*NodeGroup, error)
+	SetGroupTaints(name string, taints []corev1.Taint) ([]string, error)
+	RollingReboot(name string, options DrainOptions) (*RebootStatus, error)
+	RebootStatus(name string) (*RebootStatus, error)
+	AbortReboot(name string) error
+}
+
+type nodeGroupOperator struct {
+	client      kubernetes.Interface
+	drainer     DrainOperator
+	nodeManager NodeManager
+
+	mutex   sync.Mutex
+	reboots map[string]*rebootState
+}
+
+type rebootState struct {
+	status *RebootStatus
+	cancel context.CancelFunc
+}
+
+func NewNodeGroupOperator(client kubernetes.Interface, drainer DrainOperator) NodeGroupOperator {
+	return &nodeGroupOperator{
+		client:      client,
+		drainer:     drainer,
+		nodeManager: NewNodeManager(client),
+		reboots:     make(map[string]*rebootState),
+	}
+}
+
+func (g *nodeGroupOperator) ListNodeGroups() ([]NodeGroup, error) {
+	nodeList, err := g.client.CoreV1().Nodes().List(context.Background(), metav1.ListOptions{
+		// existence selector, every labeled node belongs to a group
+		LabelSelector: NodeGroupLabel,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	members := make(map[string][]corev1.Node)
+	for _, node := range nodeList.Items {
+		if name := node.Labels[NodeGroupLabel]; name != "" {
+			members[name] = append(members[name], node)
+		}
+	}
+
+	groups := make([]NodeGroup, 0, len(members))
+	for name, nodes := range members {
+		group, err := g.summarize(name, nodes)
+		if err != nil {
+			return nil, err
+		}
+		groups = append(groups, *group)
+	}
+	sort.Slice(groups, func(i, j int) bool { return groups[i].Name < groups[j].Name })
+	return groups, nil
+}
+
+func (g *nodeGroupOperator) GetNodeGroup(name string) (*NodeGroup, error) {
+	nodes, err := g.groupNodes(name)
+	if err != nil {
+		return nil, err
+	}
+	return g.summarize(name, nodes)
+}
+
+func (g *nodeGroupOperator) CordonGroup(name string, unschedulable bool) (*NodeGroup, error) {
+	nodes, err := g.groupNodes(name)
+	if err != nil {
+		return nil, err
+	}
+	for _, node := range nodes {
+		if _, err := g.drainer.Cordon(node.Name, unschedulable); err != nil {
+			return nil, fmt.Errorf("failed to cordon node %s: %v", node.Name, err)
+		}
+	}
+	return g.GetNodeGroup(name)
+}
+
+func (g *nodeGroupOperator) SetGroupTaints(name string, taints []corev1.Taint) ([]string, error) {
+	nodes, err := g.groupNodes(name)
+	if err != nil {
+		return nil, err
+	}
+	var warnings []string
+	for _, node := range nodes {
+		result, err := g.nodeManager.UpdateTaints(node.Name, taints)
+		if err != nil {
+			return warnings, fmt.Errorf("failed to update taints of node %s: %v", node.Name, err)
+		}
+		for _, warning := range result.Warnings {
+			warnings = append(warnings, fmt.Sprintf("node %s: %s", node.Name, warning))
+		}
+	}
+	return warnings, nil
+}
+
+func (g *nodeGroupOperator) RollingReboot(name string, options DrainOptions) (*RebootStatus, error) {
+	if options.Timeout <= 0 {
+		options.Timeout = 10 * time.Minute
+	}
+
+	nodes, err := g.groupNodes(name)
+	if err != nil {
+		return nil, err
+	}
+
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	if state, ok := g.reboots[name]; ok && state.status.Phase == DrainPhaseDraining {
+		return nil, fmt.Errorf("node group %s is already being rebooted", name)
+	}
+
+	names := make([]string, 0, len(nodes))
+	for _, node := range nodes {
+		names = append(names, node.Name)
+	}
+	sort.Strings(names)
+
+	// the whole pass may take one timeout per node
+	ctx, cancel := context.WithCancel(context.Background())
+	status := &RebootStatus{
+		Group:     name,
+		Phase:     DrainPhaseDraining,
+		Pending:   names,
+		StartedAt: time.Now(),
+	}
+	g.reboots[name] = &rebootState{status: status, cancel: cancel}
+
+	go g.rebootNodes(ctx, name, names, options)
+
+	return g.copyRebootStatus(status), nil
+}
+
+func (g *nodeGroupOperator) RebootStatus(name string) (*RebootStatus, error) {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	state, ok := g.reboots[name]
+	if !ok {
+		return nil, fmt.Errorf("no rolling reboot recorded for node group %s", name)
+	}
+	return g.copyRebootStatus(state.status), nil
+}
+
+func (g *nodeGroupOperator) AbortReboot(name string) error {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	state, ok := g.reboots[name]
+	if !ok || state.status.Phase != DrainPhaseDraining {
+		return fmt.Errorf("no rolling reboot in progress for node group %s", name)
+	}
+	state.status.Phase = DrainPhaseAborted
+	state.cancel()
+	return nil
+}
+
+func (g *nodeGroupOperator) rebootNodes(ctx context.Context, group string, names []string, options DrainOptions) {
+	for _, node := range names {
+		g.setCurrent(group, node)
+		if err := g.rebootNode(ctx, node, options); err != nil {
+			g.finishReboot(group, fmt.Errorf("node %s: %v", node, err))
+			return
+		}
+		g.markRebooted(group, node)
+	}
+	g.finishReboot(group, nil)
+}
+
+// rebootNode drains the node, requests the reboot through the annotation and
+// waits until the agent removed the annotation and the node reports Ready
+// again, then reopens it for scheduling.
+func (g *nodeGroupOperator) rebootNode(ctx context.Context, node string, options DrainOptions) error {
+	if _, err := g.drainer.Drain(node, options); err != nil {
+		return err
+	}
+	if err := g.waitForDrain(ctx, node); err != nil {
+		return err
+	}
+
+	patch := fmt.Sprintf(`{"metadata":{"annotations":{"%s":"%s"}}}`, RebootRequestedAnnotation, time.Now().UTC().Format(time.RFC3339))
+	if _, err := g.client.CoreV1().Nodes().Patch(ctx, node, types.StrategicMergePatchType, []byte(patch), metav1.PatchOptions{}); err != nil {
+		return err
+	}
+
+	if err := g.waitForReboot(ctx, node, options.Timeout); err != nil {
+		return err
+	}
+
+	_, err := g.drainer.Cordon(node, false)
+	return err
+}
+
+func (g *nodeGroupOperator) waitForDrain(ctx context.Context, node string) error {
+	for {
+		status, err := g.drainer.DrainStatus(node)
+		if err != nil {
+			return err
+		}
+		switch status.Phase {
+		case DrainPhaseSucceeded:
+			return nil
+		case DrainPhaseFailed, DrainPhaseAborted:
+			return fmt.Errorf("drain ended in phase %s: %s", status.Phase, status.Message)
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(rebootPollInterval):
+		}
+	}
+}
+
+func (g *nodeGroupOperator) waitForReboot(ctx context.Context, node string, timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+	for {
+		current, err := g.client.CoreV1().Nodes().Get(ctx, node, metav1.GetOptions{})
+		if err == nil {
+			_, pending := current.Annotations[RebootRequestedAnnotation]
+			if !pending && nodeReady(current) {
+				return nil
+			}
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("node did not finish rebooting within %s", timeout)
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(rebootPollInterval):
+		}
+	}
+}
+
+func (g *nodeGroupOperator) setCurrent(group, node string) {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	if state, ok := g.reboots[group]; ok {
+		state.status.Current = node
+	}
+}
+
+func (g *nodeGroupOperator) markRebooted(group, node string) {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	if state, ok := g.reboots[group]; ok {
+		state.status.Completed = append(state.status.Completed, node)
+		for index, pending := range state.status.Pending {
+			if pending == node {
+				state.status.Pending = append(state.status.Pending[:index], state.status.Pending[index+1:]...)
+				break
+			}
+		}
+	}
+}
+
+func (g *nodeGroupOperator) finishReboot(group string, err error) {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	state, ok := g.reboots[group]
+	if !ok {
+		return
+	}
+	state.cancel()
+	state.status.Current = ""
+
+	if state.status.Phase != DrainPhaseDraining {
+		return
+	}
+	if err != nil {
+		state.status.Phase = DrainPhaseFailed
+		state.status.Message = err.Error()
+		klog.Errorf("rolling reboot of node group %s failed: %v", group, err)
+		return
+	}
+	state.status.Phase = DrainPhaseSucceeded
+	klog.V(2).Infof("rolling reboot of node group %s finished, %d nodes rebooted", group, len(state.status.Completed))
+}
+
+func (g *nodeGroupOperator) groupNodes(name string) ([]corev1.Node, error) {
+	nodeList, err := g.client.CoreV1().Nodes().List(context.Background(), metav1.ListOptions{
+		LabelSelector: labels.Set{NodeGroupLabel: name}.String(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(nodeList.Items) == 0 {
+		return nil, fmt.Errorf("node group %s has no members", name)
+	}
+	return nodeList.Items, nil
+}
+
+func (g *nodeGroupOperator) summarize(name string, nodes []corev1.Node) (*NodeGroup, error) {
+	group := &NodeGroup{
+		Name:        name,
+		Nodes:       make([]string, 0, len(nodes)),
+		Capacity:    corev1.ResourceList{},
+		Allocatable: corev1.ResourceList{},
+		Requests:    corev1.ResourceList{},
+	}
+	for _, node := range nodes {
+		group.Nodes = append(group.Nodes, node.Name)
+		if nodeReady(&node) {
+			group.ReadyNodes++
+		}
+		if node.Spec.Unschedulable {
+			group.UnschedulableNodes++
+		}
+		addResourceList(group.Capacity, node.Status.Capacity)
+		addResourceList(group.Allocatable, node.Status.Allocatable)
+
+		requests, err := g.nodeRequests(node.Name)
+		if err != nil {
+			return nil, err
+		}
+		addResourceList(group.Requests, requests)
+	}
+	sort.Strings(group.Nodes)
+	return group, nil
+}
+
+func (g *nodeGroupOperator) nodeRequests(node string) (corev1.ResourceList, error) {
+	podList, err := g.client.CoreV1().Pods(metav1.NamespaceAll).List(context.Background(), metav1.ListOptions{
+		FieldSelector: fields.OneTermEqualSelector("spec.nodeName", node).String(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	requests := corev1.ResourceList{}
+	for _, pod := range podList.Items {
+		if pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+			continue
+		}
+		for _, container := range pod.Spec.Containers {
+			addResourceList(requests, container.Resources.Requests)
+		}
+	}
+	return requests, nil
+}
+
+func (g *nodeGroupOperator) copyRebootStatus(status *RebootStatus) *RebootStatus {
+	copied := *status
+	copied.Completed = append([]string(nil), status.Completed...)
+	copied.Pending = append([]string(nil), status.Pending...)
+	return &copied
+}
+
+func nodeReady(node *corev1.Node) bool {
+	for _, condition := range node.Status.Conditions {
+		if condition.Type == corev1.NodeReady {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
+
+func addResourceList(total, addition corev1.ResourceList) {
+	for name, quantity := range addition {
+		sum := total[name]
+		sum.Add(quantity)
+		total[name] = sum
+	}
+}
@@ -70,6 +70,7 @@ type Operator interface {
 	Patch(user, resource, name string, data []byte) (runtime.Object, error)
 
 	Verify(request *restful.Request, response *restful.Response)
+	TestSend(user, name, channel string) (*TestSendResult, error)
 
 	GetObject(resource, version string) runtime.Object
 	IsKnownResource(resource, version, subresource string) bool
@@ -507,7 +508,10 @@ func (o *operator) IsKnownResource(resource, version, subresource string) bool {
 		res = true
 	}
 
-	if version == V2beta2 && subresource == "feishu" {
+	if version == V2beta2 &&
+		(subresource == "feishu" ||
+			subresource == "teams" ||
+			subresource == "pagerduty") {
 		res = true
 	}
 
@@ -680,6 +684,10 @@ func clean(obj interface{}, resource, subresource string) runtime.Object {
 			newConfig.Spec.Email = config.Spec.Email
 		case "feishu":
 			newConfig.Spec.Feishu = config.Spec.Feishu
+		case "teams":
+			newConfig.Spec.Teams = config.Spec.Teams
+		case "pagerduty":
+			newConfig.Spec.PagerDuty = config.Spec.PagerDuty
 		case "slack":
 			newConfig.Spec.Slack = config.Spec.Slack
 		case "webhook":
@@ -706,6 +714,10 @@ func clean(obj interface{}, resource, subresource string) runtime.Object {
 			newReceiver.Spec.Email = receiver.Spec.Email
 		case "feishu":
 			newReceiver.Spec.Feishu = receiver.Spec.Feishu
+		case "teams":
+			newReceiver.Spec.Teams = receiver.Spec.Teams
+		case "pagerduty":
+			newReceiver.Spec.PagerDuty = receiver.Spec.PagerDuty
 		case "slack":
 			newReceiver.Spec.Slack = receiver.Spec.Slack
 		case "webhook":
@@ -31,6 +31,7 @@ import (
 	fakeks "kubesphere.io/kubesphere/pkg/client/clientset/versioned/fake"
 	"kubesphere.io/kubesphere/pkg/constants"
 	"kubesphere.io/kubesphere/pkg/informers"
+	"kubesphere.io/kubesphere/pkg/models/resources/v1alpha3"
 )
 
 func TestOperator_List(t *testing.T) {
@@ -181,6 +182,9 @@ var (
 			Labels: map[string]string{
 				"type": "global",
 			},
+			Annotations: map[string]string{
+				v1alpha3.SafeToDeleteAnnotation: "true",
+			},
 		},
 	}
 
@@ -191,6 +195,9 @@ var (
 			Labels: map[string]string{
 				"type": "global",
 			},
+			Annotations: map[string]string{
+				v1alpha3.SafeToDeleteAnnotation: "true",
+			},
 		},
 	}
 
@@ -201,6 +208,9 @@ var (
 			Labels: map[string]string{
 				"type": "global",
 			},
+			Annotations: map[string]string{
+				v1alpha3.SafeToDeleteAnnotation: "true",
+			},
 		},
 	}
 
@@ -0,0 +1,205 @@
+/*
+Copyright 2022 The KubeSphere Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package notification
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"text/template"
+
+	"k8s.io/apimachinery/pkg/types"
+	runtimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	"kubesphere.io/api/notification/v1alpha1"
+
+	"kubesphere.io/kubesphere/pkg/simple/client/notification"
+)
+
+const (
+	// TestSendAPIPath is the notification-manager endpoint test messages
+	// are posted to, the manager routes them like ordinary alerts.
+	TestSendAPIPath = "/api/v2/alerts"
+
+	// DefaultLanguage is assumed when neither the request nor the template
+	// names one.
+	DefaultLanguage = "en"
+)
+
+// RenderRequest asks for a template to be rendered with the given
+// variables, either a stored template referenced by name or an inline
+// content for previewing unsaved edits.
+type RenderRequest struct {
+	// Name of the NotificationTemplate to render, ignored when Content is
+	// set.
+	Name string `json:"name,omitempty"`
+	// Content to render inline, takes precedence over Name.
+	Content *v1alpha1.TemplateContent `json:"content,omitempty"`
+	// Language of the content to render, falls back to the default
+	// language of the template.
+	Language string `json:"language,omitempty"`
+	// Variables substituted into the template.
+	Variables map[string]string `json:"variables,omitempty"`
+}
+
+// RenderResult is the rendered wording of a template.
+type RenderResult struct {
+	Subject string `json:"subject,omitempty"`
+	Body    string `json:"body"`
+}
+
+// TemplateOperator renders NotificationTemplate objects and pushes test
+// messages through the notification-manager.
+type TemplateOperator interface {
+	Render(ctx context.Context, request *RenderRequest) (*RenderResult, error)
+	TestSend(ctx context.Context, request *RenderRequest) error
+}
+
+type templateOperator struct {
+	client     runtimeclient.Client
+	options    *notification.Options
+	httpClient *http.Client
+}
+
+func NewTemplateOperator(client runtimeclient.Client, options *notification.Options) TemplateOperator {
+	return &templateOperator{
+		client:     client,
+		options:    options,
+		httpClient: &http.Client{},
+	}
+}
+
+func (t *templateOperator) Render(ctx context.Context, request *RenderRequest) (*RenderResult, error) {
+	content := request.Content
+	if content == nil {
+		var err error
+		if content, err = t.resolveContent(ctx, request.Name, request.Language); err != nil {
+			return nil, err
+		}
+	}
+
+	subject, err := render("subject", content.Subject, request.Variables)
+	if err != nil {
+		return nil, err
+	}
+	body, err := render("body", content.Body, request.Variables)
+	if err != nil {
+		return nil, err
+	}
+
+	return &RenderResult{Subject: subject, Body: body}, nil
+}
+
+func (t *templateOperator) TestSend(ctx context.Context, request *RenderRequest) error {
+	if t.options == nil || len(t.options.Endpoint) == 0 {
+		return fmt.Errorf("cannot find Notification Manager endpoint")
+	}
+
+	rendered, err := t.Render(ctx, request)
+	if err != nil {
+		return err
+	}
+
+	// The test message travels the same path as a firing alert, the
+	// notification-manager routes it to the receivers matching its labels.
+	message := []map[string]interface{}{
+		{
+			"labels": map[string]string{
+				"alertname": "notification-template-test",
+				"severity":  "info",
+			},
+			"annotations": map[string]string{
+				"summary": rendered.Subject,
+				"message": rendered.Body,
+			},
+		},
+	}
+	payload, err := json.Marshal(message)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, fmt.Sprintf("%s%s", t.options.Endpoint, TestSendAPIPath), bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := t.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("notification manager returned status %s", resp.Status)
+	}
+	return nil
+}
+
+// resolveContent picks the content of the requested language, falling back
+// to the default language of the template.
+func (t *templateOperator) resolveContent(ctx context.Context, name, language string) (*v1alpha1.TemplateContent, error) {
+	if name == "" {
+		return nil, fmt.Errorf("either template name or inline content is required")
+	}
+
+	tmpl := &v1alpha1.NotificationTemplate{}
+	if err := t.client.Get(ctx, types.NamespacedName{Name: name}, tmpl); err != nil {
+		return nil, err
+	}
+
+	defaultLanguage := tmpl.Spec.DefaultLanguage
+	if defaultLanguage == "" {
+		defaultLanguage = DefaultLanguage
+	}
+	if language == "" {
+		language = defaultLanguage
+	}
+
+	var fallback *v1alpha1.TemplateContent
+	for i := range tmpl.Spec.Contents {
+		content := &tmpl.Spec.Contents[i]
+		if content.Language == language {
+			return content, nil
+		}
+		if content.Language == defaultLanguage {
+			fallback = content
+		}
+	}
+	if fallback != nil {
+		return fallback, nil
+	}
+	return nil, fmt.Errorf("template %s has no content for language %s", name, language)
+}
+
+func render(name, text string, variables map[string]string) (string, error) {
+	if text == "" {
+		return "", nil
+	}
+	tmpl, err := template.New(name).Option("missingkey=zero").Parse(text)
+	if err != nil {
+		return "", fmt.Errorf("invalid %s template: %v", name, err)
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, variables); err != nil {
+		return "", fmt.Errorf("failed to render %s template: %v", name, err)
+	}
+	return buf.String(), nil
+}
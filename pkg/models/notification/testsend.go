@@ -0,0 +1,221 @@
+/*
+Copyright 2022 The KubeSphere Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package notification
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/klog"
+	"kubesphere.io/api/notification/v2beta2"
+
+	"kubesphere.io/kubesphere/pkg/apiserver/query"
+)
+
+// TestSendResult is the delivery diagnostics of a test message.
+type TestSendResult struct {
+	// Receiver the test message was sent through.
+	Receiver string `json:"receiver"`
+	// Channel of the receiver, e.g. email or slack.
+	Channel string `json:"channel"`
+	// Success is true when the notification manager accepted and delivered
+	// the message.
+	Success bool `json:"success"`
+	// Message carries the response of the notification manager, on failure
+	// it explains what went wrong.
+	Message string `json:"message,omitempty"`
+	// DurationMs is the time the delivery took.
+	DurationMs int64 `json:"durationMs"`
+}
+
+// TestSend delivers a test message through the named receiver and reports
+// the outcome, so a channel configuration can be verified without waiting
+// for a real alert. The user follows the same tenant semantics as Get.
+func (o *operator) TestSend(user, name, channel string) (*TestSendResult, error) {
+	if o.options == nil || len(o.options.Endpoint) == 0 {
+		return nil, fmt.Errorf("cannot find Notification Manager endpoint")
+	}
+
+	obj, err := o.Get(user, v2beta2.ResourcesPluralReceiver, name, channel)
+	if err != nil {
+		return nil, err
+	}
+	receiver := obj.(*v2beta2.Receiver)
+
+	if channel == "" {
+		if channel = receiverChannel(receiver); channel == "" {
+			return nil, fmt.Errorf("receiver %s has no channel configured", name)
+		}
+	}
+
+	data := Data{Receiver: *receiver}
+	if config := o.matchingConfig(user, receiver, channel); config != nil {
+		data.Config = *config
+	}
+
+	body, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+
+	start := time.Now()
+	resp, err := http.Post(fmt.Sprintf("%s%s", o.options.Endpoint, VerificationAPIPath), "application/json", bytes.NewReader(body))
+	duration := time.Since(start).Milliseconds()
+	if err != nil {
+		return &TestSendResult{
+			Receiver:   name,
+			Channel:    channel,
+			Success:    false,
+			Message:    err.Error(),
+			DurationMs: duration,
+		}, nil
+	}
+	defer resp.Body.Close()
+
+	result := Result{}
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(respBody, &result); err != nil {
+		result.Code = resp.StatusCode
+		result.Message = string(respBody)
+	}
+
+	return &TestSendResult{
+		Receiver:   name,
+		Channel:    channel,
+		Success:    resp.StatusCode == http.StatusOK && result.Code == http.StatusOK,
+		Message:    result.Message,
+		DurationMs: duration,
+	}, nil
+}
+
+// matchingConfig resolves the config the receiver selects for the channel,
+// nil when the channel needs none, e.g. a webhook with a plain url.
+func (o *operator) matchingConfig(user string, receiver *v2beta2.Receiver, channel string) *v2beta2.Config {
+	selector := configSelector(receiver, channel)
+
+	res, err := o.List(user, v2beta2.ResourcesPluralConfig, channel, query.New())
+	if err != nil || len(res.Items) == 0 {
+		// Tenant receivers may rely on the default config.
+		if user != "" {
+			res, err = o.List("", v2beta2.ResourcesPluralConfig, channel, query.New())
+		}
+		if err != nil || res == nil {
+			return nil
+		}
+	}
+
+	for _, item := range res.Items {
+		config, ok := item.(*v2beta2.Config)
+		if !ok {
+			continue
+		}
+		if selector == nil {
+			return config
+		}
+		s, err := metav1.LabelSelectorAsSelector(selector)
+		if err != nil {
+			klog.Error(err)
+			return nil
+		}
+		if s.Matches(labels.Set(config.Labels)) {
+			return config
+		}
+	}
+	return nil
+}
+
+func receiverChannel(receiver *v2beta2.Receiver) string {
+	switch {
+	case receiver.Spec.DingTalk != nil:
+		return "dingtalk"
+	case receiver.Spec.Email != nil:
+		return "email"
+	case receiver.Spec.Feishu != nil:
+		return "feishu"
+	case receiver.Spec.PagerDuty != nil:
+		return "pagerduty"
+	case receiver.Spec.Pushover != nil:
+		return "pushover"
+	case receiver.Spec.Slack != nil:
+		return "slack"
+	case receiver.Spec.Sms != nil:
+		return "sms"
+	case receiver.Spec.Teams != nil:
+		return "teams"
+	case receiver.Spec.Webhook != nil:
+		return "webhook"
+	case receiver.Spec.Wechat != nil:
+		return "wechat"
+	default:
+		return ""
+	}
+}
+
+func configSelector(receiver *v2beta2.Receiver, channel string) *metav1.LabelSelector {
+	switch channel {
+	case "dingtalk":
+		if receiver.Spec.DingTalk != nil {
+			return receiver.Spec.DingTalk.DingTalkConfigSelector
+		}
+	case "email":
+		if receiver.Spec.Email != nil {
+			return receiver.Spec.Email.EmailConfigSelector
+		}
+	case "feishu":
+		if receiver.Spec.Feishu != nil {
+			return receiver.Spec.Feishu.FeishuConfigSelector
+		}
+	case "pagerduty":
+		if receiver.Spec.PagerDuty != nil {
+			return receiver.Spec.PagerDuty.PagerDutyConfigSelector
+		}
+	case "pushover":
+		if receiver.Spec.Pushover != nil {
+			return receiver.Spec.Pushover.PushoverConfigSelector
+		}
+	case "slack":
+		if receiver.Spec.Slack != nil {
+			return receiver.Spec.Slack.SlackConfigSelector
+		}
+	case "sms":
+		if receiver.Spec.Sms != nil {
+			return receiver.Spec.Sms.SmsConfigSelector
+		}
+	case "teams":
+		if receiver.Spec.Teams != nil {
+			return receiver.Spec.Teams.TeamsConfigSelector
+		}
+	case "webhook":
+		if receiver.Spec.Webhook != nil {
+			return receiver.Spec.Webhook.WebhookConfigSelector
+		}
+	case "wechat":
+		if receiver.Spec.Wechat != nil {
+			return receiver.Spec.Wechat.WechatConfigSelector
+		}
+	}
+	return nil
+}
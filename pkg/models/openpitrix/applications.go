@@ -61,6 +61,7 @@ type ApplicationInterface interface {
 	DoAppVersionAction(versionId string, request *ActionRequest) error
 	ListAppVersionAudits(conditions *params.Conditions, orderBy string, reverse bool, limit, offset int) (*models.PageableResponse, error)
 	GetAppVersionFiles(versionId string, request *GetAppVersionFilesRequest) (*GetAppVersionPackageFilesResponse, error)
+	GetAppVersionExtras(versionId string) (*GetAppVersionExtrasResponse, error)
 	ListAppVersionReviews(conditions *params.Conditions, orderBy string, reverse bool, limit, offset int) (*models.PageableResponse, error)
 	ListAppVersions(conditions *params.Conditions, orderBy string, reverse bool, limit, offset int) (*models.PageableResponse, error)
 }
@@ -266,6 +267,7 @@ func (c *applicationOperator) CreateApp(req *CreateAppRequest) (*CreateAppRespon
 	// create app version
 	chartPackage := req.VersionPackage.String()
 	ver := buildApplicationVersion(app, chrt, &chartPackage, req.Username)
+	attachChartExtras(ver, req.VersionPackage)
 	ver, err = c.createApplicationVersion(ver)
 
 	if err != nil {
@@ -44,6 +44,7 @@ import (
 	"kubesphere.io/kubesphere/pkg/models"
 	"kubesphere.io/kubesphere/pkg/server/params"
 	"kubesphere.io/kubesphere/pkg/simple/client/openpitrix/helmrepoindex"
+	"kubesphere.io/kubesphere/pkg/utils/sliceutil"
 	"kubesphere.io/kubesphere/pkg/utils/stringutils"
 )
 
@@ -83,6 +84,7 @@ func (c *applicationOperator) CreateAppVersion(request *CreateAppVersionRequest)
 	}
 	chartPackage := request.Package.String()
 	version := buildApplicationVersion(app, chrt, &chartPackage, request.Username)
+	attachChartExtras(version, request.Package)
 	version, err = c.createApplicationVersion(version)
 
 	if err != nil {
@@ -203,6 +205,7 @@ func (c *applicationOperator) ModifyAppVersion(id string, request *ModifyAppVers
 
 		now := metav1.Now()
 		spec.Created = &now
+		attachChartExtras(versionCopy, request.Package)
 
 		// 3. save chart data to s3 storage, just overwrite the legacy data
 		err = c.backingStoreClient.Upload(dataKeyInStorage(versionCopy.GetWorkspace(), versionCopy.Name), versionCopy.Name, bytes.NewBuffer(request.Package), len(request.Package))
@@ -350,6 +353,18 @@ func (c *applicationOperator) ListAppVersionAudits(conditions *params.Conditions
 	return &models.PageableResponse{Items: items, TotalCount: totalCount}, nil
 }
 
+// appVersionActionStates lists the states an action may be performed
+// from, an action applied to a version in any other state is rejected.
+var appVersionActionStates = map[string][]string{
+	ActionSubmit:  {v1alpha1.StateDraft, v1alpha1.StateRejected},
+	ActionCancel:  {v1alpha1.StateSubmitted},
+	ActionPass:    {v1alpha1.StateSubmitted},
+	ActionReject:  {v1alpha1.StateSubmitted},
+	ActionRelease: {v1alpha1.StatePassed},
+	ActionSuspend: {v1alpha1.StateActive},
+	ActionRecover: {v1alpha1.StateSuspended},
+}
+
 func (c *applicationOperator) DoAppVersionAction(versionId string, request *ActionRequest) error {
 	var err error
 	t := metav1.Now()
@@ -371,6 +386,17 @@ func (c *applicationOperator) DoAppVersionAction(versionId string, request *Acti
 		return apierrors.NewForbidden(v1alpha1.Resource(v1alpha1.ResourcePluralHelmApplicationVersion), version.Name, errors.New("version is immutable"))
 	}
 
+	// The version controller sets the initial draft state asynchronously,
+	// a version without state yet is still a draft.
+	currentState := version.Status.State
+	if currentState == "" {
+		currentState = v1alpha1.StateDraft
+	}
+	if states, exists := appVersionActionStates[request.Action]; exists && !sliceutil.HasString(states, currentState) {
+		return apierrors.NewForbidden(v1alpha1.Resource(v1alpha1.ResourcePluralHelmApplicationVersion), version.Name,
+			fmt.Errorf("can not %s app version in state %s", request.Action, currentState))
+	}
+
 	switch request.Action {
 	case ActionCancel:
 		state = v1alpha1.StateDraft
@@ -382,11 +408,9 @@ func (c *applicationOperator) DoAppVersionAction(versionId string, request *Acti
 		state = v1alpha1.StateActive
 		audit.State = v1alpha1.StateActive
 	case ActionReject:
-		// todo check status
 		state = v1alpha1.StateRejected
 		audit.State = v1alpha1.StateRejected
 	case ActionSubmit:
-		// todo check status
 		state = v1alpha1.StateSubmitted
 		audit.State = v1alpha1.StateSubmitted
 	case ActionSuspend:
@@ -542,6 +566,42 @@ func (c *applicationOperator) GetAppVersionFiles(versionId string, request *GetA
 	return res, nil
 }
 
+// GetAppVersionExtras returns the readme, icon and default values extracted
+// from the chart package. Versions imported before extraction was in place
+// fall back to parsing the chart data on the fly.
+func (c *applicationOperator) GetAppVersionExtras(versionId string) (*GetAppVersionExtrasResponse, error) {
+	version, err := c.getAppVersion(versionId)
+	if err != nil {
+		klog.Errorf("get app version [%s] failed, error: %s", versionId, err)
+		return nil, err
+	}
+
+	res := &GetAppVersionExtrasResponse{
+		VersionId:     versionId,
+		Readme:        version.Status.Readme,
+		Icon:          version.Status.Icon,
+		DefaultValues: version.Status.DefaultValues,
+	}
+
+	if res.Readme == "" && res.DefaultValues == "" {
+		version, err = c.getAppVersionByVersionIdWithData(versionId)
+		if err != nil {
+			klog.Errorf("get app version %s chart data failed: %v", versionId, err)
+			return nil, err
+		}
+		extras, err := helmrepoindex.LoadChartExtras(version.Spec.Data)
+		if err != nil {
+			klog.Errorf("Failed to load package for app version: %s, error: %+v", versionId, err)
+			return nil, err
+		}
+		res.Readme = extras.Readme
+		res.Icon = extras.Icon
+		res.DefaultValues = extras.DefaultValues
+	}
+
+	return res, nil
+}
+
 func (c *applicationOperator) getAppVersionByVersionIdWithData(versionId string) (*v1alpha1.HelmApplicationVersion, error) {
 	if version, exists, err := c.cachedRepos.GetAppVersionWithData(versionId); exists {
 		if err != nil {
@@ -59,6 +59,10 @@ type ReleaseInterface interface {
 	ModifyApplication(request ModifyClusterAttributesRequest) error
 	DeleteApplication(workspace, clusterName, namespace, id string) error
 	UpgradeApplication(request UpgradeClusterRequest) error
+	GetApplicationValues(applicationId string) ([]byte, error)
+	DiffApplicationValues(workspace, clusterName, namespace, applicationId string, fromVersion, toVersion int) (*ValuesDiff, error)
+	ListImportableApplications(clusterName, namespace string) (*models.PageableResponse, error)
+	ImportApplication(workspace, clusterName, namespace string, request ImportApplicationRequest) error
 }
 
 type releaseOperator struct {
@@ -117,6 +121,10 @@ func (c *releaseOperator) UpgradeApplication(request UpgradeClusterRequest) erro
 	newRls := oldRls.DeepCopy()
 	newRls.Spec.ApplicationId = request.AppId
 	newRls.Spec.ApplicationVersionId = request.VersionId
+	if newRls.Annotations == nil {
+		newRls.Annotations = map[string]string{}
+	}
+	newRls.Annotations[v1alpha1.HelmReleaseLastOperatorAnnotationKey] = request.Username
 
 	newRls.Spec.Version += 1
 	newRls.Spec.RepoId = version.GetHelmRepoId()
@@ -163,6 +171,11 @@ func (c *releaseOperator) CreateApplication(workspace, clusterName, namespace st
 		return err
 	}
 
+	if err := c.checkRepoVisibility(version, workspace, request.Username); err != nil {
+		klog.Error(err)
+		return err
+	}
+
 	rls := &v1alpha1.HelmRelease{
 		ObjectMeta: metav1.ObjectMeta{
 			Name: idutils.GetUuid36(v1alpha1.HelmReleasePrefix),
@@ -210,6 +223,32 @@ func (c *releaseOperator) CreateApplication(workspace, clusterName, namespace st
 	return nil
 }
 
+// checkRepoVisibility rejects deployments from repos the target workspace
+// may not use: workspace scoped repos only serve their own workspace and
+// private repos only their creator.
+func (c *releaseOperator) checkRepoVisibility(version *v1alpha1.HelmApplicationVersion, workspace, username string) error {
+	repoId := version.GetHelmRepoId()
+	if repoId == "" || repoId == v1alpha1.AppStoreRepoId {
+		return nil
+	}
+	repo, exists := c.cachedRepos.GetRepo(repoId)
+	if !exists {
+		return nil
+	}
+	switch repo.GetVisibility() {
+	case v1alpha1.RepoVisibilityGlobal:
+	case v1alpha1.RepoVisibilityPrivate:
+		if username != "" && repo.GetCreator() != username {
+			return fmt.Errorf("repo %s is private", repo.GetTrueName())
+		}
+	default:
+		if ws := repo.GetWorkspace(); ws != "" && workspace != "" && ws != workspace {
+			return fmt.Errorf("repo %s is not visible in workspace %s", repo.GetTrueName(), workspace)
+		}
+	}
+	return nil
+}
+
 func (c *releaseOperator) releaseExists(workspace, clusterName, namespace, name string) (bool, error) {
 	set := map[string]string{
 		constants.WorkspaceLabelKey: workspace,
@@ -0,0 +1,213 @@
+/*
+Copyright 2020 The KubeSphere Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package openpitrix
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-openapi/strfmt"
+	rspb "helm.sh/helm/v3/pkg/release"
+	"helm.sh/helm/v3/pkg/storage/driver"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/klog"
+	"sigs.k8s.io/yaml"
+
+	"kubesphere.io/api/application/v1alpha1"
+
+	"kubesphere.io/kubesphere/pkg/constants"
+	"kubesphere.io/kubesphere/pkg/models"
+	"kubesphere.io/kubesphere/pkg/utils/idutils"
+	"kubesphere.io/kubesphere/pkg/utils/stringutils"
+)
+
+// ListImportableApplications scans the helm v3 release secrets in the given
+// cluster and namespace and returns the releases that are not managed by
+// KubeSphere, so they can be adopted as HelmReleases.
+func (c *releaseOperator) ListImportableApplications(clusterName, namespace string) (*models.PageableResponse, error) {
+	releases, err := c.listUnmanagedReleases(clusterName, namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]interface{}, 0, len(releases))
+	for _, rel := range releases {
+		item := ImportableApplication{
+			Name:      rel.Name,
+			Namespace: rel.Namespace,
+			Version:   rel.Version,
+		}
+		if rel.Chart != nil && rel.Chart.Metadata != nil {
+			item.ChartName = rel.Chart.Metadata.Name
+			item.ChartVersion = rel.Chart.Metadata.Version
+			item.ChartAppVersion = rel.Chart.Metadata.AppVersion
+		}
+		if rel.Info != nil {
+			item.Status = rel.Info.Status.String()
+			if !rel.Info.LastDeployed.IsZero() {
+				t := strfmt.DateTime(rel.Info.LastDeployed.Time)
+				item.LastDeployed = &t
+			}
+		}
+		items = append(items, item)
+	}
+
+	return &models.PageableResponse{TotalCount: len(items), Items: items}, nil
+}
+
+// ImportApplication adopts an existing helm v3 release as a HelmRelease.
+// The chart metadata and values are imported from the release secret and
+// the created resource is marked active at the imported revision, so the
+// controller does not redeploy the release.
+func (c *releaseOperator) ImportApplication(workspace, clusterName, namespace string, request ImportApplicationRequest) error {
+	exists, err := c.releaseExists(workspace, clusterName, namespace, request.Name)
+	if err != nil {
+		klog.Errorf("get helm release %s failed, error: %v", request.Name, err)
+		return err
+	}
+	if exists {
+		err = fmt.Errorf("release %s exists", request.Name)
+		klog.Error(err)
+		return err
+	}
+
+	releases, err := c.listUnmanagedReleases(clusterName, namespace)
+	if err != nil {
+		return err
+	}
+	var rel *rspb.Release
+	for _, item := range releases {
+		if item.Name == request.Name {
+			rel = item
+			break
+		}
+	}
+	if rel == nil {
+		return fmt.Errorf("helm release %s not found in %s/%s", request.Name, clusterName, namespace)
+	}
+
+	var values []byte
+	if len(rel.Config) > 0 {
+		values, err = yaml.Marshal(rel.Config)
+		if err != nil {
+			klog.Errorf("marshal values of release %s failed, error: %s", rel.Name, err)
+			return err
+		}
+	}
+
+	rls := &v1alpha1.HelmRelease{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: idutils.GetUuid36(v1alpha1.HelmReleasePrefix),
+			Annotations: map[string]string{
+				constants.CreatorAnnotationKey: request.Username,
+			},
+			Labels: map[string]string{
+				constants.WorkspaceLabelKey: workspace,
+				constants.NamespaceLabelKey: namespace,
+			},
+		},
+		Spec: v1alpha1.HelmReleaseSpec{
+			Name:        rel.Name,
+			Description: stringutils.ShortenString(request.Description, v1alpha1.MsgLen),
+			Values:      values,
+			Version:     rel.Version,
+		},
+	}
+	if rel.Chart != nil && rel.Chart.Metadata != nil {
+		rls.Spec.ChartName = rel.Chart.Metadata.Name
+		rls.Spec.ChartVersion = rel.Chart.Metadata.Version
+		rls.Spec.ChartAppVersion = rel.Chart.Metadata.AppVersion
+	}
+	if clusterName != "" {
+		rls.Labels[constants.ClusterNameLabelKey] = clusterName
+	}
+
+	created, err := c.rlsClient.Create(context.TODO(), rls, metav1.CreateOptions{})
+	if err != nil {
+		klog.Errorln(err)
+		return err
+	}
+
+	// mark the release active at the imported revision, so the controller
+	// adopts the existing deployment instead of running helm install
+	created.Status.State = v1alpha1.HelmStatusActive
+	created.Status.Version = rel.Version
+	created.Status.LastUpdate = metav1.Now()
+	_, err = c.rlsClient.UpdateStatus(context.TODO(), created, metav1.UpdateOptions{})
+	if err != nil {
+		klog.Errorf("update status of imported release %s failed, error: %s", created.Name, err)
+		return err
+	}
+
+	klog.Infof("import helm release %s success in %s", rel.Name, namespace)
+	return nil
+}
+
+// listUnmanagedReleases returns the latest revision of each deployed helm v3
+// release in the given cluster and namespace that has no matching
+// HelmRelease resource.
+func (c *releaseOperator) listUnmanagedReleases(clusterName, namespace string) ([]*rspb.Release, error) {
+	client, err := c.clusterClients.GetKubernetesClientSet(clusterName)
+	if err != nil {
+		klog.Errorf("get kubernetes client of cluster %s failed, error: %s", clusterName, err)
+		return nil, err
+	}
+
+	all, err := driver.NewSecrets(client.CoreV1().Secrets(namespace)).List(func(*rspb.Release) bool {
+		return true
+	})
+	if err != nil {
+		klog.Errorf("list helm release secrets in %s/%s failed, error: %s", clusterName, namespace, err)
+		return nil, err
+	}
+
+	latest := make(map[string]*rspb.Release, len(all))
+	for _, rel := range all {
+		if cur, ok := latest[rel.Name]; !ok || rel.Version > cur.Version {
+			latest[rel.Name] = rel
+		}
+	}
+
+	set := map[string]string{
+		constants.NamespaceLabelKey: namespace,
+	}
+	if clusterName != "" {
+		set[constants.ClusterNameLabelKey] = clusterName
+	}
+	list, err := c.rlsLister.List(labels.SelectorFromSet(set))
+	if err != nil {
+		return nil, err
+	}
+	managed := make(map[string]bool, len(list))
+	for _, rls := range list {
+		managed[rls.Spec.Name] = true
+	}
+
+	ret := make([]*rspb.Release, 0, len(latest))
+	for _, rel := range latest {
+		if managed[rel.Name] {
+			continue
+		}
+		if rel.Info != nil && rel.Info.Status != rspb.StatusDeployed {
+			continue
+		}
+		ret = append(ret, rel)
+	}
+
+	return ret, nil
+}
@@ -287,6 +287,27 @@ func (c *repoOperator) ListRepos(conditions *params.Conditions, orderBy string,
 		klog.Error(err)
 		return nil, err
 	}
+
+	// workspace members also see repos published to the whole platform
+	if conditions.Match[WorkspaceLabel] != "" {
+		all, err := c.repoLister.List(labels.Everything())
+		if err != nil {
+			klog.Error(err)
+			return nil, err
+		}
+		seen := make(map[string]bool, len(repos))
+		for _, repo := range repos {
+			seen[repo.Name] = true
+		}
+		for _, repo := range all {
+			if !seen[repo.Name] && repo.GetVisibility() == v1alpha1.RepoVisibilityGlobal {
+				repos = append(repos, repo)
+			}
+		}
+	}
+
+	repos = filterReposByVisibility(repos, conditions.Match[CurrentUser])
+
 	if conditions.Match[Keyword] != "" {
 		repos = helmRepoFilter(conditions.Match[Keyword], repos)
 	}
@@ -307,6 +328,22 @@ func (c *repoOperator) ListRepos(conditions *params.Conditions, orderBy string,
 	return &models.PageableResponse{Items: items, TotalCount: totalCount}, nil
 }
 
+// filterReposByVisibility drops repos the current user may not see:
+// private repos are only visible to their creator. Callers that do not
+// supply a user keep the full list.
+func filterReposByVisibility(list []*v1alpha1.HelmRepo, currentUser string) (res []*v1alpha1.HelmRepo) {
+	if currentUser == "" {
+		return list
+	}
+	for _, repo := range list {
+		if repo.GetVisibility() == v1alpha1.RepoVisibilityPrivate && repo.GetCreator() != currentUser {
+			continue
+		}
+		res = append(res, repo)
+	}
+	return
+}
+
 func helmRepoFilter(namePrefix string, list []*v1alpha1.HelmRepo) (res []*v1alpha1.HelmRepo) {
 	lowerPrefix := strings.ToLower(namePrefix)
 	for _, repo := range list {
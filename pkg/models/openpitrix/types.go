@@ -249,6 +249,21 @@ type GetAppVersionPackageFilesResponse struct {
 	// version id
 	VersionId string `json:"version_id,omitempty"`
 }
+
+type GetAppVersionExtrasResponse struct {
+
+	// readme extracted from the chart package
+	Readme string `json:"readme,omitempty"`
+
+	// icon url from the chart metadata, or the base64 encoded icon file
+	Icon string `json:"icon,omitempty"`
+
+	// default values.yaml of the chart
+	DefaultValues string `json:"default_values,omitempty"`
+
+	// version id
+	VersionId string `json:"version_id,omitempty"`
+}
 type AppVersionReview struct {
 
 	// app id
@@ -599,7 +614,7 @@ type CreateRepoRequest struct {
 	// required, url of visiting the repository
 	URL string `json:"url,omitempty"`
 
-	// required, visibility eg:[public|private]
+	// repo visibility, valid values are "global", "workspace" and "private"
 	Visibility string `json:"visibility,omitempty"`
 }
 
@@ -775,6 +790,46 @@ type CreateClusterRequest struct {
 	Workspace string `json:"workspace,omitempty"`
 }
 
+// ImportableApplication is a helm v3 release found in a member cluster that
+// is not managed by KubeSphere.
+type ImportableApplication struct {
+
+	// release name
+	Name string `json:"name"`
+
+	// namespace the release is deployed in
+	Namespace string `json:"namespace"`
+
+	// name of the chart the release was installed from
+	ChartName string `json:"chart_name,omitempty"`
+
+	// version of the chart the release was installed from
+	ChartVersion string `json:"chart_version,omitempty"`
+
+	// appVersion from Chart.yaml
+	ChartAppVersion string `json:"chart_app_version,omitempty"`
+
+	// current release revision
+	Version int `json:"version"`
+
+	// helm release status
+	Status string `json:"status,omitempty"`
+
+	// last deploy time
+	LastDeployed *strfmt.DateTime `json:"last_deployed,omitempty"`
+}
+
+type ImportApplicationRequest struct {
+
+	// release name
+	Name string `json:"name"`
+
+	// release import description
+	Description string `json:"description,omitempty"`
+
+	Username string `json:"-"`
+}
+
 type UpgradeClusterRequest struct {
 	// release namespace
 	Namespace string `json:"namespace,omitempty"`
@@ -912,6 +967,7 @@ const (
 	Keyword         = "keyword"
 	ISV             = "isv"
 	WorkspaceLabel  = "workspace"
+	CurrentUser     = "current_user"
 	BuiltinRepoId   = "repo-helm"
 	StatusActive    = "active"
 	StatusSuspended = "suspended"
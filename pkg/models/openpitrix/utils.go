@@ -29,6 +29,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/cli-runtime/pkg/resource"
+	"k8s.io/klog"
 
 	"kubesphere.io/api/application/v1alpha1"
 
@@ -601,6 +602,20 @@ func buildApplicationVersion(app *v1alpha1.HelmApplication, chrt helmrepoindex.V
 	return ver
 }
 
+// attachChartExtras extracts README.md, icon and default values from the
+// chart package into the app version status, so the console can render the
+// app detail page without downloading and unpacking the chart.
+func attachChartExtras(ver *v1alpha1.HelmApplicationVersion, pkg []byte) {
+	extras, err := helmrepoindex.LoadChartExtras(pkg)
+	if err != nil {
+		klog.Warningf("extract chart extras for app version %s failed, error: %s", ver.GetVersionName(), err)
+		return
+	}
+	ver.Status.Readme = extras.Readme
+	ver.Status.Icon = extras.Icon
+	ver.Status.DefaultValues = extras.DefaultValues
+}
+
 func filterAppByName(app *v1alpha1.HelmApplication, namePart string) bool {
 	if len(namePart) == 0 {
 		return true
@@ -0,0 +1,204 @@
+/*
+Copyright 2022 The KubeSphere Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package openpitrix
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/ghodss/yaml"
+	"k8s.io/klog"
+
+	"kubesphere.io/api/application/v1alpha1"
+)
+
+const (
+	// ValuesDiffAdded means the key only exists in the newer revision.
+	ValuesDiffAdded = "added"
+	// ValuesDiffRemoved means the key only exists in the older revision.
+	ValuesDiffRemoved = "removed"
+	// ValuesDiffModified means the key exists in both revisions with different values.
+	ValuesDiffModified = "modified"
+
+	// redactedValue replaces the value of sensitive keys in a diff.
+	redactedValue = "******"
+)
+
+// sensitiveKeyWords marks values keys whose content must not appear in a
+// diff or an audit event.
+var sensitiveKeyWords = []string{"password", "passwd", "secret", "token", "credential", "cert", "key"}
+
+// ValuesDiffEntry is one changed key between two values revisions, values
+// of sensitive keys are redacted.
+type ValuesDiffEntry struct {
+	Key      string `json:"key" description:"dotted path of the changed values key"`
+	Change   string `json:"change" description:"added, removed or modified"`
+	OldValue string `json:"old_value,omitempty" description:"value in the older revision, redacted for sensitive keys"`
+	NewValue string `json:"new_value,omitempty" description:"value in the newer revision, redacted for sensitive keys"`
+}
+
+// ValuesDiff is the difference of the values between two deployed revisions
+// of an application release.
+type ValuesDiff struct {
+	FromVersion  int               `json:"from_version" description:"the older revision"`
+	FromOperator string            `json:"from_operator,omitempty" description:"who submitted the older values"`
+	ToVersion    int               `json:"to_version" description:"the newer revision"`
+	ToOperator   string            `json:"to_operator,omitempty" description:"who submitted the newer values"`
+	Entries      []ValuesDiffEntry `json:"entries" description:"changed keys"`
+}
+
+// GetApplicationValues returns the values of the current spec version of
+// the release.
+func (c *releaseOperator) GetApplicationValues(applicationId string) ([]byte, error) {
+	rls, err := c.rlsLister.Get(applicationId)
+	if err != nil {
+		klog.Errorf("get release failed, error: %s", err)
+		return nil, err
+	}
+	return rls.Spec.Values, nil
+}
+
+func (c *releaseOperator) DiffApplicationValues(workspace, clusterName, namespace, applicationId string, fromVersion, toVersion int) (*ValuesDiff, error) {
+	rls, err := c.rlsLister.Get(applicationId)
+	if err != nil {
+		klog.Errorf("get release failed, error: %s", err)
+		return nil, err
+	}
+
+	fromValues, fromOperator, err := releaseValuesRevision(rls, fromVersion)
+	if err != nil {
+		return nil, err
+	}
+	toValues, toOperator, err := releaseValuesRevision(rls, toVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := DiffValues(fromValues, toValues)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ValuesDiff{
+		FromVersion:  fromVersion,
+		FromOperator: fromOperator,
+		ToVersion:    toVersion,
+		ToOperator:   toOperator,
+		Entries:      entries,
+	}, nil
+}
+
+// releaseValuesRevision returns the values and the operator of the given
+// spec version, the pending spec version may not be deployed yet.
+func releaseValuesRevision(rls *v1alpha1.HelmRelease, version int) ([]byte, string, error) {
+	for _, revision := range rls.Status.ValuesHistory {
+		if revision.Version == version {
+			return revision.Values, revision.Operator, nil
+		}
+	}
+	if version == rls.Spec.Version {
+		return rls.Spec.Values, rls.Annotations[v1alpha1.HelmReleaseLastOperatorAnnotationKey], nil
+	}
+	return nil, "", fmt.Errorf("values of revision %d not found, only the last %d revisions are kept", version, v1alpha1.MaxValuesHistory)
+}
+
+// DiffValues compares two values.yaml payloads and returns the changed
+// keys, values of sensitive keys are redacted.
+func DiffValues(oldValues, newValues []byte) ([]ValuesDiffEntry, error) {
+	oldFlat, err := flattenValues(oldValues)
+	if err != nil {
+		return nil, err
+	}
+	newFlat, err := flattenValues(newValues)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]ValuesDiffEntry, 0)
+	for key, oldValue := range oldFlat {
+		newValue, ok := newFlat[key]
+		if !ok {
+			entries = append(entries, ValuesDiffEntry{Key: key, Change: ValuesDiffRemoved, OldValue: redact(key, oldValue)})
+		} else if oldValue != newValue {
+			entries = append(entries, ValuesDiffEntry{Key: key, Change: ValuesDiffModified, OldValue: redact(key, oldValue), NewValue: redact(key, newValue)})
+		}
+	}
+	for key, newValue := range newFlat {
+		if _, ok := oldFlat[key]; !ok {
+			entries = append(entries, ValuesDiffEntry{Key: key, Change: ValuesDiffAdded, NewValue: redact(key, newValue)})
+		}
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Key < entries[j].Key })
+	return entries, nil
+}
+
+// ChangedValuesKeys returns the dotted paths of the keys that differ
+// between two values.yaml payloads, for audit events. Only key names are
+// returned, never values, so nothing sensitive can leak.
+func ChangedValuesKeys(oldValues, newValues []byte) []string {
+	entries, err := DiffValues(oldValues, newValues)
+	if err != nil {
+		klog.Warningf("failed to diff values: %v", err)
+		return nil
+	}
+	keys := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		keys = append(keys, entry.Key)
+	}
+	return keys
+}
+
+// flattenValues parses a values.yaml payload into a map of dotted key
+// paths to their scalar representation.
+func flattenValues(values []byte) (map[string]string, error) {
+	flat := map[string]string{}
+	if len(values) == 0 {
+		return flat, nil
+	}
+	parsed := map[string]interface{}{}
+	if err := yaml.Unmarshal(values, &parsed); err != nil {
+		return nil, err
+	}
+	flattenInto(flat, "", parsed)
+	return flat, nil
+}
+
+func flattenInto(flat map[string]string, prefix string, values map[string]interface{}) {
+	for key, value := range values {
+		path := key
+		if prefix != "" {
+			path = prefix + "." + key
+		}
+		if nested, ok := value.(map[string]interface{}); ok {
+			flattenInto(flat, path, nested)
+		} else {
+			flat[path] = fmt.Sprintf("%v", value)
+		}
+	}
+}
+
+// redact masks the value when any segment of the key path looks sensitive.
+func redact(key, value string) string {
+	lower := strings.ToLower(key)
+	for _, word := range sensitiveKeyWords {
+		if strings.Contains(lower, word) {
+			return redactedValue
+		}
+	}
+	return value
+}
@@ -0,0 +1,178 @@
+/*
+Copyright 2022 The KubeSphere Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package recommendation derives container request and limit recommendations
+// from the historical usage kept in the monitoring backend. Recommendations
+// are stored as a workload annotation, so the regular workload getters return
+// them without an extra round trip.
+package recommendation
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	"kubesphere.io/kubesphere/pkg/simple/client/monitoring"
+)
+
+// ResourcesAnnotation carries the latest recommendation as JSON on the
+// workload object.
+const ResourcesAnnotation = "recommendation.kubesphere.io/resources"
+
+const (
+	// lookback is the usage window the percentiles are computed over.
+	lookback = 7 * 24 * time.Hour
+
+	// requests follow the 90th usage percentile, limits leave headroom above
+	// the 99th
+	requestQuantile   = 0.90
+	limitQuantile     = 0.99
+	limitMarginFactor = 1.3
+
+	// floors keep recommendations for mostly idle containers schedulable
+	minCPUMilli     = 10
+	minMemoryBytes  = 10 << 20
+	milliPerCore    = 1000
+	memoryRoundToMi = 1 << 20
+)
+
+// ContainerRecommendation is the suggested resource setting of one container.
+type ContainerRecommendation struct {
+	Container     string `json:"container" description:"container name"`
+	CPURequest    string `json:"cpuRequest" description:"suggested cpu request"`
+	CPULimit      string `json:"cpuLimit" description:"suggested cpu limit"`
+	MemoryRequest string `json:"memoryRequest" description:"suggested memory request"`
+	MemoryLimit   string `json:"memoryLimit" description:"suggested memory limit"`
+}
+
+// WorkloadRecommendation bundles the container recommendations of one
+// workload, this is the value stored in the annotation.
+type WorkloadRecommendation struct {
+	GeneratedAt time.Time                 `json:"generatedAt" description:"time the recommendation was computed"`
+	Containers  []ContainerRecommendation `json:"containers" description:"per-container suggestions"`
+}
+
+// Recommender computes resource recommendations for the pods of a workload.
+type Recommender interface {
+	// Recommend derives suggestions for every container that has usage data
+	// within the lookback window, containers without data are omitted. A nil
+	// result means the backend has no data for the workload at all.
+	Recommend(namespace, podNamePrefix string) (*WorkloadRecommendation, error)
+}
+
+type recommender struct {
+	monitoringClient monitoring.Interface
+}
+
+func NewRecommender(monitoringClient monitoring.Interface) Recommender {
+	return &recommender{monitoringClient: monitoringClient}
+}
+
+func (r *recommender) Recommend(namespace, podNamePrefix string) (*WorkloadRecommendation, error) {
+	now := time.Now()
+
+	cpuRequests, err := r.containerQuantiles(cpuUsageExpr(namespace, podNamePrefix, requestQuantile), now)
+	if err != nil {
+		return nil, err
+	}
+	cpuLimits, err := r.containerQuantiles(cpuUsageExpr(namespace, podNamePrefix, limitQuantile), now)
+	if err != nil {
+		return nil, err
+	}
+	memoryRequests, err := r.containerQuantiles(memoryUsageExpr(namespace, podNamePrefix, requestQuantile), now)
+	if err != nil {
+		return nil, err
+	}
+	memoryLimits, err := r.containerQuantiles(memoryUsageExpr(namespace, podNamePrefix, limitQuantile), now)
+	if err != nil {
+		return nil, err
+	}
+
+	var containers []ContainerRecommendation
+	for container, cpuRequest := range cpuRequests {
+		memoryRequest, ok := memoryRequests[container]
+		if !ok {
+			continue
+		}
+		containers = append(containers, ContainerRecommendation{
+			Container:     container,
+			CPURequest:    cpuQuantity(cpuRequest).String(),
+			CPULimit:      cpuQuantity(cpuLimits[container] * limitMarginFactor).String(),
+			MemoryRequest: memoryQuantity(memoryRequest).String(),
+			MemoryLimit:   memoryQuantity(memoryLimits[container] * limitMarginFactor).String(),
+		})
+	}
+	if len(containers) == 0 {
+		return nil, nil
+	}
+
+	return &WorkloadRecommendation{GeneratedAt: now, Containers: containers}, nil
+}
+
+// containerQuantiles evaluates the expression and maps the container label to
+// the sampled value.
+func (r *recommender) containerQuantiles(expr string, now time.Time) (map[string]float64, error) {
+	metric := r.monitoringClient.GetMetric(expr, now)
+	if metric.Error != "" {
+		return nil, fmt.Errorf("monitoring query failed: %s", metric.Error)
+	}
+
+	values := make(map[string]float64)
+	for _, value := range metric.MetricValues {
+		container := value.Metadata["container"]
+		if container == "" || value.Sample == nil {
+			continue
+		}
+		sample := value.Sample.Value()
+		if math.IsNaN(sample) || sample < 0 {
+			continue
+		}
+		values[container] = sample
+	}
+	return values, nil
+}
+
+func cpuUsageExpr(namespace, podNamePrefix string, quantile float64) string {
+	return fmt.Sprintf(`quantile_over_time(%.2f, sum by (container) (irate(container_cpu_usage_seconds_total{namespace="%s", pod=~"%s.*", container!="", container!="POD"}[5m]))[%s:5m])`,
+		quantile, namespace, podNamePrefix, formatDuration(lookback))
+}
+
+func memoryUsageExpr(namespace, podNamePrefix string, quantile float64) string {
+	return fmt.Sprintf(`quantile_over_time(%.2f, sum by (container) (container_memory_working_set_bytes{namespace="%s", pod=~"%s.*", container!="", container!="POD"})[%s:5m])`,
+		quantile, namespace, podNamePrefix, formatDuration(lookback))
+}
+
+func formatDuration(d time.Duration) string {
+	return fmt.Sprintf("%dh", int(d.Hours()))
+}
+
+func cpuQuantity(cores float64) *resource.Quantity {
+	milli := int64(math.Ceil(cores * milliPerCore))
+	if milli < minCPUMilli {
+		milli = minCPUMilli
+	}
+	return resource.NewMilliQuantity(milli, resource.DecimalSI)
+}
+
+func memoryQuantity(bytes float64) *resource.Quantity {
+	rounded := int64(math.Ceil(bytes/memoryRoundToMi)) * memoryRoundToMi
+	if rounded < minMemoryBytes {
+		rounded = minMemoryBytes
+	}
+	return resource.NewQuantity(rounded, resource.BinarySI)
+}
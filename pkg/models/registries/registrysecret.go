@@ -0,0 +1,139 @@
+/*
+Copyright 2022 The KubeSphere Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package registries
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	tenantv1alpha1 "kubesphere.io/api/tenant/v1alpha1"
+
+	"kubesphere.io/kubesphere/pkg/api"
+)
+
+const (
+	// TargetNamespacesAnnotation lists the namespaces a registry secret is
+	// propagated to, comma separated, the wildcard selects every namespace of
+	// the workspace the secret belongs to.
+	TargetNamespacesAnnotation = "registries.kubesphere.io/target-namespaces"
+
+	// AllWorkspaceNamespaces is the wildcard value of TargetNamespacesAnnotation.
+	AllWorkspaceNamespaces = "*"
+
+	// SourceSecretLabel marks a propagated copy with its source secret in the
+	// form <namespace>.<name>.
+	SourceSecretLabel = "registries.kubesphere.io/source-secret"
+)
+
+// RegistrySecretSpec describes an image registry credential to be stored as
+// a dockerconfigjson secret and propagated to the selected namespaces.
+type RegistrySecretSpec struct {
+	Name       string   `json:"name" description:"name of the secret"`
+	ServerHost string   `json:"serverHost" description:"registry server address"`
+	Username   string   `json:"username" description:"registry username"`
+	Password   string   `json:"password" description:"registry password"`
+	Email      string   `json:"email,omitempty" description:"registry email"`
+	SkipVerify bool     `json:"skipVerify,omitempty" description:"create the secret without testing connectivity against the registry"`
+	// TargetNamespaces selects the namespaces the secret is propagated to,
+	// "*" selects every namespace of the workspace.
+	TargetNamespaces []string `json:"targetNamespaces,omitempty" description:"namespaces the secret is propagated to, * for all namespaces of the workspace"`
+}
+
+// SecretManager creates validated registry secrets, the registrysecret
+// controller takes care of propagating them.
+type SecretManager interface {
+	CreateRegistrySecret(namespace string, spec *RegistrySecretSpec) (*corev1.Secret, error)
+}
+
+type secretManager struct {
+	client         kubernetes.Interface
+	registryGetter RegistryGetter
+}
+
+func NewSecretManager(client kubernetes.Interface, registryGetter RegistryGetter) SecretManager {
+	return &secretManager{client: client, registryGetter: registryGetter}
+}
+
+func (s *secretManager) CreateRegistrySecret(namespace string, spec *RegistrySecretSpec) (*corev1.Secret, error) {
+	if spec.Name == "" || spec.ServerHost == "" {
+		return nil, fmt.Errorf("name and serverHost must not be empty")
+	}
+
+	if !spec.SkipVerify {
+		if err := s.registryGetter.VerifyRegistryCredential(api.RegistryCredential{
+			Username:   spec.Username,
+			Password:   spec.Password,
+			ServerHost: spec.ServerHost,
+		}); err != nil {
+			return nil, fmt.Errorf("failed to login registry %s: %v", spec.ServerHost, err)
+		}
+	}
+
+	dockerConfig, err := json.Marshal(DockerConfigJson{Auths: DockerConfigMap{
+		spec.ServerHost: DockerConfigEntry{
+			Username:      spec.Username,
+			Password:      spec.Password,
+			Email:         spec.Email,
+			ServerAddress: spec.ServerHost,
+		},
+	}})
+	if err != nil {
+		return nil, err
+	}
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      spec.Name,
+			Namespace: namespace,
+		},
+		Type: corev1.SecretTypeDockerConfigJson,
+		Data: map[string][]byte{
+			corev1.DockerConfigJsonKey: dockerConfig,
+		},
+	}
+
+	if len(spec.TargetNamespaces) > 0 {
+		secret.Annotations = map[string]string{
+			TargetNamespacesAnnotation: joinNamespaces(spec.TargetNamespaces),
+		}
+	}
+
+	// the workspace label scopes wildcard propagation to the namespaces of
+	// the workspace the source namespace belongs to
+	if ns, err := s.client.CoreV1().Namespaces().Get(context.Background(), namespace, metav1.GetOptions{}); err == nil {
+		if workspace := ns.Labels[tenantv1alpha1.WorkspaceLabel]; workspace != "" {
+			secret.Labels = map[string]string{tenantv1alpha1.WorkspaceLabel: workspace}
+		}
+	}
+
+	return s.client.CoreV1().Secrets(namespace).Create(context.Background(), secret, metav1.CreateOptions{})
+}
+
+func joinNamespaces(namespaces []string) string {
+	result := ""
+	for index, namespace := range namespaces {
+		if index > 0 {
+			result += ","
+		}
+		result += namespace
+	}
+	return result
+}
@@ -0,0 +1,154 @@
+// Copyright 2022 The KubeSphere Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+package v2
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/google/go-containerregistry/pkg/v1/remote/transport"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// diagnosis reason codes, ordered from most to least actionable
+const (
+	// ReasonImagePullable means the registry serves the image with the
+	// supplied credentials, the pull failure is on the node side.
+	ReasonImagePullable = "ImagePullable"
+	// ReasonInvalidImageReference means the image reference does not parse.
+	ReasonInvalidImageReference = "InvalidImageReference"
+	// ReasonArchitectureMismatch means the image exists but offers no
+	// manifest for the architecture of the node.
+	ReasonArchitectureMismatch = "ArchitectureMismatch"
+	// ReasonImageNotFound means the repository or tag does not exist.
+	ReasonImageNotFound = "ImageNotFound"
+	// ReasonAuthenticationFailed means none of the pull secrets is accepted
+	// by the registry.
+	ReasonAuthenticationFailed = "AuthenticationFailed"
+	// ReasonRegistryUnreachable means the registry did not answer at all.
+	ReasonRegistryUnreachable = "RegistryUnreachable"
+)
+
+// ImagePullDiagnosis explains why an image cannot be pulled, computed by
+// checking the image against the registry with the pull secrets of the pod.
+type ImagePullDiagnosis struct {
+	Container string   `json:"container,omitempty" description:"container the image belongs to, set by the pod diagnostics endpoint"`
+	Image     string   `json:"image" description:"the image reference that was checked"`
+	Reason    string   `json:"reason" description:"machine readable reason code"`
+	Message   string   `json:"message" description:"actionable explanation"`
+	Secret    string   `json:"secret,omitempty" description:"name of the pull secret the registry accepted"`
+	Platforms []string `json:"platforms,omitempty" description:"platforms the image is available for"`
+}
+
+// DiagnoseImage checks the image against its registry with each of the pull
+// secrets, and anonymously, and condenses the outcome into one actionable
+// reason. nodeArchitecture is matched against the published platforms when
+// known.
+func (r *registryHelper) DiagnoseImage(secrets []*corev1.Secret, image, nodeArchitecture string) ImagePullDiagnosis {
+	// anonymous access is the fallback when no secret matches
+	candidates := append(secrets, nil)
+
+	best := ImagePullDiagnosis{
+		Image:   image,
+		Reason:  ReasonRegistryUnreachable,
+		Message: "the registry could not be reached",
+	}
+	for _, secret := range candidates {
+		secretAuth, err := NewSecretAuthenticator(secret)
+		if err != nil {
+			continue
+		}
+		platforms, err := NewRegistryer(secretAuth.Options()...).Platforms(image)
+		if err == nil {
+			diagnosis := ImagePullDiagnosis{Image: image, Platforms: platforms}
+			if secret != nil {
+				diagnosis.Secret = secret.Name
+			}
+			if nodeArchitecture != "" && len(platforms) > 0 && !hasArchitecture(platforms, nodeArchitecture) {
+				diagnosis.Reason = ReasonArchitectureMismatch
+				diagnosis.Message = fmt.Sprintf("the image is only published for %s but the node runs %s",
+					strings.Join(platforms, ", "), nodeArchitecture)
+				return diagnosis
+			}
+			diagnosis.Reason = ReasonImagePullable
+			diagnosis.Message = "the registry serves the image with these credentials, check the node network and its image garbage collection"
+			return diagnosis
+		}
+		if diagnosis := classifyRegistryError(image, err); moreActionable(diagnosis.Reason, best.Reason) {
+			best = diagnosis
+		}
+	}
+	return best
+}
+
+func classifyRegistryError(image string, err error) ImagePullDiagnosis {
+	diagnosis := ImagePullDiagnosis{Image: image}
+
+	var transportErr *transport.Error
+	if !errors.As(err, &transportErr) {
+		if strings.Contains(err.Error(), "could not parse reference") {
+			diagnosis.Reason = ReasonInvalidImageReference
+			diagnosis.Message = err.Error()
+			return diagnosis
+		}
+		diagnosis.Reason = ReasonRegistryUnreachable
+		diagnosis.Message = fmt.Sprintf("the registry could not be reached: %v", err)
+		return diagnosis
+	}
+
+	for _, diagnostic := range transportErr.Errors {
+		switch diagnostic.Code {
+		case transport.ManifestUnknownErrorCode, transport.NameUnknownErrorCode:
+			diagnosis.Reason = ReasonImageNotFound
+			diagnosis.Message = "the repository or tag does not exist in the registry, check the image name and tag"
+			return diagnosis
+		}
+	}
+	switch transportErr.StatusCode {
+	case http.StatusNotFound:
+		diagnosis.Reason = ReasonImageNotFound
+		diagnosis.Message = "the repository or tag does not exist in the registry, check the image name and tag"
+	case http.StatusUnauthorized, http.StatusForbidden:
+		diagnosis.Reason = ReasonAuthenticationFailed
+		diagnosis.Message = "the registry rejected the credentials, check the image pull secrets of the pod"
+	default:
+		diagnosis.Reason = ReasonRegistryUnreachable
+		diagnosis.Message = fmt.Sprintf("the registry answered with an unexpected status: %v", transportErr)
+	}
+	return diagnosis
+}
+
+// moreActionable orders reason codes, a definitive answer about the image
+// beats an authentication failure, which beats not reaching the registry.
+func moreActionable(a, b string) bool {
+	rank := map[string]int{
+		ReasonInvalidImageReference: 3,
+		ReasonImageNotFound:         2,
+		ReasonAuthenticationFailed:  1,
+		ReasonRegistryUnreachable:   0,
+	}
+	return rank[a] > rank[b]
+}
+
+func hasArchitecture(platforms []string, architecture string) bool {
+	for _, platform := range platforms {
+		if strings.HasSuffix(platform, "/"+architecture) || platform == architecture {
+			return true
+		}
+	}
+	return false
+}
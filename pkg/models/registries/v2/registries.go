@@ -15,6 +15,8 @@
 package v2
 
 import (
+	"context"
+
 	"github.com/google/go-containerregistry/pkg/name"
 	v1 "github.com/google/go-containerregistry/pkg/v1"
 	"github.com/google/go-containerregistry/pkg/v1/remote"
@@ -24,8 +26,17 @@ type Registryer interface {
 	// list repository tags
 	ListRepositoryTags(image string) (RepositoryTags, error)
 
+	// list all repositories of the registry through the catalog API
+	ListRepositories(registry string) (RepositoryCatalog, error)
+
+	// resolve the manifest digest of an image reference
+	Digest(image string) (ImageDigest, error)
+
 	// get image config
 	Config(image string) (*v1.ConfigFile, error)
+
+	// list the platforms the image is published for
+	Platforms(image string) ([]string, error)
 }
 
 type registryer struct {
@@ -56,6 +67,40 @@ func (r *registryer) ListRepositoryTags(src string) (RepositoryTags, error) {
 	}, nil
 }
 
+func (r *registryer) ListRepositories(registry string) (RepositoryCatalog, error) {
+	reg, err := name.NewRegistry(registry, r.opts.name...)
+	if err != nil {
+		return RepositoryCatalog{}, err
+	}
+
+	repositories, err := remote.Catalog(context.Background(), reg, r.opts.remote...)
+	if err != nil {
+		return RepositoryCatalog{}, err
+	}
+
+	return RepositoryCatalog{
+		Registry:     reg.RegistryStr(),
+		Repositories: repositories,
+	}, nil
+}
+
+func (r *registryer) Digest(image string) (ImageDigest, error) {
+	ref, err := name.ParseReference(image, r.opts.name...)
+	if err != nil {
+		return ImageDigest{}, err
+	}
+
+	descriptor, err := remote.Head(ref, r.opts.remote...)
+	if err != nil {
+		return ImageDigest{}, err
+	}
+
+	return ImageDigest{
+		Image:  ref.Name(),
+		Digest: descriptor.Digest.String(),
+	}, nil
+}
+
 func (r *registryer) Config(image string) (*v1.ConfigFile, error) {
 	img, _, err := r.getImage(image)
 	if err != nil {
@@ -70,6 +115,48 @@ func (r *registryer) Config(image string) (*v1.ConfigFile, error) {
 	return configFile, nil
 }
 
+// Platforms resolves the image reference and lists the os/arch pairs it is
+// available for, a single-platform image yields one entry from its config.
+func (r *registryer) Platforms(image string) ([]string, error) {
+	ref, err := name.ParseReference(image, r.opts.name...)
+	if err != nil {
+		return nil, err
+	}
+
+	descriptor, err := remote.Get(ref, r.opts.remote...)
+	if err != nil {
+		return nil, err
+	}
+
+	if descriptor.MediaType.IsIndex() {
+		index, err := descriptor.ImageIndex()
+		if err != nil {
+			return nil, err
+		}
+		manifest, err := index.IndexManifest()
+		if err != nil {
+			return nil, err
+		}
+		var platforms []string
+		for _, entry := range manifest.Manifests {
+			if entry.Platform != nil && entry.Platform.Architecture != "unknown" {
+				platforms = append(platforms, entry.Platform.OS+"/"+entry.Platform.Architecture)
+			}
+		}
+		return platforms, nil
+	}
+
+	img, err := descriptor.Image()
+	if err != nil {
+		return nil, err
+	}
+	configFile, err := img.ConfigFile()
+	if err != nil {
+		return nil, err
+	}
+	return []string{configFile.OS + "/" + configFile.Architecture}, nil
+}
+
 func (r *registryer) getImage(reference string) (v1.Image, name.Reference, error) {
 	ref, err := name.ParseReference(reference, r.opts.name...)
 	if err != nil {
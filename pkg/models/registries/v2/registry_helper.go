@@ -27,6 +27,15 @@ type RegistryHelper interface {
 
 	// list all tags of given repository, experimental
 	ListRepositoryTags(secret *corev1.Secret, repository string) (RepositoryTags, error)
+
+	// list all repositories of the given registry, experimental
+	ListRepositories(secret *corev1.Secret, registry string) (RepositoryCatalog, error)
+
+	// resolve the manifest digest of the given image reference
+	ImageDigest(secret *corev1.Secret, image string) (ImageDigest, error)
+
+	// explain why an image cannot be pulled with the given pull secrets
+	DiagnoseImage(secrets []*corev1.Secret, image, nodeArchitecture string) ImagePullDiagnosis
 }
 
 type registryHelper struct{}
@@ -64,3 +73,23 @@ func (r *registryHelper) ListRepositoryTags(secret *corev1.Secret, image string)
 	registryer := NewRegistryer(secretAuth.Options()...)
 	return registryer.ListRepositoryTags(image)
 }
+
+func (r *registryHelper) ListRepositories(secret *corev1.Secret, registry string) (RepositoryCatalog, error) {
+	secretAuth, err := NewSecretAuthenticator(secret)
+	if err != nil {
+		return RepositoryCatalog{}, err
+	}
+
+	registryer := NewRegistryer(secretAuth.Options()...)
+	return registryer.ListRepositories(registry)
+}
+
+func (r *registryHelper) ImageDigest(secret *corev1.Secret, image string) (ImageDigest, error) {
+	secretAuth, err := NewSecretAuthenticator(secret)
+	if err != nil {
+		return ImageDigest{}, err
+	}
+
+	registryer := NewRegistryer(secretAuth.Options()...)
+	return registryer.Digest(image)
+}
@@ -49,3 +49,16 @@ type RepositoryTags struct {
 type ImageConfig struct {
 	*v1.ConfigFile `json:",inline"`
 }
+
+// RepositoryCatalog holds the repositories of a registry as returned by the
+// Docker Registry v2 catalog API, Harbor included.
+type RepositoryCatalog struct {
+	Registry     string   `json:"registry"`
+	Repositories []string `json:"repositories"`
+}
+
+// ImageDigest holds the manifest digest of an image reference.
+type ImageDigest struct {
+	Image  string `json:"image"`
+	Digest string `json:"digest"`
+}
@@ -0,0 +1,108 @@
+/*
+Copyright 2022 The KubeSphere Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cached
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/klog"
+
+	"kubesphere.io/kubesphere/pkg/api"
+	"kubesphere.io/kubesphere/pkg/apiserver/query"
+	"kubesphere.io/kubesphere/pkg/models/resources/v1alpha3"
+	"kubesphere.io/kubesphere/pkg/simple/client/cache"
+)
+
+const (
+	listKeyFormat = "kubesphere:resources:list:%s:%s:%s"
+
+	// DefaultTTL bounds the staleness of list snapshots served from the
+	// shared cache, expired snapshots are rebuilt from the local informers.
+	DefaultTTL = 30 * time.Second
+)
+
+// cachedGetter wraps a resource getter so List results are served as
+// snapshots from the shared cache layer. With a distributed cache backend
+// only one ks-apiserver replica has to materialize the full list of a heavy
+// resource, the other replicas reuse the snapshot until it expires.
+type cachedGetter struct {
+	delegate    v1alpha3.Interface
+	cacheClient cache.Interface
+	gvr         schema.GroupVersionResource
+	ttl         time.Duration
+}
+
+// New wraps the delegate getter with a shared cache for List results,
+// Get requests always hit the delegate.
+func New(delegate v1alpha3.Interface, cacheClient cache.Interface, gvr schema.GroupVersionResource, ttl time.Duration) v1alpha3.Interface {
+	if ttl <= 0 {
+		ttl = DefaultTTL
+	}
+	return &cachedGetter{delegate: delegate, cacheClient: cacheClient, gvr: gvr, ttl: ttl}
+}
+
+func (c *cachedGetter) Get(namespace, name string) (runtime.Object, error) {
+	return c.delegate.Get(namespace, name)
+}
+
+func (c *cachedGetter) List(namespace string, q *query.Query) (*api.ListResult, error) {
+	key := c.listKey(namespace, q)
+	if value, err := c.cacheClient.Get(key); err == nil {
+		var result api.ListResult
+		if err = json.Unmarshal([]byte(value), &result); err == nil {
+			// The round trip through JSON loses the item types. Rehydrate
+			// each item as unstructured so consumers reading object
+			// metadata, e.g. via meta.Accessor, keep working on cache hits.
+			for i, item := range result.Items {
+				if object, ok := item.(map[string]interface{}); ok {
+					result.Items[i] = &unstructured.Unstructured{Object: object}
+				}
+			}
+			return &result, nil
+		}
+		klog.Warningf("failed to decode cached list snapshot %s: %v", key, err)
+	}
+
+	result, err := c.delegate.List(namespace, q)
+	if err != nil {
+		return nil, err
+	}
+
+	if data, err := json.Marshal(result); err == nil {
+		if err = c.cacheClient.Set(key, string(data), c.ttl); err != nil {
+			klog.Warningf("failed to store list snapshot %s: %v", key, err)
+		}
+	}
+
+	return result, nil
+}
+
+// listKey derives a deterministic cache key from the resource, namespace and
+// the whole query, so different filters or pagination never share a snapshot.
+func (c *cachedGetter) listKey(namespace string, q *query.Query) string {
+	data, err := json.Marshal(q)
+	if err != nil {
+		data = []byte(fmt.Sprintf("%v", q))
+	}
+	return fmt.Sprintf(listKeyFormat, c.gvr.String(), namespace, fmt.Sprintf("%x", sha256.Sum256(data)))
+}
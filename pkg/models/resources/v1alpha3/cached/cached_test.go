@@ -0,0 +1,94 @@
+/*
+Copyright 2022 The KubeSphere Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cached
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"kubesphere.io/kubesphere/pkg/api"
+	"kubesphere.io/kubesphere/pkg/apiserver/query"
+	"kubesphere.io/kubesphere/pkg/simple/client/cache"
+)
+
+// countingGetter serves a fixed list and counts delegate hits.
+type countingGetter struct {
+	lists int
+}
+
+func (g *countingGetter) Get(namespace, name string) (runtime.Object, error) {
+	return nil, nil
+}
+
+func (g *countingGetter) List(namespace string, q *query.Query) (*api.ListResult, error) {
+	g.lists++
+	return &api.ListResult{
+		Items: []interface{}{
+			&corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "foo",
+					Namespace: namespace,
+					Labels:    map[string]string{"app": "foo"},
+				},
+			},
+		},
+		TotalItems: 1,
+	}, nil
+}
+
+func TestCachedListSnapshots(t *testing.T) {
+	cacheClient, err := cache.NewInMemoryCache(nil, make(chan struct{}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	delegate := &countingGetter{}
+	gvr := schema.GroupVersionResource{Group: "", Version: "v1", Resource: "pods"}
+	getter := New(delegate, cacheClient, gvr, DefaultTTL)
+
+	for i := 0; i < 2; i++ {
+		result, err := getter.List("bar", query.New())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if result.TotalItems != 1 {
+			t.Fatalf("expected 1 item, got %d", result.TotalItems)
+		}
+
+		// items must stay readable through meta.Accessor on cache hits,
+		// the consumers above the getter depend on it
+		accessor, err := meta.Accessor(result.Items[0])
+		if err != nil {
+			t.Fatalf("expected an accessible item, got %v", err)
+		}
+		if accessor.GetName() != "foo" || accessor.GetNamespace() != "bar" {
+			t.Errorf("expected foo/bar, got %s/%s", accessor.GetNamespace(), accessor.GetName())
+		}
+		if accessor.GetLabels()["app"] != "foo" {
+			t.Errorf("expected label app=foo, got %v", accessor.GetLabels())
+		}
+	}
+
+	if delegate.lists != 1 {
+		t.Errorf("expected the second list to be served from the snapshot, delegate saw %d lists", delegate.lists)
+	}
+}
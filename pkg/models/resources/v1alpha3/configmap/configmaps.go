@@ -28,14 +28,27 @@ import (
 
 type configmapsGetter struct {
 	informer informers.SharedInformerFactory
+	resolver *v1alpha3.ReferenceResolver
 }
 
 func New(sharedInformers informers.SharedInformerFactory) v1alpha3.Interface {
-	return &configmapsGetter{informer: sharedInformers}
+	return &configmapsGetter{informer: sharedInformers, resolver: v1alpha3.NewReferenceResolver(sharedInformers)}
 }
 
 func (d *configmapsGetter) Get(namespace, name string) (runtime.Object, error) {
-	return d.informer.Core().V1().ConfigMaps().Lister().ConfigMaps(namespace).Get(name)
+	configmap, err := d.informer.Core().V1().ConfigMaps().Lister().ConfigMaps(namespace).Get(name)
+	if err != nil {
+		return nil, err
+	}
+	return d.withReferences(configmap), nil
+}
+
+// withReferences annotates a copy of the configmap with the workloads that
+// reference it and whether deleting it is safe.
+func (d *configmapsGetter) withReferences(configmap *corev1.ConfigMap) *corev1.ConfigMap {
+	annotated := configmap.DeepCopy()
+	d.resolver.Annotate(&annotated.ObjectMeta, v1alpha3.PodConfigMapsIndex, configmap.Namespace, configmap.Name)
+	return annotated
 }
 
 func (d *configmapsGetter) List(namespace string, query *query.Query) (*api.ListResult, error) {
@@ -46,7 +59,7 @@ func (d *configmapsGetter) List(namespace string, query *query.Query) (*api.List
 
 	var result []runtime.Object
 	for _, configmap := range configmaps {
-		result = append(result, configmap)
+		result = append(result, d.withReferences(configmap))
 	}
 
 	return v1alpha3.DefaultList(result, query, d.compare, d.filter), nil
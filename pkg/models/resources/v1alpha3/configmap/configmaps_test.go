@@ -51,7 +51,7 @@ func TestListConfigMaps(t *testing.T) {
 				Filters:   map[query.Field]query.Value{query.FieldNamespace: query.Value("default")},
 			},
 			&api.ListResult{
-				Items:      []interface{}{foo3, foo2, foo1},
+				Items:      []interface{}{unreferenced(foo3), unreferenced(foo2), unreferenced(foo1)},
 				TotalItems: len(configmaps),
 			},
 			nil,
@@ -96,6 +96,13 @@ var (
 	configmaps = []interface{}{foo1, foo2, foo3}
 )
 
+// unreferenced is what the getter returns for a configmap no pod references
+func unreferenced(configmap *corev1.ConfigMap) *corev1.ConfigMap {
+	annotated := configmap.DeepCopy()
+	annotated.Annotations = map[string]string{v1alpha3.SafeToDeleteAnnotation: "true"}
+	return annotated
+}
+
 func prepare() v1alpha3.Interface {
 
 	client := fake.NewSimpleClientset()
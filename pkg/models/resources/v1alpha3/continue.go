@@ -0,0 +1,65 @@
+/*
+Copyright 2022 The KubeSphere Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha3
+
+import (
+	"encoding/base64"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// encodeContinue returns an opaque cursor naming the object a page ended
+// with, the next page resumes right after it. The cursor addresses the
+// object instead of carrying an offset, so pages stay stable while
+// objects are added or removed in between requests.
+func encodeContinue(object runtime.Object) string {
+	accessor, err := meta.Accessor(object)
+	if err != nil {
+		return ""
+	}
+	return base64.URLEncoding.EncodeToString([]byte(accessor.GetNamespace() + "/" + accessor.GetName()))
+}
+
+// continueRange locates the page the cursor points at, limit bounds the
+// page size when set. A cursor whose object has disappeared from the
+// sorted list starts over from the first item.
+func continueRange(objects []runtime.Object, token string, limit int) (int, int) {
+	start := 0
+	if decoded, err := base64.URLEncoding.DecodeString(token); err == nil {
+		parts := strings.SplitN(string(decoded), "/", 2)
+		if len(parts) == 2 {
+			for i, object := range objects {
+				accessor, err := meta.Accessor(object)
+				if err != nil {
+					continue
+				}
+				if accessor.GetNamespace() == parts[0] && accessor.GetName() == parts[1] {
+					start = i + 1
+					break
+				}
+			}
+		}
+	}
+
+	end := len(objects)
+	if limit >= 0 && start+limit < end {
+		end = start + limit
+	}
+	return start, end
+}
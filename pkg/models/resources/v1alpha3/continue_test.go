@@ -0,0 +1,74 @@
+/*
+Copyright 2022 The KubeSphere Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha3
+
+import (
+	"fmt"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"kubesphere.io/kubesphere/pkg/apiserver/query"
+)
+
+func TestContinuePagination(t *testing.T) {
+	objects := make([]runtime.Object, 0)
+	for i := 0; i < 5; i++ {
+		objects = append(objects, &corev1.Pod{ObjectMeta: metav1.ObjectMeta{
+			Name:      fmt.Sprintf("pod-%d", i),
+			Namespace: "default",
+		}})
+	}
+
+	compare := func(left, right runtime.Object, field query.Field) bool {
+		l, _ := left.(*corev1.Pod)
+		r, _ := right.(*corev1.Pod)
+		return l.Name > r.Name
+	}
+	filter := func(object runtime.Object, f query.Filter) bool { return true }
+
+	q := query.New()
+	q.Pagination = &query.Pagination{Limit: 2}
+	q.Ascending = true
+
+	// walk all pages by cursor
+	var names []string
+	for page := 0; page < 4; page++ {
+		result := DefaultList(objects, q, compare, filter)
+		for _, item := range result.Items {
+			names = append(names, item.(*corev1.Pod).Name)
+		}
+		if result.Continue == "" {
+			break
+		}
+		q.Continue = result.Continue
+	}
+
+	expected := []string{"pod-0", "pod-1", "pod-2", "pod-3", "pod-4"}
+	if fmt.Sprint(names) != fmt.Sprint(expected) {
+		t.Errorf("expected %v, got %v", expected, names)
+	}
+
+	// an unknown cursor starts over from the first item
+	q.Continue = "bm90LWZvdW5kL25vdC1mb3VuZA=="
+	result := DefaultList(objects, q, compare, filter)
+	if len(result.Items) != 2 || result.Items[0].(*corev1.Pod).Name != "pod-0" {
+		t.Errorf("expected restart from the first item, got %v", result.Items)
+	}
+}
@@ -0,0 +1,75 @@
+/*
+Copyright 2022 The KubeSphere Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllerrevision
+
+import (
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/informers"
+
+	"kubesphere.io/kubesphere/pkg/api"
+	"kubesphere.io/kubesphere/pkg/apiserver/query"
+	"kubesphere.io/kubesphere/pkg/models/resources/v1alpha3"
+)
+
+type controllerRevisionGetter struct {
+	sharedInformers informers.SharedInformerFactory
+}
+
+func New(sharedInformers informers.SharedInformerFactory) v1alpha3.Interface {
+	return &controllerRevisionGetter{sharedInformers: sharedInformers}
+}
+
+func (c *controllerRevisionGetter) Get(namespace, name string) (runtime.Object, error) {
+	return c.sharedInformers.Apps().V1().ControllerRevisions().Lister().ControllerRevisions(namespace).Get(name)
+}
+
+func (c *controllerRevisionGetter) List(namespace string, query *query.Query) (*api.ListResult, error) {
+	controllerRevisions, err := c.sharedInformers.Apps().V1().ControllerRevisions().Lister().ControllerRevisions(namespace).List(query.Selector())
+	if err != nil {
+		return nil, err
+	}
+
+	var result []runtime.Object
+	for _, controllerRevision := range controllerRevisions {
+		result = append(result, controllerRevision)
+	}
+
+	return v1alpha3.DefaultList(result, query, c.compare, c.filter), nil
+}
+
+func (c *controllerRevisionGetter) compare(left runtime.Object, right runtime.Object, field query.Field) bool {
+	leftControllerRevision, ok := left.(*appsv1.ControllerRevision)
+	if !ok {
+		return false
+	}
+
+	rightControllerRevision, ok := right.(*appsv1.ControllerRevision)
+	if !ok {
+		return false
+	}
+
+	return v1alpha3.DefaultObjectMetaCompare(leftControllerRevision.ObjectMeta, rightControllerRevision.ObjectMeta, field)
+}
+
+func (c *controllerRevisionGetter) filter(object runtime.Object, filter query.Filter) bool {
+	controllerRevision, ok := object.(*appsv1.ControllerRevision)
+	if !ok {
+		return false
+	}
+	return v1alpha3.DefaultObjectMetaFilter(controllerRevision.ObjectMeta, filter)
+}
@@ -0,0 +1,135 @@
+/*
+Copyright 2022 The KubeSphere Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cronjob
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	batchv1beta1 "k8s.io/api/batch/v1beta1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/informers"
+
+	"kubesphere.io/kubesphere/pkg/api"
+	"kubesphere.io/kubesphere/pkg/apiserver/query"
+	"kubesphere.io/kubesphere/pkg/constants"
+	"kubesphere.io/kubesphere/pkg/models/resources/v1alpha3"
+)
+
+// ServesV1 reports whether the cluster serves cronjobs under batch/v1,
+// legacy clusters stop at batch/v1beta1. A modern cluster is assumed when
+// discovery is unavailable.
+func ServesV1(discovery discovery.DiscoveryInterface) bool {
+	resources, err := discovery.ServerResourcesForGroupVersion("batch/v1")
+	if err != nil {
+		return true
+	}
+	for _, resource := range resources.APIResources {
+		if resource.Name == "cronjobs" {
+			return true
+		}
+	}
+	return false
+}
+
+type cronJobsV1beta1Getter struct {
+	sharedInformers informers.SharedInformerFactory
+}
+
+// NewV1beta1 returns a cronjob getter backed by batch/v1beta1, for clusters
+// that do not serve cronjob v1 yet.
+func NewV1beta1(sharedInformers informers.SharedInformerFactory) v1alpha3.Interface {
+	return &cronJobsV1beta1Getter{sharedInformers: sharedInformers}
+}
+
+func (d *cronJobsV1beta1Getter) Get(namespace, name string) (runtime.Object, error) {
+	return d.sharedInformers.Batch().V1beta1().CronJobs().Lister().CronJobs(namespace).Get(name)
+}
+
+func (d *cronJobsV1beta1Getter) List(namespace string, query *query.Query) (*api.ListResult, error) {
+	cronJobs, err := d.sharedInformers.Batch().V1beta1().CronJobs().Lister().CronJobs(namespace).List(query.Selector())
+	if err != nil {
+		return nil, err
+	}
+
+	var result []runtime.Object
+	for _, cronJob := range cronJobs {
+		result = append(result, cronJob)
+	}
+
+	return v1alpha3.DefaultList(result, query, d.compare, d.filter), nil
+}
+
+func (d *cronJobsV1beta1Getter) compare(left runtime.Object, right runtime.Object, field query.Field) bool {
+
+	leftCronJob, ok := left.(*batchv1beta1.CronJob)
+	if !ok {
+		return false
+	}
+
+	rightCronJob, ok := right.(*batchv1beta1.CronJob)
+	if !ok {
+		return false
+	}
+
+	switch field {
+	case fieldLastScheduleTime:
+		return lastScheduleTimeV1beta1(leftCronJob).After(lastScheduleTimeV1beta1(rightCronJob))
+	case query.FieldStatus:
+		return strings.Compare(cronJobStatusV1beta1(leftCronJob), cronJobStatusV1beta1(rightCronJob)) > 0
+	default:
+		return v1alpha3.DefaultObjectMetaCompare(leftCronJob.ObjectMeta, rightCronJob.ObjectMeta, field)
+	}
+}
+
+func (d *cronJobsV1beta1Getter) filter(object runtime.Object, filter query.Filter) bool {
+	cronJob, ok := object.(*batchv1beta1.CronJob)
+	if !ok {
+		return false
+	}
+
+	switch filter.Field {
+	case query.FieldStatus:
+		return cronJobStatusV1beta1(cronJob) == string(filter.Value)
+	case fieldSuspend:
+		suspend, err := strconv.ParseBool(string(filter.Value))
+		if err != nil {
+			return false
+		}
+		return cronJobSuspended(cronJob.Spec.Suspend) == suspend
+	case fieldWorkspace:
+		return cronJob.Labels[constants.WorkspaceLabelKey] == string(filter.Value)
+	default:
+		return v1alpha3.DefaultObjectMetaFilter(cronJob.ObjectMeta, filter)
+	}
+}
+
+func cronJobStatusV1beta1(cronJob *batchv1beta1.CronJob) string {
+	if cronJobSuspended(cronJob.Spec.Suspend) {
+		return cronJobPaused
+	}
+	return cronJobRunning
+}
+
+func lastScheduleTimeV1beta1(cronJob *batchv1beta1.CronJob) time.Time {
+	if cronJob.Status.LastScheduleTime != nil {
+		return cronJob.Status.LastScheduleTime.Time
+	}
+	return cronJob.CreationTimestamp.Time
+}
@@ -0,0 +1,192 @@
+/*
+Copyright 2022 The KubeSphere Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package customresourcedefinition
+
+import (
+	"context"
+	"fmt"
+
+	v1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apiextensionsinformers "k8s.io/apiextensions-apiserver/pkg/client/informers/externalversions"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/util/jsonpath"
+
+	"kubesphere.io/kubesphere/pkg/apiserver/query"
+	"kubesphere.io/kubesphere/pkg/models/resources/v1alpha3"
+)
+
+// PrinterColumn is a table column declared by the CRD schema, the name
+// and age columns are always present.
+type PrinterColumn struct {
+	Name     string `json:"name" description:"column header"`
+	Type     string `json:"type" description:"OpenAPI type of the cells"`
+	Format   string `json:"format,omitempty" description:"OpenAPI format of the cells"`
+	Priority int32  `json:"priority,omitempty" description:"columns with a priority above zero are only shown in wide views"`
+	JSONPath string `json:"jsonPath" description:"path the cells are read from"`
+}
+
+// CustomResourceRow pairs an instance with its cells evaluated against
+// the printer columns.
+type CustomResourceRow struct {
+	Cells  []string                   `json:"cells" description:"cell per printer column, evaluated server side"`
+	Object *unstructured.Unstructured `json:"object" description:"the instance itself"`
+}
+
+// CustomResourceList is a page of instances of one CRD rendered as a table.
+type CustomResourceList struct {
+	Columns    []PrinterColumn     `json:"columns"`
+	Rows       []CustomResourceRow `json:"rows"`
+	TotalItems int                 `json:"totalItems"`
+}
+
+// InstanceLister lists instances of any CRD through the dynamic client,
+// which keeps CRDs installed after startup reachable without a restart.
+type InstanceLister interface {
+	ListInstances(crd, namespace string, query *query.Query) (*CustomResourceList, error)
+}
+
+type instanceLister struct {
+	informers apiextensionsinformers.SharedInformerFactory
+	client    dynamic.Interface
+}
+
+func NewInstanceLister(informers apiextensionsinformers.SharedInformerFactory, client dynamic.Interface) InstanceLister {
+	return &instanceLister{informers: informers, client: client}
+}
+
+func (l *instanceLister) ListInstances(crdName, namespace string, query *query.Query) (*CustomResourceList, error) {
+	crd, err := l.informers.Apiextensions().V1().CustomResourceDefinitions().Lister().Get(crdName)
+	if err != nil {
+		return nil, err
+	}
+
+	version := storageVersion(crd)
+	if version == nil {
+		return nil, fmt.Errorf("custom resource definition %s serves no version", crdName)
+	}
+	if namespace != "" && crd.Spec.Scope != v1.NamespaceScoped {
+		return nil, fmt.Errorf("custom resource definition %s is cluster scoped", crdName)
+	}
+
+	gvr := schema.GroupVersionResource{Group: crd.Spec.Group, Version: version.Name, Resource: crd.Spec.Names.Plural}
+	var list *unstructured.UnstructuredList
+	if crd.Spec.Scope == v1.NamespaceScoped && namespace != "" {
+		list, err = l.client.Resource(gvr).Namespace(namespace).List(context.Background(), metav1.ListOptions{})
+	} else {
+		list, err = l.client.Resource(gvr).List(context.Background(), metav1.ListOptions{})
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	instances := make([]runtime.Object, 0, len(list.Items))
+	for index := range list.Items {
+		instances = append(instances, &list.Items[index])
+	}
+
+	result := v1alpha3.DefaultList(instances, query, compareInstances, filterInstances)
+
+	columns := printerColumns(version)
+	rows := make([]CustomResourceRow, 0, len(result.Items))
+	for _, item := range result.Items {
+		instance := item.(*unstructured.Unstructured)
+		cells := make([]string, 0, len(columns))
+		for _, column := range columns {
+			cells = append(cells, evaluateCell(instance, column.JSONPath))
+		}
+		rows = append(rows, CustomResourceRow{Cells: cells, Object: instance})
+	}
+
+	return &CustomResourceList{Columns: columns, Rows: rows, TotalItems: result.TotalItems}, nil
+}
+
+// storageVersion picks the served version the instances are stored in.
+func storageVersion(crd *v1.CustomResourceDefinition) *v1.CustomResourceDefinitionVersion {
+	for index := range crd.Spec.Versions {
+		if version := &crd.Spec.Versions[index]; version.Storage && version.Served {
+			return version
+		}
+	}
+	return nil
+}
+
+// printerColumns prepends the name column to the columns declared by the
+// version, the age column stands in when the version declares none.
+func printerColumns(version *v1.CustomResourceDefinitionVersion) []PrinterColumn {
+	columns := []PrinterColumn{{Name: "Name", Type: "string", JSONPath: ".metadata.name"}}
+	for _, column := range version.AdditionalPrinterColumns {
+		columns = append(columns, PrinterColumn{
+			Name:     column.Name,
+			Type:     column.Type,
+			Format:   column.Format,
+			Priority: column.Priority,
+			JSONPath: column.JSONPath,
+		})
+	}
+	if len(version.AdditionalPrinterColumns) == 0 {
+		columns = append(columns, PrinterColumn{Name: "Age", Type: "date", JSONPath: ".metadata.creationTimestamp"})
+	}
+	return columns
+}
+
+func evaluateCell(instance *unstructured.Unstructured, path string) string {
+	parser := jsonpath.New("cell").AllowMissingKeys(true)
+	if err := parser.Parse(fmt.Sprintf("{%s}", path)); err != nil {
+		return ""
+	}
+	results, err := parser.FindResults(instance.UnstructuredContent())
+	if err != nil || len(results) == 0 || len(results[0]) == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%v", results[0][0].Interface())
+}
+
+func compareInstances(left runtime.Object, right runtime.Object, field query.Field) bool {
+	leftInstance, ok := left.(*unstructured.Unstructured)
+	if !ok {
+		return false
+	}
+	rightInstance, ok := right.(*unstructured.Unstructured)
+	if !ok {
+		return false
+	}
+	return v1alpha3.DefaultObjectMetaCompare(instanceMeta(leftInstance), instanceMeta(rightInstance), field)
+}
+
+func filterInstances(object runtime.Object, filter query.Filter) bool {
+	instance, ok := object.(*unstructured.Unstructured)
+	if !ok {
+		return false
+	}
+	return v1alpha3.DefaultObjectMetaFilter(instanceMeta(instance), filter)
+}
+
+// instanceMeta projects the metadata of an unstructured instance into the
+// ObjectMeta the default compare and filter helpers work on.
+func instanceMeta(instance *unstructured.Unstructured) metav1.ObjectMeta {
+	return metav1.ObjectMeta{
+		Name:              instance.GetName(),
+		Namespace:         instance.GetNamespace(),
+		CreationTimestamp: instance.GetCreationTimestamp(),
+		Labels:            instance.GetLabels(),
+		Annotations:       instance.GetAnnotations(),
+	}
+}
@@ -0,0 +1,75 @@
+/*
+Copyright 2022 The KubeSphere Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package endpoints
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/informers"
+
+	"kubesphere.io/kubesphere/pkg/api"
+	"kubesphere.io/kubesphere/pkg/apiserver/query"
+	"kubesphere.io/kubesphere/pkg/models/resources/v1alpha3"
+)
+
+type endpointsGetter struct {
+	sharedInformers informers.SharedInformerFactory
+}
+
+func New(sharedInformers informers.SharedInformerFactory) v1alpha3.Interface {
+	return &endpointsGetter{sharedInformers: sharedInformers}
+}
+
+func (e *endpointsGetter) Get(namespace, name string) (runtime.Object, error) {
+	return e.sharedInformers.Core().V1().Endpoints().Lister().Endpoints(namespace).Get(name)
+}
+
+func (e *endpointsGetter) List(namespace string, query *query.Query) (*api.ListResult, error) {
+	endpoints, err := e.sharedInformers.Core().V1().Endpoints().Lister().Endpoints(namespace).List(query.Selector())
+	if err != nil {
+		return nil, err
+	}
+
+	var result []runtime.Object
+	for _, item := range endpoints {
+		result = append(result, item)
+	}
+
+	return v1alpha3.DefaultList(result, query, e.compare, e.filter), nil
+}
+
+func (e *endpointsGetter) compare(left runtime.Object, right runtime.Object, field query.Field) bool {
+	leftEndpoints, ok := left.(*corev1.Endpoints)
+	if !ok {
+		return false
+	}
+
+	rightEndpoints, ok := right.(*corev1.Endpoints)
+	if !ok {
+		return false
+	}
+
+	return v1alpha3.DefaultObjectMetaCompare(leftEndpoints.ObjectMeta, rightEndpoints.ObjectMeta, field)
+}
+
+func (e *endpointsGetter) filter(object runtime.Object, filter query.Filter) bool {
+	endpoints, ok := object.(*corev1.Endpoints)
+	if !ok {
+		return false
+	}
+	return v1alpha3.DefaultObjectMetaFilter(endpoints.ObjectMeta, filter)
+}
@@ -0,0 +1,84 @@
+/*
+Copyright 2022 The KubeSphere Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package endpointslice
+
+import (
+	discoveryv1 "k8s.io/api/discovery/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/informers"
+
+	"kubesphere.io/kubesphere/pkg/api"
+	"kubesphere.io/kubesphere/pkg/apiserver/query"
+	"kubesphere.io/kubesphere/pkg/models/resources/v1alpha3"
+)
+
+// fieldServiceName filters endpoint slices by the service they belong to,
+// matched through the well known kubernetes.io/service-name label.
+const fieldServiceName = "serviceName"
+
+type endpointSliceGetter struct {
+	sharedInformers informers.SharedInformerFactory
+}
+
+func New(sharedInformers informers.SharedInformerFactory) v1alpha3.Interface {
+	return &endpointSliceGetter{sharedInformers: sharedInformers}
+}
+
+func (e *endpointSliceGetter) Get(namespace, name string) (runtime.Object, error) {
+	return e.sharedInformers.Discovery().V1().EndpointSlices().Lister().EndpointSlices(namespace).Get(name)
+}
+
+func (e *endpointSliceGetter) List(namespace string, query *query.Query) (*api.ListResult, error) {
+	endpointSlices, err := e.sharedInformers.Discovery().V1().EndpointSlices().Lister().EndpointSlices(namespace).List(query.Selector())
+	if err != nil {
+		return nil, err
+	}
+
+	var result []runtime.Object
+	for _, endpointSlice := range endpointSlices {
+		result = append(result, endpointSlice)
+	}
+
+	return v1alpha3.DefaultList(result, query, e.compare, e.filter), nil
+}
+
+func (e *endpointSliceGetter) compare(left runtime.Object, right runtime.Object, field query.Field) bool {
+	leftEndpointSlice, ok := left.(*discoveryv1.EndpointSlice)
+	if !ok {
+		return false
+	}
+
+	rightEndpointSlice, ok := right.(*discoveryv1.EndpointSlice)
+	if !ok {
+		return false
+	}
+
+	return v1alpha3.DefaultObjectMetaCompare(leftEndpointSlice.ObjectMeta, rightEndpointSlice.ObjectMeta, field)
+}
+
+func (e *endpointSliceGetter) filter(object runtime.Object, filter query.Filter) bool {
+	endpointSlice, ok := object.(*discoveryv1.EndpointSlice)
+	if !ok {
+		return false
+	}
+	switch filter.Field {
+	case fieldServiceName:
+		return endpointSlice.Labels[discoveryv1.LabelServiceName] == string(filter.Value)
+	default:
+		return v1alpha3.DefaultObjectMetaFilter(endpointSlice.ObjectMeta, filter)
+	}
+}
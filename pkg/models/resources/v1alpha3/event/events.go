@@ -0,0 +1,105 @@
+/*
+Copyright 2022 The KubeSphere Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package event
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/informers"
+
+	"kubesphere.io/kubesphere/pkg/api"
+	"kubesphere.io/kubesphere/pkg/apiserver/query"
+	"kubesphere.io/kubesphere/pkg/models/resources/v1alpha3"
+)
+
+const (
+	fieldInvolvedObjectKind = "involvedObject.kind"
+	fieldInvolvedObjectName = "involvedObject.name"
+	fieldInvolvedObjectUID  = "involvedObject.uid"
+	fieldReason             = "reason"
+	fieldType               = "type"
+	fieldLastTimestamp      = "lastTimestamp"
+)
+
+type eventsGetter struct {
+	informer informers.SharedInformerFactory
+}
+
+func New(sharedInformers informers.SharedInformerFactory) v1alpha3.Interface {
+	return &eventsGetter{informer: sharedInformers}
+}
+
+func (e *eventsGetter) Get(namespace, name string) (runtime.Object, error) {
+	return e.informer.Core().V1().Events().Lister().Events(namespace).Get(name)
+}
+
+func (e *eventsGetter) List(namespace string, query *query.Query) (*api.ListResult, error) {
+	events, err := e.informer.Core().V1().Events().Lister().Events(namespace).List(query.Selector())
+	if err != nil {
+		return nil, err
+	}
+
+	var result []runtime.Object
+	for _, event := range events {
+		result = append(result, event)
+	}
+
+	return v1alpha3.DefaultList(result, query, e.compare, e.filter), nil
+}
+
+func (e *eventsGetter) compare(left runtime.Object, right runtime.Object, field query.Field) bool {
+
+	leftEvent, ok := left.(*corev1.Event)
+	if !ok {
+		return false
+	}
+
+	rightEvent, ok := right.(*corev1.Event)
+	if !ok {
+		return false
+	}
+
+	switch field {
+	case fieldLastTimestamp:
+		return leftEvent.LastTimestamp.After(rightEvent.LastTimestamp.Time)
+	default:
+		return v1alpha3.DefaultObjectMetaCompare(leftEvent.ObjectMeta, rightEvent.ObjectMeta, field)
+	}
+}
+
+func (e *eventsGetter) filter(object runtime.Object, filter query.Filter) bool {
+	event, ok := object.(*corev1.Event)
+	if !ok {
+		return false
+	}
+
+	switch filter.Field {
+	case fieldInvolvedObjectKind:
+		return event.InvolvedObject.Kind == string(filter.Value)
+	case fieldInvolvedObjectName:
+		return event.InvolvedObject.Name == string(filter.Value)
+	case fieldInvolvedObjectUID:
+		return event.InvolvedObject.UID == types.UID(filter.Value)
+	case fieldReason:
+		return event.Reason == string(filter.Value)
+	case fieldType:
+		return event.Type == string(filter.Value)
+	default:
+		return v1alpha3.DefaultObjectMetaFilter(event.ObjectMeta, filter)
+	}
+}
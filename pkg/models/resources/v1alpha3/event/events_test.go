@@ -0,0 +1,200 @@
+/*
+Copyright 2022 The KubeSphere Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package event
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"kubesphere.io/kubesphere/pkg/api"
+	"kubesphere.io/kubesphere/pkg/apiserver/query"
+	"kubesphere.io/kubesphere/pkg/models/resources/v1alpha3"
+)
+
+func TestListEvents(t *testing.T) {
+	tests := []struct {
+		description string
+		namespace   string
+		query       *query.Query
+		expected    *api.ListResult
+		expectedErr error
+	}{
+		{
+			"test involvedObject name filter",
+			"default",
+			&query.Query{
+				Pagination: &query.Pagination{
+					Limit:  10,
+					Offset: 0,
+				},
+				SortBy:    query.FieldName,
+				Ascending: false,
+				Filters: map[query.Field]query.Value{
+					fieldInvolvedObjectName: query.Value("nginx-0"),
+				},
+			},
+			&api.ListResult{
+				Items:      []interface{}{pulledEvent, backoffEvent},
+				TotalItems: 2,
+			},
+			nil,
+		},
+		{
+			"test type filter",
+			"default",
+			&query.Query{
+				Pagination: &query.Pagination{
+					Limit:  10,
+					Offset: 0,
+				},
+				SortBy:    query.FieldName,
+				Ascending: false,
+				Filters: map[query.Field]query.Value{
+					fieldType: query.Value(corev1.EventTypeWarning),
+				},
+			},
+			&api.ListResult{
+				Items:      []interface{}{backoffEvent},
+				TotalItems: 1,
+			},
+			nil,
+		},
+		{
+			"test reason filter",
+			"default",
+			&query.Query{
+				Pagination: &query.Pagination{
+					Limit:  10,
+					Offset: 0,
+				},
+				SortBy:    query.FieldName,
+				Ascending: false,
+				Filters: map[query.Field]query.Value{
+					fieldReason: query.Value("Scheduled"),
+				},
+			},
+			&api.ListResult{
+				Items:      []interface{}{scheduledEvent},
+				TotalItems: 1,
+			},
+			nil,
+		},
+		{
+			"test involvedObject kind filter with lastTimestamp sort",
+			"default",
+			&query.Query{
+				Pagination: &query.Pagination{
+					Limit:  10,
+					Offset: 0,
+				},
+				SortBy:    fieldLastTimestamp,
+				Ascending: false,
+				Filters: map[query.Field]query.Value{
+					fieldInvolvedObjectKind: query.Value("Pod"),
+				},
+			},
+			&api.ListResult{
+				Items:      []interface{}{backoffEvent, pulledEvent, scheduledEvent},
+				TotalItems: 3,
+			},
+			nil,
+		},
+	}
+
+	getter := prepare()
+
+	for _, test := range tests {
+		got, err := getter.List(test.namespace, test.query)
+		if test.expectedErr != nil && err != test.expectedErr {
+			t.Errorf("expected error, got nothing")
+		} else if err != nil {
+			t.Fatal(err)
+		}
+
+		if diff := cmp.Diff(got, test.expected); diff != "" {
+			t.Errorf("[%s] %T differ (-got, +want): %s", test.description, test.expected, diff)
+		}
+	}
+}
+
+var (
+	baseTime = time.Date(2022, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	scheduledEvent = &corev1.Event{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "nginx-1.scheduled",
+			Namespace: "default",
+		},
+		InvolvedObject: corev1.ObjectReference{
+			Kind:      "Pod",
+			Namespace: "default",
+			Name:      "nginx-1",
+			UID:       "uid-nginx-1",
+		},
+		Reason:        "Scheduled",
+		Type:          corev1.EventTypeNormal,
+		LastTimestamp: metav1.NewTime(baseTime),
+	}
+	pulledEvent = &corev1.Event{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "nginx-0.pulled",
+			Namespace: "default",
+		},
+		InvolvedObject: corev1.ObjectReference{
+			Kind:      "Pod",
+			Namespace: "default",
+			Name:      "nginx-0",
+			UID:       "uid-nginx-0",
+		},
+		Reason:        "Pulled",
+		Type:          corev1.EventTypeNormal,
+		LastTimestamp: metav1.NewTime(baseTime.Add(time.Minute)),
+	}
+	backoffEvent = &corev1.Event{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "nginx-0.backoff",
+			Namespace: "default",
+		},
+		InvolvedObject: corev1.ObjectReference{
+			Kind:      "Pod",
+			Namespace: "default",
+			Name:      "nginx-0",
+			UID:       "uid-nginx-0",
+		},
+		Reason:        "BackOff",
+		Type:          corev1.EventTypeWarning,
+		LastTimestamp: metav1.NewTime(baseTime.Add(2 * time.Minute)),
+	}
+
+	events = []interface{}{scheduledEvent, pulledEvent, backoffEvent}
+)
+
+func prepare() v1alpha3.Interface {
+	client := fake.NewSimpleClientset()
+	informer := informers.NewSharedInformerFactory(client, 0)
+
+	for _, event := range events {
+		_ = informer.Core().V1().Events().Informer().GetIndexer().Add(event)
+	}
+	return New(informer)
+}
@@ -0,0 +1,78 @@
+/*
+Copyright 2022 KubeSphere Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package federatedjob
+
+import (
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"kubesphere.io/api/types/v1beta1"
+
+	"kubesphere.io/kubesphere/pkg/api"
+	"kubesphere.io/kubesphere/pkg/apiserver/query"
+	informers "kubesphere.io/kubesphere/pkg/client/informers/externalversions"
+	"kubesphere.io/kubesphere/pkg/models/resources/v1alpha3"
+)
+
+type fedJobGetter struct {
+	sharedInformers informers.SharedInformerFactory
+}
+
+func New(sharedInformers informers.SharedInformerFactory) v1alpha3.Interface {
+	return &fedJobGetter{sharedInformers: sharedInformers}
+}
+
+func (d *fedJobGetter) Get(namespace, name string) (runtime.Object, error) {
+	return d.sharedInformers.Types().V1beta1().FederatedJobs().Lister().FederatedJobs(namespace).Get(name)
+}
+
+func (d *fedJobGetter) List(namespace string, query *query.Query) (*api.ListResult, error) {
+	objects, err := d.sharedInformers.Types().V1beta1().FederatedJobs().Lister().FederatedJobs(namespace).List(query.Selector())
+	if err != nil {
+		return nil, err
+	}
+
+	var result []runtime.Object
+	for _, object := range objects {
+		result = append(result, object)
+	}
+
+	return v1alpha3.DefaultList(result, query, d.compare, d.filter), nil
+}
+
+func (d *fedJobGetter) compare(left runtime.Object, right runtime.Object, field query.Field) bool {
+
+	leftObject, ok := left.(*v1beta1.FederatedJob)
+	if !ok {
+		return false
+	}
+
+	rightObject, ok := right.(*v1beta1.FederatedJob)
+	if !ok {
+		return false
+	}
+
+	return v1alpha3.DefaultObjectMetaCompare(leftObject.ObjectMeta, rightObject.ObjectMeta, field)
+}
+
+func (d *fedJobGetter) filter(object runtime.Object, filter query.Filter) bool {
+	federated, ok := object.(*v1beta1.FederatedJob)
+	if !ok {
+		return false
+	}
+
+	return v1alpha3.DefaultObjectMetaFilter(federated.ObjectMeta, filter)
+}
@@ -26,6 +26,15 @@ import (
 	"kubesphere.io/kubesphere/pkg/models/resources/v1alpha3"
 )
 
+const (
+	// FieldIngressClassName filters ingresses by the class serving them.
+	FieldIngressClassName = "ingressClassName"
+
+	// legacyIngressClassAnnotation predates spec.ingressClassName and is
+	// still honored as a fallback when the spec field is unset.
+	legacyIngressClassAnnotation = "kubernetes.io/ingress.class"
+)
+
 type ingressGetter struct {
 	sharedInformers informers.SharedInformerFactory
 }
@@ -74,9 +83,25 @@ func (g *ingressGetter) compare(left runtime.Object, right runtime.Object, field
 }
 
 func (g *ingressGetter) filter(object runtime.Object, filter query.Filter) bool {
-	deployment, ok := object.(*v1.Ingress)
+	ingress, ok := object.(*v1.Ingress)
 	if !ok {
 		return false
 	}
-	return v1alpha3.DefaultObjectMetaFilter(deployment.ObjectMeta, filter)
+
+	// /ingresses?ingressClassName=nginx
+	if filter.Field == FieldIngressClassName {
+		return ingressClassName(ingress) == string(filter.Value)
+	}
+
+	return v1alpha3.DefaultObjectMetaFilter(ingress.ObjectMeta, filter)
+}
+
+// ingressClassName resolves the class of the ingress, spec.ingressClassName
+// when set, otherwise the legacy kubernetes.io/ingress.class annotation
+// older controllers still rely on.
+func ingressClassName(ingress *v1.Ingress) string {
+	if ingress.Spec.IngressClassName != nil {
+		return *ingress.Spec.IngressClassName
+	}
+	return ingress.Annotations[legacyIngressClassAnnotation]
 }
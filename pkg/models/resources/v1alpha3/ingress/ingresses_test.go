@@ -80,12 +80,36 @@ func TestListIngresses(t *testing.T) {
 	}
 }
 
+func TestIngressClassNameFilter(t *testing.T) {
+	getter := prepare()
+
+	q := query.New()
+	q.Filters[FieldIngressClassName] = "nginx"
+	result, err := getter.List("bar", q)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.TotalItems != 2 {
+		t.Fatalf("expected 2 nginx ingresses, got %d", result.TotalItems)
+	}
+	for _, item := range result.Items {
+		ingress := item.(*v1.Ingress)
+		// foo1 carries the class in the spec, bar1 in the legacy annotation
+		if ingress.Name != "foo1" && ingress.Name != "bar1" {
+			t.Errorf("unexpected ingress %s", ingress.Name)
+		}
+	}
+}
+
 var (
+	nginxClass = "nginx"
+
 	foo1 = &v1.Ingress{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      "foo1",
 			Namespace: "bar",
 		},
+		Spec: v1.IngressSpec{IngressClassName: &nginxClass},
 	}
 
 	foo2 = &v1.Ingress{
@@ -96,8 +120,9 @@ var (
 	}
 	bar1 = &v1.Ingress{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      "bar1",
-			Namespace: "bar",
+			Name:        "bar1",
+			Namespace:   "bar",
+			Annotations: map[string]string{legacyIngressClassAnnotation: "nginx"},
 		},
 	}
 
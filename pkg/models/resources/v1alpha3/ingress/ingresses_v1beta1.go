@@ -0,0 +1,103 @@
+/*
+Copyright 2022 The KubeSphere Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ingress
+
+import (
+	v1beta1 "k8s.io/api/networking/v1beta1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/informers"
+
+	"kubesphere.io/kubesphere/pkg/api"
+	"kubesphere.io/kubesphere/pkg/apiserver/query"
+	"kubesphere.io/kubesphere/pkg/models/resources/v1alpha3"
+)
+
+// ServesV1 reports whether the cluster serves ingresses under
+// networking.k8s.io/v1, legacy clusters stop at v1beta1. A modern cluster
+// is assumed when discovery is unavailable.
+func ServesV1(discovery discovery.DiscoveryInterface) bool {
+	resources, err := discovery.ServerResourcesForGroupVersion("networking.k8s.io/v1")
+	if err != nil {
+		return true
+	}
+	for _, resource := range resources.APIResources {
+		if resource.Name == "ingresses" {
+			return true
+		}
+	}
+	return false
+}
+
+type ingressV1beta1Getter struct {
+	sharedInformers informers.SharedInformerFactory
+}
+
+// NewV1beta1 returns an ingress getter backed by networking.k8s.io/v1beta1,
+// for clusters that do not serve ingress v1 yet.
+func NewV1beta1(sharedInformers informers.SharedInformerFactory) v1alpha3.Interface {
+	return &ingressV1beta1Getter{sharedInformers: sharedInformers}
+}
+
+func (g *ingressV1beta1Getter) Get(namespace, name string) (runtime.Object, error) {
+	return g.sharedInformers.Networking().V1beta1().Ingresses().Lister().Ingresses(namespace).Get(name)
+}
+
+func (g *ingressV1beta1Getter) List(namespace string, query *query.Query) (*api.ListResult, error) {
+	ingresses, err := g.sharedInformers.Networking().V1beta1().Ingresses().Lister().Ingresses(namespace).List(query.Selector())
+	if err != nil {
+		return nil, err
+	}
+
+	var result []runtime.Object
+	for _, ingress := range ingresses {
+		result = append(result, ingress)
+	}
+
+	return v1alpha3.DefaultList(result, query, g.compare, g.filter), nil
+}
+
+func (g *ingressV1beta1Getter) compare(left runtime.Object, right runtime.Object, field query.Field) bool {
+	leftIngress, ok := left.(*v1beta1.Ingress)
+	if !ok {
+		return false
+	}
+
+	rightIngress, ok := right.(*v1beta1.Ingress)
+	if !ok {
+		return false
+	}
+
+	return v1alpha3.DefaultObjectMetaCompare(leftIngress.ObjectMeta, rightIngress.ObjectMeta, field)
+}
+
+func (g *ingressV1beta1Getter) filter(object runtime.Object, filter query.Filter) bool {
+	ingress, ok := object.(*v1beta1.Ingress)
+	if !ok {
+		return false
+	}
+
+	// /ingresses?ingressClassName=nginx
+	if filter.Field == FieldIngressClassName {
+		if ingress.Spec.IngressClassName != nil {
+			return *ingress.Spec.IngressClassName == string(filter.Value)
+		}
+		return ingress.Annotations[legacyIngressClassAnnotation] == string(filter.Value)
+	}
+
+	return v1alpha3.DefaultObjectMetaFilter(ingress.ObjectMeta, filter)
+}
@@ -0,0 +1,101 @@
+/*
+Copyright 2022 The KubeSphere Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ingressclass
+
+import (
+	"strconv"
+
+	v1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/informers"
+
+	"kubesphere.io/kubesphere/pkg/api"
+	"kubesphere.io/kubesphere/pkg/apiserver/query"
+	"kubesphere.io/kubesphere/pkg/models/resources/v1alpha3"
+)
+
+const (
+	// FieldDefault filters ingress classes on whether they are the
+	// cluster default, e.g. default=true.
+	FieldDefault = "default"
+
+	// defaultClassAnnotation marks an ingress class as the default for
+	// ingresses that do not specify a class.
+	defaultClassAnnotation = "ingressclass.kubernetes.io/is-default-class"
+)
+
+type ingressClassGetter struct {
+	sharedInformers informers.SharedInformerFactory
+}
+
+func New(sharedInformers informers.SharedInformerFactory) v1alpha3.Interface {
+	return &ingressClassGetter{sharedInformers: sharedInformers}
+}
+
+func (g *ingressClassGetter) Get(namespace, name string) (runtime.Object, error) {
+	return g.sharedInformers.Networking().V1().IngressClasses().Lister().Get(name)
+}
+
+func (g *ingressClassGetter) List(namespace string, query *query.Query) (*api.ListResult, error) {
+	ingressClasses, err := g.sharedInformers.Networking().V1().IngressClasses().Lister().List(query.Selector())
+	if err != nil {
+		return nil, err
+	}
+
+	var result []runtime.Object
+	for _, ingressClass := range ingressClasses {
+		result = append(result, ingressClass)
+	}
+
+	return v1alpha3.DefaultList(result, query, g.compare, g.filter), nil
+}
+
+func (g *ingressClassGetter) compare(left runtime.Object, right runtime.Object, field query.Field) bool {
+	leftIngressClass, ok := left.(*v1.IngressClass)
+	if !ok {
+		return false
+	}
+
+	rightIngressClass, ok := right.(*v1.IngressClass)
+	if !ok {
+		return false
+	}
+
+	return v1alpha3.DefaultObjectMetaCompare(leftIngressClass.ObjectMeta, rightIngressClass.ObjectMeta, field)
+}
+
+func (g *ingressClassGetter) filter(object runtime.Object, filter query.Filter) bool {
+	ingressClass, ok := object.(*v1.IngressClass)
+	if !ok {
+		return false
+	}
+
+	if filter.Field == FieldDefault {
+		want, err := strconv.ParseBool(string(filter.Value))
+		if err != nil {
+			return false
+		}
+		return isDefault(ingressClass) == want
+	}
+
+	return v1alpha3.DefaultObjectMetaFilter(ingressClass.ObjectMeta, filter)
+}
+
+func isDefault(ingressClass *v1.IngressClass) bool {
+	isDefault, err := strconv.ParseBool(ingressClass.Annotations[defaultClassAnnotation])
+	return err == nil && isDefault
+}
@@ -0,0 +1,102 @@
+/*
+Copyright 2022 The KubeSphere Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ingressclass
+
+import (
+	"testing"
+
+	v1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"kubesphere.io/kubesphere/pkg/apiserver/query"
+	"kubesphere.io/kubesphere/pkg/models/resources/v1alpha3"
+)
+
+var (
+	nginx = &v1.IngressClass{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "nginx",
+			Annotations: map[string]string{defaultClassAnnotation: "true"},
+		},
+	}
+
+	traefik = &v1.IngressClass{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "traefik",
+		},
+	}
+)
+
+func prepare() v1alpha3.Interface {
+	client := fake.NewSimpleClientset()
+	informer := informers.NewSharedInformerFactory(client, 0)
+
+	for _, ingressClass := range []*v1.IngressClass{nginx, traefik} {
+		informer.Networking().V1().IngressClasses().Informer().GetIndexer().Add(ingressClass)
+	}
+
+	return New(informer)
+}
+
+func TestListIngressClasses(t *testing.T) {
+	getter := prepare()
+
+	result, err := getter.List("", query.New())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.TotalItems != 2 {
+		t.Fatalf("expected 2 ingress classes, got %d", result.TotalItems)
+	}
+}
+
+func TestDefaultClassFilter(t *testing.T) {
+	tests := []struct {
+		description string
+		value       query.Value
+		expected    []string
+	}{
+		{"default classes only", "true", []string{"nginx"}},
+		{"non-default classes only", "false", []string{"traefik"}},
+		{"invalid value matches nothing", "maybe", []string{}},
+	}
+
+	getter := prepare()
+
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			q := query.New()
+			q.Filters[FieldDefault] = test.value
+
+			result, err := getter.List("", q)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if result.TotalItems != len(test.expected) {
+				t.Fatalf("expected %d ingress classes, got %d", len(test.expected), result.TotalItems)
+			}
+			for i, item := range result.Items {
+				ingressClass := item.(*v1.IngressClass)
+				if ingressClass.Name != test.expected[i] {
+					t.Errorf("expected ingress class %s, got %s", test.expected[i], ingressClass.Name)
+				}
+			}
+		})
+	}
+}
@@ -65,25 +65,67 @@ func DefaultList(objects []runtime.Object, q *query.Query, compareFunc CompareFu
 		}
 	}
 
-	// sort by sortBy field
-	sort.Slice(filtered, func(i, j int) bool {
-		if !q.Ascending {
-			return compareFunc(filtered[i], filtered[j], q.SortBy)
-		}
-		return !compareFunc(filtered[i], filtered[j], q.SortBy)
-	})
-
-	total := len(filtered)
+	// sort by sortBy field, a leading-dot field path such as
+	// .status.availableReplicas bypasses the compare function and sorts
+	// on the value at that path, a collation does the same for name sorts
+	if isFieldPathSort(q.SortBy) {
+		sortByFieldPath(filtered, q.SortBy, q.Ascending)
+	} else if compare := nameCompareFor(q.Collation); compare != nil && q.SortBy == query.FieldName {
+		sortByCollatedName(filtered, compare, q.Ascending)
+	} else {
+		sort.Slice(filtered, func(i, j int) bool {
+			if !q.Ascending {
+				return compareFunc(filtered[i], filtered[j], q.SortBy)
+			}
+			return !compareFunc(filtered[i], filtered[j], q.SortBy)
+		})
+	}
 
 	if q.Pagination == nil {
 		q.Pagination = query.NoPagination
 	}
 
-	start, end := q.Pagination.GetValidPagination(total)
+	return PaginateObjects(filtered, q)
+}
+
+// PaginateObjects applies the pagination, continue cursor and field
+// projection steps of DefaultList to an already filtered and sorted
+// list, for callers that drop items after a getter has listed them.
+func PaginateObjects(objects []runtime.Object, q *query.Query) *api.ListResult {
+	total := len(objects)
+
+	pagination := q.Pagination
+	if pagination == nil {
+		pagination = query.NoPagination
+	}
+	start, end := pagination.GetValidPagination(total)
+	// a cursor resumes after the item the previous page ended with and
+	// takes precedence over the offset
+	if q.Continue != "" {
+		start, end = continueRange(objects, q.Continue, pagination.Limit)
+	}
+
+	// hand the caller a cursor whenever more items follow
+	var nextContinue string
+	if end > start && end < total {
+		nextContinue = encodeContinue(objects[end-1])
+	}
+
+	items := objects[start:end]
+	// project items onto the requested field paths, only the returned
+	// page is converted
+	if len(q.Fields) > 0 {
+		projected := make([]runtime.Object, 0, len(items))
+		for _, object := range items {
+			projected = append(projected, project(object, q.Fields))
+		}
+		items = projected
+	}
 
 	return &api.ListResult{
-		TotalItems: len(filtered),
-		Items:      objectsToInterfaces(filtered[start:end]),
+		TotalItems: total,
+		Items:      objectsToInterfaces(items),
+		Continue:   nextContinue,
 	}
 }
 
@@ -142,6 +184,14 @@ func DefaultObjectMetaFilter(item metav1.ObjectMeta, filter query.Filter) bool {
 			}
 		}
 		return false
+		// /replicasets?page=1&limit=10&ownerName=foo-deployment
+	case query.FieldOwnerName:
+		for _, ownerReference := range item.OwnerReferences {
+			if strings.Compare(ownerReference.Name, string(filter.Value)) == 0 {
+				return true
+			}
+		}
+		return false
 		// /namespaces?page=1&limit=10&annotation=openpitrix_runtime
 	case query.FieldAnnotation:
 		return labelMatch(item.Annotations, string(filter.Value))
@@ -0,0 +1,90 @@
+/*
+Copyright 2022 The KubeSphere Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package lease
+
+import (
+	coordinationv1 "k8s.io/api/coordination/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/informers"
+
+	"kubesphere.io/kubesphere/pkg/api"
+	"kubesphere.io/kubesphere/pkg/apiserver/query"
+	"kubesphere.io/kubesphere/pkg/constants"
+	"kubesphere.io/kubesphere/pkg/models/resources/v1alpha3"
+)
+
+// leaderNamespaces are the namespaces whose leases are exposed by the
+// cluster level view, controller leader election happens there.
+var leaderNamespaces = []string{constants.KubeSystemNamespace, constants.KubeSphereNamespace}
+
+type leaseGetter struct {
+	sharedInformers informers.SharedInformerFactory
+}
+
+func New(sharedInformers informers.SharedInformerFactory) v1alpha3.Interface {
+	return &leaseGetter{sharedInformers: sharedInformers}
+}
+
+func (g *leaseGetter) Get(namespace, name string) (runtime.Object, error) {
+	return g.sharedInformers.Coordination().V1().Leases().Lister().Leases(namespace).Get(name)
+}
+
+// List lists the leases of the namespace; the cluster level view, queried
+// with an empty namespace, is restricted to the system namespaces so
+// operators can see who holds controller leadership without wading
+// through per-node leases.
+func (g *leaseGetter) List(namespace string, query *query.Query) (*api.ListResult, error) {
+	namespaces := []string{namespace}
+	if namespace == "" {
+		namespaces = leaderNamespaces
+	}
+
+	var result []runtime.Object
+	for _, ns := range namespaces {
+		leases, err := g.sharedInformers.Coordination().V1().Leases().Lister().Leases(ns).List(query.Selector())
+		if err != nil {
+			return nil, err
+		}
+		for _, lease := range leases {
+			result = append(result, lease)
+		}
+	}
+
+	return v1alpha3.DefaultList(result, query, g.compare, g.filter), nil
+}
+
+func (g *leaseGetter) compare(left runtime.Object, right runtime.Object, field query.Field) bool {
+	leftLease, ok := left.(*coordinationv1.Lease)
+	if !ok {
+		return false
+	}
+
+	rightLease, ok := right.(*coordinationv1.Lease)
+	if !ok {
+		return false
+	}
+
+	return v1alpha3.DefaultObjectMetaCompare(leftLease.ObjectMeta, rightLease.ObjectMeta, field)
+}
+
+func (g *leaseGetter) filter(object runtime.Object, filter query.Filter) bool {
+	lease, ok := object.(*coordinationv1.Lease)
+	if !ok {
+		return false
+	}
+	return v1alpha3.DefaultObjectMetaFilter(lease.ObjectMeta, filter)
+}
@@ -17,9 +17,14 @@ limitations under the License.
 package namespace
 
 import (
+	"strconv"
 	"strings"
+	"sync"
+	"time"
 
 	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/informers"
 
@@ -28,19 +33,58 @@ import (
 	"kubesphere.io/kubesphere/pkg/models/resources/v1alpha3"
 )
 
+const (
+	// PodCountAnnotation carries the number of running or pending pods in
+	// the namespace.
+	PodCountAnnotation = "resource.kubesphere.io/pod-count"
+
+	// CPURequestsAnnotation carries the sum of the container cpu requests
+	// of those pods.
+	CPURequestsAnnotation = "resource.kubesphere.io/cpu-requests"
+
+	// MemoryRequestsAnnotation carries the sum of the container memory
+	// requests of those pods.
+	MemoryRequestsAnnotation = "resource.kubesphere.io/memory-requests"
+
+	// PVCStorageAnnotation carries the sum of the storage requests of the
+	// persistent volume claims in the namespace.
+	PVCStorageAnnotation = "resource.kubesphere.io/pvc-storage"
+
+	// usageRefreshInterval bounds how stale the cached usage summaries may
+	// get, summaries are recomputed from the informer caches at most this
+	// often no matter how frequently namespaces are listed.
+	usageRefreshInterval = 30 * time.Second
+)
+
+// namespaceUsage aggregates what the namespace consumes.
+type namespaceUsage struct {
+	pods    int
+	cpu     resource.Quantity
+	memory  resource.Quantity
+	storage resource.Quantity
+}
+
 type namespacesGetter struct {
 	informers informers.SharedInformerFactory
+
+	mutex       sync.Mutex
+	usage       map[string]namespaceUsage
+	lastRefresh time.Time
 }
 
 func New(informers informers.SharedInformerFactory) v1alpha3.Interface {
 	return &namespacesGetter{informers: informers}
 }
 
-func (n namespacesGetter) Get(_, name string) (runtime.Object, error) {
-	return n.informers.Core().V1().Namespaces().Lister().Get(name)
+func (n *namespacesGetter) Get(_, name string) (runtime.Object, error) {
+	ns, err := n.informers.Core().V1().Namespaces().Lister().Get(name)
+	if err != nil {
+		return nil, err
+	}
+	return n.transform(ns), nil
 }
 
-func (n namespacesGetter) List(_ string, query *query.Query) (*api.ListResult, error) {
+func (n *namespacesGetter) List(_ string, query *query.Query) (*api.ListResult, error) {
 	ns, err := n.informers.Core().V1().Namespaces().Lister().List(query.Selector())
 	if err != nil {
 		return nil, err
@@ -51,10 +95,78 @@ func (n namespacesGetter) List(_ string, query *query.Query) (*api.ListResult, e
 		result = append(result, item)
 	}
 
-	return v1alpha3.DefaultList(result, query, n.compare, n.filter), nil
+	return v1alpha3.DefaultList(result, query, n.compare, n.filter, n.transform), nil
+}
+
+// transform annotates a copy of the namespace with its cached usage
+// summary, so the project list can show usage columns without issuing a
+// metric query per namespace.
+func (n *namespacesGetter) transform(obj runtime.Object) runtime.Object {
+	ns, ok := obj.(*v1.Namespace)
+	if !ok {
+		return obj
+	}
+	usage := n.usageOf(ns.Name)
+
+	out := ns.DeepCopy()
+	if out.Annotations == nil {
+		out.Annotations = make(map[string]string)
+	}
+	out.Annotations[PodCountAnnotation] = strconv.Itoa(usage.pods)
+	out.Annotations[CPURequestsAnnotation] = usage.cpu.String()
+	out.Annotations[MemoryRequestsAnnotation] = usage.memory.String()
+	out.Annotations[PVCStorageAnnotation] = usage.storage.String()
+	return out
+}
+
+func (n *namespacesGetter) usageOf(name string) namespaceUsage {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+
+	if n.usage == nil || time.Since(n.lastRefresh) > usageRefreshInterval {
+		n.usage = n.summarizeUsage()
+		n.lastRefresh = time.Now()
+	}
+	return n.usage[name]
+}
+
+// summarizeUsage walks the pod and persistent volume claim caches once
+// and aggregates per-namespace usage, failures leave the affected
+// namespaces at zero rather than failing the list.
+func (n *namespacesGetter) summarizeUsage() map[string]namespaceUsage {
+	usage := make(map[string]namespaceUsage)
+
+	pods, err := n.informers.Core().V1().Pods().Lister().List(labels.Everything())
+	if err == nil {
+		for _, pod := range pods {
+			if pod.Status.Phase == v1.PodSucceeded || pod.Status.Phase == v1.PodFailed {
+				continue
+			}
+			summary := usage[pod.Namespace]
+			summary.pods++
+			for _, container := range pod.Spec.Containers {
+				summary.cpu.Add(*container.Resources.Requests.Cpu())
+				summary.memory.Add(*container.Resources.Requests.Memory())
+			}
+			usage[pod.Namespace] = summary
+		}
+	}
+
+	pvcs, err := n.informers.Core().V1().PersistentVolumeClaims().Lister().List(labels.Everything())
+	if err == nil {
+		for _, pvc := range pvcs {
+			summary := usage[pvc.Namespace]
+			if request, ok := pvc.Spec.Resources.Requests[v1.ResourceStorage]; ok {
+				summary.storage.Add(request)
+			}
+			usage[pvc.Namespace] = summary
+		}
+	}
+
+	return usage
 }
 
-func (n namespacesGetter) filter(item runtime.Object, filter query.Filter) bool {
+func (n *namespacesGetter) filter(item runtime.Object, filter query.Filter) bool {
 	namespace, ok := item.(*v1.Namespace)
 	if !ok {
 		return false
@@ -67,7 +179,7 @@ func (n namespacesGetter) filter(item runtime.Object, filter query.Filter) bool
 	}
 }
 
-func (n namespacesGetter) compare(left runtime.Object, right runtime.Object, field query.Field) bool {
+func (n *namespacesGetter) compare(left runtime.Object, right runtime.Object, field query.Field) bool {
 	leftNs, ok := left.(*v1.Namespace)
 	if !ok {
 		return false
@@ -15,3 +15,111 @@ limitations under the License.
 */
 
 package namespace
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"kubesphere.io/kubesphere/pkg/models/resources/v1alpha3"
+)
+
+func TestGetNamespaceUsageAnnotations(t *testing.T) {
+	getter := prepare()
+
+	got, err := getter.Get("", "foo")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ns, ok := got.(*corev1.Namespace)
+	if !ok {
+		t.Fatalf("expected *corev1.Namespace, got %T", got)
+	}
+	if pods := ns.Annotations[PodCountAnnotation]; pods != "2" {
+		t.Errorf("expected 2 pods, got %q", pods)
+	}
+	if cpu := ns.Annotations[CPURequestsAnnotation]; cpu != "300m" {
+		t.Errorf("expected 300m cpu requests, got %q", cpu)
+	}
+	if memory := ns.Annotations[MemoryRequestsAnnotation]; memory != "192Mi" {
+		t.Errorf("expected 192Mi memory requests, got %q", memory)
+	}
+	if storage := ns.Annotations[PVCStorageAnnotation]; storage != "10Gi" {
+		t.Errorf("expected 10Gi pvc storage, got %q", storage)
+	}
+}
+
+func TestGetNamespaceWithoutWorkloads(t *testing.T) {
+	getter := prepare()
+
+	got, err := getter.Get("", "empty")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ns := got.(*corev1.Namespace)
+	if pods := ns.Annotations[PodCountAnnotation]; pods != "0" {
+		t.Errorf("expected 0 pods, got %q", pods)
+	}
+	if storage := ns.Annotations[PVCStorageAnnotation]; storage != "0" {
+		t.Errorf("expected 0 pvc storage, got %q", storage)
+	}
+}
+
+func pod(name string, phase corev1.PodPhase, cpu, memory string) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "foo",
+		},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{
+				{
+					Name: "main",
+					Resources: corev1.ResourceRequirements{
+						Requests: corev1.ResourceList{
+							corev1.ResourceCPU:    resource.MustParse(cpu),
+							corev1.ResourceMemory: resource.MustParse(memory),
+						},
+					},
+				},
+			},
+		},
+		Status: corev1.PodStatus{Phase: phase},
+	}
+}
+
+func prepare() v1alpha3.Interface {
+	client := fake.NewSimpleClientset()
+	informer := informers.NewSharedInformerFactory(client, 0)
+
+	for _, name := range []string{"foo", "empty"} {
+		informer.Core().V1().Namespaces().Informer().GetIndexer().Add(&corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+		})
+	}
+	informer.Core().V1().Pods().Informer().GetIndexer().Add(pod("running", corev1.PodRunning, "200m", "128Mi"))
+	informer.Core().V1().Pods().Informer().GetIndexer().Add(pod("pending", corev1.PodPending, "100m", "64Mi"))
+	// completed pods no longer consume their requests
+	informer.Core().V1().Pods().Informer().GetIndexer().Add(pod("completed", corev1.PodSucceeded, "500m", "512Mi"))
+	informer.Core().V1().PersistentVolumeClaims().Informer().GetIndexer().Add(&corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "data",
+			Namespace: "foo",
+		},
+		Spec: corev1.PersistentVolumeClaimSpec{
+			Resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceStorage: resource.MustParse("10Gi"),
+				},
+			},
+		},
+	})
+
+	return New(informer)
+}
@@ -30,6 +30,9 @@ import (
 
 const (
 	storageClassName = "storageClassName"
+
+	fieldClaimRefNamespace = "claimRefNamespace"
+	fieldCapacity          = "capacity"
 )
 
 type persistentVolumeGetter struct {
@@ -66,7 +69,14 @@ func (p *persistentVolumeGetter) compare(obj1, obj2 runtime.Object, field query.
 	if !ok {
 		return false
 	}
-	return v1alpha3.DefaultObjectMetaCompare(pv1.ObjectMeta, pv2.ObjectMeta, field)
+	switch field {
+	case fieldCapacity:
+		leftCapacity := pv1.Spec.Capacity[corev1.ResourceStorage]
+		rightCapacity := pv2.Spec.Capacity[corev1.ResourceStorage]
+		return leftCapacity.Cmp(rightCapacity) > 0
+	default:
+		return v1alpha3.DefaultObjectMetaCompare(pv1.ObjectMeta, pv2.ObjectMeta, field)
+	}
 }
 
 func (p *persistentVolumeGetter) filter(object runtime.Object, filter query.Filter) bool {
@@ -79,6 +89,8 @@ func (p *persistentVolumeGetter) filter(object runtime.Object, filter query.Filt
 		return strings.EqualFold(string(pv.Status.Phase), string(filter.Value))
 	case storageClassName:
 		return pv.Spec.StorageClassName != "" && pv.Spec.StorageClassName == string(filter.Value)
+	case fieldClaimRefNamespace:
+		return pv.Spec.ClaimRef != nil && pv.Spec.ClaimRef.Namespace == string(filter.Value)
 	default:
 		return v1alpha3.DefaultObjectMetaFilter(pv.ObjectMeta, filter)
 	}
@@ -21,6 +21,7 @@ import (
 
 	"github.com/google/go-cmp/cmp"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/informers"
 	"k8s.io/client-go/kubernetes/fake"
@@ -80,6 +81,44 @@ func TestListPods(t *testing.T) {
 			},
 			nil,
 		},
+		{
+			"test claimRef namespace filter",
+			"",
+			&query.Query{
+				Pagination: &query.Pagination{
+					Limit:  10,
+					Offset: 0,
+				},
+				SortBy:    query.FieldName,
+				Ascending: false,
+				Filters: map[query.Field]query.Value{
+					fieldClaimRefNamespace: query.Value("default"),
+				},
+			},
+			&api.ListResult{
+				Items:      []interface{}{pv1},
+				TotalItems: 1,
+			},
+			nil,
+		},
+		{
+			"test capacity sort",
+			"",
+			&query.Query{
+				Pagination: &query.Pagination{
+					Limit:  10,
+					Offset: 0,
+				},
+				SortBy:    fieldCapacity,
+				Ascending: false,
+				Filters:   map[query.Field]query.Value{},
+			},
+			&api.ListResult{
+				Items:      []interface{}{pv1, pv2, pv3},
+				TotalItems: len(persistentVolumes),
+			},
+			nil,
+		},
 	}
 
 	getter := prepare()
@@ -107,6 +146,15 @@ var (
 		Status: corev1.PersistentVolumeStatus{
 			Phase: "bound",
 		},
+		Spec: corev1.PersistentVolumeSpec{
+			Capacity: corev1.ResourceList{
+				corev1.ResourceStorage: resource.MustParse("20Gi"),
+			},
+			ClaimRef: &corev1.ObjectReference{
+				Namespace: "default",
+				Name:      "pvc-1",
+			},
+		},
 	}
 	pv2 = &corev1.PersistentVolume{
 		ObjectMeta: metav1.ObjectMeta{
@@ -117,6 +165,9 @@ var (
 		},
 		Spec: corev1.PersistentVolumeSpec{
 			StorageClassName: testStorageClassName,
+			Capacity: corev1.ResourceList{
+				corev1.ResourceStorage: resource.MustParse("10Gi"),
+			},
 		},
 	}
 	pv3 = &corev1.PersistentVolume{
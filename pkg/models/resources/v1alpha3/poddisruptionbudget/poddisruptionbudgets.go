@@ -0,0 +1,75 @@
+/*
+Copyright 2022 The KubeSphere Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package poddisruptionbudget
+
+import (
+	v1 "k8s.io/api/policy/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/informers"
+
+	"kubesphere.io/kubesphere/pkg/api"
+	"kubesphere.io/kubesphere/pkg/apiserver/query"
+	"kubesphere.io/kubesphere/pkg/models/resources/v1alpha3"
+)
+
+type podDisruptionBudgetGetter struct {
+	informers informers.SharedInformerFactory
+}
+
+func New(informers informers.SharedInformerFactory) v1alpha3.Interface {
+	return &podDisruptionBudgetGetter{informers: informers}
+}
+
+func (p podDisruptionBudgetGetter) Get(namespace, name string) (runtime.Object, error) {
+	return p.informers.Policy().V1().PodDisruptionBudgets().Lister().PodDisruptionBudgets(namespace).Get(name)
+}
+
+func (p podDisruptionBudgetGetter) List(namespace string, query *query.Query) (*api.ListResult, error) {
+	budgets, err := p.informers.Policy().V1().PodDisruptionBudgets().Lister().PodDisruptionBudgets(namespace).List(query.Selector())
+	if err != nil {
+		return nil, err
+	}
+
+	var result []runtime.Object
+	for _, item := range budgets {
+		result = append(result, item)
+	}
+
+	return v1alpha3.DefaultList(result, query, p.compare, p.filter), nil
+}
+
+func (p podDisruptionBudgetGetter) filter(item runtime.Object, filter query.Filter) bool {
+	budget, ok := item.(*v1.PodDisruptionBudget)
+	if !ok {
+		return false
+	}
+
+	return v1alpha3.DefaultObjectMetaFilter(budget.ObjectMeta, filter)
+}
+
+func (p podDisruptionBudgetGetter) compare(left runtime.Object, right runtime.Object, field query.Field) bool {
+	leftBudget, ok := left.(*v1.PodDisruptionBudget)
+	if !ok {
+		return false
+	}
+
+	rightBudget, ok := right.(*v1.PodDisruptionBudget)
+	if !ok {
+		return true
+	}
+	return v1alpha3.DefaultObjectMetaCompare(leftBudget.ObjectMeta, rightBudget.ObjectMeta, field)
+}
@@ -0,0 +1,48 @@
+/*
+Copyright 2022 The KubeSphere Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha3
+
+import (
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/klog"
+)
+
+// project returns a pruned copy of the object carrying only the selected
+// dot separated field paths, missing paths are silently dropped. The
+// object is returned untouched when it cannot be converted.
+func project(object runtime.Object, fields []string) runtime.Object {
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(object)
+	if err != nil {
+		klog.Warningf("failed to convert %T for field projection: %v", object, err)
+		return object
+	}
+	pruned := map[string]interface{}{}
+	for _, field := range fields {
+		path := strings.Split(field, ".")
+		value, found, err := unstructured.NestedFieldNoCopy(content, path...)
+		if err != nil || !found {
+			continue
+		}
+		if err := unstructured.SetNestedField(pruned, value, path...); err != nil {
+			klog.Warningf("failed to project field %s: %v", field, err)
+		}
+	}
+	return &unstructured.Unstructured{Object: pruned}
+}
@@ -0,0 +1,61 @@
+/*
+Copyright 2022 The KubeSphere Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha3
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"kubesphere.io/kubesphere/pkg/apiserver/query"
+)
+
+func TestFieldProjection(t *testing.T) {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "foo",
+			Namespace: "bar",
+			Labels:    map[string]string{"app": "foo"},
+		},
+		Status: corev1.PodStatus{Phase: corev1.PodRunning},
+	}
+
+	q := query.New()
+	q.Fields = []string{"metadata.name", "status.phase", "spec.nodeName"}
+
+	result := DefaultList([]runtime.Object{pod}, q, func(left, right runtime.Object, field query.Field) bool {
+		return false
+	}, func(object runtime.Object, filter query.Filter) bool {
+		return true
+	})
+
+	if result.TotalItems != 1 {
+		t.Fatalf("expected 1 item, got %d", result.TotalItems)
+	}
+
+	expected := &unstructured.Unstructured{Object: map[string]interface{}{
+		"metadata": map[string]interface{}{"name": "foo"},
+		"status":   map[string]interface{}{"phase": "Running"},
+	}}
+	if diff := cmp.Diff(result.Items[0], expected); diff != "" {
+		t.Errorf("%T differ (-got, +want): %s", expected, diff)
+	}
+}
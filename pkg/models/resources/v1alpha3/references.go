@@ -0,0 +1,213 @@
+/*
+Copyright 2022 The KubeSphere Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha3
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog"
+)
+
+const (
+	// PodSecretsIndex maps namespace/name of a secret to the pods mounting
+	// or referencing it.
+	PodSecretsIndex = "pod.secrets"
+	// PodConfigMapsIndex maps namespace/name of a configmap to the pods
+	// mounting or referencing it.
+	PodConfigMapsIndex = "pod.configmaps"
+
+	// ReferencedByAnnotation lists the workloads referencing the object,
+	// as kind/name, computed when the object is read.
+	ReferencedByAnnotation = "resource.kubesphere.io/referenced-by"
+	// SafeToDeleteAnnotation is "true" when no running pod references the
+	// object anymore.
+	SafeToDeleteAnnotation = "resource.kubesphere.io/safe-to-delete"
+)
+
+// ReferenceResolver answers which workloads reference a secret or configmap,
+// backed by indexes on the pod informer so reads stay cheap.
+type ReferenceResolver struct {
+	informers informers.SharedInformerFactory
+}
+
+func NewReferenceResolver(factory informers.SharedInformerFactory) *ReferenceResolver {
+	podInformer := factory.Core().V1().Pods().Informer()
+	// AddIndexers only fails when the index exists already, which happens
+	// when both the secret and the configmap getter register the resolver
+	if err := podInformer.AddIndexers(cache.Indexers{
+		PodSecretsIndex:    podSecretKeys,
+		PodConfigMapsIndex: podConfigMapKeys,
+	}); err != nil {
+		klog.V(4).Infof("failed to register pod reference indexers: %v", err)
+	}
+	return &ReferenceResolver{informers: factory}
+}
+
+// Annotate records the referencing workloads and the safe-to-delete verdict
+// in the annotations of the object, the caller passes a deep copy.
+func (r *ReferenceResolver) Annotate(objectMeta *metav1.ObjectMeta, index, namespace, name string) {
+	workloads, err := r.referencingWorkloads(index, namespace, name)
+	if err != nil {
+		klog.V(4).Infof("failed to resolve references of %s/%s: %v", namespace, name, err)
+		return
+	}
+	if objectMeta.Annotations == nil {
+		objectMeta.Annotations = make(map[string]string)
+	}
+	if len(workloads) > 0 {
+		objectMeta.Annotations[ReferencedByAnnotation] = strings.Join(workloads, ",")
+		objectMeta.Annotations[SafeToDeleteAnnotation] = "false"
+	} else {
+		delete(objectMeta.Annotations, ReferencedByAnnotation)
+		objectMeta.Annotations[SafeToDeleteAnnotation] = "true"
+	}
+}
+
+// referencingWorkloads maps the pods of the index entry to their owning
+// workloads, bare pods are reported as Pod/name.
+func (r *ReferenceResolver) referencingWorkloads(index, namespace, name string) ([]string, error) {
+	items, err := r.informers.Core().V1().Pods().Informer().GetIndexer().ByIndex(index, namespace+"/"+name)
+	if err != nil {
+		return nil, err
+	}
+
+	workloads := make(map[string]bool)
+	for _, item := range items {
+		pod, ok := item.(*corev1.Pod)
+		if !ok {
+			continue
+		}
+		workloads[r.ownerWorkload(pod)] = true
+	}
+
+	result := make([]string, 0, len(workloads))
+	for workload := range workloads {
+		result = append(result, workload)
+	}
+	sort.Strings(result)
+	return result, nil
+}
+
+func (r *ReferenceResolver) ownerWorkload(pod *corev1.Pod) string {
+	controller := metav1.GetControllerOf(pod)
+	if controller == nil {
+		return fmt.Sprintf("Pod/%s", pod.Name)
+	}
+	// replicasets are an implementation detail of deployments
+	if controller.Kind == "ReplicaSet" {
+		if replicaSet, err := r.informers.Apps().V1().ReplicaSets().Lister().ReplicaSets(pod.Namespace).Get(controller.Name); err == nil {
+			if owner := metav1.GetControllerOf(replicaSet); owner != nil {
+				return fmt.Sprintf("%s/%s", owner.Kind, owner.Name)
+			}
+		}
+	}
+	return fmt.Sprintf("%s/%s", controller.Kind, controller.Name)
+}
+
+func podSecretKeys(object interface{}) ([]string, error) {
+	pod, ok := object.(*corev1.Pod)
+	if !ok {
+		return nil, nil
+	}
+
+	names := make(map[string]bool)
+	for _, reference := range pod.Spec.ImagePullSecrets {
+		names[reference.Name] = true
+	}
+	for _, volume := range pod.Spec.Volumes {
+		if volume.Secret != nil {
+			names[volume.Secret.SecretName] = true
+		}
+		if volume.Projected != nil {
+			for _, source := range volume.Projected.Sources {
+				if source.Secret != nil {
+					names[source.Secret.Name] = true
+				}
+			}
+		}
+	}
+	forEachContainer(pod, func(container *corev1.Container) {
+		for _, envFrom := range container.EnvFrom {
+			if envFrom.SecretRef != nil {
+				names[envFrom.SecretRef.Name] = true
+			}
+		}
+		for _, env := range container.Env {
+			if env.ValueFrom != nil && env.ValueFrom.SecretKeyRef != nil {
+				names[env.ValueFrom.SecretKeyRef.Name] = true
+			}
+		}
+	})
+	return indexKeys(pod.Namespace, names), nil
+}
+
+func podConfigMapKeys(object interface{}) ([]string, error) {
+	pod, ok := object.(*corev1.Pod)
+	if !ok {
+		return nil, nil
+	}
+
+	names := make(map[string]bool)
+	for _, volume := range pod.Spec.Volumes {
+		if volume.ConfigMap != nil {
+			names[volume.ConfigMap.Name] = true
+		}
+		if volume.Projected != nil {
+			for _, source := range volume.Projected.Sources {
+				if source.ConfigMap != nil {
+					names[source.ConfigMap.Name] = true
+				}
+			}
+		}
+	}
+	forEachContainer(pod, func(container *corev1.Container) {
+		for _, envFrom := range container.EnvFrom {
+			if envFrom.ConfigMapRef != nil {
+				names[envFrom.ConfigMapRef.Name] = true
+			}
+		}
+		for _, env := range container.Env {
+			if env.ValueFrom != nil && env.ValueFrom.ConfigMapKeyRef != nil {
+				names[env.ValueFrom.ConfigMapKeyRef.Name] = true
+			}
+		}
+	})
+	return indexKeys(pod.Namespace, names), nil
+}
+
+func forEachContainer(pod *corev1.Pod, visit func(container *corev1.Container)) {
+	for index := range pod.Spec.InitContainers {
+		visit(&pod.Spec.InitContainers[index])
+	}
+	for index := range pod.Spec.Containers {
+		visit(&pod.Spec.Containers[index])
+	}
+}
+
+func indexKeys(namespace string, names map[string]bool) []string {
+	keys := make([]string, 0, len(names))
+	for name := range names {
+		keys = append(keys, namespace+"/"+name)
+	}
+	return keys
+}
@@ -0,0 +1,100 @@
+/*
+Copyright 2022 The KubeSphere Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package replicaset
+
+import (
+	"strconv"
+
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/informers"
+
+	"kubesphere.io/kubesphere/pkg/api"
+	"kubesphere.io/kubesphere/pkg/apiserver/query"
+	"kubesphere.io/kubesphere/pkg/models/resources/v1alpha3"
+)
+
+const (
+	// fieldRevision sorts by the rollout revision the deployment
+	// controller records on each replica set
+	fieldRevision = "revision"
+
+	revisionAnnotation = "deployment.kubernetes.io/revision"
+)
+
+type replicaSetGetter struct {
+	sharedInformers informers.SharedInformerFactory
+}
+
+func New(sharedInformers informers.SharedInformerFactory) v1alpha3.Interface {
+	return &replicaSetGetter{sharedInformers: sharedInformers}
+}
+
+func (r *replicaSetGetter) Get(namespace, name string) (runtime.Object, error) {
+	return r.sharedInformers.Apps().V1().ReplicaSets().Lister().ReplicaSets(namespace).Get(name)
+}
+
+func (r *replicaSetGetter) List(namespace string, query *query.Query) (*api.ListResult, error) {
+	replicaSets, err := r.sharedInformers.Apps().V1().ReplicaSets().Lister().ReplicaSets(namespace).List(query.Selector())
+	if err != nil {
+		return nil, err
+	}
+
+	var result []runtime.Object
+	for _, replicaSet := range replicaSets {
+		result = append(result, replicaSet)
+	}
+
+	return v1alpha3.DefaultList(result, query, r.compare, r.filter), nil
+}
+
+func (r *replicaSetGetter) compare(left runtime.Object, right runtime.Object, field query.Field) bool {
+	leftReplicaSet, ok := left.(*appsv1.ReplicaSet)
+	if !ok {
+		return false
+	}
+
+	rightReplicaSet, ok := right.(*appsv1.ReplicaSet)
+	if !ok {
+		return false
+	}
+
+	switch field {
+	case fieldRevision:
+		return revision(leftReplicaSet) > revision(rightReplicaSet)
+	default:
+		return v1alpha3.DefaultObjectMetaCompare(leftReplicaSet.ObjectMeta, rightReplicaSet.ObjectMeta, field)
+	}
+}
+
+// revision returns the rollout revision of the replica set, zero when the
+// annotation is missing or malformed.
+func revision(replicaSet *appsv1.ReplicaSet) int64 {
+	parsed, err := strconv.ParseInt(replicaSet.Annotations[revisionAnnotation], 10, 64)
+	if err != nil {
+		return 0
+	}
+	return parsed
+}
+
+func (r *replicaSetGetter) filter(object runtime.Object, filter query.Filter) bool {
+	replicaSet, ok := object.(*appsv1.ReplicaSet)
+	if !ok {
+		return false
+	}
+	return v1alpha3.DefaultObjectMetaFilter(replicaSet.ObjectMeta, filter)
+}
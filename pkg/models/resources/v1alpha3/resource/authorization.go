@@ -0,0 +1,136 @@
+/*
+Copyright 2022 The KubeSphere Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resource
+
+import (
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apiserver/pkg/authentication/user"
+	"k8s.io/klog"
+
+	tenantv1alpha1 "kubesphere.io/api/tenant/v1alpha1"
+
+	"kubesphere.io/kubesphere/pkg/api"
+	"kubesphere.io/kubesphere/pkg/apiserver/authorization/authorizer"
+	"kubesphere.io/kubesphere/pkg/apiserver/query"
+	"kubesphere.io/kubesphere/pkg/apiserver/request"
+	"kubesphere.io/kubesphere/pkg/models/resources/v1alpha3"
+)
+
+// ListFilter decides whether a single listed object may be included in a
+// result handed to the user, it runs once per item.
+type ListFilter func(gvr schema.GroupVersionResource, object metav1.Object) bool
+
+// listScope is the authorization cache key, matches of the same kind in
+// the same namespace or workspace share one decision.
+type listScope struct {
+	gvr       schema.GroupVersionResource
+	namespace string
+	workspace string
+}
+
+// NewAuthorizingFilter returns a ListFilter that admits an object when the
+// user may list its kind in the scope the object lives in: the namespace
+// of namespaced objects, the workspace of cluster scoped objects carrying
+// the workspace label, the cluster otherwise. Decisions are cached inside
+// the filter, so a filter must not outlive the request it was built for.
+func NewAuthorizingFilter(user user.Info, auth authorizer.Authorizer) ListFilter {
+	decisions := make(map[listScope]bool)
+	return func(gvr schema.GroupVersionResource, object metav1.Object) bool {
+		scope := listScope{gvr: gvr, namespace: object.GetNamespace()}
+		if scope.namespace == "" {
+			scope.workspace = object.GetLabels()[tenantv1alpha1.WorkspaceLabel]
+		}
+		if allowed, ok := decisions[scope]; ok {
+			return allowed
+		}
+		listAttributes := authorizer.AttributesRecord{
+			User:            user,
+			Verb:            "list",
+			APIGroup:        gvr.Group,
+			APIVersion:      gvr.Version,
+			Resource:        gvr.Resource,
+			Namespace:       scope.namespace,
+			Workspace:       scope.workspace,
+			ResourceRequest: true,
+			ResourceScope:   request.ClusterScope,
+		}
+		if scope.namespace != "" {
+			listAttributes.ResourceScope = request.NamespaceScope
+		} else if scope.workspace != "" {
+			listAttributes.ResourceScope = request.WorkspaceScope
+		}
+		decision, _, err := auth.Authorize(listAttributes)
+		if err != nil {
+			klog.Error(err)
+		}
+		allowed := err == nil && decision == authorizer.DecisionAllow
+		decisions[scope] = allowed
+		return allowed
+	}
+}
+
+// ListFiltered lists the resource like List does and additionally drops
+// items rejected by the filter. Pagination and the continue cursor are
+// applied after filtering so pages never betray the existence of items
+// the user cannot access.
+func (r *ResourceGetter) ListFiltered(resource, namespace string, q *query.Query, filter ListFilter) (*api.ListResult, error) {
+	if filter == nil {
+		return r.List(resource, namespace, q)
+	}
+
+	gvr := schema.GroupVersionResource{Resource: resource}
+	// the filter authorizes against the matched group and version
+	matched, getter := r.tryResource(namespace == "", gvr)
+	if getter == nil {
+		matched = gvr
+	}
+
+	// delegate the listing to the shared path so the search index
+	// rewrite and the dynamic fallback apply the same way they do
+	// without a filter; field projection waits until the page is cut,
+	// the filter needs the unprojected metadata
+	unpaged := *q
+	unpaged.Pagination = query.NoPagination
+	unpaged.Continue = ""
+	unpaged.Fields = nil
+	result, err := r.ListResource(gvr, namespace, &unpaged)
+	if err != nil {
+		return nil, err
+	}
+
+	filtered := make([]runtime.Object, 0, len(result.Items))
+	for _, item := range result.Items {
+		object, ok := item.(runtime.Object)
+		if !ok {
+			continue
+		}
+		accessor, err := meta.Accessor(object)
+		if err != nil {
+			continue
+		}
+		if filter(matched, accessor) {
+			filtered = append(filtered, object)
+		}
+	}
+
+	// the delegate already sorted the list, only pagination, the cursor
+	// and the projection remain
+	return v1alpha3.PaginateObjects(filtered, q), nil
+}
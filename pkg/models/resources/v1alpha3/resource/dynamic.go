@@ -0,0 +1,100 @@
+/*
+Copyright 2022 The KubeSphere Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resource
+
+import (
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog"
+
+	"kubesphere.io/kubesphere/pkg/api"
+	"kubesphere.io/kubesphere/pkg/apiserver/query"
+	"kubesphere.io/kubesphere/pkg/models/crds"
+	"kubesphere.io/kubesphere/pkg/models/resources/v1alpha3"
+)
+
+// dynamicGetter is the fallback for group version resources without a
+// registered getter. It serves any installed custom resource as
+// unstructured objects through the controller-runtime cache, with the
+// generic object meta filtering and sorting every getter provides.
+type dynamicGetter struct {
+	reader crds.Reader
+}
+
+func (d *dynamicGetter) Get(namespace, name string) (runtime.Object, error) {
+	return d.reader.Get(types.NamespacedName{Namespace: namespace, Name: name})
+}
+
+func (d *dynamicGetter) List(namespace string, query *query.Query) (*api.ListResult, error) {
+	return d.reader.List(namespace, query)
+}
+
+// dynamicResource resolves the getter of a custom resource without a
+// registered one, matching the requested resource against the installed
+// custom resource definitions.
+func (r *ResourceGetter) dynamicResource(gvr schema.GroupVersionResource, clusterScope bool) v1alpha3.Interface {
+	if r.cache == nil || r.crdLister == nil {
+		return nil
+	}
+	definitions, err := r.crdLister.List(labels.Everything())
+	if err != nil {
+		klog.Error(err)
+		return nil
+	}
+	for _, crd := range definitions {
+		if crd.Spec.Names.Plural != gvr.Resource {
+			continue
+		}
+		if gvr.Group != "" && crd.Spec.Group != gvr.Group {
+			continue
+		}
+		if clusterScope != (crd.Spec.Scope == apiextensionsv1.ClusterScoped) {
+			continue
+		}
+		version := servedVersion(crd, gvr.Version)
+		if version == "" {
+			continue
+		}
+		gvk := schema.GroupVersionKind{Group: crd.Spec.Group, Version: version, Kind: crd.Spec.Names.Kind}
+		return &dynamicGetter{reader: crds.NewUnstructured(r.cache, gvk)}
+	}
+	return nil
+}
+
+// servedVersion picks the version instances are served from, the storage
+// version unless the request pins another served one.
+func servedVersion(crd *apiextensionsv1.CustomResourceDefinition, requested string) string {
+	var storage string
+	for _, version := range crd.Spec.Versions {
+		if !version.Served {
+			continue
+		}
+		if requested != "" && version.Name == requested {
+			return version.Name
+		}
+		if version.Storage {
+			storage = version.Name
+		}
+	}
+	if requested != "" {
+		return ""
+	}
+	return storage
+}
@@ -0,0 +1,110 @@
+/*
+Copyright 2022 The KubeSphere Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resource
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+
+	"kubesphere.io/kubesphere/pkg/api"
+	"kubesphere.io/kubesphere/pkg/apiserver/query"
+	"kubesphere.io/kubesphere/pkg/informers"
+	"kubesphere.io/kubesphere/pkg/models/resources/v1alpha3"
+)
+
+// lazySyncTimeout bounds how long the first access to a lazy kind waits
+// for its informer to sync.
+const lazySyncTimeout = 30 * time.Second
+
+// lazyGetter defers constructing its delegate to the first access, the
+// informer behind the getter is then registered with the shared factory,
+// started and synced on demand. Kinds most clusters never list this way
+// stay out of memory entirely instead of being cached at startup.
+type lazyGetter struct {
+	construct func() v1alpha3.Interface
+	// register forces the informer of the kind to be registered with the
+	// factory, a shared informer factory never starts informers
+	// registered after Start.
+	register func() error
+	factory  informers.GenericInformerFactory
+
+	mutex    sync.Mutex
+	delegate v1alpha3.Interface
+}
+
+func newLazyGetter(construct func() v1alpha3.Interface, register func() error, factory informers.GenericInformerFactory) *lazyGetter {
+	return &lazyGetter{construct: construct, register: register, factory: factory}
+}
+
+// resolve constructs the delegate on first call and waits for its
+// informer to sync. A failed sync wait is not cached, the next access
+// waits again on the already registered informer.
+func (l *lazyGetter) resolve() (v1alpha3.Interface, error) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	if l.delegate != nil {
+		return l.delegate, nil
+	}
+
+	delegate := l.construct()
+	// the factory is nil when only the api docs are generated, there is
+	// no cache to wait for then
+	if l.factory != nil {
+		// registration must precede Start, the delegate itself only
+		// touches its informer on the first lister access which would be
+		// too late for the factory to start it
+		if l.register != nil {
+			if err := l.register(); err != nil {
+				return nil, err
+			}
+		}
+		// the freshly registered informer runs for the rest of the
+		// process lifetime, only the sync wait is bounded
+		l.factory.Start(wait.NeverStop)
+		waitCh := make(chan struct{})
+		timer := time.AfterFunc(lazySyncTimeout, func() { close(waitCh) })
+		defer timer.Stop()
+		for informerType, synced := range l.factory.WaitForCacheSync(waitCh) {
+			if !synced {
+				return nil, fmt.Errorf("timed out waiting for the %s informer to sync", informerType)
+			}
+		}
+	}
+
+	l.delegate = delegate
+	return l.delegate, nil
+}
+
+func (l *lazyGetter) Get(namespace, name string) (runtime.Object, error) {
+	delegate, err := l.resolve()
+	if err != nil {
+		return nil, err
+	}
+	return delegate.Get(namespace, name)
+}
+
+func (l *lazyGetter) List(namespace string, q *query.Query) (*api.ListResult, error) {
+	delegate, err := l.resolve()
+	if err != nil {
+		return nil, err
+	}
+	return delegate.List(namespace, q)
+}
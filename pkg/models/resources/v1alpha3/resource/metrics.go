@@ -0,0 +1,76 @@
+/*
+Copyright 2022 The KubeSphere Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resource
+
+import (
+	compbasemetrics "k8s.io/component-base/metrics"
+
+	"kubesphere.io/kubesphere/pkg/utils/metrics"
+)
+
+const (
+	// dynamicCacheHit and dynamicCacheMiss classify lookups on the
+	// controller-runtime cache fallback, a miss means no served custom
+	// resource definition matched the requested kind.
+	dynamicCacheHit  = "hit"
+	dynamicCacheMiss = "miss"
+)
+
+var (
+	resourceListLatencies = compbasemetrics.NewHistogramVec(
+		&compbasemetrics.HistogramOpts{
+			Name:           "ks_server_resource_list_duration_seconds",
+			Help:           "Latency distribution in seconds of resource list queries, broken out for each resource plural, so slow kinds stand out.",
+			Buckets:        []float64{0.001, 0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5},
+			StabilityLevel: compbasemetrics.ALPHA,
+		},
+		[]string{"resource"},
+	)
+
+	resourceListSizes = compbasemetrics.NewHistogramVec(
+		&compbasemetrics.HistogramOpts{
+			Name:           "ks_server_resource_list_items",
+			Help:           "Distribution of the number of items resource list queries match after filtering and before pagination, broken out for each resource plural.",
+			Buckets:        []float64{1, 10, 50, 100, 500, 1000, 5000, 10000},
+			StabilityLevel: compbasemetrics.ALPHA,
+		},
+		[]string{"resource"},
+	)
+
+	dynamicCacheLookups = compbasemetrics.NewCounterVec(
+		&compbasemetrics.CounterOpts{
+			Name:           "ks_server_resource_dynamic_cache_requests_total",
+			Help:           "Counter of queries falling through to the controller-runtime cache for kinds without a registered getter, broken out for each resource plural and hit or miss.",
+			StabilityLevel: compbasemetrics.ALPHA,
+		},
+		[]string{"resource", "result"},
+	)
+)
+
+func init() {
+	metrics.MustRegister(resourceListLatencies, resourceListSizes, dynamicCacheLookups)
+}
+
+// observeDynamicLookup counts a lookup on the controller-runtime cache
+// fallback for the given resource plural.
+func observeDynamicLookup(resource string, hit bool) {
+	result := dynamicCacheMiss
+	if hit {
+		result = dynamicCacheHit
+	}
+	dynamicCacheLookups.WithLabelValues(resource, result).Inc()
+}
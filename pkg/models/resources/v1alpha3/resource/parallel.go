@@ -0,0 +1,62 @@
+/*
+Copyright 2022 The KubeSphere Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resource
+
+import (
+	"context"
+	"sync"
+)
+
+// maxConcurrentQueries bounds how many getter queries a single request
+// fans out at once, the getters read informer caches so a small number
+// of workers already turns the latency from sum-of into max-of.
+const maxConcurrentQueries = 8
+
+// runConcurrently runs the tasks with at most maxConcurrentQueries in
+// flight and stops launching new ones once the context is cancelled.
+// Already running tasks finish, the first error wins.
+func runConcurrently(ctx context.Context, tasks []func() error) error {
+	semaphore := make(chan struct{}, maxConcurrentQueries)
+	var wg sync.WaitGroup
+	var mutex sync.Mutex
+	var firstErr error
+
+	for _, task := range tasks {
+		if ctx.Err() != nil {
+			break
+		}
+		wg.Add(1)
+		semaphore <- struct{}{}
+		go func(task func() error) {
+			defer wg.Done()
+			defer func() { <-semaphore }()
+			if err := task(); err != nil {
+				mutex.Lock()
+				if firstErr == nil {
+					firstErr = err
+				}
+				mutex.Unlock()
+			}
+		}(task)
+	}
+	wg.Wait()
+
+	if firstErr != nil {
+		return firstErr
+	}
+	return ctx.Err()
+}
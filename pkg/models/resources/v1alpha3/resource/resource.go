@@ -17,7 +17,13 @@ limitations under the License.
 package resource
 
 import (
+	"context"
 	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
 
 	"kubesphere.io/kubesphere/pkg/models/resources/v1alpha3/volumesnapshotcontent"
 
@@ -27,10 +33,16 @@ import (
 
 	snapshotv1 "github.com/kubernetes-csi/external-snapshotter/client/v4/apis/volumesnapshot/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
+	apiextensionslisters "k8s.io/apiextensions-apiserver/pkg/client/listers/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/klog"
 	monitoringdashboardv1alpha2 "kubesphere.io/monitoring-dashboard/api/v1alpha2"
 	"sigs.k8s.io/controller-runtime/pkg/cache"
+	runtimeclient "sigs.k8s.io/controller-runtime/pkg/client"
 
 	clusterv1alpha1 "kubesphere.io/api/cluster/v1alpha1"
 	devopsv1alpha3 "kubesphere.io/api/devops/v1alpha3"
@@ -46,20 +58,29 @@ import (
 	"kubesphere.io/kubesphere/pkg/informers"
 	"kubesphere.io/kubesphere/pkg/models/resources/v1alpha3"
 	"kubesphere.io/kubesphere/pkg/models/resources/v1alpha3/application"
+	"kubesphere.io/kubesphere/pkg/models/resources/v1alpha3/cached"
 	"kubesphere.io/kubesphere/pkg/models/resources/v1alpha3/cluster"
 	"kubesphere.io/kubesphere/pkg/models/resources/v1alpha3/clusterdashboard"
 	"kubesphere.io/kubesphere/pkg/models/resources/v1alpha3/clusterrole"
 	"kubesphere.io/kubesphere/pkg/models/resources/v1alpha3/clusterrolebinding"
 	"kubesphere.io/kubesphere/pkg/models/resources/v1alpha3/configmap"
+	"kubesphere.io/kubesphere/pkg/models/resources/v1alpha3/controllerrevision"
+	"kubesphere.io/kubesphere/pkg/models/resources/v1alpha3/cronjob"
 	"kubesphere.io/kubesphere/pkg/models/resources/v1alpha3/customresourcedefinition"
 	"kubesphere.io/kubesphere/pkg/models/resources/v1alpha3/daemonset"
 	"kubesphere.io/kubesphere/pkg/models/resources/v1alpha3/dashboard"
 	"kubesphere.io/kubesphere/pkg/models/resources/v1alpha3/deployment"
 	"kubesphere.io/kubesphere/pkg/models/resources/v1alpha3/devops"
+	"kubesphere.io/kubesphere/pkg/models/resources/v1alpha3/endpoints"
+	"kubesphere.io/kubesphere/pkg/models/resources/v1alpha3/endpointslice"
+	"kubesphere.io/kubesphere/pkg/models/resources/v1alpha3/event"
 	"kubesphere.io/kubesphere/pkg/models/resources/v1alpha3/federatedapplication"
 	"kubesphere.io/kubesphere/pkg/models/resources/v1alpha3/federatedconfigmap"
+	"kubesphere.io/kubesphere/pkg/models/resources/v1alpha3/federatedcronjob"
+	"kubesphere.io/kubesphere/pkg/models/resources/v1alpha3/federateddaemonset"
 	"kubesphere.io/kubesphere/pkg/models/resources/v1alpha3/federateddeployment"
 	"kubesphere.io/kubesphere/pkg/models/resources/v1alpha3/federatedingress"
+	"kubesphere.io/kubesphere/pkg/models/resources/v1alpha3/federatedjob"
 	"kubesphere.io/kubesphere/pkg/models/resources/v1alpha3/federatednamespace"
 	"kubesphere.io/kubesphere/pkg/models/resources/v1alpha3/federatedpersistentvolumeclaim"
 	"kubesphere.io/kubesphere/pkg/models/resources/v1alpha3/federatedsecret"
@@ -70,8 +91,10 @@ import (
 	"kubesphere.io/kubesphere/pkg/models/resources/v1alpha3/group"
 	"kubesphere.io/kubesphere/pkg/models/resources/v1alpha3/groupbinding"
 	"kubesphere.io/kubesphere/pkg/models/resources/v1alpha3/ingress"
+	"kubesphere.io/kubesphere/pkg/models/resources/v1alpha3/ingressclass"
 	"kubesphere.io/kubesphere/pkg/models/resources/v1alpha3/ippool"
 	"kubesphere.io/kubesphere/pkg/models/resources/v1alpha3/job"
+	"kubesphere.io/kubesphere/pkg/models/resources/v1alpha3/lease"
 	"kubesphere.io/kubesphere/pkg/models/resources/v1alpha3/loginrecord"
 	"kubesphere.io/kubesphere/pkg/models/resources/v1alpha3/namespace"
 	"kubesphere.io/kubesphere/pkg/models/resources/v1alpha3/networkpolicy"
@@ -79,18 +102,24 @@ import (
 	"kubesphere.io/kubesphere/pkg/models/resources/v1alpha3/notification"
 	"kubesphere.io/kubesphere/pkg/models/resources/v1alpha3/persistentvolumeclaim"
 	"kubesphere.io/kubesphere/pkg/models/resources/v1alpha3/pod"
+	"kubesphere.io/kubesphere/pkg/models/resources/v1alpha3/poddisruptionbudget"
+	"kubesphere.io/kubesphere/pkg/models/resources/v1alpha3/replicaset"
 	"kubesphere.io/kubesphere/pkg/models/resources/v1alpha3/role"
 	"kubesphere.io/kubesphere/pkg/models/resources/v1alpha3/rolebinding"
+	"kubesphere.io/kubesphere/pkg/models/resources/v1alpha3/searchindex"
 	"kubesphere.io/kubesphere/pkg/models/resources/v1alpha3/secret"
 	"kubesphere.io/kubesphere/pkg/models/resources/v1alpha3/service"
 	"kubesphere.io/kubesphere/pkg/models/resources/v1alpha3/serviceaccount"
 	"kubesphere.io/kubesphere/pkg/models/resources/v1alpha3/statefulset"
 	"kubesphere.io/kubesphere/pkg/models/resources/v1alpha3/user"
 	"kubesphere.io/kubesphere/pkg/models/resources/v1alpha3/volumesnapshot"
+	"kubesphere.io/kubesphere/pkg/models/resources/v1alpha3/webhookconfiguration"
 	"kubesphere.io/kubesphere/pkg/models/resources/v1alpha3/workspace"
 	"kubesphere.io/kubesphere/pkg/models/resources/v1alpha3/workspacerole"
 	"kubesphere.io/kubesphere/pkg/models/resources/v1alpha3/workspacerolebinding"
 	"kubesphere.io/kubesphere/pkg/models/resources/v1alpha3/workspacetemplate"
+	simplecache "kubesphere.io/kubesphere/pkg/simple/client/cache"
+	"kubesphere.io/kubesphere/pkg/simple/client/monitoring"
 )
 
 var ErrResourceNotSupported = errors.New("resource is not supported")
@@ -98,6 +127,15 @@ var ErrResourceNotSupported = errors.New("resource is not supported")
 type ResourceGetter struct {
 	clusterResourceGetters    map[schema.GroupVersionResource]v1alpha3.Interface
 	namespacedResourceGetters map[schema.GroupVersionResource]v1alpha3.Interface
+
+	// cache and crdLister back the dynamic fallback getter serving
+	// custom resources without a registered getter
+	cache     runtimeclient.Reader
+	crdLister apiextensionslisters.CustomResourceDefinitionLister
+
+	// searchIndexes resolve the search query parameter for the kinds
+	// covered by EnableSearchIndex, nil when the indexes are disabled
+	searchIndexes map[schema.GroupVersionResource]*searchindex.Index
 }
 
 func NewResourceGetter(factory informers.InformerFactory, cache cache.Cache) *ResourceGetter {
@@ -107,14 +145,23 @@ func NewResourceGetter(factory informers.InformerFactory, cache cache.Cache) *Re
 	namespacedResourceGetters[schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "deployments"}] = deployment.New(factory.KubernetesSharedInformerFactory())
 	namespacedResourceGetters[schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "daemonsets"}] = daemonset.New(factory.KubernetesSharedInformerFactory())
 	namespacedResourceGetters[schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "statefulsets"}] = statefulset.New(factory.KubernetesSharedInformerFactory())
+	namespacedResourceGetters[schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "replicasets"}] = replicaset.New(factory.KubernetesSharedInformerFactory())
+	namespacedResourceGetters[schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "controllerrevisions"}] = controllerrevision.New(factory.KubernetesSharedInformerFactory())
 	namespacedResourceGetters[schema.GroupVersionResource{Group: "", Version: "v1", Resource: "services"}] = service.New(factory.KubernetesSharedInformerFactory())
+	namespacedResourceGetters[schema.GroupVersionResource{Group: "", Version: "v1", Resource: "endpoints"}] = endpoints.New(factory.KubernetesSharedInformerFactory())
+	namespacedResourceGetters[schema.GroupVersionResource{Group: "discovery.k8s.io", Version: "v1", Resource: "endpointslices"}] = endpointslice.New(factory.KubernetesSharedInformerFactory())
 	namespacedResourceGetters[schema.GroupVersionResource{Group: "", Version: "v1", Resource: "configmaps"}] = configmap.New(factory.KubernetesSharedInformerFactory())
 	namespacedResourceGetters[schema.GroupVersionResource{Group: "", Version: "v1", Resource: "secrets"}] = secret.New(factory.KubernetesSharedInformerFactory())
 	namespacedResourceGetters[schema.GroupVersionResource{Group: "", Version: "v1", Resource: "pods"}] = pod.New(factory.KubernetesSharedInformerFactory())
 	namespacedResourceGetters[schema.GroupVersionResource{Group: "", Version: "v1", Resource: "serviceaccounts"}] = serviceaccount.New(factory.KubernetesSharedInformerFactory())
+	namespacedResourceGetters[schema.GroupVersionResource{Group: "", Version: "v1", Resource: "events"}] = event.New(factory.KubernetesSharedInformerFactory())
 	namespacedResourceGetters[schema.GroupVersionResource{Group: "networking.k8s.io", Version: "v1", Resource: "ingresses"}] = ingress.New(factory.KubernetesSharedInformerFactory())
+	clusterResourceGetters[schema.GroupVersionResource{Group: "networking.k8s.io", Version: "v1", Resource: "ingressclasses"}] = ingressclass.New(factory.KubernetesSharedInformerFactory())
 	namespacedResourceGetters[schema.GroupVersionResource{Group: "networking.k8s.io", Version: "v1", Resource: "networkpolicies"}] = networkpolicy.New(factory.KubernetesSharedInformerFactory())
+	namespacedResourceGetters[schema.GroupVersionResource{Group: "policy", Version: "v1", Resource: "poddisruptionbudgets"}] = poddisruptionbudget.New(factory.KubernetesSharedInformerFactory())
 	namespacedResourceGetters[schema.GroupVersionResource{Group: "batch", Version: "v1", Resource: "jobs"}] = job.New(factory.KubernetesSharedInformerFactory())
+	namespacedResourceGetters[schema.GroupVersionResource{Group: "batch", Version: "v1", Resource: "cronjobs"}] = cronjob.New(factory.KubernetesSharedInformerFactory())
+	namespacedResourceGetters[schema.GroupVersionResource{Group: "coordination.k8s.io", Version: "v1", Resource: "leases"}] = lease.New(factory.KubernetesSharedInformerFactory())
 	namespacedResourceGetters[schema.GroupVersionResource{Group: "app.k8s.io", Version: "v1beta1", Resource: "applications"}] = application.New(cache)
 	clusterResourceGetters[schema.GroupVersionResource{Group: "", Version: "v1", Resource: "persistentvolumes"}] = persistentvolume.New(factory.KubernetesSharedInformerFactory())
 	namespacedResourceGetters[schema.GroupVersionResource{Group: "", Version: "v1", Resource: "persistentvolumeclaims"}] = persistentvolumeclaim.New(factory.KubernetesSharedInformerFactory(), factory.SnapshotSharedInformerFactory())
@@ -126,6 +173,8 @@ func NewResourceGetter(factory informers.InformerFactory, cache cache.Cache) *Re
 	clusterResourceGetters[schema.GroupVersionResource{Group: "", Version: "v1", Resource: "nodes"}] = node.New(factory.KubernetesSharedInformerFactory())
 	clusterResourceGetters[schema.GroupVersionResource{Group: "", Version: "v1", Resource: "namespaces"}] = namespace.New(factory.KubernetesSharedInformerFactory())
 	clusterResourceGetters[schema.GroupVersionResource{Group: "apiextensions.k8s.io", Version: "v1", Resource: "customresourcedefinitions"}] = customresourcedefinition.New(factory.ApiExtensionSharedInformerFactory())
+	clusterResourceGetters[schema.GroupVersionResource{Group: "admissionregistration.k8s.io", Version: "v1", Resource: "mutatingwebhookconfigurations"}] = webhookconfiguration.NewMutating(factory.KubernetesSharedInformerFactory())
+	clusterResourceGetters[schema.GroupVersionResource{Group: "admissionregistration.k8s.io", Version: "v1", Resource: "validatingwebhookconfigurations"}] = webhookconfiguration.NewValidating(factory.KubernetesSharedInformerFactory())
 
 	// kubesphere resources
 	namespacedResourceGetters[networkv1alpha1.SchemeGroupVersion.WithResource(networkv1alpha1.ResourcePluralIPPool)] = ippool.New(factory.KubeSphereSharedInformerFactory(), factory.KubernetesSharedInformerFactory())
@@ -150,45 +199,220 @@ func NewResourceGetter(factory informers.InformerFactory, cache cache.Cache) *Re
 	clusterResourceGetters[notificationv2beta2.SchemeGroupVersion.WithResource(notificationv2beta2.ResourcesPluralSilence)] = notification.NewNotificationSilenceGetter(factory.KubeSphereSharedInformerFactory())
 	clusterResourceGetters[monitoringdashboardv1alpha2.GroupVersion.WithResource("clusterdashboards")] = clusterdashboard.New(cache)
 
-	// federated resources
-	namespacedResourceGetters[typesv1beta1.SchemeGroupVersion.WithResource(typesv1beta1.ResourcePluralFederatedNamespace)] = federatednamespace.New(factory.KubeSphereSharedInformerFactory())
-	namespacedResourceGetters[typesv1beta1.SchemeGroupVersion.WithResource(typesv1beta1.ResourcePluralFederatedDeployment)] = federateddeployment.New(factory.KubeSphereSharedInformerFactory())
-	namespacedResourceGetters[typesv1beta1.SchemeGroupVersion.WithResource(typesv1beta1.ResourcePluralFederatedSecret)] = federatedsecret.New(factory.KubeSphereSharedInformerFactory())
-	namespacedResourceGetters[typesv1beta1.SchemeGroupVersion.WithResource(typesv1beta1.ResourcePluralFederatedConfigmap)] = federatedconfigmap.New(factory.KubeSphereSharedInformerFactory())
-	namespacedResourceGetters[typesv1beta1.SchemeGroupVersion.WithResource(typesv1beta1.ResourcePluralFederatedService)] = federatedservice.New(factory.KubeSphereSharedInformerFactory())
-	namespacedResourceGetters[typesv1beta1.SchemeGroupVersion.WithResource(typesv1beta1.ResourcePluralFederatedApplication)] = federatedapplication.New(factory.KubeSphereSharedInformerFactory())
-	namespacedResourceGetters[typesv1beta1.SchemeGroupVersion.WithResource(typesv1beta1.ResourcePluralFederatedPersistentVolumeClaim)] = federatedpersistentvolumeclaim.New(factory.KubeSphereSharedInformerFactory())
-	namespacedResourceGetters[typesv1beta1.SchemeGroupVersion.WithResource(typesv1beta1.ResourcePluralFederatedStatefulSet)] = federatedstatefulset.New(factory.KubeSphereSharedInformerFactory())
-	namespacedResourceGetters[typesv1beta1.SchemeGroupVersion.WithResource(typesv1beta1.ResourcePluralFederatedIngress)] = federatedingress.New(factory.KubeSphereSharedInformerFactory())
+	// federated resources are only touched on clusters where multicluster
+	// is in use, their getters are constructed lazily on first access so
+	// the informers behind them are registered, started and synced on
+	// demand instead of being cached at startup
+	ksFactory := factory.KubeSphereSharedInformerFactory()
+	federatedGetters := map[schema.GroupVersionResource]func() v1alpha3.Interface{
+		typesv1beta1.SchemeGroupVersion.WithResource(typesv1beta1.ResourcePluralFederatedNamespace):             func() v1alpha3.Interface { return federatednamespace.New(ksFactory) },
+		typesv1beta1.SchemeGroupVersion.WithResource(typesv1beta1.ResourcePluralFederatedDeployment):            func() v1alpha3.Interface { return federateddeployment.New(ksFactory) },
+		typesv1beta1.SchemeGroupVersion.WithResource(typesv1beta1.ResourcePluralFederatedSecret):                func() v1alpha3.Interface { return federatedsecret.New(ksFactory) },
+		typesv1beta1.SchemeGroupVersion.WithResource(typesv1beta1.ResourcePluralFederatedConfigmap):             func() v1alpha3.Interface { return federatedconfigmap.New(ksFactory) },
+		typesv1beta1.SchemeGroupVersion.WithResource(typesv1beta1.ResourcePluralFederatedService):               func() v1alpha3.Interface { return federatedservice.New(ksFactory) },
+		typesv1beta1.SchemeGroupVersion.WithResource(typesv1beta1.ResourcePluralFederatedApplication):           func() v1alpha3.Interface { return federatedapplication.New(ksFactory) },
+		typesv1beta1.SchemeGroupVersion.WithResource(typesv1beta1.ResourcePluralFederatedPersistentVolumeClaim): func() v1alpha3.Interface { return federatedpersistentvolumeclaim.New(ksFactory) },
+		typesv1beta1.SchemeGroupVersion.WithResource(typesv1beta1.ResourcePluralFederatedStatefulSet):           func() v1alpha3.Interface { return federatedstatefulset.New(ksFactory) },
+		typesv1beta1.SchemeGroupVersion.WithResource(typesv1beta1.ResourcePluralFederatedIngress):               func() v1alpha3.Interface { return federatedingress.New(ksFactory) },
+		typesv1beta1.SchemeGroupVersion.WithResource(typesv1beta1.ResourcePluralFederatedJob):                   func() v1alpha3.Interface { return federatedjob.New(ksFactory) },
+		typesv1beta1.SchemeGroupVersion.WithResource(typesv1beta1.ResourcePluralFederatedCronJob):               func() v1alpha3.Interface { return federatedcronjob.New(ksFactory) },
+		typesv1beta1.SchemeGroupVersion.WithResource(typesv1beta1.ResourcePluralFederatedDaemonSet):             func() v1alpha3.Interface { return federateddaemonset.New(ksFactory) },
+	}
+	for gvr, construct := range federatedGetters {
+		gvr := gvr
+		var syncFactory informers.GenericInformerFactory
+		var register func() error
+		if ksFactory != nil {
+			syncFactory = ksFactory
+			register = func() error {
+				_, err := ksFactory.ForResource(gvr)
+				return err
+			}
+		}
+		namespacedResourceGetters[gvr] = newLazyGetter(construct, register, syncFactory)
+	}
 	namespacedResourceGetters[monitoringdashboardv1alpha2.GroupVersion.WithResource("dashboards")] = dashboard.New(cache)
 
+	var crdLister apiextensionslisters.CustomResourceDefinitionLister
+	if apiextensionsInformers := factory.ApiExtensionSharedInformerFactory(); apiextensionsInformers != nil {
+		crdLister = apiextensionsInformers.Apiextensions().V1().CustomResourceDefinitions().Lister()
+	}
+
 	return &ResourceGetter{
 		namespacedResourceGetters: namespacedResourceGetters,
 		clusterResourceGetters:    clusterResourceGetters,
+		cache:                     cache,
+		crdLister:                 crdLister,
+	}
+}
+
+// heavyResources are the getters worth backing with the shared cache layer
+// and the search indexes, they hold the largest informer caches in big
+// clusters.
+var heavyResources = []schema.GroupVersionResource{
+	{Group: "", Version: "v1", Resource: "pods"},
+	{Group: "apps", Version: "v1", Resource: "deployments"},
+	{Group: "apps", Version: "v1", Resource: "daemonsets"},
+	{Group: "apps", Version: "v1", Resource: "statefulsets"},
+	{Group: "batch", Version: "v1", Resource: "jobs"},
+	{Group: "", Version: "v1", Resource: "configmaps"},
+	{Group: "", Version: "v1", Resource: "secrets"},
+}
+
+// EnableSharedCache backs the heavy getters with the shared cache layer so
+// list snapshots are reused across ks-apiserver replicas instead of each
+// replica materializing full lists from its own informer caches.
+func (r *ResourceGetter) EnableSharedCache(cacheClient simplecache.Interface, ttl time.Duration) {
+	for _, gvr := range heavyResources {
+		if getter, ok := r.namespacedResourceGetters[gvr]; ok {
+			r.namespacedResourceGetters[gvr] = cached.New(getter, cacheClient, gvr, ttl)
+		}
+		if getter, ok := r.clusterResourceGetters[gvr]; ok {
+			r.clusterResourceGetters[gvr] = cached.New(getter, cacheClient, gvr, ttl)
+		}
+	}
+}
+
+// EnableSearchIndex maintains in-memory inverted indexes over the heavy
+// informer caches, so the search query parameter matches name, labels,
+// annotations and container images by token lookup instead of
+// substring-scanning every object on each request.
+func (r *ResourceGetter) EnableSearchIndex(factory informers.InformerFactory) {
+	r.searchIndexes = make(map[schema.GroupVersionResource]*searchindex.Index, len(heavyResources))
+	for _, gvr := range heavyResources {
+		informer, err := factory.KubernetesSharedInformerFactory().ForResource(gvr)
+		if err != nil {
+			klog.Warningf("search index for %s: %s", gvr, err)
+			continue
+		}
+		index := searchindex.New()
+		informer.Informer().AddEventHandler(index)
+		r.searchIndexes[gvr] = index
+	}
+}
+
+// Register adds a getter for a namespaced resource, so downstream
+// distributions and plugins hook their own kinds in without patching
+// NewResourceGetter. Registering a GroupVersionResource that is already
+// covered fails, silently replacing a built-in getter would be surprising.
+func (r *ResourceGetter) Register(gvr schema.GroupVersionResource, getter v1alpha3.Interface) error {
+	if err := r.checkConflict(gvr); err != nil {
+		return err
+	}
+	r.namespacedResourceGetters[gvr] = getter
+	return nil
+}
+
+// RegisterClusterScoped is the cluster scoped variant of Register.
+func (r *ResourceGetter) RegisterClusterScoped(gvr schema.GroupVersionResource, getter v1alpha3.Interface) error {
+	if err := r.checkConflict(gvr); err != nil {
+		return err
+	}
+	r.clusterResourceGetters[gvr] = getter
+	return nil
+}
+
+func (r *ResourceGetter) checkConflict(gvr schema.GroupVersionResource) error {
+	if _, ok := r.namespacedResourceGetters[gvr]; ok {
+		return fmt.Errorf("getter for %s is already registered", gvr)
+	}
+	if _, ok := r.clusterResourceGetters[gvr]; ok {
+		return fmt.Errorf("getter for %s is already registered", gvr)
+	}
+	return nil
+}
+
+// RegisteredResources lists every GroupVersionResource a getter is
+// registered for, built-in and plugged-in alike, in a stable order.
+func (r *ResourceGetter) RegisteredResources() []schema.GroupVersionResource {
+	resources := make([]schema.GroupVersionResource, 0, len(r.namespacedResourceGetters)+len(r.clusterResourceGetters))
+	for gvr := range r.namespacedResourceGetters {
+		resources = append(resources, gvr)
+	}
+	for gvr := range r.clusterResourceGetters {
+		resources = append(resources, gvr)
+	}
+	sort.Slice(resources, func(i, j int) bool {
+		return resources[i].String() < resources[j].String()
+	})
+	return resources
+}
+
+// UseLegacyCronJob swaps the cronjob getter for the batch/v1beta1
+// implementation, for legacy clusters that do not serve cronjob v1 yet.
+func (r *ResourceGetter) UseLegacyCronJob(factory informers.InformerFactory) {
+	delete(r.namespacedResourceGetters, schema.GroupVersionResource{Group: "batch", Version: "v1", Resource: "cronjobs"})
+	r.namespacedResourceGetters[schema.GroupVersionResource{Group: "batch", Version: "v1beta1", Resource: "cronjobs"}] = cronjob.NewV1beta1(factory.KubernetesSharedInformerFactory())
+}
+
+// UseLegacyIngress swaps the ingress getter for the
+// networking.k8s.io/v1beta1 implementation, for legacy clusters that do
+// not serve ingress v1 yet. Such clusters have no usable ingressclasses
+// under v1 either, so that getter is dropped.
+func (r *ResourceGetter) UseLegacyIngress(factory informers.InformerFactory) {
+	delete(r.namespacedResourceGetters, schema.GroupVersionResource{Group: "networking.k8s.io", Version: "v1", Resource: "ingresses"})
+	delete(r.clusterResourceGetters, schema.GroupVersionResource{Group: "networking.k8s.io", Version: "v1", Resource: "ingressclasses"})
+	r.namespacedResourceGetters[schema.GroupVersionResource{Group: "networking.k8s.io", Version: "v1beta1", Resource: "ingresses"}] = ingress.NewV1beta1(factory.KubernetesSharedInformerFactory())
+}
+
+// EnableWebhookMetrics supplies the monitoring client to the admission
+// webhook configuration getters so their detail responses include the
+// recent rejection counts observed by the apiservers.
+func (r *ResourceGetter) EnableWebhookMetrics(client monitoring.Interface) {
+	webhookConfigurations := []schema.GroupVersionResource{
+		{Group: "admissionregistration.k8s.io", Version: "v1", Resource: "mutatingwebhookconfigurations"},
+		{Group: "admissionregistration.k8s.io", Version: "v1", Resource: "validatingwebhookconfigurations"},
+	}
+	for _, gvr := range webhookConfigurations {
+		if getter, ok := r.clusterResourceGetters[gvr]; ok {
+			webhookconfiguration.EnableMetrics(getter, client)
+		}
 	}
 }
 
-// TryResource will retrieve a getter with resource name, it doesn't guarantee find resource with correct group version
-// need to refactor this use schema.GroupVersionResource
 func (r *ResourceGetter) TryResource(clusterScope bool, resource string) v1alpha3.Interface {
+	_, getter := r.tryResource(clusterScope, schema.GroupVersionResource{Resource: resource})
+	return getter
+}
+
+// tryResource retrieves a getter matching the given GroupVersionResource.
+// Group and version constrain the lookup when set, so resources sharing a
+// plural name across groups do not collide; a gvr carrying only the
+// resource keeps the legacy resource-name-only behavior.
+func (r *ResourceGetter) tryResource(clusterScope bool, gvr schema.GroupVersionResource) (schema.GroupVersionResource, v1alpha3.Interface) {
+	match := func(k schema.GroupVersionResource) bool {
+		return k.Resource == gvr.Resource &&
+			(gvr.Group == "" || k.Group == gvr.Group) &&
+			(gvr.Version == "" || k.Version == gvr.Version)
+	}
 	if clusterScope {
 		for k, v := range r.clusterResourceGetters {
-			if k.Resource == resource {
-				return v
+			if match(k) {
+				return k, v
 			}
 		}
 	}
 	for k, v := range r.namespacedResourceGetters {
-		if k.Resource == resource {
-			return v
+		if match(k) {
+			return k, v
 		}
 	}
-	return nil
+	return schema.GroupVersionResource{}, nil
 }
 
 func (r *ResourceGetter) Get(resource, namespace, name string) (runtime.Object, error) {
+	return r.GetResource(schema.GroupVersionResource{Resource: resource}, namespace, name)
+}
+
+// GetResource is the GroupVersionResource-aware variant of Get, for callers
+// that need to disambiguate group and version explicitly.
+func (r *ResourceGetter) GetResource(gvr schema.GroupVersionResource, namespace, name string) (runtime.Object, error) {
 	clusterScope := namespace == ""
-	getter := r.TryResource(clusterScope, resource)
+	_, getter := r.tryResource(clusterScope, gvr)
+	if getter == nil {
+		getter = r.dynamicResource(gvr, clusterScope)
+		observeDynamicLookup(gvr.Resource, getter != nil)
+	}
 	if getter == nil {
 		return nil, ErrResourceNotSupported
 	}
@@ -196,10 +420,174 @@ func (r *ResourceGetter) Get(resource, namespace, name string) (runtime.Object,
 }
 
 func (r *ResourceGetter) List(resource, namespace string, query *query.Query) (*api.ListResult, error) {
+	return r.ListResource(schema.GroupVersionResource{Resource: resource}, namespace, query)
+}
+
+// ListMulti lists several resource kinds in one call, the result maps
+// each requested resource plural onto its list. The kinds are queried
+// concurrently so the latency is that of the slowest kind, not their
+// sum. The whole call fails with ErrResourceNotSupported when any of the
+// kinds is unknown, a partial answer would silently hide kinds from the
+// caller. A non-nil filter drops items the user may not see.
+func (r *ResourceGetter) ListMulti(ctx context.Context, resources []string, namespace string, q *query.Query, filter ListFilter) (map[string]*api.ListResult, error) {
+	// DefaultList writes a default pagination back into a shared query,
+	// settle it before the fan-out
+	if q.Pagination == nil {
+		q.Pagination = query.NoPagination
+	}
+
+	results := make(map[string]*api.ListResult, len(resources))
+	var mutex sync.Mutex
+	tasks := make([]func() error, 0, len(resources))
+	for _, resource := range resources {
+		if _, ok := results[resource]; ok {
+			continue
+		}
+		results[resource] = nil
+		resource := resource
+		tasks = append(tasks, func() error {
+			result, err := r.ListFiltered(resource, namespace, q, filter)
+			if err != nil {
+				if err == ErrResourceNotSupported {
+					klog.Errorf("resource type %s is not supported", resource)
+				}
+				return err
+			}
+			mutex.Lock()
+			results[resource] = result
+			mutex.Unlock()
+			return nil
+		})
+	}
+	if err := runConcurrently(ctx, tasks); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// ListInWorkspace lists a namespaced resource across every namespace
+// labeled with the workspace and merges the per-namespace lists into one
+// sorted, paginated result, so workspace level pages need a single call
+// instead of one per namespace. A non-nil filter drops items from
+// namespaces the user may not list.
+func (r *ResourceGetter) ListInWorkspace(resource, workspace string, q *query.Query, filter ListFilter) (*api.ListResult, error) {
+	namespaces, err := r.workspaceNamespaces(workspace)
+	if err != nil {
+		return nil, err
+	}
+
+	// gather the full filtered set of every namespace, sorting, pagination
+	// and field projection only make sense on the merged list
+	scoped := *q
+	scoped.Pagination = query.NoPagination
+	scoped.Continue = ""
+	scoped.Fields = nil
+
+	var merged []runtime.Object
+	for _, namespace := range namespaces {
+		result, err := r.ListFiltered(resource, namespace, &scoped, filter)
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range result.Items {
+			if object, ok := item.(runtime.Object); ok {
+				merged = append(merged, object)
+			}
+		}
+	}
+
+	// the per-namespace lists already applied the filters and the search
+	// term, the merged list only needs sorting and pagination
+	paged := *q
+	paged.Filters = nil
+	paged.Search = ""
+	return v1alpha3.DefaultList(merged, &paged, objectMetaCompare, nil), nil
+}
+
+// workspaceNamespaces resolves the names of the namespaces labeled with
+// the workspace.
+func (r *ResourceGetter) workspaceNamespaces(workspace string) ([]string, error) {
+	nsQuery := query.New()
+	nsQuery.Pagination = query.NoPagination
+	nsQuery.LabelSelector = labels.Set{tenantv1alpha1.WorkspaceLabel: workspace}.String()
+	result, err := r.List("namespaces", "", nsQuery)
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(result.Items))
+	for _, item := range result.Items {
+		object, ok := item.(runtime.Object)
+		if !ok {
+			continue
+		}
+		if accessor, err := meta.Accessor(object); err == nil {
+			names = append(names, accessor.GetName())
+		}
+	}
+	return names, nil
+}
+
+// objectMetaCompare orders merged items of any kind by their object meta,
+// the kind specific compare functions live with the getters and are not
+// reachable here.
+func objectMetaCompare(left, right runtime.Object, sortBy query.Field) bool {
+	leftMeta, err := meta.Accessor(left)
+	if err != nil {
+		return false
+	}
+	rightMeta, err := meta.Accessor(right)
+	if err != nil {
+		return true
+	}
+	return v1alpha3.DefaultObjectMetaCompare(
+		metav1.ObjectMeta{Name: leftMeta.GetName(), CreationTimestamp: leftMeta.GetCreationTimestamp()},
+		metav1.ObjectMeta{Name: rightMeta.GetName(), CreationTimestamp: rightMeta.GetCreationTimestamp()},
+		sortBy)
+}
+
+// ListResource is the GroupVersionResource-aware variant of List, for
+// callers that need to disambiguate group and version explicitly.
+func (r *ResourceGetter) ListResource(gvr schema.GroupVersionResource, namespace string, q *query.Query) (*api.ListResult, error) {
 	clusterScope := namespace == ""
-	getter := r.TryResource(clusterScope, resource)
+	matched, getter := r.tryResource(clusterScope, gvr)
+	if getter == nil {
+		getter = r.dynamicResource(gvr, clusterScope)
+		observeDynamicLookup(gvr.Resource, getter != nil)
+	}
 	if getter == nil {
 		return nil, ErrResourceNotSupported
 	}
-	return getter.List(namespace, query)
+	if q.Search != "" {
+		q = r.applySearch(matched, namespace, q)
+	}
+	start := time.Now()
+	result, err := getter.List(namespace, q)
+	resourceListLatencies.WithLabelValues(gvr.Resource).Observe(time.Since(start).Seconds())
+	if err != nil {
+		return nil, err
+	}
+	resourceListSizes.WithLabelValues(gvr.Resource).Observe(float64(result.TotalItems))
+	return result, nil
+}
+
+// applySearch rewrites the search term of the query into a filter the
+// getter can evaluate, without mutating the caller's query. Kinds backed
+// by an inverted index resolve the term to the names of the matching
+// objects, other kinds degrade to the substring filter on the name.
+func (r *ResourceGetter) applySearch(gvr schema.GroupVersionResource, namespace string, q *query.Query) *query.Query {
+	rewritten := *q
+	rewritten.Search = ""
+	rewritten.Filters = make(map[query.Field]query.Value, len(q.Filters)+1)
+	for field, value := range q.Filters {
+		rewritten.Filters[field] = value
+	}
+
+	index := r.searchIndexes[gvr]
+	if index == nil {
+		rewritten.Filters[query.FieldName] = query.Value(q.Search)
+		return &rewritten
+	}
+	names := index.Search(q.Search, namespace)
+	rewritten.Filters[query.FieldNames] = query.Value(strings.Join(names, ","))
+	return &rewritten
 }
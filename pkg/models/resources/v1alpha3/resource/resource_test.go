@@ -17,20 +17,34 @@ limitations under the License.
 package resource
 
 import (
+	"context"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
 	fakesnapshot "github.com/kubernetes-csi/external-snapshotter/client/v4/clientset/versioned/fake"
 	fakeistio "istio.io/client-go/pkg/clientset/versioned/fake"
 	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	fakeapiextensions "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset/fake"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	fakek8s "k8s.io/client-go/kubernetes/fake"
+	runtimefake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	tenantv1alpha1 "kubesphere.io/api/tenant/v1alpha1"
+	typesv1beta1 "kubesphere.io/api/types/v1beta1"
 
 	"kubesphere.io/kubesphere/pkg/api"
 	"kubesphere.io/kubesphere/pkg/apiserver/query"
 	fakeks "kubesphere.io/kubesphere/pkg/client/clientset/versioned/fake"
 	"kubesphere.io/kubesphere/pkg/informers"
+	"kubesphere.io/kubesphere/pkg/models/resources/v1alpha3"
+	"kubesphere.io/kubesphere/pkg/models/resources/v1alpha3/namespace"
 )
 
 func TestResourceGetter(t *testing.T) {
@@ -60,7 +74,7 @@ func TestResourceGetter(t *testing.T) {
 			},
 			ExpectError: nil,
 			ExpectResponse: &api.ListResult{
-				Items:      []interface{}{foo2, foo1, bar1},
+				Items:      []interface{}{withUsageAnnotations(foo2), withUsageAnnotations(foo1), withUsageAnnotations(bar1)},
 				TotalItems: 3,
 			},
 		},
@@ -79,6 +93,28 @@ func TestResourceGetter(t *testing.T) {
 	}
 }
 
+func TestListMulti(t *testing.T) {
+	resource := prepare()
+
+	results, err := resource.ListMulti(context.Background(), []string{"namespaces", "configmaps"}, "", query.New(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 2 {
+		t.Fatalf("expected 2 results, got %d", len(results))
+	}
+	if results["namespaces"].TotalItems != 3 {
+		t.Errorf("expected 3 namespaces, got %d", results["namespaces"].TotalItems)
+	}
+	if results["configmaps"].TotalItems != 0 {
+		t.Errorf("expected 0 configmaps, got %d", results["configmaps"].TotalItems)
+	}
+
+	if _, err = resource.ListMulti(context.Background(), []string{"namespaces", "unknown"}, "", query.New(), nil); err != ErrResourceNotSupported {
+		t.Errorf("expected ErrResourceNotSupported, got %v", err)
+	}
+}
+
 var (
 	foo1 = &corev1.Namespace{
 		ObjectMeta: metav1.ObjectMeta{
@@ -103,6 +139,18 @@ var (
 	namespaces = []interface{}{foo1, foo2, bar1}
 )
 
+// the namespace getter annotates returned namespaces with their cached usage
+func withUsageAnnotations(ns *corev1.Namespace) *corev1.Namespace {
+	out := ns.DeepCopy()
+	out.Annotations = map[string]string{
+		namespace.PodCountAnnotation:       "0",
+		namespace.CPURequestsAnnotation:    "0",
+		namespace.MemoryRequestsAnnotation: "0",
+		namespace.PVCStorageAnnotation:     "0",
+	}
+	return out
+}
+
 func prepare() *ResourceGetter {
 
 	ksClient := fakeks.NewSimpleClientset()
@@ -119,3 +167,328 @@ func prepare() *ResourceGetter {
 
 	return NewResourceGetter(fakeInformerFactory, nil)
 }
+
+func TestListInWorkspace(t *testing.T) {
+	ksClient := fakeks.NewSimpleClientset()
+	k8sClient := fakek8s.NewSimpleClientset()
+	istioClient := fakeistio.NewSimpleClientset()
+	snapshotClient := fakesnapshot.NewSimpleClientset()
+	apiextensionsClient := fakeapiextensions.NewSimpleClientset()
+	fakeInformerFactory := informers.NewInformerFactories(k8sClient, ksClient, istioClient, snapshotClient, apiextensionsClient, nil)
+
+	workspaceNamespace := func(name string) *corev1.Namespace {
+		return &corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   name,
+				Labels: map[string]string{tenantv1alpha1.WorkspaceLabel: "demo"},
+			},
+		}
+	}
+	nsIndexer := fakeInformerFactory.KubernetesSharedInformerFactory().Core().V1().
+		Namespaces().Informer().GetIndexer()
+	nsIndexer.Add(workspaceNamespace("ws-ns1"))
+	nsIndexer.Add(workspaceNamespace("ws-ns2"))
+	nsIndexer.Add(&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "other-ns"}})
+
+	podIndexer := fakeInformerFactory.KubernetesSharedInformerFactory().Core().V1().
+		Pods().Informer().GetIndexer()
+	podIndexer.Add(&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pod-a", Namespace: "ws-ns1"}})
+	podIndexer.Add(&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pod-b", Namespace: "ws-ns2"}})
+	podIndexer.Add(&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pod-c", Namespace: "other-ns"}})
+
+	getter := NewResourceGetter(fakeInformerFactory, nil)
+
+	q := query.New()
+	q.SortBy = query.FieldName
+	result, err := getter.ListInWorkspace("pods", "demo", q, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.TotalItems != 2 {
+		t.Fatalf("expected 2 pods, got %d", result.TotalItems)
+	}
+	names := make([]string, 0, len(result.Items))
+	for _, item := range result.Items {
+		accessor, err := meta.Accessor(item.(runtime.Object))
+		if err != nil {
+			t.Fatal(err)
+		}
+		names = append(names, accessor.GetName())
+	}
+	if diff := cmp.Diff([]string{"pod-b", "pod-a"}, names); diff != "" {
+		t.Errorf(diff)
+	}
+
+	// pagination applies to the merged list, not per namespace
+	q.Pagination = &query.Pagination{Limit: 1, Offset: 1}
+	result, err = getter.ListInWorkspace("pods", "demo", q, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.TotalItems != 2 || len(result.Items) != 1 {
+		t.Fatalf("expected 1 of 2 pods, got %d of %d", len(result.Items), result.TotalItems)
+	}
+
+	// a filter drops items from namespaces the user may not list
+	q.Pagination = query.NoPagination
+	result, err = getter.ListInWorkspace("pods", "demo", q, func(gvr schema.GroupVersionResource, object metav1.Object) bool {
+		return object.GetNamespace() != "ws-ns2"
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.TotalItems != 1 {
+		t.Fatalf("expected 1 pod after filtering, got %d", result.TotalItems)
+	}
+
+	if _, err = getter.ListInWorkspace("unknown", "demo", query.New(), nil); err != ErrResourceNotSupported {
+		t.Errorf("expected ErrResourceNotSupported, got %v", err)
+	}
+}
+
+// fakeGetter serves a static set of objects for registry tests
+type fakeGetter struct {
+	objects []runtime.Object
+}
+
+func (f fakeGetter) Get(namespace, name string) (runtime.Object, error) {
+	for _, object := range f.objects {
+		accessor, _ := meta.Accessor(object)
+		if accessor.GetNamespace() == namespace && accessor.GetName() == name {
+			return object, nil
+		}
+	}
+	return nil, apierrors.NewNotFound(schema.GroupResource{Resource: "widgets"}, name)
+}
+
+func (f fakeGetter) List(namespace string, q *query.Query) (*api.ListResult, error) {
+	items := make([]interface{}, 0, len(f.objects))
+	for _, object := range f.objects {
+		items = append(items, object)
+	}
+	return &api.ListResult{Items: items, TotalItems: len(items)}, nil
+}
+
+func TestLazyGetter(t *testing.T) {
+	constructed := 0
+	lazy := newLazyGetter(func() v1alpha3.Interface {
+		constructed++
+		return fakeGetter{objects: []runtime.Object{
+			&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "bar"}},
+		}}
+	}, nil, nil)
+
+	if constructed != 0 {
+		t.Fatal("expected the delegate not to be constructed before first access")
+	}
+
+	result, err := lazy.List("bar", query.New())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.TotalItems != 1 {
+		t.Errorf("expected 1 item, got %d", result.TotalItems)
+	}
+	if _, err := lazy.Get("bar", "foo"); err != nil {
+		t.Fatal(err)
+	}
+	if constructed != 1 {
+		t.Errorf("expected the delegate to be constructed once, got %d", constructed)
+	}
+}
+
+func TestListFiltered(t *testing.T) {
+	getter := prepare()
+
+	allow := func(gvr schema.GroupVersionResource, object metav1.Object) bool { return true }
+
+	// the search term applies on the filtered path too
+	q := query.New()
+	q.Search = "foo"
+	q.SortBy = query.FieldName
+	result, err := getter.ListFiltered("namespaces", "", q, func(gvr schema.GroupVersionResource, object metav1.Object) bool {
+		return object.GetName() != "foo2"
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.TotalItems != 1 {
+		t.Fatalf("expected 1 namespace matching the search after filtering, got %d", result.TotalItems)
+	}
+
+	// the continue cursor pages through the filtered list
+	q = query.New()
+	q.Search = "foo"
+	q.SortBy = query.FieldName
+	q.Pagination = &query.Pagination{Limit: 1, Offset: 0}
+	result, err = getter.ListFiltered("namespaces", "", q, allow)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.TotalItems != 2 || len(result.Items) != 1 || result.Continue == "" {
+		t.Fatalf("expected the first page of 2 with a cursor, got %d of %d, continue %q",
+			len(result.Items), result.TotalItems, result.Continue)
+	}
+	q.Continue = result.Continue
+	result, err = getter.ListFiltered("namespaces", "", q, allow)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Items) != 1 || result.Continue != "" {
+		t.Fatalf("expected the last page without a cursor, got %d items, continue %q",
+			len(result.Items), result.Continue)
+	}
+}
+
+func TestLazyFederatedResources(t *testing.T) {
+	ksClient := fakeks.NewSimpleClientset(
+		&typesv1beta1.FederatedNamespace{ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "bar"}},
+		&typesv1beta1.FederatedDeployment{ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "bar"}},
+		&typesv1beta1.FederatedJob{ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "bar"}},
+		&typesv1beta1.FederatedCronJob{ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "bar"}},
+		&typesv1beta1.FederatedDaemonSet{ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "bar"}},
+	)
+	k8sClient := fakek8s.NewSimpleClientset()
+	istioClient := fakeistio.NewSimpleClientset()
+	snapshotClient := fakesnapshot.NewSimpleClientset()
+	apiextensionsClient := fakeapiextensions.NewSimpleClientset()
+	fakeInformerFactory := informers.NewInformerFactories(k8sClient, ksClient, istioClient, snapshotClient, apiextensionsClient, nil)
+
+	getter := NewResourceGetter(fakeInformerFactory, nil)
+
+	// the federated getters resolve on first access, registering,
+	// starting and syncing their informers on demand; the seeded objects
+	// prove the informer actually listed from the client
+	for _, resource := range []string{"federatednamespaces", "federateddeployments",
+		"federatedjobs", "federatedcronjobs", "federateddaemonsets"} {
+		result, err := getter.List(resource, "bar", query.New())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if result.TotalItems != 1 {
+			t.Errorf("expected 1 %s, got %d", resource, result.TotalItems)
+		}
+	}
+
+	// a second access reuses the resolved delegate
+	result, err := getter.List("federatednamespaces", "bar", query.New())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.TotalItems != 1 {
+		t.Errorf("expected 1 federated namespace on the second access, got %d", result.TotalItems)
+	}
+}
+
+func TestRegister(t *testing.T) {
+	getter := prepare()
+
+	gvr := schema.GroupVersionResource{Group: "example.com", Version: "v1", Resource: "widgets"}
+	widget := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "bar"}}
+	if err := getter.Register(gvr, fakeGetter{objects: []runtime.Object{widget}}); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := getter.List("widgets", "bar", query.New())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.TotalItems != 1 {
+		t.Errorf("expected 1 widget, got %d", result.TotalItems)
+	}
+
+	// a second registration of the same gvr is a conflict
+	if err := getter.Register(gvr, fakeGetter{}); err == nil {
+		t.Error("expected a conflict error")
+	}
+	// built-in getters are protected the same way
+	namespacesGVR := schema.GroupVersionResource{Group: "", Version: "v1", Resource: "namespaces"}
+	if err := getter.RegisterClusterScoped(namespacesGVR, fakeGetter{}); err == nil {
+		t.Error("expected a conflict error")
+	}
+
+	registered := getter.RegisteredResources()
+	found := false
+	for _, resource := range registered {
+		if resource == gvr {
+			found = true
+			break
+		}
+	}
+	if !found {
+		t.Errorf("expected %s in the registered resources", gvr)
+	}
+}
+
+func TestDynamicResource(t *testing.T) {
+	getter := prepare()
+
+	crd := &apiextensionsv1.CustomResourceDefinition{
+		ObjectMeta: metav1.ObjectMeta{Name: "widgets.example.com"},
+		Spec: apiextensionsv1.CustomResourceDefinitionSpec{
+			Group: "example.com",
+			Names: apiextensionsv1.CustomResourceDefinitionNames{
+				Plural: "widgets",
+				Kind:   "Widget",
+			},
+			Scope: apiextensionsv1.NamespaceScoped,
+			Versions: []apiextensionsv1.CustomResourceDefinitionVersion{
+				{Name: "v1", Served: true, Storage: true},
+			},
+		},
+	}
+	getter.crdLister = fakeCRDLister{crd}
+
+	gvk := schema.GroupVersionKind{Group: "example.com", Version: "v1", Kind: "Widget"}
+	scheme := runtime.NewScheme()
+	scheme.AddKnownTypeWithName(gvk, &unstructured.Unstructured{})
+	scheme.AddKnownTypeWithName(gvk.GroupVersion().WithKind("WidgetList"), &unstructured.UnstructuredList{})
+
+	widget := &unstructured.Unstructured{}
+	widget.SetGroupVersionKind(gvk)
+	widget.SetNamespace("bar")
+	widget.SetName("foo")
+	getter.cache = runtimefake.NewClientBuilder().WithScheme(scheme).WithObjects(widget).Build()
+
+	got, err := getter.Get("widgets", "bar", "foo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if accessor, _ := meta.Accessor(got); accessor.GetName() != "foo" {
+		t.Errorf("expected widget foo, got %v", got)
+	}
+
+	result, err := getter.List("widgets", "bar", query.New())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.TotalItems != 1 {
+		t.Errorf("expected 1 widget, got %d", result.TotalItems)
+	}
+
+	// cluster scoped lookups do not match a namespaced definition
+	if _, err = getter.List("widgets", "", query.New()); err != ErrResourceNotSupported {
+		t.Errorf("expected ErrResourceNotSupported, got %v", err)
+	}
+
+	// resources without a matching definition stay unsupported
+	if _, err = getter.List("gadgets", "bar", query.New()); err != ErrResourceNotSupported {
+		t.Errorf("expected ErrResourceNotSupported, got %v", err)
+	}
+}
+
+// fakeCRDLister serves a static list of custom resource definitions
+type fakeCRDLister []*apiextensionsv1.CustomResourceDefinition
+
+func (f fakeCRDLister) List(labels.Selector) ([]*apiextensionsv1.CustomResourceDefinition, error) {
+	return f, nil
+}
+
+func (f fakeCRDLister) Get(name string) (*apiextensionsv1.CustomResourceDefinition, error) {
+	for _, crd := range f {
+		if crd.Name == name {
+			return crd, nil
+		}
+	}
+	return nil, apierrors.NewNotFound(apiextensionsv1.Resource("customresourcedefinitions"), name)
+}
@@ -0,0 +1,128 @@
+/*
+Copyright 2022 The KubeSphere Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resource
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/klog"
+
+	"kubesphere.io/kubesphere/pkg/apiserver/query"
+	"kubesphere.io/kubesphere/pkg/models/resources/v1alpha3"
+)
+
+// SearchResult is a single match of a cross-kind search, it carries just
+// enough identity to navigate to the resource.
+type SearchResult struct {
+	Group     string `json:"group,omitempty"`
+	Version   string `json:"version"`
+	Resource  string `json:"resource"`
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name"`
+}
+
+// Search queries every registered getter, cluster scoped and namespaced,
+// for objects whose name or labels contain the keyword, keeping at most
+// limit matches per kind. The kinds are queried concurrently, searching
+// dozens of them sequentially would stack up their latencies. Matches
+// rejected by the filter are left out. Getters that fail to list are
+// skipped so a single broken kind does not break the whole search.
+func (r *ResourceGetter) Search(ctx context.Context, keyword string, limit int, filter ListFilter) []SearchResult {
+	results := make([]SearchResult, 0)
+	var mutex sync.Mutex
+	var tasks []func() error
+	searchTask := func(gvr schema.GroupVersionResource, getter v1alpha3.Interface) func() error {
+		return func() error {
+			matches := r.searchKind(gvr, getter, keyword, limit, filter)
+			mutex.Lock()
+			results = append(results, matches...)
+			mutex.Unlock()
+			return nil
+		}
+	}
+	for gvr, getter := range r.clusterResourceGetters {
+		tasks = append(tasks, searchTask(gvr, getter))
+	}
+	for gvr, getter := range r.namespacedResourceGetters {
+		tasks = append(tasks, searchTask(gvr, getter))
+	}
+	// the tasks never fail, only cancellation cuts the search short
+	if err := runConcurrently(ctx, tasks); err != nil {
+		return results[:0]
+	}
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].Resource != results[j].Resource {
+			return results[i].Resource < results[j].Resource
+		}
+		if results[i].Namespace != results[j].Namespace {
+			return results[i].Namespace < results[j].Namespace
+		}
+		return results[i].Name < results[j].Name
+	})
+	return results
+}
+
+func (r *ResourceGetter) searchKind(gvr schema.GroupVersionResource, getter v1alpha3.Interface, keyword string, limit int, filter ListFilter) []SearchResult {
+	result, err := getter.List("", query.New())
+	if err != nil {
+		klog.Warningf("search %s: %s", gvr, err)
+		return nil
+	}
+	matches := make([]SearchResult, 0)
+	for _, item := range result.Items {
+		accessor, err := meta.Accessor(item)
+		if err != nil {
+			continue
+		}
+		if !matchesKeyword(accessor.GetName(), accessor.GetLabels(), keyword) {
+			continue
+		}
+		if filter != nil && !filter(gvr, accessor) {
+			continue
+		}
+		matches = append(matches, SearchResult{
+			Group:     gvr.Group,
+			Version:   gvr.Version,
+			Resource:  gvr.Resource,
+			Namespace: accessor.GetNamespace(),
+			Name:      accessor.GetName(),
+		})
+		if len(matches) >= limit {
+			break
+		}
+	}
+	return matches
+}
+
+// matchesKeyword reports whether the keyword occurs in the name or in any
+// label key or value.
+func matchesKeyword(name string, labels map[string]string, keyword string) bool {
+	if strings.Contains(name, keyword) {
+		return true
+	}
+	for key, value := range labels {
+		if strings.Contains(key, keyword) || strings.Contains(value, keyword) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,128 @@
+/*
+Copyright 2022 The KubeSphere Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resource
+
+import (
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+
+	"kubesphere.io/kubesphere/pkg/apiserver/query"
+)
+
+// ObjectTree is an object together with its expanded ownership
+// relations, e.g. a deployment with its replica sets and their pods.
+type ObjectTree struct {
+	Object runtime.Object `json:"object"`
+	// Owners walk metadata.ownerReferences upwards.
+	Owners []*ObjectTree `json:"owners,omitempty"`
+	// Dependents are the cached objects owned by this one.
+	Dependents []*ObjectTree `json:"dependents,omitempty"`
+}
+
+// dependentResources are the kinds scanned for dependents, the chains
+// worth expanding in the console all end in one of them.
+var dependentResources = []string{"replicasets", "pods", "jobs", "controllerrevisions"}
+
+// GetTree retrieves an object together with its owners, its dependents
+// or both, resolved from the informer caches, so the console renders the
+// whole deployment-replicaset-pod chain with one request.
+func (r *ResourceGetter) GetTree(resource, namespace, name string, includeOwners, includeDependents bool) (*ObjectTree, error) {
+	object, err := r.Get(resource, namespace, name)
+	if err != nil {
+		return nil, err
+	}
+	tree := &ObjectTree{Object: object}
+	// seen guards against ownership cycles, broken references would
+	// otherwise recurse forever
+	seen := map[types.UID]struct{}{}
+	if accessor, err := meta.Accessor(object); err == nil {
+		seen[accessor.GetUID()] = struct{}{}
+	}
+	if includeOwners {
+		tree.Owners = r.owners(object, namespace, seen)
+	}
+	if includeDependents {
+		tree.Dependents = r.dependents(object, namespace, seen)
+	}
+	return tree, nil
+}
+
+// owners resolves the ownerReferences of the object against the caches,
+// owners of kinds the getter does not cover are left out.
+func (r *ResourceGetter) owners(object runtime.Object, namespace string, seen map[types.UID]struct{}) []*ObjectTree {
+	accessor, err := meta.Accessor(object)
+	if err != nil {
+		return nil
+	}
+	var owners []*ObjectTree
+	for _, reference := range accessor.GetOwnerReferences() {
+		if _, ok := seen[reference.UID]; ok {
+			continue
+		}
+		seen[reference.UID] = struct{}{}
+		owner, err := r.Get(strings.ToLower(reference.Kind)+"s", namespace, reference.Name)
+		if err != nil {
+			continue
+		}
+		owners = append(owners, &ObjectTree{
+			Object: owner,
+			Owners: r.owners(owner, namespace, seen),
+		})
+	}
+	return owners
+}
+
+// dependents scans the dependent kinds for cached objects owned by the
+// given one and expands them recursively.
+func (r *ResourceGetter) dependents(object runtime.Object, namespace string, seen map[types.UID]struct{}) []*ObjectTree {
+	accessor, err := meta.Accessor(object)
+	if err != nil || accessor.GetUID() == "" {
+		return nil
+	}
+	var dependents []*ObjectTree
+	for _, resource := range dependentResources {
+		ownedQuery := query.New()
+		ownedQuery.Pagination = query.NoPagination
+		ownedQuery.Filters[query.FieldOwnerReference] = query.Value(accessor.GetUID())
+		result, err := r.List(resource, namespace, ownedQuery)
+		if err != nil {
+			continue
+		}
+		for _, item := range result.Items {
+			dependent, ok := item.(runtime.Object)
+			if !ok {
+				continue
+			}
+			dependentAccessor, err := meta.Accessor(dependent)
+			if err != nil {
+				continue
+			}
+			if _, ok := seen[dependentAccessor.GetUID()]; ok {
+				continue
+			}
+			seen[dependentAccessor.GetUID()] = struct{}{}
+			dependents = append(dependents, &ObjectTree{
+				Object:     dependent,
+				Dependents: r.dependents(dependent, namespace, seen),
+			})
+		}
+	}
+	return dependents
+}
@@ -0,0 +1,100 @@
+/*
+Copyright 2022 The KubeSphere Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resource
+
+import (
+	"testing"
+
+	fakesnapshot "github.com/kubernetes-csi/external-snapshotter/client/v4/clientset/versioned/fake"
+	fakeistio "istio.io/client-go/pkg/clientset/versioned/fake"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	fakeapiextensions "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset/fake"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	fakek8s "k8s.io/client-go/kubernetes/fake"
+
+	fakeks "kubesphere.io/kubesphere/pkg/client/clientset/versioned/fake"
+	"kubesphere.io/kubesphere/pkg/informers"
+)
+
+func TestGetTree(t *testing.T) {
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "nginx", Namespace: "default", UID: "deployment-uid"},
+	}
+	replicaSet := &appsv1.ReplicaSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "nginx-6799fc88d8",
+			Namespace: "default",
+			UID:       "replicaset-uid",
+			OwnerReferences: []metav1.OwnerReference{
+				{Kind: "Deployment", Name: "nginx", UID: "deployment-uid"},
+			},
+		},
+	}
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "nginx-6799fc88d8-xvq2b",
+			Namespace: "default",
+			UID:       "pod-uid",
+			OwnerReferences: []metav1.OwnerReference{
+				{Kind: "ReplicaSet", Name: "nginx-6799fc88d8", UID: "replicaset-uid"},
+			},
+		},
+	}
+
+	fakeInformerFactory := informers.NewInformerFactories(fakek8s.NewSimpleClientset(), fakeks.NewSimpleClientset(),
+		fakeistio.NewSimpleClientset(), fakesnapshot.NewSimpleClientset(), fakeapiextensions.NewSimpleClientset(), nil)
+	k8sInformers := fakeInformerFactory.KubernetesSharedInformerFactory()
+	k8sInformers.Apps().V1().Deployments().Informer().GetIndexer().Add(deployment)
+	k8sInformers.Apps().V1().ReplicaSets().Informer().GetIndexer().Add(replicaSet)
+	k8sInformers.Core().V1().Pods().Informer().GetIndexer().Add(pod)
+
+	getter := NewResourceGetter(fakeInformerFactory, nil)
+
+	// the whole descendant chain of the deployment
+	tree, err := getter.GetTree("deployments", "default", "nginx", false, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tree.Dependents) != 1 {
+		t.Fatalf("expected 1 dependent replica set, got %d", len(tree.Dependents))
+	}
+	if len(tree.Dependents[0].Dependents) != 1 {
+		t.Fatalf("expected 1 dependent pod, got %d", len(tree.Dependents[0].Dependents))
+	}
+	accessor, _ := meta.Accessor(tree.Dependents[0].Dependents[0].Object)
+	if accessor.GetName() != pod.Name {
+		t.Errorf("expected pod %s, got %s", pod.Name, accessor.GetName())
+	}
+
+	// the ancestry of the pod
+	tree, err = getter.GetTree("pods", "default", pod.Name, true, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tree.Owners) != 1 {
+		t.Fatalf("expected 1 owner replica set, got %d", len(tree.Owners))
+	}
+	if len(tree.Owners[0].Owners) != 1 {
+		t.Fatalf("expected 1 owner deployment, got %d", len(tree.Owners[0].Owners))
+	}
+	accessor, _ = meta.Accessor(tree.Owners[0].Owners[0].Object)
+	if accessor.GetName() != deployment.Name {
+		t.Errorf("expected deployment %s, got %s", deployment.Name, accessor.GetName())
+	}
+}
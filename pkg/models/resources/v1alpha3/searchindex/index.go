@@ -0,0 +1,208 @@
+/*
+Copyright 2022 The KubeSphere Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package searchindex
+
+import (
+	"sort"
+	"strings"
+	"sync"
+
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+)
+
+// maxTokenLength keeps oversized values out of the index, most notably
+// the last-applied-configuration annotation which holds whole manifests.
+const maxTokenLength = 256
+
+// Index is an in-memory inverted index over the searchable text of one
+// informer cache: object names, labels, annotations and container
+// images. It implements cache.ResourceEventHandler so it stays current
+// by riding the informer events, and lookups only walk the distinct
+// token vocabulary instead of substring-scanning every object.
+type Index struct {
+	mutex sync.RWMutex
+	// postings maps a token onto the keys of the objects carrying it
+	postings map[string]map[string]struct{}
+	// tokens remembers the tokens of each object so updates and deletes
+	// can unindex the previous state
+	tokens map[string][]string
+}
+
+func New() *Index {
+	return &Index{
+		postings: make(map[string]map[string]struct{}),
+		tokens:   make(map[string][]string),
+	}
+}
+
+func (i *Index) OnAdd(obj interface{}) {
+	i.index(obj)
+}
+
+func (i *Index) OnUpdate(oldObj, newObj interface{}) {
+	i.index(newObj)
+}
+
+func (i *Index) OnDelete(obj interface{}) {
+	if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		obj = tombstone.Obj
+	}
+	accessor, err := meta.Accessor(obj)
+	if err != nil {
+		return
+	}
+	i.mutex.Lock()
+	defer i.mutex.Unlock()
+	i.remove(objectKey(accessor.GetNamespace(), accessor.GetName()))
+}
+
+// Search returns the sorted names of the objects with a token containing
+// the term, matching is case-insensitive. A non-empty namespace restricts
+// the result to that namespace.
+func (i *Index) Search(term, namespace string) []string {
+	term = strings.ToLower(term)
+
+	matched := make(map[string]struct{})
+	i.mutex.RLock()
+	for token, keys := range i.postings {
+		if !strings.Contains(token, term) {
+			continue
+		}
+		for key := range keys {
+			matched[key] = struct{}{}
+		}
+	}
+	i.mutex.RUnlock()
+
+	uniqueNames := make(map[string]struct{}, len(matched))
+	for key := range matched {
+		keyNamespace, name := splitObjectKey(key)
+		if namespace != "" && keyNamespace != namespace {
+			continue
+		}
+		uniqueNames[name] = struct{}{}
+	}
+	names := make([]string, 0, len(uniqueNames))
+	for name := range uniqueNames {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (i *Index) index(obj interface{}) {
+	accessor, err := meta.Accessor(obj)
+	if err != nil {
+		return
+	}
+	key := objectKey(accessor.GetNamespace(), accessor.GetName())
+	tokens := objectTokens(obj, accessor)
+
+	i.mutex.Lock()
+	defer i.mutex.Unlock()
+	i.remove(key)
+	i.tokens[key] = tokens
+	for _, token := range tokens {
+		keys := i.postings[token]
+		if keys == nil {
+			keys = make(map[string]struct{})
+			i.postings[token] = keys
+		}
+		keys[key] = struct{}{}
+	}
+}
+
+// remove unindexes the object, the caller holds the lock.
+func (i *Index) remove(key string) {
+	for _, token := range i.tokens[key] {
+		keys := i.postings[token]
+		delete(keys, key)
+		if len(keys) == 0 {
+			delete(i.postings, token)
+		}
+	}
+	delete(i.tokens, key)
+}
+
+func objectKey(namespace, name string) string {
+	return namespace + "/" + name
+}
+
+func splitObjectKey(key string) (namespace, name string) {
+	parts := strings.SplitN(key, "/", 2)
+	return parts[0], parts[1]
+}
+
+func objectTokens(obj interface{}, accessor metav1.Object) []string {
+	seen := make(map[string]struct{})
+	add := func(token string) {
+		token = strings.ToLower(token)
+		if token == "" || len(token) > maxTokenLength {
+			return
+		}
+		seen[token] = struct{}{}
+	}
+
+	add(accessor.GetName())
+	for key, value := range accessor.GetLabels() {
+		add(key)
+		add(value)
+	}
+	for key, value := range accessor.GetAnnotations() {
+		add(key)
+		add(value)
+	}
+	if spec := podSpecOf(obj); spec != nil {
+		for _, container := range spec.InitContainers {
+			add(container.Image)
+		}
+		for _, container := range spec.Containers {
+			add(container.Image)
+		}
+	}
+
+	tokens := make([]string, 0, len(seen))
+	for token := range seen {
+		tokens = append(tokens, token)
+	}
+	return tokens
+}
+
+func podSpecOf(obj interface{}) *corev1.PodSpec {
+	switch workload := obj.(type) {
+	case *corev1.Pod:
+		return &workload.Spec
+	case *appsv1.Deployment:
+		return &workload.Spec.Template.Spec
+	case *appsv1.StatefulSet:
+		return &workload.Spec.Template.Spec
+	case *appsv1.DaemonSet:
+		return &workload.Spec.Template.Spec
+	case *appsv1.ReplicaSet:
+		return &workload.Spec.Template.Spec
+	case *batchv1.Job:
+		return &workload.Spec.Template.Spec
+	case *batchv1.CronJob:
+		return &workload.Spec.JobTemplate.Spec.Template.Spec
+	}
+	return nil
+}
@@ -0,0 +1,101 @@
+/*
+Copyright 2022 The KubeSphere Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package searchindex
+
+import (
+	"reflect"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestIndexSearch(t *testing.T) {
+	index := New()
+
+	index.OnAdd(&corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "nginx-1",
+			Namespace: "default",
+			Labels:    map[string]string{"app": "nginx"},
+		},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{{Name: "nginx", Image: "docker.io/library/nginx:1.21"}},
+		},
+	})
+	index.OnAdd(&corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "redis-1",
+			Namespace:   "kube-system",
+			Annotations: map[string]string{"team": "storage"},
+		},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{{Name: "redis", Image: "docker.io/library/redis:6"}},
+		},
+	})
+
+	tests := []struct {
+		description string
+		term        string
+		namespace   string
+		expected    []string
+	}{
+		{"match by name", "nginx-1", "", []string{"nginx-1"}},
+		{"match by label value", "NGINX", "", []string{"nginx-1"}},
+		{"match by annotation value", "storage", "", []string{"redis-1"}},
+		{"match by container image", "library/redis", "", []string{"redis-1"}},
+		{"namespace restricts the result", "docker.io", "default", []string{"nginx-1"}},
+		{"no match", "mysql", "", []string{}},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			names := index.Search(test.term, test.namespace)
+			if !reflect.DeepEqual(names, test.expected) {
+				t.Errorf("expected %v, got %v", test.expected, names)
+			}
+		})
+	}
+}
+
+func TestIndexUpdateAndDelete(t *testing.T) {
+	index := New()
+
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "web-1",
+			Namespace: "default",
+			Labels:    map[string]string{"version": "v1"},
+		},
+	}
+	index.OnAdd(pod)
+
+	updated := pod.DeepCopy()
+	updated.Labels["version"] = "v2"
+	index.OnUpdate(pod, updated)
+
+	if names := index.Search("v1", ""); len(names) != 0 {
+		t.Errorf("expected stale token to be unindexed, got %v", names)
+	}
+	if names := index.Search("v2", ""); !reflect.DeepEqual(names, []string{"web-1"}) {
+		t.Errorf("expected updated pod to match, got %v", names)
+	}
+
+	index.OnDelete(updated)
+	if names := index.Search("web", ""); len(names) != 0 {
+		t.Errorf("expected deleted pod to be unindexed, got %v", names)
+	}
+}
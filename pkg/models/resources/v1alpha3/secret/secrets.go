@@ -19,6 +19,7 @@ package secret
 import (
 	"encoding/json"
 	"fmt"
+	"strconv"
 
 	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -34,16 +35,53 @@ import (
 	v1 "k8s.io/api/core/v1"
 )
 
+const (
+	// ParameterRedact asks for secrets with their data values masked,
+	// keys are kept so views still show which entries exist.
+	ParameterRedact = "redact"
+
+	// redactedValue replaces masked data values.
+	redactedValue = "******"
+)
+
 type secretSearcher struct {
 	informers informers.SharedInformerFactory
+	resolver  *v1alpha3.ReferenceResolver
+
+	// alwaysRedact masks data values on every response regardless of the
+	// redact query flag.
+	alwaysRedact bool
 }
 
 func New(informers informers.SharedInformerFactory) v1alpha3.Interface {
-	return &secretSearcher{informers: informers}
+	return &secretSearcher{informers: informers, resolver: v1alpha3.NewReferenceResolver(informers)}
+}
+
+// NewRedacted returns a getter that always masks data values, for
+// deployments that must never serve secret payloads through the list
+// APIs.
+func NewRedacted(informers informers.SharedInformerFactory) v1alpha3.Interface {
+	return &secretSearcher{informers: informers, resolver: v1alpha3.NewReferenceResolver(informers), alwaysRedact: true}
 }
 
 func (s *secretSearcher) Get(namespace, name string) (runtime.Object, error) {
-	return s.informers.Core().V1().Secrets().Lister().Secrets(namespace).Get(name)
+	secret, err := s.informers.Core().V1().Secrets().Lister().Secrets(namespace).Get(name)
+	if err != nil {
+		return nil, err
+	}
+	annotated := s.withReferences(secret)
+	if s.alwaysRedact {
+		redact(annotated)
+	}
+	return annotated, nil
+}
+
+// withReferences annotates a copy of the secret with the workloads that
+// reference it and whether deleting it is safe.
+func (s *secretSearcher) withReferences(secret *v1.Secret) *v1.Secret {
+	annotated := secret.DeepCopy()
+	s.resolver.Annotate(&annotated.ObjectMeta, v1alpha3.PodSecretsIndex, secret.Namespace, secret.Name)
+	return annotated
 }
 
 func (s *secretSearcher) List(namespace string, query *query.Query) (*api.ListResult, error) {
@@ -52,14 +90,46 @@ func (s *secretSearcher) List(namespace string, query *query.Query) (*api.ListRe
 		return nil, err
 	}
 
+	redacted := s.alwaysRedact || wantsRedaction(query)
+
 	var result []runtime.Object
 	for _, secret := range secrets {
-		result = append(result, secret)
+		annotated := s.withReferences(secret)
+		if redacted {
+			redact(annotated)
+		}
+		result = append(result, annotated)
 	}
 
 	return v1alpha3.DefaultList(result, query, s.compare, s.filter), nil
 }
 
+// wantsRedaction reports whether the query carries redact=true.
+func wantsRedaction(q *query.Query) bool {
+	value, ok := q.Filters[ParameterRedact]
+	if !ok {
+		return false
+	}
+	redacted, err := strconv.ParseBool(string(value))
+	if err != nil {
+		klog.V(4).Infof("invalid redact value %s: %s", value, err)
+		return false
+	}
+	return redacted
+}
+
+// redact masks the data values of the secret in place, the caller hands
+// in a copy. The keys stay so consumers can tell which entries exist
+// without receiving the payloads.
+func redact(secret *v1.Secret) {
+	for key := range secret.Data {
+		secret.Data[key] = []byte(redactedValue)
+	}
+	for key := range secret.StringData {
+		secret.StringData[key] = redactedValue
+	}
+}
+
 func (s *secretSearcher) compare(left runtime.Object, right runtime.Object, field query.Field) bool {
 
 	leftSecret, ok := left.(*v1.Secret)
@@ -85,6 +155,11 @@ func (s *secretSearcher) filter(object runtime.Object, filter query.Filter) bool
 		return contains(secret, filter.Value)
 	}
 
+	// the redact flag is consumed in List, it does not select items
+	if filter.Field == ParameterRedact {
+		return true
+	}
+
 	return v1alpha3.DefaultObjectMetaFilter(secret.ObjectMeta, filter)
 }
 
@@ -0,0 +1,140 @@
+/*
+Copyright 2022 The KubeSphere Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secret
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"kubesphere.io/kubesphere/pkg/apiserver/query"
+	"kubesphere.io/kubesphere/pkg/models/resources/v1alpha3"
+)
+
+var secrets = []*corev1.Secret{
+	{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "credentials",
+			Namespace: "default",
+		},
+		Data: map[string][]byte{"password": []byte("hunter2")},
+	},
+	{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "token",
+			Namespace: "default",
+		},
+		Data: map[string][]byte{"token": []byte("opaque")},
+	},
+}
+
+func prepare(redacted bool) v1alpha3.Interface {
+	client := fake.NewSimpleClientset()
+	informer := informers.NewSharedInformerFactory(client, 0)
+
+	for _, secret := range secrets {
+		informer.Core().V1().Secrets().Informer().GetIndexer().Add(secret)
+	}
+
+	if redacted {
+		return NewRedacted(informer)
+	}
+	return New(informer)
+}
+
+func TestListSecretsRedaction(t *testing.T) {
+	getter := prepare(false)
+
+	// the default keeps the payloads
+	result, err := getter.List("default", query.New())
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, item := range result.Items {
+		secret := item.(*corev1.Secret)
+		for key, value := range secret.Data {
+			if string(value) == redactedValue {
+				t.Errorf("expected %s/%s to keep its payload", secret.Name, key)
+			}
+		}
+	}
+
+	// redact=true masks the values and keeps the keys
+	q := query.New()
+	q.Filters[ParameterRedact] = "true"
+	result, err = getter.List("default", q)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Items) != len(secrets) {
+		t.Fatalf("expected %d secrets, got %d", len(secrets), len(result.Items))
+	}
+	for _, item := range result.Items {
+		secret := item.(*corev1.Secret)
+		if len(secret.Data) == 0 {
+			t.Errorf("expected %s to keep its data keys", secret.Name)
+		}
+		for key, value := range secret.Data {
+			if string(value) != redactedValue {
+				t.Errorf("expected %s/%s to be masked, got %q", secret.Name, key, value)
+			}
+		}
+	}
+
+	// the cached objects stay untouched, only the returned copies are masked
+	result, err = getter.List("default", query.New())
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, item := range result.Items {
+		secret := item.(*corev1.Secret)
+		for key, value := range secret.Data {
+			if string(value) == redactedValue {
+				t.Errorf("expected %s/%s to keep its payload after a redacted list", secret.Name, key)
+			}
+		}
+	}
+}
+
+func TestRedactedGetter(t *testing.T) {
+	getter := prepare(true)
+
+	object, err := getter.Get("default", "credentials")
+	if err != nil {
+		t.Fatal(err)
+	}
+	secret := object.(*corev1.Secret)
+	if string(secret.Data["password"]) != redactedValue {
+		t.Errorf("expected masked password, got %q", secret.Data["password"])
+	}
+
+	result, err := getter.List("default", query.New())
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, item := range result.Items {
+		secret := item.(*corev1.Secret)
+		for key, value := range secret.Data {
+			if string(value) != redactedValue {
+				t.Errorf("expected %s/%s to be masked, got %q", secret.Name, key, value)
+			}
+		}
+	}
+}
@@ -17,6 +17,8 @@ limitations under the License.
 package service
 
 import (
+	"strconv"
+
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/informers"
@@ -26,6 +28,16 @@ import (
 	"kubesphere.io/kubesphere/pkg/models/resources/v1alpha3"
 )
 
+const (
+	// ReadyEndpointsAnnotation carries the number of ready endpoint
+	// addresses behind the service in the detail response.
+	ReadyEndpointsAnnotation = "resource.kubesphere.io/ready-endpoints"
+
+	// NotReadyEndpointsAnnotation carries the number of endpoint addresses
+	// behind the service that are not ready yet.
+	NotReadyEndpointsAnnotation = "resource.kubesphere.io/not-ready-endpoints"
+)
+
 type servicesGetter struct {
 	sharedInformers informers.SharedInformerFactory
 }
@@ -35,7 +47,36 @@ func New(sharedInformers informers.SharedInformerFactory) v1alpha3.Interface {
 }
 
 func (d *servicesGetter) Get(namespace, name string) (runtime.Object, error) {
-	return d.sharedInformers.Core().V1().Services().Lister().Services(namespace).Get(name)
+	service, err := d.sharedInformers.Core().V1().Services().Lister().Services(namespace).Get(name)
+	if err != nil {
+		return nil, err
+	}
+	return d.withEndpointCounts(service), nil
+}
+
+// withEndpointCounts annotates a copy of the service with the number of
+// ready and not ready endpoint addresses behind it, so troubleshooting a
+// service does not require inspecting the endpoints object separately.
+func (d *servicesGetter) withEndpointCounts(service *corev1.Service) *corev1.Service {
+	endpoints, err := d.sharedInformers.Core().V1().Endpoints().Lister().Endpoints(service.Namespace).Get(service.Name)
+	if err != nil {
+		// selectorless and ExternalName services have no endpoints object
+		return service
+	}
+
+	ready, notReady := 0, 0
+	for _, subset := range endpoints.Subsets {
+		ready += len(subset.Addresses)
+		notReady += len(subset.NotReadyAddresses)
+	}
+
+	annotated := service.DeepCopy()
+	if annotated.Annotations == nil {
+		annotated.Annotations = make(map[string]string)
+	}
+	annotated.Annotations[ReadyEndpointsAnnotation] = strconv.Itoa(ready)
+	annotated.Annotations[NotReadyEndpointsAnnotation] = strconv.Itoa(notReady)
+	return annotated
 }
 
 func (d *servicesGetter) List(namespace string, query *query.Query) (*api.ListResult, error) {
@@ -80,6 +80,26 @@ func TestListServices(t *testing.T) {
 	}
 }
 
+func TestGetServiceEndpointCounts(t *testing.T) {
+	getter := prepare()
+
+	got, err := getter.Get("bar", "foo1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	service, ok := got.(*corev1.Service)
+	if !ok {
+		t.Fatalf("expected *corev1.Service, got %T", got)
+	}
+	if ready := service.Annotations[ReadyEndpointsAnnotation]; ready != "2" {
+		t.Errorf("expected 2 ready endpoints, got %q", ready)
+	}
+	if notReady := service.Annotations[NotReadyEndpointsAnnotation]; notReady != "1" {
+		t.Errorf("expected 1 not ready endpoint, got %q", notReady)
+	}
+}
+
 var (
 	foo1 = &corev1.Service{
 		ObjectMeta: metav1.ObjectMeta{
@@ -101,6 +121,19 @@ var (
 		},
 	}
 
+	foo1Endpoints = &corev1.Endpoints{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "foo1",
+			Namespace: "bar",
+		},
+		Subsets: []corev1.EndpointSubset{
+			{
+				Addresses:         []corev1.EndpointAddress{{IP: "10.0.0.1"}, {IP: "10.0.0.2"}},
+				NotReadyAddresses: []corev1.EndpointAddress{{IP: "10.0.0.3"}},
+			},
+		},
+	}
+
 	services = []interface{}{foo1, foo2, bar1}
 )
 
@@ -111,6 +144,7 @@ func prepare() v1alpha3.Interface {
 	for _, service := range services {
 		informer.Core().V1().Services().Informer().GetIndexer().Add(service)
 	}
+	informer.Core().V1().Endpoints().Informer().GetIndexer().Add(foo1Endpoints)
 
 	return New(informer)
 }
@@ -0,0 +1,158 @@
+/*
+Copyright 2022 The KubeSphere Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha3
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"golang.org/x/text/collate"
+	"golang.org/x/text/language"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/klog"
+
+	"kubesphere.io/kubesphere/pkg/apiserver/query"
+)
+
+// isFieldPathSort reports whether the sortBy field is a leading-dot field
+// path, e.g. .status.availableReplicas, rather than one of the named
+// sortable fields the compare functions know.
+func isFieldPathSort(sortBy query.Field) bool {
+	return strings.HasPrefix(string(sortBy), ".")
+}
+
+// sortByFieldPath sorts the objects on the value at the dot separated
+// field path. Numbers compare numerically, everything else compares by
+// its string form, objects missing the path sort after the rest in the
+// default descending order. The field values are extracted once per
+// object, not once per comparison.
+func sortByFieldPath(objects []runtime.Object, sortBy query.Field, ascending bool) {
+	path := strings.Split(strings.TrimPrefix(string(sortBy), "."), ".")
+
+	type keyedObject struct {
+		object runtime.Object
+		value  interface{}
+		found  bool
+	}
+	keyed := make([]keyedObject, len(objects))
+	for i, object := range objects {
+		value, found := fieldPathValue(object, path)
+		keyed[i] = keyedObject{object: object, value: value, found: found}
+	}
+
+	greater := func(left, right keyedObject) bool {
+		if left.found != right.found {
+			return left.found
+		}
+		if !left.found {
+			return false
+		}
+		leftNumber, leftIsNumber := toNumber(left.value)
+		rightNumber, rightIsNumber := toNumber(right.value)
+		if leftIsNumber && rightIsNumber {
+			return leftNumber > rightNumber
+		}
+		return strings.Compare(fmt.Sprintf("%v", left.value), fmt.Sprintf("%v", right.value)) > 0
+	}
+	sort.SliceStable(keyed, func(i, j int) bool {
+		if !ascending {
+			return greater(keyed[i], keyed[j])
+		}
+		return !greater(keyed[i], keyed[j])
+	})
+
+	for i := range keyed {
+		objects[i] = keyed[i].object
+	}
+}
+
+// nameCompareFor maps the requested collation onto a name ordering, nil
+// keeps the byte-wise default. Natural compares runs of digits by their
+// numeric value so app-2 sorts before app-10, any other value is parsed
+// as a BCP 47 language tag selecting that locale's collation, with the
+// numeric ordering on top. Unknown tags fall back to the default, a typo
+// in a sort option is not worth failing the whole list.
+func nameCompareFor(collation string) func(left, right string) int {
+	switch collation {
+	case "", query.CollationDefault:
+		return nil
+	case query.CollationNatural:
+		return collate.New(language.Und, collate.Numeric).CompareString
+	default:
+		tag, err := language.Parse(collation)
+		if err != nil {
+			klog.Warningf("invalid collation %s: %s", collation, err)
+			return nil
+		}
+		return collate.New(tag, collate.Numeric).CompareString
+	}
+}
+
+// sortByCollatedName sorts the objects on their name under the given
+// ordering. The sort is stable, objects the collation considers equal
+// keep their filtered order.
+func sortByCollatedName(objects []runtime.Object, compare func(left, right string) int, ascending bool) {
+	names := make([]string, len(objects))
+	for i, object := range objects {
+		if accessor, err := meta.Accessor(object); err == nil {
+			names[i] = accessor.GetName()
+		}
+	}
+
+	indexes := make([]int, len(objects))
+	for i := range indexes {
+		indexes[i] = i
+	}
+	sort.SliceStable(indexes, func(i, j int) bool {
+		ordered := compare(names[indexes[i]], names[indexes[j]])
+		if ascending {
+			return ordered < 0
+		}
+		return ordered > 0
+	})
+
+	sorted := make([]runtime.Object, len(objects))
+	for i, index := range indexes {
+		sorted[i] = objects[index]
+	}
+	copy(objects, sorted)
+}
+
+func fieldPathValue(object runtime.Object, path []string) (interface{}, bool) {
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(object)
+	if err != nil {
+		return nil, false
+	}
+	value, found, err := unstructured.NestedFieldNoCopy(content, path...)
+	if err != nil || !found {
+		return nil, false
+	}
+	return value, true
+}
+
+func toNumber(value interface{}) (float64, bool) {
+	switch number := value.(type) {
+	case int64:
+		return float64(number), true
+	case float64:
+		return number, true
+	}
+	return 0, false
+}
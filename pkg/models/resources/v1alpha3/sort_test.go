@@ -0,0 +1,116 @@
+/*
+Copyright 2022 The KubeSphere Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha3
+
+import (
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"kubesphere.io/kubesphere/pkg/apiserver/query"
+)
+
+func TestSortByFieldPath(t *testing.T) {
+	deployment := func(name string, availableReplicas int32) runtime.Object {
+		return &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Status:     appsv1.DeploymentStatus{AvailableReplicas: availableReplicas},
+		}
+	}
+	objects := []runtime.Object{
+		deployment("two", 2),
+		deployment("ten", 10),
+		deployment("one", 1),
+	}
+
+	sortByFieldPath(objects, ".status.availableReplicas", false)
+	if names := objectNames(objects); names[0] != "ten" || names[1] != "two" || names[2] != "one" {
+		t.Errorf("expected descending numeric order ten,two,one, got %v", names)
+	}
+
+	sortByFieldPath(objects, ".status.availableReplicas", true)
+	if names := objectNames(objects); names[0] != "one" || names[1] != "two" || names[2] != "ten" {
+		t.Errorf("expected ascending numeric order one,two,ten, got %v", names)
+	}
+
+	// objects missing the path sort after the rest
+	objects = append(objects, &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "none"}})
+	sortByFieldPath(objects, ".status.conditions", false)
+	if names := objectNames(objects); names[len(names)-1] != "none" {
+		t.Errorf("expected object missing the path to sort last, got %v", names)
+	}
+}
+
+func TestIsFieldPathSort(t *testing.T) {
+	if !isFieldPathSort(".status.availableReplicas") {
+		t.Error("expected leading-dot path to be a field path sort")
+	}
+	if isFieldPathSort(query.FieldName) {
+		t.Error("expected named field not to be a field path sort")
+	}
+}
+
+func TestSortByCollatedName(t *testing.T) {
+	objects := []runtime.Object{
+		&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "app-10"}},
+		&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "app-2"}},
+		&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "app-1"}},
+	}
+
+	compare := nameCompareFor(query.CollationNatural)
+	if compare == nil {
+		t.Fatal("expected a comparer for the natural collation")
+	}
+
+	sortByCollatedName(objects, compare, true)
+	if names := objectNames(objects); names[0] != "app-1" || names[1] != "app-2" || names[2] != "app-10" {
+		t.Errorf("expected natural ascending order app-1,app-2,app-10, got %v", names)
+	}
+
+	sortByCollatedName(objects, compare, false)
+	if names := objectNames(objects); names[0] != "app-10" || names[1] != "app-2" || names[2] != "app-1" {
+		t.Errorf("expected natural descending order app-10,app-2,app-1, got %v", names)
+	}
+}
+
+func TestNameCompareFor(t *testing.T) {
+	if nameCompareFor("") != nil {
+		t.Error("expected no comparer for the empty collation")
+	}
+	if nameCompareFor(query.CollationDefault) != nil {
+		t.Error("expected no comparer for the default collation")
+	}
+	// unknown tags fall back to the byte-wise default
+	if nameCompareFor("not a tag") != nil {
+		t.Error("expected no comparer for an invalid language tag")
+	}
+	if compare := nameCompareFor("en"); compare == nil {
+		t.Error("expected a comparer for a language tag")
+	} else if compare("app-2", "app-10") >= 0 {
+		t.Error("expected locale collation to order app-2 before app-10")
+	}
+}
+
+func objectNames(objects []runtime.Object) []string {
+	names := make([]string, 0, len(objects))
+	for _, object := range objects {
+		names = append(names, object.(*appsv1.Deployment).Name)
+	}
+	return names
+}
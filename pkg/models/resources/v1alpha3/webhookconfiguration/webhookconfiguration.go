@@ -0,0 +1,253 @@
+/*
+Copyright 2022 The KubeSphere Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhookconfiguration
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/informers"
+
+	"kubesphere.io/kubesphere/pkg/api"
+	"kubesphere.io/kubesphere/pkg/apiserver/query"
+	"kubesphere.io/kubesphere/pkg/models/resources/v1alpha3"
+	"kubesphere.io/kubesphere/pkg/simple/client/monitoring"
+)
+
+const (
+	// UnavailableWebhooksAnnotation lists the webhooks of the configuration
+	// whose backing service is missing or has no ready endpoints, a broken
+	// webhook is a common cause of cluster wide create failures.
+	UnavailableWebhooksAnnotation = "resource.kubesphere.io/unavailable-webhooks"
+
+	// RecentRejectionsAnnotation carries the number of requests the
+	// webhooks of the configuration rejected during the last hour as
+	// observed by the apiservers, only present when a monitoring client
+	// is available.
+	RecentRejectionsAnnotation = "resource.kubesphere.io/recent-rejections"
+
+	rejectionsQuery = `sum(increase(apiserver_admission_webhook_rejection_count{name=~"%s"}[1h]))`
+)
+
+// webhookBackend is the part of a webhook relevant for diagnostics.
+type webhookBackend struct {
+	name    string
+	service *admissionregistrationv1.ServiceReference
+}
+
+// webhookDiagnostics is shared by the mutating and validating getters, it
+// annotates configurations with the availability of the backing services
+// and, when a monitoring client is supplied, the recent rejection count.
+type webhookDiagnostics struct {
+	sharedInformers  informers.SharedInformerFactory
+	monitoringClient monitoring.Interface
+}
+
+func (d *webhookDiagnostics) setMonitoringClient(client monitoring.Interface) {
+	d.monitoringClient = client
+}
+
+func (d *webhookDiagnostics) annotate(objectMeta *metav1.ObjectMeta, backends []webhookBackend, includeMetrics bool) {
+	unavailable := make([]string, 0)
+	names := make([]string, 0, len(backends))
+	for _, backend := range backends {
+		names = append(names, backend.name)
+		if backend.service == nil {
+			// URL webhooks cannot be checked from inside the cluster
+			continue
+		}
+		if !d.serviceAvailable(backend.service.Namespace, backend.service.Name) {
+			unavailable = append(unavailable, backend.name)
+		}
+	}
+	if len(unavailable) > 0 {
+		if objectMeta.Annotations == nil {
+			objectMeta.Annotations = make(map[string]string)
+		}
+		objectMeta.Annotations[UnavailableWebhooksAnnotation] = strings.Join(unavailable, ",")
+	}
+
+	if !includeMetrics || d.monitoringClient == nil || len(names) == 0 {
+		return
+	}
+	expr := fmt.Sprintf(rejectionsQuery, strings.Join(names, "|"))
+	metric := d.monitoringClient.GetMetric(expr, time.Now())
+	if metric.Error != "" || len(metric.MetricValues) == 0 || metric.MetricValues[0].Sample == nil {
+		return
+	}
+	if objectMeta.Annotations == nil {
+		objectMeta.Annotations = make(map[string]string)
+	}
+	objectMeta.Annotations[RecentRejectionsAnnotation] = fmt.Sprintf("%.0f", metric.MetricValues[0].Sample.Value())
+}
+
+// serviceAvailable reports whether the service exists and has at least
+// one ready endpoint address.
+func (d *webhookDiagnostics) serviceAvailable(namespace, name string) bool {
+	endpoints, err := d.sharedInformers.Core().V1().Endpoints().Lister().Endpoints(namespace).Get(name)
+	if err != nil {
+		return false
+	}
+	for _, subset := range endpoints.Subsets {
+		if len(subset.Addresses) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// metricsAware is implemented by the getters of this package so the
+// monitoring client can be supplied after construction.
+type metricsAware interface {
+	setMonitoringClient(client monitoring.Interface)
+}
+
+// EnableMetrics supplies the monitoring client to a getter of this
+// package, getters of other packages are left untouched.
+func EnableMetrics(getter v1alpha3.Interface, client monitoring.Interface) {
+	if aware, ok := getter.(metricsAware); ok {
+		aware.setMonitoringClient(client)
+	}
+}
+
+type mutatingWebhookConfigurationGetter struct {
+	webhookDiagnostics
+}
+
+func NewMutating(sharedInformers informers.SharedInformerFactory) v1alpha3.Interface {
+	return &mutatingWebhookConfigurationGetter{webhookDiagnostics{sharedInformers: sharedInformers}}
+}
+
+func (m *mutatingWebhookConfigurationGetter) Get(namespace, name string) (runtime.Object, error) {
+	configuration, err := m.sharedInformers.Admissionregistration().V1().MutatingWebhookConfigurations().Lister().Get(name)
+	if err != nil {
+		return nil, err
+	}
+	return m.withDiagnostics(configuration, true), nil
+}
+
+func (m *mutatingWebhookConfigurationGetter) withDiagnostics(configuration *admissionregistrationv1.MutatingWebhookConfiguration, includeMetrics bool) *admissionregistrationv1.MutatingWebhookConfiguration {
+	backends := make([]webhookBackend, 0, len(configuration.Webhooks))
+	for _, webhook := range configuration.Webhooks {
+		backends = append(backends, webhookBackend{name: webhook.Name, service: webhook.ClientConfig.Service})
+	}
+	annotated := configuration.DeepCopy()
+	m.annotate(&annotated.ObjectMeta, backends, includeMetrics)
+	return annotated
+}
+
+func (m *mutatingWebhookConfigurationGetter) List(namespace string, query *query.Query) (*api.ListResult, error) {
+	configurations, err := m.sharedInformers.Admissionregistration().V1().MutatingWebhookConfigurations().Lister().List(query.Selector())
+	if err != nil {
+		return nil, err
+	}
+
+	var result []runtime.Object
+	for _, configuration := range configurations {
+		result = append(result, m.withDiagnostics(configuration, false))
+	}
+
+	return v1alpha3.DefaultList(result, query, m.compare, m.filter), nil
+}
+
+func (m *mutatingWebhookConfigurationGetter) compare(left runtime.Object, right runtime.Object, field query.Field) bool {
+	leftConfiguration, ok := left.(*admissionregistrationv1.MutatingWebhookConfiguration)
+	if !ok {
+		return false
+	}
+
+	rightConfiguration, ok := right.(*admissionregistrationv1.MutatingWebhookConfiguration)
+	if !ok {
+		return false
+	}
+
+	return v1alpha3.DefaultObjectMetaCompare(leftConfiguration.ObjectMeta, rightConfiguration.ObjectMeta, field)
+}
+
+func (m *mutatingWebhookConfigurationGetter) filter(object runtime.Object, filter query.Filter) bool {
+	configuration, ok := object.(*admissionregistrationv1.MutatingWebhookConfiguration)
+	if !ok {
+		return false
+	}
+	return v1alpha3.DefaultObjectMetaFilter(configuration.ObjectMeta, filter)
+}
+
+type validatingWebhookConfigurationGetter struct {
+	webhookDiagnostics
+}
+
+func NewValidating(sharedInformers informers.SharedInformerFactory) v1alpha3.Interface {
+	return &validatingWebhookConfigurationGetter{webhookDiagnostics{sharedInformers: sharedInformers}}
+}
+
+func (v *validatingWebhookConfigurationGetter) Get(namespace, name string) (runtime.Object, error) {
+	configuration, err := v.sharedInformers.Admissionregistration().V1().ValidatingWebhookConfigurations().Lister().Get(name)
+	if err != nil {
+		return nil, err
+	}
+	return v.withDiagnostics(configuration, true), nil
+}
+
+func (v *validatingWebhookConfigurationGetter) withDiagnostics(configuration *admissionregistrationv1.ValidatingWebhookConfiguration, includeMetrics bool) *admissionregistrationv1.ValidatingWebhookConfiguration {
+	backends := make([]webhookBackend, 0, len(configuration.Webhooks))
+	for _, webhook := range configuration.Webhooks {
+		backends = append(backends, webhookBackend{name: webhook.Name, service: webhook.ClientConfig.Service})
+	}
+	annotated := configuration.DeepCopy()
+	v.annotate(&annotated.ObjectMeta, backends, includeMetrics)
+	return annotated
+}
+
+func (v *validatingWebhookConfigurationGetter) List(namespace string, query *query.Query) (*api.ListResult, error) {
+	configurations, err := v.sharedInformers.Admissionregistration().V1().ValidatingWebhookConfigurations().Lister().List(query.Selector())
+	if err != nil {
+		return nil, err
+	}
+
+	var result []runtime.Object
+	for _, configuration := range configurations {
+		result = append(result, v.withDiagnostics(configuration, false))
+	}
+
+	return v1alpha3.DefaultList(result, query, v.compare, v.filter), nil
+}
+
+func (v *validatingWebhookConfigurationGetter) compare(left runtime.Object, right runtime.Object, field query.Field) bool {
+	leftConfiguration, ok := left.(*admissionregistrationv1.ValidatingWebhookConfiguration)
+	if !ok {
+		return false
+	}
+
+	rightConfiguration, ok := right.(*admissionregistrationv1.ValidatingWebhookConfiguration)
+	if !ok {
+		return false
+	}
+
+	return v1alpha3.DefaultObjectMetaCompare(leftConfiguration.ObjectMeta, rightConfiguration.ObjectMeta, field)
+}
+
+func (v *validatingWebhookConfigurationGetter) filter(object runtime.Object, filter query.Filter) bool {
+	configuration, ok := object.(*admissionregistrationv1.ValidatingWebhookConfiguration)
+	if !ok {
+		return false
+	}
+	return v1alpha3.DefaultObjectMetaFilter(configuration.ObjectMeta, filter)
+}
@@ -0,0 +1,212 @@
+/*
+Copyright 2022 The KubeSphere Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package revisions
+
+import (
+	"encoding/json"
+	"sort"
+	"strconv"
+	"time"
+
+	v1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// deploymentRevisionAnnotation records the rollout revision on a deployment
+// and its replicasets.
+const deploymentRevisionAnnotation = "deployment.kubernetes.io/revision"
+
+// WorkloadRevision is one entry of a workload's rollout history, the diff
+// lists the pod template lines changed relative to the previous revision.
+type WorkloadRevision struct {
+	Revision          int64                   `json:"revision" description:"revision number"`
+	Name              string                  `json:"name" description:"name of the replicaset or controllerrevision holding the revision"`
+	CreationTimestamp time.Time               `json:"creationTimestamp" description:"creation time of the revision"`
+	Current           bool                    `json:"current" description:"whether the workload currently runs this revision"`
+	PodTemplate       *corev1.PodTemplateSpec `json:"podTemplate,omitempty" description:"pod template of the revision"`
+	Diff              []string                `json:"diff,omitempty" description:"pod template lines changed since the previous revision, prefixed with - and +"`
+}
+
+func (c *revisionGetter) ListDeploymentRevisions(namespace, name string) ([]WorkloadRevision, error) {
+	deploy, err := c.informers.Apps().V1().Deployments().Lister().Deployments(namespace).Get(name)
+	if err != nil {
+		return nil, err
+	}
+
+	labelSelector := labels.Set(deploy.Spec.Template.Labels).AsSelector()
+	rsList, err := c.informers.Apps().V1().ReplicaSets().Lister().ReplicaSets(namespace).List(labelSelector)
+	if err != nil {
+		return nil, err
+	}
+
+	currentRevision := deploy.Annotations[deploymentRevisionAnnotation]
+
+	var history []WorkloadRevision
+	for _, rs := range rsList {
+		if !isOwnedBy(rs.OwnerReferences, name) {
+			continue
+		}
+		revision, err := strconv.ParseInt(rs.Annotations[deploymentRevisionAnnotation], 10, 64)
+		if err != nil {
+			continue
+		}
+		template := rs.Spec.Template.DeepCopy()
+		history = append(history, WorkloadRevision{
+			Revision:          revision,
+			Name:              rs.Name,
+			CreationTimestamp: rs.CreationTimestamp.Time,
+			Current:           rs.Annotations[deploymentRevisionAnnotation] == currentRevision,
+			PodTemplate:       template,
+		})
+	}
+
+	return fillDiffs(history), nil
+}
+
+func (c *revisionGetter) ListStatefulSetRevisions(namespace, name string) ([]WorkloadRevision, error) {
+	st, err := c.informers.Apps().V1().StatefulSets().Lister().StatefulSets(namespace).Get(name)
+	if err != nil {
+		return nil, err
+	}
+	return c.listControllerRevisions(namespace, name, st.Spec.Template.Labels, st.Status.UpdateRevision)
+}
+
+func (c *revisionGetter) ListDaemonSetRevisions(namespace, name string) ([]WorkloadRevision, error) {
+	ds, err := c.informers.Apps().V1().DaemonSets().Lister().DaemonSets(namespace).Get(name)
+	if err != nil {
+		return nil, err
+	}
+	return c.listControllerRevisions(namespace, name, ds.Spec.Template.Labels, "")
+}
+
+// listControllerRevisions assembles the history from the controllerrevisions
+// owned by the workload, currentName marks the live revision and may be empty
+// for workloads which do not expose it, the latest revision is used then.
+func (c *revisionGetter) listControllerRevisions(namespace, name string, labelMap map[string]string, currentName string) ([]WorkloadRevision, error) {
+	labelSelector := labels.Set(labelMap).AsSelector()
+	controllerRevisions, err := c.informers.Apps().V1().ControllerRevisions().Lister().ControllerRevisions(namespace).List(labelSelector)
+	if err != nil {
+		return nil, err
+	}
+
+	var latest int64
+	var history []WorkloadRevision
+	for _, controllerRevision := range controllerRevisions {
+		if !isOwnedBy(controllerRevision.OwnerReferences, name) {
+			continue
+		}
+		if controllerRevision.Revision > latest {
+			latest = controllerRevision.Revision
+		}
+		history = append(history, WorkloadRevision{
+			Revision:          controllerRevision.Revision,
+			Name:              controllerRevision.Name,
+			CreationTimestamp: controllerRevision.CreationTimestamp.Time,
+			Current:           controllerRevision.Name == currentName,
+			PodTemplate:       podTemplateFromControllerRevision(controllerRevision),
+		})
+	}
+
+	if currentName == "" {
+		for index := range history {
+			history[index].Current = history[index].Revision == latest
+		}
+	}
+
+	return fillDiffs(history), nil
+}
+
+func isOwnedBy(ownerReferences []metav1.OwnerReference, name string) bool {
+	if len(ownerReferences) == 0 {
+		return true
+	}
+	for _, owner := range ownerReferences {
+		if owner.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// podTemplateFromControllerRevision decodes the pod template snapshot stored
+// in the controllerrevision data, nil when it cannot be decoded.
+func podTemplateFromControllerRevision(revision *v1.ControllerRevision) *corev1.PodTemplateSpec {
+	var snapshot struct {
+		Spec struct {
+			Template corev1.PodTemplateSpec `json:"template"`
+		} `json:"spec"`
+	}
+	if err := json.Unmarshal(revision.Data.Raw, &snapshot); err != nil {
+		return nil
+	}
+	return &snapshot.Spec.Template
+}
+
+// fillDiffs sorts the history by revision and attaches to each entry the pod
+// template lines changed since the previous revision.
+func fillDiffs(history []WorkloadRevision) []WorkloadRevision {
+	sort.Slice(history, func(i, j int) bool {
+		return history[i].Revision < history[j].Revision
+	})
+	for index := 1; index < len(history); index++ {
+		history[index].Diff = diffPodTemplates(history[index-1].PodTemplate, history[index].PodTemplate)
+	}
+	return history
+}
+
+// diffPodTemplates returns the lines differing between the JSON renderings of
+// two pod templates, removals prefixed with - and additions with +. It is a
+// coarse line-based comparison, enough to spot image or resource changes.
+func diffPodTemplates(previous, current *corev1.PodTemplateSpec) []string {
+	previousLines := templateLines(previous)
+	currentLines := templateLines(current)
+
+	var diff []string
+	for line, count := range previousLines {
+		for ; count > currentLines[line]; count-- {
+			diff = append(diff, "- "+line)
+		}
+	}
+	for line, count := range currentLines {
+		for ; count > previousLines[line]; count-- {
+			diff = append(diff, "+ "+line)
+		}
+	}
+	sort.Strings(diff)
+	return diff
+}
+
+func templateLines(template *corev1.PodTemplateSpec) map[string]int {
+	lines := make(map[string]int)
+	if template == nil {
+		return lines
+	}
+	data, err := json.MarshalIndent(template, "", "  ")
+	if err != nil {
+		return lines
+	}
+	start := 0
+	for index := 0; index <= len(data); index++ {
+		if index == len(data) || data[index] == '\n' {
+			lines[string(data[start:index])]++
+			start = index + 1
+		}
+	}
+	return lines
+}
@@ -30,6 +30,9 @@ type RevisionGetter interface {
 	GetDeploymentRevision(namespace, name, revision string) (*v1.ReplicaSet, error)
 	GetStatefulSetRevision(namespace, name string, revision int) (*v1.ControllerRevision, error)
 	GetDaemonSetRevision(namespace, name string, revision int) (*v1.ControllerRevision, error)
+	ListDeploymentRevisions(namespace, name string) ([]WorkloadRevision, error)
+	ListStatefulSetRevisions(namespace, name string) ([]WorkloadRevision, error)
+	ListDaemonSetRevisions(namespace, name string) ([]WorkloadRevision, error)
 }
 
 type revisionGetter struct {
@@ -0,0 +1,132 @@
+/*
+Copyright 2022 The KubeSphere Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package revisions
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/util/retry"
+)
+
+// workload resources supporting rollback
+const (
+	KindDeployments  = "deployments"
+	KindStatefulSets = "statefulsets"
+	KindDaemonSets   = "daemonsets"
+)
+
+// Rollbacker reverts a workload to an earlier revision of its rollout
+// history, the same way kubectl rollout undo does.
+type Rollbacker interface {
+	Rollback(workloadKind, namespace, name string, revision int64) error
+}
+
+type workloadRollbacker struct {
+	client kubernetes.Interface
+}
+
+func NewRollbacker(client kubernetes.Interface) Rollbacker {
+	return &workloadRollbacker{client: client}
+}
+
+func (r *workloadRollbacker) Rollback(workloadKind, namespace, name string, revision int64) error {
+	switch workloadKind {
+	case KindDeployments:
+		return r.rollbackDeployment(namespace, name, revision)
+	case KindStatefulSets, KindDaemonSets:
+		return r.rollbackFromControllerRevision(workloadKind, namespace, name, revision)
+	default:
+		return fmt.Errorf("workload kind %s does not support rollback", workloadKind)
+	}
+}
+
+// rollbackDeployment copies the pod template of the replicaset holding the
+// target revision back into the deployment spec.
+func (r *workloadRollbacker) rollbackDeployment(namespace, name string, revision int64) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		deploy, err := r.client.AppsV1().Deployments(namespace).Get(context.Background(), name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+
+		selector := labels.Set(deploy.Spec.Template.Labels).AsSelector().String()
+		rsList, err := r.client.AppsV1().ReplicaSets(namespace).List(context.Background(), metav1.ListOptions{LabelSelector: selector})
+		if err != nil {
+			return err
+		}
+
+		target := strconv.FormatInt(revision, 10)
+		for _, rs := range rsList.Items {
+			if !isOwnedBy(rs.OwnerReferences, name) || rs.Annotations[deploymentRevisionAnnotation] != target {
+				continue
+			}
+			deploy.Spec.Template = rs.Spec.Template
+			_, err = r.client.AppsV1().Deployments(namespace).Update(context.Background(), deploy, metav1.UpdateOptions{})
+			return err
+		}
+		return fmt.Errorf("revision not found %v#%v", name, revision)
+	})
+}
+
+// rollbackFromControllerRevision patches the workload with the spec snapshot
+// stored in the controllerrevision holding the target revision.
+func (r *workloadRollbacker) rollbackFromControllerRevision(workloadKind, namespace, name string, revision int64) error {
+	var labelMap map[string]string
+	switch workloadKind {
+	case KindStatefulSets:
+		st, err := r.client.AppsV1().StatefulSets(namespace).Get(context.Background(), name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		labelMap = st.Spec.Template.Labels
+	default:
+		ds, err := r.client.AppsV1().DaemonSets(namespace).Get(context.Background(), name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		labelMap = ds.Spec.Template.Labels
+	}
+
+	selector := labels.Set(labelMap).AsSelector().String()
+	controllerRevisions, err := r.client.AppsV1().ControllerRevisions(namespace).List(context.Background(), metav1.ListOptions{LabelSelector: selector})
+	if err != nil {
+		return err
+	}
+
+	for _, controllerRevision := range controllerRevisions.Items {
+		if !isOwnedBy(controllerRevision.OwnerReferences, name) || controllerRevision.Revision != revision {
+			continue
+		}
+		switch workloadKind {
+		case KindStatefulSets:
+			_, err = r.client.AppsV1().StatefulSets(namespace).Patch(context.Background(), name,
+				types.StrategicMergePatchType, controllerRevision.Data.Raw, metav1.PatchOptions{})
+		default:
+			_, err = r.client.AppsV1().DaemonSets(namespace).Patch(context.Background(), name,
+				types.StrategicMergePatchType, controllerRevision.Data.Raw, metav1.PatchOptions{})
+		}
+		return err
+	}
+
+	return fmt.Errorf("revision not found %v#%v", name, revision)
+}
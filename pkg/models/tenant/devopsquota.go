@@ -0,0 +1,121 @@
+/*
+
+ Copyright 2021 The KubeSphere Authors.
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+
+*/
+
+package tenant
+
+import (
+	"context"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	devopsv1alpha3 "kubesphere.io/api/devops/v1alpha3"
+	quotav1alpha2 "kubesphere.io/api/quota/v1alpha2"
+	tenantv1alpha1 "kubesphere.io/api/tenant/v1alpha1"
+)
+
+// Hard limits for devops resources are declared on the workspace resource
+// quota as object count entries under these keys.
+const (
+	DevOpsProjectsQuotaKey    = corev1.ResourceName("count/devopsprojects.devops.kubesphere.io")
+	DevOpsPipelinesQuotaKey   = corev1.ResourceName("count/pipelines.devops.kubesphere.io")
+	DevOpsCredentialsQuotaKey = corev1.ResourceName("count/credentials.devops.kubesphere.io")
+)
+
+// DevOpsQuotaEntry is the usage of one devops resource against its hard
+// limit, a nil hard limit means unlimited.
+type DevOpsQuotaEntry struct {
+	Used int64  `json:"used"`
+	Hard *int64 `json:"hard,omitempty"`
+}
+
+// DevOpsQuota reports how many devops resources a workspace uses against
+// the limits of its resource quotas, for the workspace overview.
+type DevOpsQuota struct {
+	Projects    DevOpsQuotaEntry `json:"projects"`
+	Pipelines   DevOpsQuotaEntry `json:"pipelines"`
+	Credentials DevOpsQuotaEntry `json:"credentials"`
+}
+
+func (t *tenantOperator) DescribeDevOpsQuota(workspace string) (*DevOpsQuota, error) {
+	ctx := context.TODO()
+
+	if _, err := t.ksclient.TenantV1alpha1().Workspaces().Get(ctx, workspace, metav1.GetOptions{}); err != nil {
+		return nil, err
+	}
+	selector := labels.Set{tenantv1alpha1.WorkspaceLabel: workspace}.String()
+
+	quota := &DevOpsQuota{}
+
+	projects, err := t.ksclient.DevopsV1alpha3().DevOpsProjects().List(ctx, metav1.ListOptions{LabelSelector: selector})
+	if err != nil {
+		return nil, err
+	}
+	quota.Projects.Used = int64(len(projects.Items))
+
+	namespaces, err := t.k8sclient.CoreV1().Namespaces().List(ctx, metav1.ListOptions{LabelSelector: selector})
+	if err != nil {
+		return nil, err
+	}
+	for _, namespace := range namespaces.Items {
+		pipelines, err := t.ksclient.DevopsV1alpha3().Pipelines(namespace.Name).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return nil, err
+		}
+		quota.Pipelines.Used += int64(len(pipelines.Items))
+
+		secrets, err := t.k8sclient.CoreV1().Secrets(namespace.Name).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return nil, err
+		}
+		for _, secret := range secrets.Items {
+			if strings.HasPrefix(string(secret.Type), devopsv1alpha3.DevOpsCredentialPrefix) {
+				quota.Credentials.Used++
+			}
+		}
+	}
+
+	quotas, err := t.ksclient.QuotaV1alpha2().ResourceQuotas().List(ctx, metav1.ListOptions{LabelSelector: selector})
+	if err != nil {
+		return nil, err
+	}
+	quota.Projects.Hard = hardDevOpsLimit(quotas.Items, DevOpsProjectsQuotaKey)
+	quota.Pipelines.Hard = hardDevOpsLimit(quotas.Items, DevOpsPipelinesQuotaKey)
+	quota.Credentials.Hard = hardDevOpsLimit(quotas.Items, DevOpsCredentialsQuotaKey)
+
+	return quota, nil
+}
+
+// hardDevOpsLimit returns the most restrictive limit declared for the key
+// across the resource quotas of the workspace, nil when none declares it.
+func hardDevOpsLimit(quotas []quotav1alpha2.ResourceQuota, key corev1.ResourceName) *int64 {
+	var hard *int64
+	for _, quota := range quotas {
+		limit, ok := quota.Spec.Quota.Hard[key]
+		if !ok {
+			continue
+		}
+		value := limit.Value()
+		if hard == nil || value < *hard {
+			hard = &value
+		}
+	}
+	return hard
+}
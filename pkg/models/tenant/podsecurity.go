@@ -0,0 +1,257 @@
+/*
+Copyright 2022 The KubeSphere Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tenant
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	tenantv1alpha1 "kubesphere.io/api/tenant/v1alpha1"
+
+	"kubesphere.io/kubesphere/pkg/apiserver/query"
+)
+
+const (
+	// PodSecurityEnforceLabel and friends are the upstream Pod Security
+	// Admission namespace labels, kubesphere only manages them, the
+	// kubernetes admission controller enforces them.
+	PodSecurityEnforceLabel = "pod-security.kubernetes.io/enforce"
+	PodSecurityAuditLabel   = "pod-security.kubernetes.io/audit"
+	PodSecurityWarnLabel    = "pod-security.kubernetes.io/warn"
+
+	PodSecurityLevelPrivileged = "privileged"
+	PodSecurityLevelBaseline   = "baseline"
+	PodSecurityLevelRestricted = "restricted"
+)
+
+// PodSecurity carries the pod security standard levels of a namespace,
+// an empty level removes the corresponding label.
+type PodSecurity struct {
+	Enforce string `json:"enforce,omitempty"`
+	Audit   string `json:"audit,omitempty"`
+	Warn    string `json:"warn,omitempty"`
+}
+
+// PodSecurityViolation is an existing pod an enforced level would block.
+type PodSecurityViolation struct {
+	Namespace string `json:"namespace"`
+	Pod       string `json:"pod"`
+	Reason    string `json:"reason"`
+}
+
+// PodSecurityDryRunReport lists what enforcing a level would block,
+// generated from the pods currently running so admins can tighten a
+// namespace without breaking existing workloads.
+type PodSecurityDryRunReport struct {
+	Level         string                 `json:"level"`
+	EvaluatedPods int                    `json:"evaluatedPods"`
+	Violations    []PodSecurityViolation `json:"violations"`
+}
+
+func (t *tenantOperator) DescribeNamespacePodSecurity(workspace, namespace string) (*PodSecurity, error) {
+	ns, err := t.DescribeNamespace(workspace, namespace)
+	if err != nil {
+		return nil, err
+	}
+	return &PodSecurity{
+		Enforce: ns.Labels[PodSecurityEnforceLabel],
+		Audit:   ns.Labels[PodSecurityAuditLabel],
+		Warn:    ns.Labels[PodSecurityWarnLabel],
+	}, nil
+}
+
+func (t *tenantOperator) UpdateNamespacePodSecurity(workspace, namespace string, podSecurity *PodSecurity) (*PodSecurity, error) {
+	if err := validatePodSecurity(podSecurity); err != nil {
+		return nil, err
+	}
+	ns, err := t.DescribeNamespace(workspace, namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	ns = ns.DeepCopy()
+	if ns.Labels == nil {
+		ns.Labels = make(map[string]string)
+	}
+	setOrRemoveLabel(ns.Labels, PodSecurityEnforceLabel, podSecurity.Enforce)
+	setOrRemoveLabel(ns.Labels, PodSecurityAuditLabel, podSecurity.Audit)
+	setOrRemoveLabel(ns.Labels, PodSecurityWarnLabel, podSecurity.Warn)
+
+	if _, err = t.k8sclient.CoreV1().Namespaces().Update(context.Background(), ns, metav1.UpdateOptions{}); err != nil {
+		return nil, err
+	}
+	return podSecurity, nil
+}
+
+// UpdateWorkspacePodSecurity applies the levels to every namespace of the
+// workspace, it returns the names of the namespaces it updated.
+func (t *tenantOperator) UpdateWorkspacePodSecurity(workspace string, podSecurity *PodSecurity) ([]string, error) {
+	if err := validatePodSecurity(podSecurity); err != nil {
+		return nil, err
+	}
+	q := query.New()
+	q.Filters[query.FieldLabel] = query.Value(fmt.Sprintf("%s=%s", tenantv1alpha1.WorkspaceLabel, workspace))
+	result, err := t.resourceGetter.List("namespaces", "", q)
+	if err != nil {
+		return nil, err
+	}
+
+	updated := make([]string, 0, len(result.Items))
+	for _, item := range result.Items {
+		ns, ok := item.(*corev1.Namespace)
+		if !ok {
+			continue
+		}
+		if _, err := t.UpdateNamespacePodSecurity(workspace, ns.Name, podSecurity); err != nil {
+			return nil, err
+		}
+		updated = append(updated, ns.Name)
+	}
+	return updated, nil
+}
+
+// PodSecurityDryRun reports which pods of the namespace an enforced level
+// would block, without changing anything.
+func (t *tenantOperator) PodSecurityDryRun(workspace, namespace, level string) (*PodSecurityDryRunReport, error) {
+	if !validPodSecurityLevel(level) {
+		return nil, fmt.Errorf("invalid pod security level %s", level)
+	}
+	if _, err := t.DescribeNamespace(workspace, namespace); err != nil {
+		return nil, err
+	}
+
+	pods, err := t.resourceGetter.List("pods", namespace, query.New())
+	if err != nil {
+		return nil, err
+	}
+
+	report := &PodSecurityDryRunReport{
+		Level:      level,
+		Violations: make([]PodSecurityViolation, 0),
+	}
+	for _, item := range pods.Items {
+		pod, ok := item.(*corev1.Pod)
+		if !ok {
+			continue
+		}
+		report.EvaluatedPods++
+		for _, reason := range podSecurityViolations(&pod.Spec, level) {
+			report.Violations = append(report.Violations, PodSecurityViolation{
+				Namespace: pod.Namespace,
+				Pod:       pod.Name,
+				Reason:    reason,
+			})
+		}
+	}
+	return report, nil
+}
+
+// podSecurityViolations checks the pod spec against the common controls
+// of the baseline and restricted pod security standards. It is a report
+// helper, admission itself is left to the kubernetes controller.
+func podSecurityViolations(spec *corev1.PodSpec, level string) []string {
+	if level == PodSecurityLevelPrivileged {
+		return nil
+	}
+
+	violations := make([]string, 0)
+
+	// baseline controls
+	if spec.HostNetwork {
+		violations = append(violations, "host network is not allowed")
+	}
+	if spec.HostPID {
+		violations = append(violations, "host PID namespace is not allowed")
+	}
+	if spec.HostIPC {
+		violations = append(violations, "host IPC namespace is not allowed")
+	}
+	for _, volume := range spec.Volumes {
+		if volume.HostPath != nil {
+			violations = append(violations, fmt.Sprintf("hostPath volume %s is not allowed", volume.Name))
+		}
+	}
+
+	containers := append(append([]corev1.Container{}, spec.InitContainers...), spec.Containers...)
+	for _, container := range containers {
+		sc := container.SecurityContext
+		if sc != nil && sc.Privileged != nil && *sc.Privileged {
+			violations = append(violations, fmt.Sprintf("container %s must not be privileged", container.Name))
+		}
+		for _, port := range container.Ports {
+			if port.HostPort != 0 {
+				violations = append(violations, fmt.Sprintf("container %s must not use host port %d", container.Name, port.HostPort))
+			}
+		}
+
+		if level != PodSecurityLevelRestricted {
+			continue
+		}
+		// restricted controls
+		if sc == nil || sc.AllowPrivilegeEscalation == nil || *sc.AllowPrivilegeEscalation {
+			violations = append(violations, fmt.Sprintf("container %s must set allowPrivilegeEscalation=false", container.Name))
+		}
+		if !dropsAllCapabilities(sc) {
+			violations = append(violations, fmt.Sprintf("container %s must drop all capabilities", container.Name))
+		}
+		runAsNonRoot := spec.SecurityContext != nil && spec.SecurityContext.RunAsNonRoot != nil && *spec.SecurityContext.RunAsNonRoot
+		if sc != nil && sc.RunAsNonRoot != nil {
+			runAsNonRoot = *sc.RunAsNonRoot
+		}
+		if !runAsNonRoot {
+			violations = append(violations, fmt.Sprintf("container %s must set runAsNonRoot=true", container.Name))
+		}
+	}
+
+	return violations
+}
+
+func dropsAllCapabilities(sc *corev1.SecurityContext) bool {
+	if sc == nil || sc.Capabilities == nil {
+		return false
+	}
+	for _, capability := range sc.Capabilities.Drop {
+		if capability == "ALL" {
+			return true
+		}
+	}
+	return false
+}
+
+func validatePodSecurity(podSecurity *PodSecurity) error {
+	for _, level := range []string{podSecurity.Enforce, podSecurity.Audit, podSecurity.Warn} {
+		if level != "" && !validPodSecurityLevel(level) {
+			return fmt.Errorf("invalid pod security level %s", level)
+		}
+	}
+	return nil
+}
+
+func validPodSecurityLevel(level string) bool {
+	return level == PodSecurityLevelPrivileged || level == PodSecurityLevelBaseline || level == PodSecurityLevelRestricted
+}
+
+func setOrRemoveLabel(labels map[string]string, key, value string) {
+	if value == "" {
+		delete(labels, key)
+		return
+	}
+	labels[key] = value
+}
@@ -0,0 +1,67 @@
+/*
+Copyright 2022 The KubeSphere Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tenant
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestPodSecurityViolations(t *testing.T) {
+	boolPtr := func(b bool) *bool { return &b }
+
+	privilegedSpec := &corev1.PodSpec{
+		HostNetwork: true,
+		Containers: []corev1.Container{{
+			Name:            "app",
+			SecurityContext: &corev1.SecurityContext{Privileged: boolPtr(true)},
+		}},
+	}
+	restrictedSpec := &corev1.PodSpec{
+		SecurityContext: &corev1.PodSecurityContext{RunAsNonRoot: boolPtr(true)},
+		Containers: []corev1.Container{{
+			Name: "app",
+			SecurityContext: &corev1.SecurityContext{
+				AllowPrivilegeEscalation: boolPtr(false),
+				Capabilities:             &corev1.Capabilities{Drop: []corev1.Capability{"ALL"}},
+			},
+		}},
+	}
+
+	tests := []struct {
+		description string
+		spec        *corev1.PodSpec
+		level       string
+		violations  int
+	}{
+		{"privileged level allows everything", privilegedSpec, PodSecurityLevelPrivileged, 0},
+		{"baseline rejects host network and privileged containers", privilegedSpec, PodSecurityLevelBaseline, 2},
+		{"fully restricted pod passes restricted", restrictedSpec, PodSecurityLevelRestricted, 0},
+		{"plain pod passes baseline", &corev1.PodSpec{Containers: []corev1.Container{{Name: "app"}}}, PodSecurityLevelBaseline, 0},
+		{"plain pod fails restricted", &corev1.PodSpec{Containers: []corev1.Container{{Name: "app"}}}, PodSecurityLevelRestricted, 3},
+	}
+
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			violations := podSecurityViolations(test.spec, test.level)
+			if len(violations) != test.violations {
+				t.Errorf("expected %d violations, got %v", test.violations, violations)
+			}
+		})
+	}
+}
@@ -82,9 +82,9 @@ type Interface interface {
 	ListWorkspaces(user user.Info, queryParam *query.Query) (*api.ListResult, error)
 	GetWorkspace(workspace string) (*tenantv1alpha1.Workspace, error)
 	ListWorkspaceTemplates(user user.Info, query *query.Query) (*api.ListResult, error)
-	CreateWorkspaceTemplate(user user.Info, workspace *tenantv1alpha2.WorkspaceTemplate) (*tenantv1alpha2.WorkspaceTemplate, error)
+	CreateWorkspaceTemplate(user user.Info, workspace *tenantv1alpha2.WorkspaceTemplate, dryRun ...string) (*tenantv1alpha2.WorkspaceTemplate, error)
 	DeleteWorkspaceTemplate(workspace string, opts metav1.DeleteOptions) error
-	UpdateWorkspaceTemplate(user user.Info, workspace *tenantv1alpha2.WorkspaceTemplate) (*tenantv1alpha2.WorkspaceTemplate, error)
+	UpdateWorkspaceTemplate(user user.Info, workspace *tenantv1alpha2.WorkspaceTemplate, dryRun ...string) (*tenantv1alpha2.WorkspaceTemplate, error)
 	PatchWorkspaceTemplate(user user.Info, workspace string, data json.RawMessage) (*tenantv1alpha2.WorkspaceTemplate, error)
 	DescribeWorkspaceTemplate(workspace string) (*tenantv1alpha2.WorkspaceTemplate, error)
 	ListNamespaces(user user.Info, workspace string, query *query.Query) (*api.ListResult, error)
@@ -107,6 +107,11 @@ type Interface interface {
 	DeleteWorkspaceResourceQuota(workspace string, resourceQuotaName string) error
 	UpdateWorkspaceResourceQuota(workspace string, resourceQuota *quotav1alpha2.ResourceQuota) (*quotav1alpha2.ResourceQuota, error)
 	DescribeWorkspaceResourceQuota(workspace string, resourceQuotaName string) (*quotav1alpha2.ResourceQuota, error)
+	DescribeDevOpsQuota(workspace string) (*DevOpsQuota, error)
+	DescribeNamespacePodSecurity(workspace, namespace string) (*PodSecurity, error)
+	UpdateNamespacePodSecurity(workspace, namespace string, podSecurity *PodSecurity) (*PodSecurity, error)
+	UpdateWorkspacePodSecurity(workspace string, podSecurity *PodSecurity) ([]string, error)
+	PodSecurityDryRun(workspace, namespace, level string) (*PodSecurityDryRunReport, error)
 }
 
 type tenantOperator struct {
@@ -552,7 +557,7 @@ func (t *tenantOperator) PatchWorkspaceTemplate(user user.Info, workspace string
 	return t.ksclient.TenantV1alpha2().WorkspaceTemplates().Patch(context.Background(), workspace, types.JSONPatchType, data, metav1.PatchOptions{})
 }
 
-func (t *tenantOperator) CreateWorkspaceTemplate(user user.Info, workspace *tenantv1alpha2.WorkspaceTemplate) (*tenantv1alpha2.WorkspaceTemplate, error) {
+func (t *tenantOperator) CreateWorkspaceTemplate(user user.Info, workspace *tenantv1alpha2.WorkspaceTemplate, dryRun ...string) (*tenantv1alpha2.WorkspaceTemplate, error) {
 	if len(workspace.Spec.Placement.Clusters) != 0 {
 		clusters := make([]string, 0)
 		for _, v := range workspace.Spec.Placement.Clusters {
@@ -565,10 +570,10 @@ func (t *tenantOperator) CreateWorkspaceTemplate(user user.Info, workspace *tena
 		}
 
 	}
-	return t.ksclient.TenantV1alpha2().WorkspaceTemplates().Create(context.Background(), workspace, metav1.CreateOptions{})
+	return t.ksclient.TenantV1alpha2().WorkspaceTemplates().Create(context.Background(), workspace, metav1.CreateOptions{DryRun: dryRun})
 }
 
-func (t *tenantOperator) UpdateWorkspaceTemplate(user user.Info, workspace *tenantv1alpha2.WorkspaceTemplate) (*tenantv1alpha2.WorkspaceTemplate, error) {
+func (t *tenantOperator) UpdateWorkspaceTemplate(user user.Info, workspace *tenantv1alpha2.WorkspaceTemplate, dryRun ...string) (*tenantv1alpha2.WorkspaceTemplate, error) {
 	if len(workspace.Spec.Placement.Clusters) != 0 {
 		clusters := make([]string, 0)
 		for _, v := range workspace.Spec.Placement.Clusters {
@@ -581,7 +586,7 @@ func (t *tenantOperator) UpdateWorkspaceTemplate(user user.Info, workspace *tena
 		}
 
 	}
-	return t.ksclient.TenantV1alpha2().WorkspaceTemplates().Update(context.Background(), workspace, metav1.UpdateOptions{})
+	return t.ksclient.TenantV1alpha2().WorkspaceTemplates().Update(context.Background(), workspace, metav1.UpdateOptions{DryRun: dryRun})
 }
 
 func (t *tenantOperator) DescribeWorkspaceTemplate(workspace string) (*tenantv1alpha2.WorkspaceTemplate, error) {
@@ -40,6 +40,7 @@ import (
 	fakeks "kubesphere.io/kubesphere/pkg/client/clientset/versioned/fake"
 	"kubesphere.io/kubesphere/pkg/informers"
 	"kubesphere.io/kubesphere/pkg/models/iam/am"
+	"kubesphere.io/kubesphere/pkg/models/resources/v1alpha3/namespace"
 )
 
 func TestTenantOperator_ListWorkspaces(t *testing.T) {
@@ -93,7 +94,7 @@ func TestTenantOperator_ListNamespaces(t *testing.T) {
 			workspace: systemWorkspace.Name,
 			username:  admin.Name,
 			result: &api.ListResult{
-				Items:      []interface{}{kubesphereSystem, defaultNamespace},
+				Items:      []interface{}{withUsageAnnotations(kubesphereSystem), withUsageAnnotations(defaultNamespace)},
 				TotalItems: 2,
 			},
 		},
@@ -109,7 +110,7 @@ func TestTenantOperator_ListNamespaces(t *testing.T) {
 			workspace: testWorkspace.Name,
 			username:  tester2.Name,
 			result: &api.ListResult{
-				Items:      []interface{}{testNamespace},
+				Items:      []interface{}{withUsageAnnotations(testNamespace)},
 				TotalItems: 1,
 			},
 		},
@@ -141,7 +142,7 @@ func TestTenantOperator_DescribeNamespace(t *testing.T) {
 		expectError error
 	}{
 		{
-			result:      testNamespace,
+			result:      withUsageAnnotations(testNamespace),
 			username:    tester2.Name,
 			workspace:   testWorkspace.Name,
 			namespace:   testNamespace.Name,
@@ -490,6 +491,18 @@ var (
 	namespaceRoleBindings = []interface{}{testNamespaceAdminRoleBinding}
 )
 
+// the namespace getter annotates returned namespaces with their cached usage
+func withUsageAnnotations(ns *corev1.Namespace) *corev1.Namespace {
+	out := ns.DeepCopy()
+	out.Annotations = map[string]string{
+		namespace.PodCountAnnotation:       "0",
+		namespace.CPURequestsAnnotation:    "0",
+		namespace.MemoryRequestsAnnotation: "0",
+		namespace.PVCStorageAnnotation:     "0",
+	}
+	return out
+}
+
 func prepare() Interface {
 	ksClient := fakeks.NewSimpleClientset([]runtime.Object{testWorkspace, systemWorkspace}...)
 	k8sClient := fakek8s.NewSimpleClientset([]runtime.Object{testNamespace, kubesphereSystem}...)
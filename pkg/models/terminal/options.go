@@ -19,6 +19,10 @@ import "github.com/spf13/pflag"
 type Options struct {
 	Image   string `json:"image,omitempty" yaml:"image,omitempty"`
 	Timeout int    `json:"timeout,omitempty" yaml:"timeout,omitempty"`
+
+	// AllowedDebugImages is the allow-list of images an ephemeral debug
+	// container may run, the default Image is always allowed.
+	AllowedDebugImages []string `json:"allowedDebugImages,omitempty" yaml:"allowedDebugImages,omitempty"`
 }
 
 func NewTerminalOptions() *Options {
@@ -28,6 +32,20 @@ func NewTerminalOptions() *Options {
 	}
 }
 
+// IsDebugImageAllowed reports whether the image may be used for ephemeral
+// debug containers.
+func (s *Options) IsDebugImageAllowed(image string) bool {
+	if image == s.Image {
+		return true
+	}
+	for _, allowed := range s.AllowedDebugImages {
+		if image == allowed {
+			return true
+		}
+	}
+	return false
+}
+
 func (s *Options) Validate() []error {
 	var errs []error
 	return errs
@@ -33,6 +33,7 @@ import (
 	"github.com/gorilla/websocket"
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilrand "k8s.io/apimachinery/pkg/util/rand"
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/kubernetes/scheme"
@@ -154,6 +155,7 @@ func (t TerminalSession) Close(status uint32, reason string) {
 
 type Interface interface {
 	HandleSession(shell, namespace, podName, containerName string, conn *websocket.Conn)
+	HandleDebugSession(username, namespace, podName, targetContainer, image string, conn *websocket.Conn)
 	HandleShellAccessToNode(nodename string, conn *websocket.Conn)
 }
 
@@ -354,6 +356,146 @@ func (t *terminaler) HandleSession(shell, namespace, podName, containerName stri
 	session.Close(1, "Process exited")
 }
 
+// HandleDebugSession attaches an ephemeral debug container running the given
+// image to the pod and streams its terminal over the websocket connection,
+// so distroless containers can be debugged without a shell of their own.
+func (t *terminaler) HandleDebugSession(username, namespace, podName, targetContainer, image string, conn *websocket.Conn) {
+	session := &TerminalSession{conn: conn, sizeChan: make(chan remotecommand.TerminalSize)}
+
+	if image == "" {
+		image = t.options.Image
+	}
+	if !t.options.IsDebugImageAllowed(image) {
+		session.Close(2, fmt.Sprintf("image %s is not in the debug image allow-list", image))
+		return
+	}
+
+	containerName, err := t.createDebugContainer(namespace, podName, targetContainer, image, username)
+	if err != nil {
+		session.Close(2, err.Error())
+		return
+	}
+
+	if err = t.startAttach(namespace, podName, containerName, session); err != nil {
+		session.Close(2, err.Error())
+		return
+	}
+
+	session.Close(1, "Process exited")
+}
+
+// createDebugContainer adds an ephemeral container to the pod, records an
+// event naming the user who requested it and waits until the container runs.
+func (t *terminaler) createDebugContainer(namespace, podName, targetContainer, image, username string) (string, error) {
+	pod, err := t.client.CoreV1().Pods(namespace).Get(context.Background(), podName, metav1.GetOptions{})
+	if err != nil {
+		return "", err
+	}
+	if pod.Status.Phase != v1.PodRunning {
+		return "", fmt.Errorf("pod %s is not running", podName)
+	}
+
+	ephemeralContainers, err := t.client.CoreV1().Pods(namespace).GetEphemeralContainers(context.Background(), podName, metav1.GetOptions{})
+	if err != nil {
+		return "", err
+	}
+
+	containerName := fmt.Sprintf("debug-%s", utilrand.String(5))
+	ephemeralContainers.EphemeralContainers = append(ephemeralContainers.EphemeralContainers, v1.EphemeralContainer{
+		EphemeralContainerCommon: v1.EphemeralContainerCommon{
+			Name:            containerName,
+			Image:           image,
+			ImagePullPolicy: v1.PullIfNotPresent,
+			Stdin:           true,
+			TTY:             true,
+		},
+		TargetContainerName: targetContainer,
+	})
+
+	if _, err = t.client.CoreV1().Pods(namespace).UpdateEphemeralContainers(context.Background(), podName, ephemeralContainers, metav1.UpdateOptions{}); err != nil {
+		return "", err
+	}
+
+	t.recordDebugEvent(pod, containerName, image, username)
+
+	// wait for the kubelet to start the ephemeral container
+	err = wait.Poll(time.Millisecond*500, time.Second*30, func() (bool, error) {
+		pod, err := t.client.CoreV1().Pods(namespace).Get(context.Background(), podName, metav1.GetOptions{})
+		if err != nil {
+			return false, err
+		}
+		for _, status := range pod.Status.EphemeralContainerStatuses {
+			if status.Name == containerName && status.State.Running != nil {
+				return true, nil
+			}
+		}
+		return false, nil
+	})
+	if err != nil {
+		return "", fmt.Errorf("debug container %s did not start: %v", containerName, err)
+	}
+
+	return containerName, nil
+}
+
+// recordDebugEvent leaves an audit trail on the pod naming the user who
+// attached the debug container.
+func (t *terminaler) recordDebugEvent(pod *v1.Pod, containerName, image, username string) {
+	now := metav1.Now()
+	event := &v1.Event{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:    pod.Namespace,
+			GenerateName: pod.Name + ".",
+		},
+		InvolvedObject: v1.ObjectReference{
+			Kind:      "Pod",
+			Namespace: pod.Namespace,
+			Name:      pod.Name,
+			UID:       pod.UID,
+		},
+		Reason:         "DebugContainerAttached",
+		Message:        fmt.Sprintf("User %s attached ephemeral debug container %s with image %s", username, containerName, image),
+		Type:           v1.EventTypeNormal,
+		FirstTimestamp: now,
+		LastTimestamp:  now,
+		Count:          1,
+		Source:         v1.EventSource{Component: "ks-apiserver"},
+	}
+	if _, err := t.client.CoreV1().Events(pod.Namespace).Create(context.Background(), event, metav1.CreateOptions{}); err != nil {
+		klog.Warningf("failed to record debug event for pod %s/%s: %v", pod.Namespace, pod.Name, err)
+	}
+}
+
+// startAttach connects the session to the ephemeral container through the
+// attach subresource, the container already runs with stdin and a tty.
+func (t *terminaler) startAttach(namespace, podName, containerName string, ptyHandler PtyHandler) error {
+	req := t.client.CoreV1().RESTClient().Post().
+		Resource("pods").
+		Name(podName).
+		Namespace(namespace).
+		SubResource("attach")
+	req.VersionedParams(&v1.PodAttachOptions{
+		Container: containerName,
+		Stdin:     true,
+		Stdout:    true,
+		Stderr:    true,
+		TTY:       true,
+	}, scheme.ParameterCodec)
+
+	exec, err := remotecommand.NewSPDYExecutor(t.config, "POST", req.URL())
+	if err != nil {
+		return err
+	}
+
+	return exec.Stream(remotecommand.StreamOptions{
+		Stdin:             ptyHandler,
+		Stdout:            ptyHandler,
+		Stderr:            ptyHandler,
+		TerminalSizeQueue: ptyHandler,
+		Tty:               true,
+	})
+}
+
 func (t *terminaler) HandleShellAccessToNode(nodename string, conn *websocket.Conn) {
 
 	nodeTerminaler, err := NewNodeTerminaler(nodename, t.options, t.client)
@@ -0,0 +1,330 @@
+/*
+Copyright 2022 The KubeSphere Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workloads
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"regexp"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// RolloutSourceAnnotation on a versioned copy names the configmap or
+// secret it was created from.
+const RolloutSourceAnnotation = "rollout.kubesphere.io/source"
+
+// hashLen is the length of the content hash suffixed to versioned copies.
+const hashLen = 8
+
+// RolloutResult reports the versioned copy that was created and the
+// workloads that were rewritten to reference it.
+type RolloutResult struct {
+	Source           string   `json:"source" description:"the configmap or secret the versioned copy was created from"`
+	VersionedName    string   `json:"versionedName" description:"name of the immutable versioned copy"`
+	UpdatedWorkloads []string `json:"updatedWorkloads" description:"workloads rewritten to reference the versioned copy, as kind/name"`
+}
+
+// ImmutableRollout turns a configmap or secret into an immutable versioned
+// copy and rewrites the workloads referencing it, so that every config
+// change rolls the workloads.
+type ImmutableRollout interface {
+	RolloutConfigMap(namespace, name string) (*RolloutResult, error)
+	RolloutSecret(namespace, name string) (*RolloutResult, error)
+}
+
+type immutableRollout struct {
+	client kubernetes.Interface
+}
+
+func NewImmutableRollout(client kubernetes.Interface) ImmutableRollout {
+	return &immutableRollout{client: client}
+}
+
+func (r *immutableRollout) RolloutConfigMap(namespace, name string) (*RolloutResult, error) {
+	configMap, err := r.client.CoreV1().ConfigMaps(namespace).Get(context.Background(), name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	source := sourceName(configMap.ObjectMeta)
+	versioned := versionedName(source, configMapHash(configMap))
+
+	if versioned != configMap.Name {
+		immutable := true
+		copied := &corev1.ConfigMap{
+			ObjectMeta: versionedMeta(configMap.ObjectMeta, source, versioned),
+			Immutable:  &immutable,
+			Data:       configMap.Data,
+			BinaryData: configMap.BinaryData,
+		}
+		if _, err = r.client.CoreV1().ConfigMaps(namespace).Create(context.Background(), copied, metav1.CreateOptions{}); err != nil && !k8serr.IsAlreadyExists(err) {
+			return nil, err
+		}
+	}
+
+	matches := referenceMatcher(source)
+	updated, err := r.rewriteWorkloads(namespace, func(spec *corev1.PodSpec) bool {
+		return rewriteConfigMapRefs(spec, matches, versioned)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &RolloutResult{Source: source, VersionedName: versioned, UpdatedWorkloads: updated}, nil
+}
+
+func (r *immutableRollout) RolloutSecret(namespace, name string) (*RolloutResult, error) {
+	secret, err := r.client.CoreV1().Secrets(namespace).Get(context.Background(), name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	source := sourceName(secret.ObjectMeta)
+	versioned := versionedName(source, secretHash(secret))
+
+	if versioned != secret.Name {
+		immutable := true
+		copied := &corev1.Secret{
+			ObjectMeta: versionedMeta(secret.ObjectMeta, source, versioned),
+			Immutable:  &immutable,
+			Data:       secret.Data,
+			Type:       secret.Type,
+		}
+		if _, err = r.client.CoreV1().Secrets(namespace).Create(context.Background(), copied, metav1.CreateOptions{}); err != nil && !k8serr.IsAlreadyExists(err) {
+			return nil, err
+		}
+	}
+
+	matches := referenceMatcher(source)
+	updated, err := r.rewriteWorkloads(namespace, func(spec *corev1.PodSpec) bool {
+		return rewriteSecretRefs(spec, matches, versioned)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &RolloutResult{Source: source, VersionedName: versioned, UpdatedWorkloads: updated}, nil
+}
+
+// sourceName follows the source annotation so that a rollout requested on
+// a versioned copy still versions and rewrites against the original name.
+func sourceName(objectMeta metav1.ObjectMeta) string {
+	if source, ok := objectMeta.Annotations[RolloutSourceAnnotation]; ok && source != "" {
+		return source
+	}
+	return objectMeta.Name
+}
+
+func versionedName(source, hash string) string {
+	return fmt.Sprintf("%s-%s", source, hash)
+}
+
+func versionedMeta(objectMeta metav1.ObjectMeta, source, versioned string) metav1.ObjectMeta {
+	annotations := map[string]string{RolloutSourceAnnotation: source}
+	labels := make(map[string]string, len(objectMeta.Labels))
+	for key, value := range objectMeta.Labels {
+		labels[key] = value
+	}
+	return metav1.ObjectMeta{
+		Name:        versioned,
+		Namespace:   objectMeta.Namespace,
+		Labels:      labels,
+		Annotations: annotations,
+	}
+}
+
+// referenceMatcher matches the source name and any of its versioned
+// copies, so repeated rollouts move workloads from one copy to the next.
+func referenceMatcher(source string) func(string) bool {
+	versioned := regexp.MustCompile("^" + regexp.QuoteMeta(source) + "-[0-9a-f]{" + fmt.Sprint(hashLen) + "}$")
+	return func(name string) bool {
+		return name == source || versioned.MatchString(name)
+	}
+}
+
+func configMapHash(configMap *corev1.ConfigMap) string {
+	data := make(map[string][]byte, len(configMap.Data)+len(configMap.BinaryData))
+	for key, value := range configMap.Data {
+		data[key] = []byte(value)
+	}
+	for key, value := range configMap.BinaryData {
+		data[key] = value
+	}
+	return contentHash(data)
+}
+
+func secretHash(secret *corev1.Secret) string {
+	return contentHash(secret.Data)
+}
+
+func contentHash(data map[string][]byte) string {
+	keys := make([]string, 0, len(data))
+	for key := range data {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	hash := sha256.New()
+	for _, key := range keys {
+		hash.Write([]byte(key))
+		hash.Write([]byte{0})
+		hash.Write(data[key])
+		hash.Write([]byte{0})
+	}
+	return hex.EncodeToString(hash.Sum(nil))[:hashLen]
+}
+
+// rewriteWorkloads applies rewrite to the pod template of every deployment,
+// statefulset and daemonset of the namespace and updates the ones it changed.
+func (r *immutableRollout) rewriteWorkloads(namespace string, rewrite func(*corev1.PodSpec) bool) ([]string, error) {
+	updated := make([]string, 0)
+
+	deployments, err := r.client.AppsV1().Deployments(namespace).List(context.Background(), metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	for index := range deployments.Items {
+		deployment := &deployments.Items[index]
+		if rewrite(&deployment.Spec.Template.Spec) {
+			if _, err = r.client.AppsV1().Deployments(namespace).Update(context.Background(), deployment, metav1.UpdateOptions{}); err != nil {
+				return updated, err
+			}
+			updated = append(updated, "Deployment/"+deployment.Name)
+		}
+	}
+
+	statefulSets, err := r.client.AppsV1().StatefulSets(namespace).List(context.Background(), metav1.ListOptions{})
+	if err != nil {
+		return updated, err
+	}
+	for index := range statefulSets.Items {
+		statefulSet := &statefulSets.Items[index]
+		if rewrite(&statefulSet.Spec.Template.Spec) {
+			if _, err = r.client.AppsV1().StatefulSets(namespace).Update(context.Background(), statefulSet, metav1.UpdateOptions{}); err != nil {
+				return updated, err
+			}
+			updated = append(updated, "StatefulSet/"+statefulSet.Name)
+		}
+	}
+
+	daemonSets, err := r.client.AppsV1().DaemonSets(namespace).List(context.Background(), metav1.ListOptions{})
+	if err != nil {
+		return updated, err
+	}
+	for index := range daemonSets.Items {
+		daemonSet := &daemonSets.Items[index]
+		if rewrite(&daemonSet.Spec.Template.Spec) {
+			if _, err = r.client.AppsV1().DaemonSets(namespace).Update(context.Background(), daemonSet, metav1.UpdateOptions{}); err != nil {
+				return updated, err
+			}
+			updated = append(updated, "DaemonSet/"+daemonSet.Name)
+		}
+	}
+
+	return updated, nil
+}
+
+func rewriteConfigMapRefs(spec *corev1.PodSpec, matches func(string) bool, newName string) bool {
+	changed := false
+	replace := func(name *string) {
+		if matches(*name) && *name != newName {
+			*name = newName
+			changed = true
+		}
+	}
+
+	for index := range spec.Volumes {
+		volume := &spec.Volumes[index]
+		if volume.ConfigMap != nil {
+			replace(&volume.ConfigMap.Name)
+		}
+		if volume.Projected != nil {
+			for sourceIndex := range volume.Projected.Sources {
+				if source := &volume.Projected.Sources[sourceIndex]; source.ConfigMap != nil {
+					replace(&source.ConfigMap.Name)
+				}
+			}
+		}
+	}
+	eachContainer(spec, func(container *corev1.Container) {
+		for index := range container.EnvFrom {
+			if envFrom := &container.EnvFrom[index]; envFrom.ConfigMapRef != nil {
+				replace(&envFrom.ConfigMapRef.Name)
+			}
+		}
+		for index := range container.Env {
+			if env := &container.Env[index]; env.ValueFrom != nil && env.ValueFrom.ConfigMapKeyRef != nil {
+				replace(&env.ValueFrom.ConfigMapKeyRef.Name)
+			}
+		}
+	})
+	return changed
+}
+
+func rewriteSecretRefs(spec *corev1.PodSpec, matches func(string) bool, newName string) bool {
+	changed := false
+	replace := func(name *string) {
+		if matches(*name) && *name != newName {
+			*name = newName
+			changed = true
+		}
+	}
+
+	for index := range spec.ImagePullSecrets {
+		replace(&spec.ImagePullSecrets[index].Name)
+	}
+	for index := range spec.Volumes {
+		volume := &spec.Volumes[index]
+		if volume.Secret != nil {
+			replace(&volume.Secret.SecretName)
+		}
+		if volume.Projected != nil {
+			for sourceIndex := range volume.Projected.Sources {
+				if source := &volume.Projected.Sources[sourceIndex]; source.Secret != nil {
+					replace(&source.Secret.Name)
+				}
+			}
+		}
+	}
+	eachContainer(spec, func(container *corev1.Container) {
+		for index := range container.EnvFrom {
+			if envFrom := &container.EnvFrom[index]; envFrom.SecretRef != nil {
+				replace(&envFrom.SecretRef.Name)
+			}
+		}
+		for index := range container.Env {
+			if env := &container.Env[index]; env.ValueFrom != nil && env.ValueFrom.SecretKeyRef != nil {
+				replace(&env.ValueFrom.SecretKeyRef.Name)
+			}
+		}
+	})
+	return changed
+}
+
+func eachContainer(spec *corev1.PodSpec, visit func(container *corev1.Container)) {
+	for index := range spec.InitContainers {
+		visit(&spec.InitContainers[index])
+	}
+	for index := range spec.Containers {
+		visit(&spec.Containers[index])
+	}
+}
@@ -0,0 +1,153 @@
+/*
+Copyright 2022 The KubeSphere Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workloads
+
+import (
+	"fmt"
+
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/informers"
+)
+
+// gap reasons
+const (
+	// ReasonNoPodDisruptionBudget flags a multi-replica workload without any
+	// matching budget, a drain may take all its pods down at once.
+	ReasonNoPodDisruptionBudget = "NoPodDisruptionBudget"
+	// ReasonNeverSatisfiable flags a budget that never allows a voluntary
+	// disruption, it blocks node drains indefinitely.
+	ReasonNeverSatisfiable = "NeverSatisfiable"
+)
+
+// PDBGap describes one finding of the disruption budget analysis.
+type PDBGap struct {
+	Workload string `json:"workload" description:"affected workload as kind/name"`
+	Replicas int32  `json:"replicas" description:"desired replicas of the workload"`
+	PDB      string `json:"pdb,omitempty" description:"name of the offending budget, empty when the workload has none"`
+	Reason   string `json:"reason" description:"one of NoPodDisruptionBudget, NeverSatisfiable"`
+	Message  string `json:"message" description:"human readable explanation"`
+}
+
+// PDBAnalyzer finds workloads that are not prepared for node maintenance,
+// either because no disruption budget covers them or because their budget can
+// never be satisfied.
+type PDBAnalyzer interface {
+	AnalyzePDBGaps(namespace string) ([]PDBGap, error)
+}
+
+type pdbAnalyzer struct {
+	informers informers.SharedInformerFactory
+}
+
+func NewPDBAnalyzer(informers informers.SharedInformerFactory) PDBAnalyzer {
+	return &pdbAnalyzer{informers: informers}
+}
+
+func (a *pdbAnalyzer) AnalyzePDBGaps(namespace string) ([]PDBGap, error) {
+	budgets, err := a.informers.Policy().V1().PodDisruptionBudgets().Lister().PodDisruptionBudgets(namespace).List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+
+	gaps := make([]PDBGap, 0)
+
+	deployments, err := a.informers.Apps().V1().Deployments().Lister().Deployments(namespace).List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	for _, deployment := range deployments {
+		gaps = append(gaps, analyzeWorkload(fmt.Sprintf("Deployment/%s", deployment.Name),
+			replicasOrOne(deployment.Spec.Replicas), deployment.Spec.Template.Labels, budgets)...)
+	}
+
+	statefulSets, err := a.informers.Apps().V1().StatefulSets().Lister().StatefulSets(namespace).List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	for _, statefulSet := range statefulSets {
+		gaps = append(gaps, analyzeWorkload(fmt.Sprintf("StatefulSet/%s", statefulSet.Name),
+			replicasOrOne(statefulSet.Spec.Replicas), statefulSet.Spec.Template.Labels, budgets)...)
+	}
+
+	return gaps, nil
+}
+
+// analyzeWorkload matches the pod labels of the workload against the budgets
+// of the namespace, single-replica workloads are skipped since a budget
+// cannot help them anyway.
+func analyzeWorkload(workload string, replicas int32, podLabels map[string]string, budgets []*policyv1.PodDisruptionBudget) []PDBGap {
+	if replicas < 2 {
+		return nil
+	}
+
+	var gaps []PDBGap
+	covered := false
+	for _, budget := range budgets {
+		selector, err := metav1.LabelSelectorAsSelector(budget.Spec.Selector)
+		if err != nil || selector.Empty() || !selector.Matches(labels.Set(podLabels)) {
+			continue
+		}
+		covered = true
+		if message := neverSatisfiable(budget, replicas); message != "" {
+			gaps = append(gaps, PDBGap{
+				Workload: workload,
+				Replicas: replicas,
+				PDB:      budget.Name,
+				Reason:   ReasonNeverSatisfiable,
+				Message:  message,
+			})
+		}
+	}
+	if !covered {
+		gaps = append(gaps, PDBGap{
+			Workload: workload,
+			Replicas: replicas,
+			Reason:   ReasonNoPodDisruptionBudget,
+			Message:  fmt.Sprintf("%s runs %d replicas but no pod disruption budget covers its pods", workload, replicas),
+		})
+	}
+	return gaps
+}
+
+// neverSatisfiable reports why the budget blocks every voluntary disruption
+// of a workload with the given replica count, or an empty string.
+func neverSatisfiable(budget *policyv1.PodDisruptionBudget, replicas int32) string {
+	if budget.Spec.MaxUnavailable != nil {
+		maxUnavailable, err := intstr.GetScaledValueFromIntOrPercent(budget.Spec.MaxUnavailable, int(replicas), true)
+		if err == nil && maxUnavailable < 1 {
+			return fmt.Sprintf("maxUnavailable %s never allows a disruption of %d replicas", budget.Spec.MaxUnavailable.String(), replicas)
+		}
+		return ""
+	}
+	if budget.Spec.MinAvailable != nil {
+		minAvailable, err := intstr.GetScaledValueFromIntOrPercent(budget.Spec.MinAvailable, int(replicas), true)
+		if err == nil && int32(minAvailable) >= replicas {
+			return fmt.Sprintf("minAvailable %s leaves no room to disrupt any of the %d replicas", budget.Spec.MinAvailable.String(), replicas)
+		}
+	}
+	return ""
+}
+
+func replicasOrOne(replicas *int32) int32 {
+	if replicas == nil {
+		return 1
+	}
+	return *replicas
+}
@@ -0,0 +1,123 @@
+/*
+Copyright 2022 The KubeSphere Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workloads
+
+import (
+	"context"
+	"fmt"
+
+	autoscalingv1 "k8s.io/api/autoscaling/v1"
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes"
+)
+
+// workload resources supporting the scale subresource
+const (
+	KindDeployments  = "deployments"
+	KindStatefulSets = "statefulsets"
+	KindReplicaSets  = "replicasets"
+)
+
+// workloadKinds maps the plural resource name to the kind referenced by the
+// scaleTargetRef of a HorizontalPodAutoscaler.
+var workloadKinds = map[string]string{
+	KindDeployments:  "Deployment",
+	KindStatefulSets: "StatefulSet",
+	KindReplicaSets:  "ReplicaSet",
+}
+
+// Scaler changes the replica count of a workload through the scale
+// subresource, rejecting manual scaling of HPA-managed workloads unless the
+// caller explicitly forces it.
+type Scaler interface {
+	Scale(workloadKind, namespace, name string, replicas int32, force bool) (*autoscalingv1.Scale, error)
+}
+
+type workloadScaler struct {
+	client kubernetes.Interface
+}
+
+func NewScaler(client kubernetes.Interface) Scaler {
+	return &workloadScaler{client: client}
+}
+
+func (s *workloadScaler) Scale(workloadKind, namespace, name string, replicas int32, force bool) (*autoscalingv1.Scale, error) {
+	kind, ok := workloadKinds[workloadKind]
+	if !ok {
+		return nil, fmt.Errorf("workload kind %s does not support scaling", workloadKind)
+	}
+
+	if replicas < 0 {
+		return nil, fmt.Errorf("replicas must not be negative")
+	}
+
+	if !force {
+		if hpa, err := s.managingHPA(namespace, kind, name); err != nil {
+			return nil, err
+		} else if hpa != "" {
+			return nil, k8serr.NewConflict(schema.GroupResource{Group: "apps", Resource: workloadKind}, name,
+				fmt.Errorf("replicas are managed by HorizontalPodAutoscaler %s, scale with force=true to override", hpa))
+		}
+	}
+
+	scale, err := s.getScale(workloadKind, namespace, name)
+	if err != nil {
+		return nil, err
+	}
+
+	scale.Spec.Replicas = replicas
+	return s.updateScale(workloadKind, namespace, scale)
+}
+
+// managingHPA returns the name of the HorizontalPodAutoscaler targeting the
+// workload, empty if none does.
+func (s *workloadScaler) managingHPA(namespace, kind, name string) (string, error) {
+	hpas, err := s.client.AutoscalingV1().HorizontalPodAutoscalers(namespace).List(context.Background(), metav1.ListOptions{})
+	if err != nil {
+		return "", err
+	}
+	for _, hpa := range hpas.Items {
+		if hpa.Spec.ScaleTargetRef.Kind == kind && hpa.Spec.ScaleTargetRef.Name == name {
+			return hpa.Name, nil
+		}
+	}
+	return "", nil
+}
+
+func (s *workloadScaler) getScale(workloadKind, namespace, name string) (*autoscalingv1.Scale, error) {
+	switch workloadKind {
+	case KindDeployments:
+		return s.client.AppsV1().Deployments(namespace).GetScale(context.Background(), name, metav1.GetOptions{})
+	case KindStatefulSets:
+		return s.client.AppsV1().StatefulSets(namespace).GetScale(context.Background(), name, metav1.GetOptions{})
+	default:
+		return s.client.AppsV1().ReplicaSets(namespace).GetScale(context.Background(), name, metav1.GetOptions{})
+	}
+}
+
+func (s *workloadScaler) updateScale(workloadKind, namespace string, scale *autoscalingv1.Scale) (*autoscalingv1.Scale, error) {
+	switch workloadKind {
+	case KindDeployments:
+		return s.client.AppsV1().Deployments(namespace).UpdateScale(context.Background(), scale.Name, scale, metav1.UpdateOptions{})
+	case KindStatefulSets:
+		return s.client.AppsV1().StatefulSets(namespace).UpdateScale(context.Background(), scale.Name, scale, metav1.UpdateOptions{})
+	default:
+		return s.client.AppsV1().ReplicaSets(namespace).UpdateScale(context.Background(), scale.Name, scale, metav1.UpdateOptions{})
+	}
+}
@@ -0,0 +1,67 @@
+/*
+Copyright 2022 The KubeSphere Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package debug serves the Go runtime diagnostic endpoints (pprof and
+// expvar) on a dedicated listener, so memory and goroutine leaks can be
+// diagnosed in production without exposing them on the API port.
+package debug
+
+import (
+	"context"
+	"expvar"
+	"net/http"
+	"net/http/pprof"
+	"time"
+
+	"k8s.io/klog"
+)
+
+// NewHandler returns the mux serving the pprof and expvar endpoints,
+// callers are responsible for wrapping it with authentication and
+// authorization before exposing it beyond localhost.
+func NewHandler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/debug/pprof/", pprof.Index)
+	mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+	mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
+	mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+	mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+	mux.Handle("/debug/vars", expvar.Handler())
+	return mux
+}
+
+// RunServer serves the given handler on the configured bind address until
+// the stop channel is closed.
+func RunServer(options *Options, handler http.Handler, stopCh <-chan struct{}) {
+	server := &http.Server{
+		Addr:    options.BindAddress,
+		Handler: handler,
+	}
+
+	go func() {
+		<-stopCh
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := server.Shutdown(ctx); err != nil {
+			klog.Errorf("failed to shut down debug server: %v", err)
+		}
+	}()
+
+	klog.V(0).Infof("Start listening debug server on %s", options.BindAddress)
+	if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		klog.Errorf("debug server exited: %v", err)
+	}
+}
@@ -0,0 +1,68 @@
+/*
+Copyright 2022 The KubeSphere Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package debug
+
+import (
+	"fmt"
+	"net"
+
+	"github.com/spf13/pflag"
+)
+
+type Options struct {
+	// Enabled turns the debug server on, it is off by default.
+	Enabled bool `json:"enabled,omitempty" yaml:"enabled,omitempty"`
+	// BindAddress is the host:port the debug server listens on.
+	BindAddress string `json:"bindAddress,omitempty" yaml:"bindAddress,omitempty"`
+}
+
+func NewOptions() *Options {
+	return &Options{
+		Enabled:     false,
+		BindAddress: "127.0.0.1:6060",
+	}
+}
+
+func (o *Options) Validate() []error {
+	var errs []error
+	if !o.Enabled {
+		return errs
+	}
+	if _, _, err := net.SplitHostPort(o.BindAddress); err != nil {
+		errs = append(errs, fmt.Errorf("invalid debug server bind address %s: %v", o.BindAddress, err))
+	}
+	return errs
+}
+
+// IsLoopback reports whether the debug server only listens on a loopback
+// address, components without an authentication stack must not expose the
+// debug endpoints beyond localhost.
+func (o *Options) IsLoopback() bool {
+	host, _, err := net.SplitHostPort(o.BindAddress)
+	if err != nil {
+		return false
+	}
+	if ip := net.ParseIP(host); ip != nil {
+		return ip.IsLoopback()
+	}
+	return host == "localhost"
+}
+
+func (o *Options) AddFlags(fs *pflag.FlagSet, s *Options) {
+	fs.BoolVar(&o.Enabled, "debug-server", s.Enabled, "Enable the pprof/expvar debug server.")
+	fs.StringVar(&o.BindAddress, "debug-server-bind-address", s.BindAddress, "The host:port the debug server listens on.")
+}
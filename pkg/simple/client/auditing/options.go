@@ -39,13 +39,26 @@ type Options struct {
 	Password           string        `json:"password" yaml:"password"`
 	IndexPrefix        string        `json:"indexPrefix,omitempty" yaml:"indexPrefix,omitempty"`
 	Version            string        `json:"version" yaml:"version"`
+	// The method used to sign auditing event batches before they are sent
+	// to the webhook, hmac or rsa. Signing is disabled when left blank.
+	SigningMethod string `json:"signingMethod,omitempty" yaml:"signingMethod,omitempty"`
+	// The file holding the signing key, the shared secret for hmac or a
+	// PEM encoded RSA private key for rsa.
+	SigningKeyFile string `json:"signingKeyFile,omitempty" yaml:"signingKeyFile,omitempty"`
+	// Url prefixes whose requests are never audited, high-frequency
+	// endpoints such as health probes and metrics scrapes by default.
+	IgnoredUrls []string `json:"ignoredUrls,omitempty" yaml:"ignoredUrls,omitempty"`
+	// Verbs whose requests are never audited, watch by default.
+	IgnoredVerbs []string `json:"ignoredVerbs,omitempty" yaml:"ignoredVerbs,omitempty"`
 }
 
 func NewAuditingOptions() *Options {
 	return &Options{
-		Host:        "",
-		IndexPrefix: "ks-logstash-auditing",
-		Version:     "",
+		Host:         "",
+		IndexPrefix:  "ks-logstash-auditing",
+		Version:      "",
+		IgnoredUrls:  []string{"/healthz", "/readyz", "/metrics"},
+		IgnoredVerbs: []string{"watch"},
 	}
 }
 
@@ -97,4 +110,19 @@ func (s *Options) AddFlags(fs *pflag.FlagSet, c *Options) {
 	fs.StringVar(&s.Version, "auditing-elasticsearch-version", c.Version, ""+
 		"Elasticsearch major version, e.g. 5/6/7, if left blank, will detect automatically."+
 		"Currently, minimum supported version is 5.x")
+
+	fs.StringVar(&s.SigningMethod, "auditing-signing-method", c.SigningMethod, ""+
+		"The method used to sign auditing event batches before they are sent to the webhook, "+
+		"hmac or rsa. Signing is disabled when left blank.")
+
+	fs.StringVar(&s.SigningKeyFile, "auditing-signing-key-file", c.SigningKeyFile, ""+
+		"The file holding the auditing signing key, the shared secret for hmac or a "+
+		"PEM encoded RSA private key for rsa.")
+
+	fs.StringSliceVar(&s.IgnoredUrls, "auditing-ignored-urls", c.IgnoredUrls, ""+
+		"Url prefixes whose requests are never audited, so high-frequency endpoints such as "+
+		"health probes and metrics scrapes do not consume audit pipeline capacity.")
+
+	fs.StringSliceVar(&s.IgnoredVerbs, "auditing-ignored-verbs", c.IgnoredVerbs, ""+
+		"Verbs whose requests are never audited, e.g. watch.")
 }
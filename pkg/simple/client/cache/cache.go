@@ -50,7 +50,8 @@ type Interface interface {
 	Expire(key string, duration time.Duration) error
 }
 
-// DynamicOptions the options of the cache. For redis, options key can be  "host", "port", "db", "password".
+// DynamicOptions the options of the cache. For redis, options key can be  "host", "port", "db", "password",
+// plus "addrs", "masterName" and "cluster" for sentinel or cluster topologies.
 // For InMemoryCache, options key can be "cleanupperiod"
 type DynamicOptions map[string]interface{}
 
@@ -34,6 +34,12 @@ func NewCacheOptions() *Options {
 	}
 }
 
+// IsDistributed reports whether the configured cache backend is shared
+// between replicas, the in-memory cache is process local.
+func (r *Options) IsDistributed() bool {
+	return r.Type == typeRedis
+}
+
 // Validate check options
 func (r *Options) Validate() []error {
 	errors := make([]error, 0)
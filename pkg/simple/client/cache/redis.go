@@ -29,7 +29,7 @@ import (
 const typeRedis = "redis"
 
 type redisClient struct {
-	client *redis.Client
+	client redis.UniversalClient
 }
 
 // redisOptions used to create a redis client.
@@ -38,22 +38,49 @@ type redisOptions struct {
 	Port     int    `json:"port" yaml:"port" mapstructure:"port"`
 	Password string `json:"password" yaml:"password" mapstructure:"password"`
 	DB       int    `json:"db" yaml:"db" mapstructure:"db"`
+	// Addrs is the endpoint list for HA redis deployments, it takes precedence
+	// over Host and Port. For sentinel mode these are the sentinel addresses,
+	// for cluster mode the cluster node addresses.
+	Addrs []string `json:"addrs" yaml:"addrs" mapstructure:"addrs"`
+	// MasterName is the name of the sentinel monitored master,
+	// a non-empty value enables sentinel failover mode.
+	MasterName string `json:"masterName" yaml:"masterName" mapstructure:"mastername"`
+	// Cluster connects to a redis cluster instead of a single instance.
+	Cluster bool `json:"cluster" yaml:"cluster" mapstructure:"cluster"`
 }
 
 func NewRedisClient(option *redisOptions, stopCh <-chan struct{}) (Interface, error) {
 	var r redisClient
 
-	redisOptions := &redis.Options{
-		Addr:     fmt.Sprintf("%s:%d", option.Host, option.Port),
-		Password: option.Password,
-		DB:       option.DB,
+	addrs := option.Addrs
+	if len(addrs) == 0 {
+		addrs = []string{fmt.Sprintf("%s:%d", option.Host, option.Port)}
 	}
 
 	if stopCh == nil {
 		klog.Fatalf("no stop channel passed, redis connections will leak.")
 	}
 
-	r.client = redis.NewClient(redisOptions)
+	switch {
+	case option.MasterName != "":
+		r.client = redis.NewFailoverClient(&redis.FailoverOptions{
+			MasterName:    option.MasterName,
+			SentinelAddrs: addrs,
+			Password:      option.Password,
+			DB:            option.DB,
+		})
+	case option.Cluster:
+		r.client = redis.NewClusterClient(&redis.ClusterOptions{
+			Addrs:    addrs,
+			Password: option.Password,
+		})
+	default:
+		r.client = redis.NewClient(&redis.Options{
+			Addr:     addrs[0],
+			Password: option.Password,
+			DB:       option.DB,
+		})
+	}
 
 	if err := r.client.Ping().Err(); err != nil {
 		r.client.Close()
@@ -113,11 +140,13 @@ func (rf *redisFactory) Create(options DynamicOptions, stopCh <-chan struct{}) (
 	if err := mapstructure.Decode(options, &rOptions); err != nil {
 		return nil, err
 	}
-	if rOptions.Port == 0 {
-		return nil, errors.New("invalid service port number")
-	}
-	if len(rOptions.Host) == 0 {
-		return nil, errors.New("invalid service host")
+	if len(rOptions.Addrs) == 0 {
+		if rOptions.Port == 0 {
+			return nil, errors.New("invalid service port number")
+		}
+		if len(rOptions.Host) == 0 {
+			return nil, errors.New("invalid service host")
+		}
 	}
 	client, err := NewRedisClient(&rOptions, stopCh)
 	if err != nil {
@@ -163,6 +163,19 @@ func NewFakeDevops(data map[string]interface{}) *Devops {
 	return &fakeData
 }
 
+// Build retention operator interface
+func (d *Devops) ListProjectPipelineBuilds(projectId, pipelineId string) ([]devops.BuildSummary, error) {
+	return nil, nil
+}
+
+func (d *Devops) GetProjectPipelineBuildLog(projectId, pipelineId string, number int64) ([]byte, error) {
+	return nil, nil
+}
+
+func (d *Devops) DeleteProjectPipelineBuild(projectId, pipelineId string, number int64) error {
+	return nil
+}
+
 // Pipelinne operator interface
 func (d *Devops) GetPipeline(projectName, pipelineName string, httpParameters *devops.HttpParameters) (*devops.Pipeline, error) {
 	return nil, nil
@@ -29,6 +29,8 @@ type Interface interface {
 
 	BuildGetter
 
+	BuildRetentionOperator
+
 	PipelineOperator
 
 	ProjectPipelineOperator
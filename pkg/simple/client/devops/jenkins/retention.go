@@ -0,0 +1,77 @@
+/*
+Copyright 2022 KubeSphere Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package jenkins
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/emicklei/go-restful"
+
+	"kubesphere.io/kubesphere/pkg/simple/client/devops"
+)
+
+func (j *Jenkins) ListProjectPipelineBuilds(projectId, pipelineId string) ([]devops.BuildSummary, error) {
+	job, err := j.GetJob(pipelineId, projectId)
+	if err != nil {
+		return nil, restful.NewError(devops.GetDevOpsStatusCode(err), err.Error())
+	}
+	var buildsResp struct {
+		Builds []devops.BuildSummary `json:"allBuilds"`
+	}
+	rsp, err := j.Requester.GetJSON(job.Base, &buildsResp, map[string]string{"tree": "allBuilds[number,building,result,timestamp]"})
+	if err != nil {
+		return nil, err
+	}
+	rsp.Body.Close()
+	return buildsResp.Builds, nil
+}
+
+func (j *Jenkins) GetProjectPipelineBuildLog(projectId, pipelineId string, number int64) ([]byte, error) {
+	job, err := j.GetJob(pipelineId, projectId)
+	if err != nil {
+		return nil, restful.NewError(devops.GetDevOpsStatusCode(err), err.Error())
+	}
+	var content string
+	rsp, err := j.Requester.GetXML(fmt.Sprintf("%s/%d/consoleText", job.Base, number), &content, nil)
+	if err != nil {
+		return nil, err
+	}
+	rsp.Body.Close()
+	if rsp.StatusCode != http.StatusOK {
+		return nil, errors.New(strconv.Itoa(rsp.StatusCode))
+	}
+	return []byte(content), nil
+}
+
+func (j *Jenkins) DeleteProjectPipelineBuild(projectId, pipelineId string, number int64) error {
+	job, err := j.GetJob(pipelineId, projectId)
+	if err != nil {
+		return restful.NewError(devops.GetDevOpsStatusCode(err), err.Error())
+	}
+	rsp, err := j.Requester.Post(fmt.Sprintf("%s/%d/doDelete", job.Base, number), nil, nil, nil)
+	if err != nil {
+		return err
+	}
+	rsp.Body.Close()
+	if rsp.StatusCode != http.StatusOK {
+		return errors.New(strconv.Itoa(rsp.StatusCode))
+	}
+	return nil
+}
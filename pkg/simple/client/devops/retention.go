@@ -0,0 +1,39 @@
+/*
+Copyright 2022 KubeSphere Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package devops
+
+// BuildSummary is the minimal record of a pipeline run kept for retention
+// decisions, Timestamp is milliseconds since the epoch.
+type BuildSummary struct {
+	Number    int64  `json:"number"`
+	Building  bool   `json:"building"`
+	Result    string `json:"result"`
+	Timestamp int64  `json:"timestamp"`
+}
+
+// BuildRetentionOperator cleans up expired pipeline runs, it is driven by
+// the retention policy annotations of a devops project.
+type BuildRetentionOperator interface {
+	// ListProjectPipelineBuilds lists all builds of the pipeline.
+	ListProjectPipelineBuilds(projectId, pipelineId string) ([]BuildSummary, error)
+
+	// GetProjectPipelineBuildLog gets the console log of a build for archival.
+	GetProjectPipelineBuildLog(projectId, pipelineId string, number int64) ([]byte, error)
+
+	// DeleteProjectPipelineBuild deletes a build and its log.
+	DeleteProjectPipelineBuild(projectId, pipelineId string, number int64) error
+}
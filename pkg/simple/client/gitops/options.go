@@ -0,0 +1,89 @@
+/*
+Copyright 2022 The KubeSphere Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitops
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/pflag"
+
+	"kubesphere.io/kubesphere/pkg/utils/reflectutils"
+)
+
+// Options describe the git repository platform configuration is
+// reconciled from.
+type Options struct {
+	Repository   string        `json:"repository,omitempty" yaml:"repository,omitempty"`
+	Branch       string        `json:"branch,omitempty" yaml:"branch,omitempty"`
+	Path         string        `json:"path,omitempty" yaml:"path,omitempty"`
+	Username     string        `json:"username,omitempty" yaml:"username,omitempty"`
+	Password     string        `json:"-" yaml:"password,omitempty"`
+	PollInterval time.Duration `json:"pollInterval,omitempty" yaml:"pollInterval,omitempty"`
+}
+
+// NewGitOpsOptions creates a default disabled Options(empty repository)
+func NewGitOpsOptions() *Options {
+	return &Options{
+		Repository:   "",
+		Branch:       "main",
+		Path:         "",
+		PollInterval: 5 * time.Minute,
+	}
+}
+
+// Validate check options values
+func (s *Options) Validate() []error {
+	var errors []error
+
+	if s.Repository != "" && s.PollInterval <= 0 {
+		errors = append(errors, fmt.Errorf("gitops poll interval must be positive"))
+	}
+
+	return errors
+}
+
+// ApplyTo overrides options if it's valid, which repository is not empty
+func (s *Options) ApplyTo(options *Options) {
+	if s.Repository != "" {
+		reflectutils.Override(options, s)
+	}
+}
+
+// AddFlags add options flags to command line flags,
+// if gitops-repository is left empty, platform configuration stays
+// managed through the API and no reconciliation happens.
+func (s *Options) AddFlags(fs *pflag.FlagSet, c *Options) {
+	fs.StringVar(&s.Repository, "gitops-repository", c.Repository, ""+
+		"Git repository platform configuration is reconciled from, if left blank "+
+		"the following options will be ignored and the gitops mode is disabled.")
+
+	fs.StringVar(&s.Branch, "gitops-branch", c.Branch, ""+
+		"Branch of the repository to reconcile from.")
+
+	fs.StringVar(&s.Path, "gitops-path", c.Path, ""+
+		"Directory inside the repository holding the manifests, defaults to the repository root.")
+
+	fs.StringVar(&s.Username, "gitops-username", c.Username, ""+
+		"Username used to clone the repository, anonymous when left blank.")
+
+	fs.StringVar(&s.Password, "gitops-password", c.Password, ""+
+		"Password or access token used to clone the repository.")
+
+	fs.DurationVar(&s.PollInterval, "gitops-poll-interval", c.PollInterval, ""+
+		"How often the repository is fetched and reconciled.")
+}
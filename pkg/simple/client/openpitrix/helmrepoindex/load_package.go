@@ -18,6 +18,7 @@ package helmrepoindex
 
 import (
 	"bytes"
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"strings"
@@ -29,6 +30,8 @@ import (
 	"k8s.io/klog"
 
 	"helm.sh/helm/v3/pkg/chart/loader"
+
+	"kubesphere.io/kubesphere/pkg/utils/stringutils"
 )
 
 func LoadPackage(pkg []byte) (VersionInterface, error) {
@@ -40,6 +43,51 @@ func LoadPackage(pkg []byte) (VersionInterface, error) {
 	return HelmVersionWrapper{ChartVersion: &repo.ChartVersion{Metadata: p.Metadata}}, nil
 }
 
+// ChartExtras holds auxiliary files extracted from a chart package.
+type ChartExtras struct {
+	Readme        string
+	Icon          string
+	DefaultValues string
+}
+
+// LoadChartExtras extracts README.md, the icon and the default values.yaml
+// from a chart package. Text files are truncated to v1alpha1.ChartFileLen
+// and an oversized bundled icon is dropped, so the extracted content is
+// small enough to live in the app version status.
+func LoadChartExtras(pkg []byte) (*ChartExtras, error) {
+	p, err := loader.LoadArchive(bytes.NewReader(pkg))
+	if err != nil {
+		klog.Errorf("Failed to load package, error: %+v", err)
+		return nil, err
+	}
+
+	extras := &ChartExtras{Icon: p.Metadata.Icon}
+	for _, f := range p.Files {
+		if f.Name == "README.md" {
+			extras.Readme = stringutils.ShortenString(string(f.Data), v1alpha1.ChartFileLen)
+			break
+		}
+	}
+	for _, f := range p.Raw {
+		if f.Name == "values.yaml" {
+			extras.DefaultValues = stringutils.ShortenString(string(f.Data), v1alpha1.ChartFileLen)
+			break
+		}
+	}
+
+	// some charts bundle the icon file instead of referencing a public url
+	if name := strings.TrimPrefix(p.Metadata.Icon, "file://"); name != p.Metadata.Icon {
+		for _, f := range p.Files {
+			if f.Name == name && len(f.Data) <= v1alpha1.ChartFileLen {
+				extras.Icon = base64.StdEncoding.EncodeToString(f.Data)
+				break
+			}
+		}
+	}
+
+	return extras, nil
+}
+
 type HelmVersionWrapper struct {
 	*repo.ChartVersion
 }
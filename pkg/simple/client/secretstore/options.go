@@ -0,0 +1,85 @@
+/*
+Copyright 2022 The KubeSphere Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secretstore
+
+import (
+	"fmt"
+	"net/url"
+
+	"github.com/spf13/pflag"
+
+	"kubesphere.io/kubesphere/pkg/utils/reflectutils"
+)
+
+// Options contains configuration to access an external secret store,
+// a Vault server with a KV version 2 secrets engine mounted.
+type Options struct {
+	Endpoint   string `json:"endpoint,omitempty" yaml:"endpoint,omitempty"`
+	Token      string `json:"-" yaml:"token,omitempty"`
+	Mount      string `json:"mount,omitempty" yaml:"mount,omitempty"`
+	PathPrefix string `json:"pathPrefix,omitempty" yaml:"pathPrefix,omitempty"`
+}
+
+// NewSecretStoreOptions creates a default disabled Options(empty endpoint)
+func NewSecretStoreOptions() *Options {
+	return &Options{
+		Endpoint:   "",
+		Mount:      "secret",
+		PathPrefix: "kubesphere",
+	}
+}
+
+// Validate check options values
+func (s *Options) Validate() []error {
+	var errors []error
+
+	if s.Endpoint != "" {
+		if _, err := url.Parse(s.Endpoint); err != nil {
+			errors = append(errors, fmt.Errorf("invalid secret store endpoint: %v", err))
+		}
+		if s.Token == "" {
+			errors = append(errors, fmt.Errorf("secret store token must be set when an endpoint is configured"))
+		}
+	}
+
+	return errors
+}
+
+// ApplyTo overrides options if it's valid, which endpoint is not empty
+func (s *Options) ApplyTo(options *Options) {
+	if s.Endpoint != "" {
+		reflectutils.Override(options, s)
+	}
+}
+
+// AddFlags add options flags to command line flags,
+// if secret-store-endpoint is left empty, the secret store is disabled
+// and credential references cannot be resolved.
+func (s *Options) AddFlags(fs *pflag.FlagSet, c *Options) {
+	fs.StringVar(&s.Endpoint, "secret-store-endpoint", c.Endpoint, ""+
+		"Endpoint of the external secret store(Vault), if left blank, the following options "+
+		"will be ignored and managed credentials are kept inline.")
+
+	fs.StringVar(&s.Token, "secret-store-token", c.Token, ""+
+		"Token used to authenticate against the secret store.")
+
+	fs.StringVar(&s.Mount, "secret-store-mount", c.Mount, ""+
+		"Mount path of the KV version 2 secrets engine.")
+
+	fs.StringVar(&s.PathPrefix, "secret-store-path-prefix", c.PathPrefix, ""+
+		"Path prefix under the mount where KubeSphere keeps its credentials.")
+}
@@ -0,0 +1,150 @@
+/*
+Copyright 2022 The KubeSphere Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secretstore
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// ReferencePrefix marks a credential field that holds a pointer into the
+// external secret store instead of the value itself, e.g.
+// secretstore://ldap/manager-password.
+const ReferencePrefix = "secretstore://"
+
+// IsReference tells whether value points into the external secret store.
+func IsReference(value string) bool {
+	return strings.HasPrefix(value, ReferencePrefix)
+}
+
+// Interface stores sensitive values in an external secret store so that
+// only opaque references end up in configuration and custom resources.
+type Interface interface {
+	// Store writes the value under path and returns the reference
+	// to persist in its place.
+	Store(path, value string) (string, error)
+	// Resolve returns the stored value a reference points to, values
+	// that are not references pass through unchanged.
+	Resolve(value string) (string, error)
+	// Delete removes the stored value the reference points to.
+	Delete(reference string) error
+}
+
+// vaultStore talks to the Vault KV version 2 HTTP API directly,
+// one value per path under the key "value".
+type vaultStore struct {
+	options *Options
+	client  *http.Client
+}
+
+// NewSecretStoreClient creates a client for the configured secret store,
+// the endpoint must not be empty.
+func NewSecretStoreClient(options *Options) (Interface, error) {
+	if options == nil || options.Endpoint == "" {
+		return nil, fmt.Errorf("secret store endpoint is empty")
+	}
+	return &vaultStore{
+		options: options,
+		client:  &http.Client{Timeout: 10 * time.Second},
+	}, nil
+}
+
+func (v *vaultStore) Store(path, value string) (string, error) {
+	body, err := json.Marshal(map[string]interface{}{
+		"data": map[string]string{"value": value},
+	})
+	if err != nil {
+		return "", err
+	}
+	if _, err = v.do(http.MethodPost, v.url("data", path), bytes.NewReader(body)); err != nil {
+		return "", err
+	}
+	return ReferencePrefix + path, nil
+}
+
+func (v *vaultStore) Resolve(value string) (string, error) {
+	if !IsReference(value) {
+		return value, nil
+	}
+	path := strings.TrimPrefix(value, ReferencePrefix)
+
+	data, err := v.do(http.MethodGet, v.url("data", path), nil)
+	if err != nil {
+		return "", err
+	}
+
+	// KV version 2 wraps the stored key value pairs in data.data
+	var response struct {
+		Data struct {
+			Data map[string]string `json:"data"`
+		} `json:"data"`
+	}
+	if err = json.Unmarshal(data, &response); err != nil {
+		return "", fmt.Errorf("unexpected response from secret store: %v", err)
+	}
+	resolved, ok := response.Data.Data["value"]
+	if !ok {
+		return "", fmt.Errorf("secret store entry %s holds no value", path)
+	}
+	return resolved, nil
+}
+
+func (v *vaultStore) Delete(reference string) error {
+	if !IsReference(reference) {
+		return fmt.Errorf("%s is not a secret store reference", reference)
+	}
+	path := strings.TrimPrefix(reference, ReferencePrefix)
+
+	// deleting the metadata removes all versions of the entry
+	_, err := v.do(http.MethodDelete, v.url("metadata", path), nil)
+	return err
+}
+
+// url builds the KV version 2 endpoint for the entry, kind is data for
+// reads and writes, metadata for deletion.
+func (v *vaultStore) url(kind, path string) string {
+	return fmt.Sprintf("%s/v1/%s/%s/%s/%s",
+		strings.TrimSuffix(v.options.Endpoint, "/"), v.options.Mount, kind, v.options.PathPrefix, path)
+}
+
+func (v *vaultStore) do(method, url string, body io.Reader) ([]byte, error) {
+	request, err := http.NewRequest(method, url, body)
+	if err != nil {
+		return nil, err
+	}
+	request.Header.Set("X-Vault-Token", v.options.Token)
+
+	response, err := v.client.Do(request)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	data, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, err
+	}
+	if response.StatusCode < http.StatusOK || response.StatusCode >= http.StatusMultipleChoices {
+		return nil, fmt.Errorf("secret store answered %s to %s %s", response.Status, method, url)
+	}
+	return data, nil
+}
@@ -25,6 +25,9 @@ import (
 
 type SonarInterface interface {
 	GetSonarResultsByTaskIds(taskId ...string) ([]*SonarStatus, error)
+	// GetQualityGateByTaskId retrieves the quality gate status of the analysis
+	// triggered by the given compute engine task.
+	GetQualityGateByTaskId(taskId string) (*sonargo.QualitygatesProjectStatusObject, error)
 }
 
 type sonarQube struct {
@@ -42,10 +45,11 @@ const (
 )
 
 type SonarStatus struct {
-	Measures      *sonargo.MeasuresComponentObject `json:"measures,omitempty"`
-	Issues        *sonargo.IssuesSearchObject      `json:"issues,omitempty"`
-	GeneralAction *devops.GeneralAction            `json:"generalAction,omitempty"`
-	Task          *sonargo.CeTaskObject            `json:"task,omitempty"`
+	Measures      *sonargo.MeasuresComponentObject         `json:"measures,omitempty"`
+	Issues        *sonargo.IssuesSearchObject              `json:"issues,omitempty"`
+	GeneralAction *devops.GeneralAction                    `json:"generalAction,omitempty"`
+	Task          *sonargo.CeTaskObject                    `json:"task,omitempty"`
+	QualityGate   *sonargo.QualitygatesProjectStatusObject `json:"qualityGate,omitempty"`
 }
 
 func (s *sonarQube) GetSonarResultsByTaskIds(taskIds ...string) ([]*SonarStatus, error) {
@@ -83,7 +87,34 @@ func (s *sonarQube) GetSonarResultsByTaskIds(taskIds ...string) ([]*SonarStatus,
 		}
 		issuesSearch, _, _ := s.client.Issues.Search(issuesSearchOption)
 		sonarStatus.Issues = issuesSearch
+
+		if len(ceTask.Task.AnalysisID) != 0 {
+			qualityGate, _, err := s.client.Qualitygates.ProjectStatus(&sonargo.QualitygatesProjectStatusOption{
+				AnalysisId: ceTask.Task.AnalysisID,
+			})
+			if err != nil {
+				klog.Errorf("get sonar quality gate status error [%+v]", err)
+			} else {
+				sonarStatus.QualityGate = qualityGate
+			}
+		}
 		sonarStatuses = append(sonarStatuses, sonarStatus)
 	}
 	return sonarStatuses, nil
 }
+
+func (s *sonarQube) GetQualityGateByTaskId(taskId string) (*sonargo.QualitygatesProjectStatusObject, error) {
+	ceTask, _, err := s.client.Ce.Task(&sonargo.CeTaskOption{Id: taskId})
+	if err != nil {
+		klog.Errorf("get sonar task error [%+v]", err)
+		return nil, err
+	}
+	qualityGate, _, err := s.client.Qualitygates.ProjectStatus(&sonargo.QualitygatesProjectStatusOption{
+		AnalysisId: ceTask.Task.AnalysisID,
+	})
+	if err != nil {
+		klog.Errorf("get sonar quality gate status error [%+v]", err)
+		return nil, err
+	}
+	return qualityGate, nil
+}
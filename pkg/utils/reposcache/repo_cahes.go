@@ -58,6 +58,7 @@ type ReposCache interface {
 	DeleteRepo(repo *v1alpha1.HelmRepo) error
 	UpdateRepo(old, new *v1alpha1.HelmRepo) error
 
+	GetRepo(repoId string) (*v1alpha1.HelmRepo, bool)
 	GetApplication(string) (*v1alpha1.HelmApplication, bool)
 	GetAppVersion(string) (*v1alpha1.HelmApplicationVersion, bool, error)
 	GetAppVersionWithData(string) (*v1alpha1.HelmApplicationVersion, bool, error)
@@ -168,6 +169,15 @@ func (c *cachedRepos) translateCategoryNameToId(ctgName string) string {
 	}
 }
 
+func (c *cachedRepos) GetRepo(repoId string) (repo *v1alpha1.HelmRepo, exists bool) {
+	c.RLock()
+	defer c.RUnlock()
+	if repo, exists := c.repos[repoId]; exists {
+		return repo, true
+	}
+	return
+}
+
 func (c *cachedRepos) GetApplication(appId string) (app *v1alpha1.HelmApplication, exists bool) {
 	c.RLock()
 	defer c.RUnlock()
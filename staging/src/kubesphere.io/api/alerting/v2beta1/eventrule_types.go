@@ -0,0 +1,108 @@
+/*
+Copyright 2020 KubeSphere Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v2beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	ResourceKindEventRule     = "EventRule"
+	ResourceSingularEventRule = "eventrule"
+	ResourcePluralEventRule   = "eventrules"
+)
+
+// EventRuleSpec selects kubernetes events by regular expressions and
+// defines how often they must occur before the rule fires.
+type EventRuleSpec struct {
+	// Reason is a regular expression matched against the event reason,
+	// e.g. BackOff or FailedScheduling. Empty matches any reason.
+	// +optional
+	Reason string `json:"reason,omitempty"`
+
+	// Kind is a regular expression matched against the kind of the object
+	// the event is about. Empty matches any kind.
+	// +optional
+	Kind string `json:"kind,omitempty"`
+
+	// Namespace is a regular expression matched against the namespace of
+	// the object the event is about. Empty matches any namespace.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+
+	// Type restricts the rule to events of this type.
+	// +kubebuilder:validation:Enum=Normal;Warning
+	// +optional
+	Type string `json:"type,omitempty"`
+
+	// Count is how many matching events must be observed within Window
+	// before the rule fires, defaults to 1.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	Count int32 `json:"count,omitempty"`
+
+	// Window is the sliding window the Count threshold is evaluated over,
+	// defaults to 5m.
+	// +optional
+	Window Duration `json:"window,omitempty"`
+
+	// Severity is attached to the dispatched notification.
+	// +kubebuilder:validation:Enum=critical;error;warning
+	// +optional
+	Severity Severity `json:"severity,omitempty"`
+}
+
+// EventRuleStatus records what the rule has fired.
+type EventRuleStatus struct {
+	// FiredCount is the number of times the rule fired.
+	// +optional
+	FiredCount int32 `json:"firedCount,omitempty"`
+
+	// LastFiredTime is when the rule last fired.
+	// +optional
+	LastFiredTime *metav1.Time `json:"lastFiredTime,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+// +genclient
+// +genclient:nonNamespaced
+// +kubebuilder:resource:scope=Cluster
+
+// EventRule fires notifications when kubernetes events matching its
+// expressions exceed a rate threshold, so common conditions like
+// CrashLoopBackOff alert without a prometheus rule.
+type EventRule struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   EventRuleSpec   `json:"spec,omitempty"`
+	Status EventRuleStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// EventRuleList contains a list of EventRule
+type EventRuleList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []EventRule `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&EventRule{}, &EventRuleList{})
+}
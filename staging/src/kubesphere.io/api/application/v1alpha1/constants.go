@@ -19,6 +19,8 @@ package v1alpha1
 const (
 	MsgLen               = 512
 	HelmRepoSyncStateLen = 10
+	// max len in bytes of a single chart file saved into the app version status
+	ChartFileLen = 64 * 1024
 
 	// app version state
 	StateDraft     = "draft"
@@ -28,6 +30,11 @@ const (
 	StateSuspended = "suspended"
 	StateActive    = "active"
 
+	// repo visibility
+	RepoVisibilityGlobal    = "global"
+	RepoVisibilityWorkspace = "workspace"
+	RepoVisibilityPrivate   = "private"
+
 	// repo state
 	RepoStateSuccessful = "successful"
 	RepoStateFailed     = "failed"
@@ -62,4 +69,12 @@ const (
 
 	RepoSyncPeriod          = "app.kubesphere.io/sync-period"
 	OriginWorkspaceLabelKey = "kubesphere.io/workspace-origin"
+
+	// AppReviewerRoleAnnotation marks a global role whose members review
+	// app store submissions, members of platform-admin always do.
+	AppReviewerRoleAnnotation = "application.kubesphere.io/app-reviewer"
+
+	// PendingReviewersAnnotationKey lists the reviewers a submitted app
+	// version is waiting on, maintained by the version controller.
+	PendingReviewersAnnotationKey = "application.kubesphere.io/pending-reviewers"
 )
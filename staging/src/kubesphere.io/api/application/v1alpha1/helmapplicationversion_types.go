@@ -54,6 +54,14 @@ type HelmApplicationVersionSpec struct {
 type HelmApplicationVersionStatus struct {
 	State string  `json:"state,omitempty"`
 	Audit []Audit `json:"audit,omitempty"`
+
+	// readme extracted from the chart package, may be truncated
+	Readme string `json:"readme,omitempty"`
+	// icon url from the chart metadata, or the base64 encoded icon file
+	// bundled in the chart package
+	Icon string `json:"icon,omitempty"`
+	// default values.yaml extracted from the chart package, may be truncated
+	DefaultValues string `json:"defaultValues,omitempty"`
 }
 
 // +kubebuilder:object:root=true
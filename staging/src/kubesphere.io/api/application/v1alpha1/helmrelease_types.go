@@ -28,6 +28,13 @@ const (
 	ResourceKindHelmRelease     = "HelmRelease"
 	ResourceSingularHelmRelease = "helmrelease"
 	ResourcePluralHelmRelease   = "helmreleases"
+
+	// HelmReleaseLastOperatorAnnotationKey records who submitted the values
+	// of the pending spec version, the deploy history keeps it per revision.
+	HelmReleaseLastOperatorAnnotationKey = "application.kubesphere.io/last-operator"
+
+	// MaxValuesHistory is how many values revisions are kept in the status.
+	MaxValuesHistory = 10
 )
 
 // HelmReleaseSpec defines the desired state of HelmRelease
@@ -64,6 +71,19 @@ type HelmReleaseDeployStatus struct {
 	Time metav1.Time `json:"deployTime"`
 }
 
+// HelmReleaseValuesRevision records the values a spec version was deployed
+// with, so upgrades can be audited and revisions diffed.
+type HelmReleaseValuesRevision struct {
+	// spec version the values were deployed with
+	Version int `json:"version"`
+	// helm release values.yaml used by the deploy
+	Values []byte `json:"values,omitempty"`
+	// operator who submitted the values
+	Operator string `json:"operator,omitempty"`
+	// deploy time of this revision
+	Time metav1.Time `json:"time"`
+}
+
 // HelmReleaseStatus defines the observed state of HelmRelease
 type HelmReleaseStatus struct {
 	// current state
@@ -74,6 +94,8 @@ type HelmReleaseStatus struct {
 	Version int `json:"version,omitempty"`
 	// deploy status list of history, which will store at most 10 state
 	DeployStatus []HelmReleaseDeployStatus `json:"deployStatus,omitempty"`
+	// values used by each deployed version, at most 10 revisions are kept
+	ValuesHistory []HelmReleaseValuesRevision `json:"valuesHistory,omitempty"`
 	// last update time
 	LastUpdate metav1.Time `json:"lastUpdate,omitempty"`
 	// last deploy time or upgrade time
@@ -62,6 +62,8 @@ type HelmRepoSpec struct {
 	Description string `json:"description,omitempty"`
 	// sync period in seconds, no sync when SyncPeriod=0, the minimum SyncPeriod is 180s
 	SyncPeriod int `json:"syncPeriod,omitempty"`
+	// visibility of the repo, valid values are "global", "workspace" and "private"
+	Visibility string `json:"visibility,omitempty"`
 	// expected repo version, when this version is not equal status.version, the repo need upgrade
 	// this filed should be modified when any filed of the spec modified.
 	Version int `json:"version,omitempty"`
@@ -139,3 +141,16 @@ func (in *HelmRepo) GetWorkspace() string {
 func (in *HelmRepo) GetCreator() string {
 	return getValue(in.Annotations, constants.CreatorAnnotationKey)
 }
+
+// GetVisibility returns the repo visibility. Repos without an explicit
+// visibility keep the legacy behavior: global when not bound to a
+// workspace, workspace scoped otherwise.
+func (in *HelmRepo) GetVisibility() string {
+	if in.Spec.Visibility != "" {
+		return in.Spec.Visibility
+	}
+	if in.GetWorkspace() == "" {
+		return RepoVisibilityGlobal
+	}
+	return RepoVisibilityWorkspace
+}
@@ -472,6 +472,13 @@ func (in *HelmReleaseStatus) DeepCopyInto(out *HelmReleaseStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.ValuesHistory != nil {
+		in, out := &in.ValuesHistory, &out.ValuesHistory
+		*out = make([]HelmReleaseValuesRevision, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	in.LastUpdate.DeepCopyInto(&out.LastUpdate)
 	if in.LastDeployed != nil {
 		in, out := &in.LastDeployed, &out.LastDeployed
@@ -489,6 +496,27 @@ func (in *HelmReleaseStatus) DeepCopy() *HelmReleaseStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HelmReleaseValuesRevision) DeepCopyInto(out *HelmReleaseValuesRevision) {
+	*out = *in
+	if in.Values != nil {
+		in, out := &in.Values, &out.Values
+		*out = make([]byte, len(*in))
+		copy(*out, *in)
+	}
+	in.Time.DeepCopyInto(&out.Time)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HelmReleaseValuesRevision.
+func (in *HelmReleaseValuesRevision) DeepCopy() *HelmReleaseValuesRevision {
+	if in == nil {
+		return nil
+	}
+	out := new(HelmReleaseValuesRevision)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *HelmRepo) DeepCopyInto(out *HelmRepo) {
 	*out = *in
@@ -0,0 +1,138 @@
+/*
+Copyright 2022 The KubeSphere Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	ResourceKindScheduledScaling     = "ScheduledScaling"
+	ResourceSingularScheduledScaling = "scheduledscaling"
+	ResourcePluralScheduledScaling   = "scheduledscalings"
+)
+
+// HPAConflictPolicy decides what happens when a selected workload is also
+// managed by a HorizontalPodAutoscaler.
+type HPAConflictPolicy string
+
+const (
+	// HPAConflictSkip leaves HPA-managed workloads alone.
+	HPAConflictSkip HPAConflictPolicy = "Skip"
+	// HPAConflictOverride scales HPA-managed workloads anyway, the HPA may
+	// scale them back within its own bounds afterwards.
+	HPAConflictOverride HPAConflictPolicy = "Override"
+)
+
+// ScalingSchedule scales the selected workloads to a fixed replica count
+// whenever the cron expression fires.
+type ScalingSchedule struct {
+	// Cron is a standard five-field cron expression evaluated in the time
+	// zone of the spec.
+	Cron string `json:"cron"`
+
+	// TargetReplicas is the replica count applied when the schedule fires.
+	// +kubebuilder:validation:Minimum=0
+	TargetReplicas int32 `json:"targetReplicas"`
+}
+
+// ScheduledScalingSpec defines the desired time-based scaling behavior.
+type ScheduledScalingSpec struct {
+	// Selector selects the deployments and statefulsets of the namespace
+	// the schedules apply to.
+	Selector *metav1.LabelSelector `json:"selector"`
+
+	// Schedules are evaluated independently, when several fire at the same
+	// instant the last one in the list wins.
+	Schedules []ScalingSchedule `json:"schedules"`
+
+	// TimeZone the cron expressions and the exclusion calendar are
+	// interpreted in, e.g. Asia/Shanghai, defaults to UTC.
+	// +optional
+	TimeZone string `json:"timeZone,omitempty"`
+
+	// ExcludeDates lists days, formatted as 2006-01-02, on which no
+	// schedule fires, e.g. release freezes or public holidays.
+	// +optional
+	ExcludeDates []string `json:"excludeDates,omitempty"`
+
+	// HPAConflictPolicy decides what happens when a selected workload is
+	// also managed by a HorizontalPodAutoscaler, defaults to Skip.
+	// +kubebuilder:validation:Enum=Skip;Override
+	// +optional
+	HPAConflictPolicy HPAConflictPolicy `json:"hpaConflictPolicy,omitempty"`
+
+	// Paused suspends the schedules without deleting the object.
+	// +optional
+	Paused bool `json:"paused,omitempty"`
+}
+
+// ScheduledScalingStatus records the last run of the schedules.
+type ScheduledScalingStatus struct {
+	// LastScheduleTime is the last time a schedule fired.
+	// +optional
+	LastScheduleTime *metav1.Time `json:"lastScheduleTime,omitempty"`
+
+	// LastAppliedReplicas is the replica count applied by the last run.
+	// +optional
+	LastAppliedReplicas *int32 `json:"lastAppliedReplicas,omitempty"`
+
+	// ScaledWorkloads lists the workloads the last run changed, as
+	// kind/name.
+	// +optional
+	ScaledWorkloads []string `json:"scaledWorkloads,omitempty"`
+
+	// SkippedWorkloads lists the workloads the last run left alone because
+	// of an HPA conflict, as kind/name.
+	// +optional
+	SkippedWorkloads []string `json:"skippedWorkloads,omitempty"`
+}
+
+// +genclient
+// +kubebuilder:object:root=true
+// +k8s:openapi-gen=true
+// +kubebuilder:resource:categories="autoscaling",path=scheduledscalings
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Paused",type="boolean",JSONPath=".spec.paused"
+// +kubebuilder:printcolumn:name="LastSchedule",type="date",JSONPath=".status.lastScheduleTime"
+
+// ScheduledScaling scales the selected workloads of its namespace to fixed
+// replica counts on cron schedules, e.g. development environments down to
+// zero outside working hours.
+type ScheduledScaling struct {
+	metav1.TypeMeta `json:",inline"`
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec ScheduledScalingSpec `json:"spec"`
+
+	// +optional
+	Status ScheduledScalingStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ScheduledScalingList contains a list of ScheduledScaling
+type ScheduledScalingList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ScheduledScaling `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ScheduledScaling{}, &ScheduledScalingList{})
+}
@@ -0,0 +1,88 @@
+/*
+Copyright 2022 The KubeSphere Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	ResourceKindCSRApprovalPolicy     = "CSRApprovalPolicy"
+	ResourceSingularCSRApprovalPolicy = "csrapprovalpolicy"
+	ResourcePluralCSRApprovalPolicy   = "csrapprovalpolicies"
+)
+
+// CSRApprovalPolicySpec describes which pending certificate signing
+// requests may be approved without an operator stepping in.
+type CSRApprovalPolicySpec struct {
+	// Requesters are usernames whose requests are approved automatically.
+	// +optional
+	Requesters []string `json:"requesters,omitempty"`
+
+	// Groups approve the requests of any of their members automatically.
+	// +optional
+	Groups []string `json:"groups,omitempty"`
+
+	// SignerNames restricts the policy to requests for these signers,
+	// empty means any signer.
+	// +optional
+	SignerNames []string `json:"signerNames,omitempty"`
+}
+
+// CSRApprovalPolicyStatus records what the policy has approved.
+type CSRApprovalPolicyStatus struct {
+	// ApprovedCount is the number of requests this policy approved.
+	// +optional
+	ApprovedCount int32 `json:"approvedCount,omitempty"`
+
+	// LastApprovedTime is when the policy last approved a request.
+	// +optional
+	LastApprovedTime *metav1.Time `json:"lastApprovedTime,omitempty"`
+}
+
+// +genclient
+// +genclient:nonNamespaced
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:subresource:status
+
+// CSRApprovalPolicy automatically approves certificate signing requests
+// of known requesters.
+// +kubebuilder:printcolumn:name="Requesters",type="string",JSONPath=".spec.requesters"
+// +kubebuilder:printcolumn:name="Approved",type="integer",JSONPath=".status.approvedCount"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+// +k8s:openapi-gen=true
+type CSRApprovalPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   CSRApprovalPolicySpec   `json:"spec,omitempty"`
+	Status CSRApprovalPolicyStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// CSRApprovalPolicyList contains a list of CSRApprovalPolicy
+type CSRApprovalPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []CSRApprovalPolicy `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&CSRApprovalPolicy{}, &CSRApprovalPolicyList{})
+}
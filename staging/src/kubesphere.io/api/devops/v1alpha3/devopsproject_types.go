@@ -33,6 +33,24 @@ const (
 	DevOpeProjectSyncStatusAnnoKey = DevOpsProjectPrefix + "syncstatus"
 	DevOpeProjectSyncTimeAnnoKey   = DevOpsProjectPrefix + "synctime"
 	DevOpeProjectSyncMsgAnnoKey    = DevOpsProjectPrefix + "syncmsg"
+	// DevOpsProjectQualityGatePolicyAnnoKey configures how the sonarqube
+	// quality gate result is enforced for pipelines of the project.
+	DevOpsProjectQualityGatePolicyAnnoKey = DevOpsProjectPrefix + "quality-gate-policy"
+	// DevOpsProjectRetentionMaxRunsAnnoKey keeps at most this many completed
+	// runs per pipeline of the project, older runs are cleaned up.
+	DevOpsProjectRetentionMaxRunsAnnoKey = DevOpsProjectPrefix + "retention-max-runs"
+	// DevOpsProjectRetentionMaxDaysAnnoKey cleans up completed runs of the
+	// project older than this many days.
+	DevOpsProjectRetentionMaxDaysAnnoKey = DevOpsProjectPrefix + "retention-max-days"
+	// DevOpsProjectArchiveRunLogsAnnoKey archives the log of an expired run
+	// to object storage before the run is deleted, value "true" enables it.
+	DevOpsProjectArchiveRunLogsAnnoKey = DevOpsProjectPrefix + "archive-run-logs"
+)
+
+const (
+	// QualityGatePolicyBlockDeploy blocks the deploy stage of a pipeline run
+	// when the quality gate of its sonarqube analysis fails.
+	QualityGatePolicyBlockDeploy = "block-deploy-on-failure"
 )
 
 // DevOpsProjectSpec defines the desired state of DevOpsProject
@@ -0,0 +1,129 @@
+/*
+Copyright 2022 The KubeSphere Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha2
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	ResourceKindAccessRequest      = "AccessRequest"
+	ResourcesSingularAccessRequest = "accessrequest"
+	ResourcesPluralAccessRequest   = "accessrequests"
+
+	// AccessRequestReferenceLabel marks the bindings a granted access
+	// request created, so they can be removed on expiry or deletion.
+	AccessRequestReferenceLabel = "iam.kubesphere.io/accessrequest-ref"
+
+	// AccessRequestApproversAnnotation lists the users who may decide the
+	// request, resolved from the admin roles of the requested scope.
+	AccessRequestApproversAnnotation = "iam.kubesphere.io/approvers"
+)
+
+// AccessRequestState tracks a request through its approval workflow.
+type AccessRequestState string
+
+const (
+	AccessRequestPending  AccessRequestState = "Pending"
+	AccessRequestApproved AccessRequestState = "Approved"
+	AccessRequestDenied   AccessRequestState = "Denied"
+	AccessRequestExpired  AccessRequestState = "Expired"
+)
+
+// AccessRequestSpec describes the role a user asks for and where.
+type AccessRequestSpec struct {
+	// Username of the user requesting access.
+	Username string `json:"username"`
+
+	// Workspace the requested role belongs to, for workspace scoped
+	// requests. Exactly one of workspace and namespace must be set.
+	// +optional
+	Workspace string `json:"workspace,omitempty"`
+
+	// Namespace the requested role belongs to, for project scoped
+	// requests.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+
+	// Role requested, a workspace role name when workspace scoped,
+	// otherwise a namespace role name.
+	Role string `json:"role"`
+
+	// Reason the requester gives for needing the role.
+	// +optional
+	Reason string `json:"reason,omitempty"`
+
+	// ExpiresIn bounds how long the granted binding lives after approval,
+	// unset grants do not expire.
+	// +optional
+	ExpiresIn *metav1.Duration `json:"expiresIn,omitempty"`
+}
+
+// AccessRequestStatus records the decision taken on the request.
+type AccessRequestStatus struct {
+	// +optional
+	State AccessRequestState `json:"state,omitempty"`
+
+	// Approver is the user who approved or denied the request.
+	// +optional
+	Approver string `json:"approver,omitempty"`
+
+	// Comment the approver left with the decision.
+	// +optional
+	Comment string `json:"comment,omitempty"`
+
+	// ApprovalTime is when the request was approved or denied.
+	// +optional
+	ApprovalTime *metav1.Time `json:"approvalTime,omitempty"`
+
+	// ExpirationTime is when the granted binding is removed again,
+	// only set when the request carries expiresIn.
+	// +optional
+	ExpirationTime *metav1.Time `json:"expirationTime,omitempty"`
+}
+
+// +genclient
+// +genclient:nonNamespaced
+// +kubebuilder:object:root=true
+// +k8s:openapi-gen=true
+// +kubebuilder:printcolumn:name="User",type="string",JSONPath=".spec.username"
+// +kubebuilder:printcolumn:name="Role",type="string",JSONPath=".spec.role"
+// +kubebuilder:printcolumn:name="State",type="string",JSONPath=".status.state"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:categories="iam",scope="Cluster"
+
+// AccessRequest lets a user ask for a role in a workspace or project,
+// approvers resolved from the admin roles of that scope approve or deny it
+// and a controller maintains the resulting binding.
+type AccessRequest struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec AccessRequestSpec `json:"spec"`
+	// +optional
+	Status AccessRequestStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +genclient:nonNamespaced
+
+// AccessRequestList contains a list of AccessRequest
+type AccessRequestList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []AccessRequest `json:"items"`
+}
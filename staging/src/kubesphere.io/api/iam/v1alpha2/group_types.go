@@ -24,6 +24,9 @@ const (
 	ResourcePluralGroup = "groups"
 	GroupReferenceLabel = "iam.kubesphere.io/group-ref"
 	GroupParent         = "iam.kubesphere.io/group-parent"
+	// GroupWorkspaceRoleMappingAnnotation lists workspace roles granted to
+	// every member of the group, comma separated workspace role names.
+	GroupWorkspaceRoleMappingAnnotation = "iam.kubesphere.io/workspace-role-mapping"
 )
 
 // GroupSpec defines the desired state of Group
@@ -62,6 +62,8 @@ const (
 	UninitializedAnnotation               = "iam.kubesphere.io/uninitialized"
 	LastPasswordChangeTimeAnnotation      = "iam.kubesphere.io/last-password-change-time"
 	RoleAnnotation                        = "iam.kubesphere.io/role"
+	RoleDeprecatedAnnotation              = "iam.kubesphere.io/deprecated"
+	RoleReplacementAnnotation             = "iam.kubesphere.io/replaced-by"
 	RoleTemplateLabel                     = "iam.kubesphere.io/role-template"
 	ScopeLabelFormat                      = "scope.kubesphere.io/%s"
 	UserReferenceLabel                    = "iam.kubesphere.io/user-ref"
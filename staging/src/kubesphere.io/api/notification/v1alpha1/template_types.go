@@ -0,0 +1,90 @@
+/*
+Copyright 2022 The KubeSphere Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	ResourceKindNotificationTemplate     = "NotificationTemplate"
+	ResourceSingularNotificationTemplate = "notificationtemplate"
+	ResourcePluralNotificationTemplate   = "notificationtemplates"
+)
+
+// TemplateContent is the wording of one language, written in Go
+// text/template syntax, variables are substituted with {{.name}}.
+type TemplateContent struct {
+	// Language the content is written in, e.g. en or zh.
+	Language string `json:"language"`
+
+	// Subject of the notification, e.g. the email subject or the message
+	// card title.
+	// +optional
+	Subject string `json:"subject,omitempty"`
+
+	// Body of the notification.
+	Body string `json:"body"`
+}
+
+// NotificationTemplateSpec holds the wording of one notification channel
+// in one or more languages.
+type NotificationTemplateSpec struct {
+	// Channel the template renders for: dingtalk, email, feishu, pagerduty,
+	// slack, sms, teams, webhook or wechat.
+	Channel string `json:"channel"`
+
+	// DefaultLanguage is used when the requested language has no content,
+	// defaults to en.
+	// +optional
+	DefaultLanguage string `json:"defaultLanguage,omitempty"`
+
+	// Contents of the template, one per language.
+	// +kubebuilder:validation:MinItems=1
+	Contents []TemplateContent `json:"contents"`
+}
+
+// +genclient
+// +genclient:nonNamespaced
+// +kubebuilder:object:root=true
+// +k8s:openapi-gen=true
+// +kubebuilder:resource:scope=Cluster,categories="notification",path=notificationtemplates
+// +kubebuilder:printcolumn:name="Channel",type="string",JSONPath=".spec.channel"
+
+// NotificationTemplate customizes the wording of notifications for one
+// channel, per language, so alert content can be adjusted without code
+// changes.
+type NotificationTemplate struct {
+	metav1.TypeMeta `json:",inline"`
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec NotificationTemplateSpec `json:"spec"`
+}
+
+// +kubebuilder:object:root=true
+
+// NotificationTemplateList contains a list of NotificationTemplate
+type NotificationTemplateList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []NotificationTemplate `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&NotificationTemplate{}, &NotificationTemplateList{})
+}
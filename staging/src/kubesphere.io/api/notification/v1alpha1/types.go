@@ -0,0 +1,150 @@
+/*
+Copyright 2022 The KubeSphere Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	ResourceKindSubscription     = "Subscription"
+	ResourceSingularSubscription = "subscription"
+	ResourcePluralSubscription   = "subscriptions"
+)
+
+// EventType identifies a resource lifecycle event a Subscription can
+// register for.
+type EventType string
+
+const (
+	// EventWorkloadCreated fires when a deployment or statefulset is
+	// created in the namespace of the subscription.
+	EventWorkloadCreated EventType = "workload.created"
+	// EventWorkloadDeleted fires when a deployment or statefulset is
+	// deleted from the namespace of the subscription.
+	EventWorkloadDeleted EventType = "workload.deleted"
+	// EventPipelineFinished fires when a pipeline run of the namespace
+	// completes, successfully or not.
+	EventPipelineFinished EventType = "pipeline.finished"
+	// EventAlertFired fires when an alert rule of the namespace starts
+	// firing.
+	EventAlertFired EventType = "alert.fired"
+	// EventAppVersionSubmitted fires when an app version is submitted for
+	// store review, it carries no namespace and reaches subscriptions of
+	// every namespace.
+	EventAppVersionSubmitted EventType = "appversion.submitted"
+	// EventAccessRequestSubmitted fires when a user files an access
+	// request, so approvers of the requested scope can be notified.
+	EventAccessRequestSubmitted EventType = "accessrequest.submitted"
+	// EventAccessRequestDecided fires when an access request is approved
+	// or denied.
+	EventAccessRequestDecided EventType = "accessrequest.decided"
+)
+
+// WebhookEndpoint is the HTTP endpoint events are delivered to.
+type WebhookEndpoint struct {
+	// URL of the webhook, only http and https schemes are accepted.
+	URL string `json:"url"`
+
+	// SecretRef points to a secret of the subscription namespace whose
+	// key holds the HMAC signing token. When set every delivery carries
+	// an X-KubeSphere-Signature header with the hex encoded HMAC-SHA256
+	// of the request body.
+	// +optional
+	SecretRef *corev1.SecretKeySelector `json:"secretRef,omitempty"`
+
+	// InsecureSkipVerify disables TLS certificate verification for
+	// https endpoints.
+	// +optional
+	InsecureSkipVerify bool `json:"insecureSkipVerify,omitempty"`
+}
+
+// SubscriptionSpec registers a webhook for lifecycle events on resources
+// of the subscription namespace.
+type SubscriptionSpec struct {
+	// Events the webhook is delivered for, at least one.
+	// +kubebuilder:validation:MinItems=1
+	Events []EventType `json:"events"`
+
+	// Webhook the events are delivered to.
+	Webhook WebhookEndpoint `json:"webhook"`
+
+	// MaxRetries bounds how often a failed delivery is retried before the
+	// event is dropped, defaults to 3.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxRetries *int32 `json:"maxRetries,omitempty"`
+
+	// Paused suspends deliveries without deleting the subscription.
+	// +optional
+	Paused bool `json:"paused,omitempty"`
+}
+
+// SubscriptionStatus records the delivery history of the webhook.
+type SubscriptionStatus struct {
+	// DeliveredEvents counts the events delivered successfully.
+	// +optional
+	DeliveredEvents int64 `json:"deliveredEvents,omitempty"`
+
+	// FailedEvents counts the events dropped after exhausting retries.
+	// +optional
+	FailedEvents int64 `json:"failedEvents,omitempty"`
+
+	// LastDeliveryTime is the last time a delivery succeeded.
+	// +optional
+	LastDeliveryTime *metav1.Time `json:"lastDeliveryTime,omitempty"`
+
+	// LastFailureReason describes the most recent delivery failure.
+	// +optional
+	LastFailureReason string `json:"lastFailureReason,omitempty"`
+}
+
+// +genclient
+// +kubebuilder:object:root=true
+// +k8s:openapi-gen=true
+// +kubebuilder:resource:categories="notification",path=subscriptions
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Paused",type="boolean",JSONPath=".spec.paused"
+// +kubebuilder:printcolumn:name="LastDelivery",type="date",JSONPath=".status.lastDeliveryTime"
+
+// Subscription registers a tenant webhook for lifecycle events on
+// resources of its namespace, e.g. workload churn or finished pipeline
+// runs, delivered by the notification-dispatch controller.
+type Subscription struct {
+	metav1.TypeMeta `json:",inline"`
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec SubscriptionSpec `json:"spec"`
+
+	// +optional
+	Status SubscriptionStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// SubscriptionList contains a list of Subscription
+type SubscriptionList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Subscription `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&Subscription{}, &SubscriptionList{})
+}
@@ -182,16 +182,30 @@ type FeishuConfig struct {
 	AppSecret *Credential `json:"appSecret"`
 }
 
-//ConfigSpec defines the desired state of Config
+type TeamsConfig struct {
+	Labels map[string]string `json:"labels,omitempty"`
+}
+
+// PagerDutyConfig is the configuration of the PagerDuty Events API v2
+type PagerDutyConfig struct {
+	Labels map[string]string `json:"labels,omitempty"`
+	// The PagerDuty events API URL, defaults to
+	// https://events.pagerduty.com/v2/enqueue.
+	PagerDutyApiUrl string `json:"pagerdutyApiUrl,omitempty"`
+}
+
+// ConfigSpec defines the desired state of Config
 type ConfigSpec struct {
-	DingTalk *DingTalkConfig `json:"dingtalk,omitempty"`
-	Email    *EmailConfig    `json:"email,omitempty"`
-	Slack    *SlackConfig    `json:"slack,omitempty"`
-	Webhook  *WebhookConfig  `json:"webhook,omitempty"`
-	Wechat   *WechatConfig   `json:"wechat,omitempty"`
-	Sms      *SmsConfig      `json:"sms,omitempty"`
-	Pushover *PushoverConfig `json:"pushover,omitempty"`
-	Feishu   *FeishuConfig   `json:"feishu,omitempty"`
+	DingTalk  *DingTalkConfig  `json:"dingtalk,omitempty"`
+	Email     *EmailConfig     `json:"email,omitempty"`
+	Slack     *SlackConfig     `json:"slack,omitempty"`
+	Webhook   *WebhookConfig   `json:"webhook,omitempty"`
+	Wechat    *WechatConfig    `json:"wechat,omitempty"`
+	Sms       *SmsConfig       `json:"sms,omitempty"`
+	Pushover  *PushoverConfig  `json:"pushover,omitempty"`
+	Feishu    *FeishuConfig    `json:"feishu,omitempty"`
+	Teams     *TeamsConfig     `json:"teams,omitempty"`
+	PagerDuty *PagerDutyConfig `json:"pagerduty,omitempty"`
 }
 
 // ConfigStatus defines the observed state of Config
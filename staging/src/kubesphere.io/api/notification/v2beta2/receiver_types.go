@@ -282,16 +282,56 @@ type FeishuReceiver struct {
 	TmplText *ConfigmapKeySelector `json:"tmplText,omitempty"`
 }
 
-//ReceiverSpec defines the desired state of Receiver
+type TeamsReceiver struct {
+	// whether the receiver is enabled
+	Enabled *bool `json:"enabled,omitempty"`
+	// TeamsConfig to be selected for this receiver
+	TeamsConfigSelector *metav1.LabelSelector `json:"teamsConfigSelector,omitempty"`
+	// Selector to filter alerts.
+	AlertSelector *metav1.LabelSelector `json:"alertSelector,omitempty"`
+	// The incoming webhook of the channel which the message will send to.
+	Webhook *Credential `json:"webhook"`
+	// The name of the template to generate notification.
+	// If the global template is not set, it will use default.
+	Template *string `json:"template,omitempty"`
+	// The name of the template to generate message card title
+	TitleTemplate *string `json:"titleTemplate,omitempty"`
+	// Template file.
+	TmplText *ConfigmapKeySelector `json:"tmplText,omitempty"`
+}
+
+type PagerDutyReceiver struct {
+	// whether the receiver is enabled
+	Enabled *bool `json:"enabled,omitempty"`
+	// PagerDutyConfig to be selected for this receiver
+	PagerDutyConfigSelector *metav1.LabelSelector `json:"pagerdutyConfigSelector,omitempty"`
+	// Selector to filter alerts.
+	AlertSelector *metav1.LabelSelector `json:"alertSelector,omitempty"`
+	// The integration key of the PagerDuty service events are routed to.
+	RoutingKey *Credential `json:"routingKey"`
+	// SeverityMap maps the severity label of an alert to a PagerDuty
+	// severity: critical, error, warning or info. Alerts with an unmapped
+	// severity are sent as warning.
+	SeverityMap map[string]string `json:"severityMap,omitempty"`
+	// The name of the template to generate the event summary.
+	// If the global template is not set, it will use default.
+	Template *string `json:"template,omitempty"`
+	// Template file.
+	TmplText *ConfigmapKeySelector `json:"tmplText,omitempty"`
+}
+
+// ReceiverSpec defines the desired state of Receiver
 type ReceiverSpec struct {
-	DingTalk *DingTalkReceiver `json:"dingtalk,omitempty"`
-	Email    *EmailReceiver    `json:"email,omitempty"`
-	Slack    *SlackReceiver    `json:"slack,omitempty"`
-	Webhook  *WebhookReceiver  `json:"webhook,omitempty"`
-	Wechat   *WechatReceiver   `json:"wechat,omitempty"`
-	Sms      *SmsReceiver      `json:"sms,omitempty"`
-	Pushover *PushoverReceiver `json:"pushover,omitempty"`
-	Feishu   *FeishuReceiver   `json:"feishu,omitempty"`
+	DingTalk  *DingTalkReceiver  `json:"dingtalk,omitempty"`
+	Email     *EmailReceiver     `json:"email,omitempty"`
+	Slack     *SlackReceiver     `json:"slack,omitempty"`
+	Webhook   *WebhookReceiver   `json:"webhook,omitempty"`
+	Wechat    *WechatReceiver    `json:"wechat,omitempty"`
+	Sms       *SmsReceiver       `json:"sms,omitempty"`
+	Pushover  *PushoverReceiver  `json:"pushover,omitempty"`
+	Feishu    *FeishuReceiver    `json:"feishu,omitempty"`
+	Teams     *TeamsReceiver     `json:"teams,omitempty"`
+	PagerDuty *PagerDutyReceiver `json:"pagerduty,omitempty"`
 }
 
 // ReceiverStatus defines the observed state of Receiver
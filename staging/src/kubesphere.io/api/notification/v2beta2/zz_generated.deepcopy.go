@@ -224,6 +224,16 @@ func (in *ConfigSpec) DeepCopyInto(out *ConfigSpec) {
 		*out = new(FeishuConfig)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Teams != nil {
+		in, out := &in.Teams, &out.Teams
+		*out = new(TeamsConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PagerDuty != nil {
+		in, out := &in.PagerDuty, &out.PagerDuty
+		*out = new(PagerDutyConfig)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConfigSpec.
@@ -1110,6 +1120,80 @@ func (in *Options) DeepCopy() *Options {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PagerDutyConfig) DeepCopyInto(out *PagerDutyConfig) {
+	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PagerDutyConfig.
+func (in *PagerDutyConfig) DeepCopy() *PagerDutyConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(PagerDutyConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PagerDutyReceiver) DeepCopyInto(out *PagerDutyReceiver) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.PagerDutyConfigSelector != nil {
+		in, out := &in.PagerDutyConfigSelector, &out.PagerDutyConfigSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AlertSelector != nil {
+		in, out := &in.AlertSelector, &out.AlertSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RoutingKey != nil {
+		in, out := &in.RoutingKey, &out.RoutingKey
+		*out = new(Credential)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SeverityMap != nil {
+		in, out := &in.SeverityMap, &out.SeverityMap
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Template != nil {
+		in, out := &in.Template, &out.Template
+		*out = new(string)
+		**out = **in
+	}
+	if in.TmplText != nil {
+		in, out := &in.TmplText, &out.TmplText
+		*out = new(ConfigmapKeySelector)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PagerDutyReceiver.
+func (in *PagerDutyReceiver) DeepCopy() *PagerDutyReceiver {
+	if in == nil {
+		return nil
+	}
+	out := new(PagerDutyReceiver)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Providers) DeepCopyInto(out *Providers) {
 	*out = *in
@@ -1410,6 +1494,16 @@ func (in *ReceiverSpec) DeepCopyInto(out *ReceiverSpec) {
 		*out = new(FeishuReceiver)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Teams != nil {
+		in, out := &in.Teams, &out.Teams
+		*out = new(TeamsReceiver)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PagerDuty != nil {
+		in, out := &in.PagerDuty, &out.PagerDuty
+		*out = new(PagerDutyReceiver)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReceiverSpec.
@@ -1942,6 +2036,78 @@ func (in *TLSConfig) DeepCopy() *TLSConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TeamsConfig) DeepCopyInto(out *TeamsConfig) {
+	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TeamsConfig.
+func (in *TeamsConfig) DeepCopy() *TeamsConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(TeamsConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TeamsReceiver) DeepCopyInto(out *TeamsReceiver) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.TeamsConfigSelector != nil {
+		in, out := &in.TeamsConfigSelector, &out.TeamsConfigSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AlertSelector != nil {
+		in, out := &in.AlertSelector, &out.AlertSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Webhook != nil {
+		in, out := &in.Webhook, &out.Webhook
+		*out = new(Credential)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Template != nil {
+		in, out := &in.Template, &out.Template
+		*out = new(string)
+		**out = **in
+	}
+	if in.TitleTemplate != nil {
+		in, out := &in.TitleTemplate, &out.TitleTemplate
+		*out = new(string)
+		**out = **in
+	}
+	if in.TmplText != nil {
+		in, out := &in.TmplText, &out.TmplText
+		*out = new(ConfigmapKeySelector)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TeamsReceiver.
+func (in *TeamsReceiver) DeepCopy() *TeamsReceiver {
+	if in == nil {
+		return nil
+	}
+	out := new(TeamsReceiver)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Template) DeepCopyInto(out *Template) {
 	*out = *in
@@ -0,0 +1,200 @@
+/*
+Copyright 2022 The KubeSphere Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	ResourceKindRolloutStrategy     = "RolloutStrategy"
+	ResourceSingularRolloutStrategy = "rolloutstrategy"
+	ResourcePluralRolloutStrategy   = "rolloutstrategies"
+)
+
+// RolloutType is the kind of progressive delivery performed.
+type RolloutType string
+
+const (
+	// CanaryRollout shifts traffic to the new version step by step.
+	CanaryRollout RolloutType = "Canary"
+	// BlueGreenRollout keeps the new version dark until it is ready, then
+	// shifts all traffic at once.
+	BlueGreenRollout RolloutType = "BlueGreen"
+)
+
+// TrafficRoutingProvider is the mechanism used to shift traffic, for
+// clusters where a service mesh is not enabled.
+type TrafficRoutingProvider string
+
+const (
+	// IngressProvider shifts traffic through ingress-nginx canary
+	// annotations.
+	IngressProvider TrafficRoutingProvider = "Ingress"
+	// GatewayAPIProvider shifts traffic through the backend weights of a
+	// Gateway API HTTPRoute.
+	GatewayAPIProvider TrafficRoutingProvider = "GatewayAPI"
+)
+
+// WorkloadReference points to the workload under rollout.
+type WorkloadReference struct {
+	// Kind of the workload, only Deployment is supported.
+	// +kubebuilder:validation:Enum=Deployment
+	Kind string `json:"kind"`
+
+	// Name of the workload in the namespace of the strategy.
+	Name string `json:"name"`
+}
+
+// TrafficRouting describes how traffic is split between the stable and the
+// canary version.
+type TrafficRouting struct {
+	// Provider shifts traffic either through ingress-nginx canary
+	// annotations or a Gateway API HTTPRoute, defaults to Ingress.
+	// +kubebuilder:validation:Enum=Ingress;GatewayAPI
+	// +optional
+	Provider TrafficRoutingProvider `json:"provider,omitempty"`
+
+	// StableService is the service in front of the stable version.
+	StableService string `json:"stableService"`
+
+	// CanaryService is the service in front of the new version.
+	CanaryService string `json:"canaryService"`
+
+	// Ingress is the name of the stable ingress, a canary ingress derived
+	// from it carries the weight annotations. Required for the Ingress
+	// provider.
+	// +optional
+	Ingress string `json:"ingress,omitempty"`
+
+	// HTTPRoute is the name of the Gateway API HTTPRoute whose backend
+	// weights are updated. Required for the GatewayAPI provider.
+	// +optional
+	HTTPRoute string `json:"httpRoute,omitempty"`
+}
+
+// RolloutStep is one stage of a canary rollout.
+type RolloutStep struct {
+	// Weight of the traffic sent to the canary version, 0 to 100.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	Weight int32 `json:"weight"`
+
+	// PauseSeconds to hold this weight before moving to the next step,
+	// the rollout waits for manual promotion when unset.
+	// +optional
+	PauseSeconds *int32 `json:"pauseSeconds,omitempty"`
+}
+
+// RolloutStrategySpec defines the desired rollout behavior.
+type RolloutStrategySpec struct {
+	// WorkloadRef points to the workload under rollout.
+	WorkloadRef WorkloadReference `json:"workloadRef"`
+
+	// Type of the rollout, defaults to Canary.
+	// +kubebuilder:validation:Enum=Canary;BlueGreen
+	// +optional
+	Type RolloutType `json:"type,omitempty"`
+
+	// TrafficRouting describes how traffic is split between the versions.
+	TrafficRouting TrafficRouting `json:"trafficRouting"`
+
+	// Steps of a canary rollout, evaluated in order. Ignored for
+	// BlueGreen rollouts.
+	// +optional
+	Steps []RolloutStep `json:"steps,omitempty"`
+
+	// AutoPromotionSeconds delays the traffic switch of a BlueGreen
+	// rollout after the new version becomes ready, the rollout waits for
+	// manual promotion when unset.
+	// +optional
+	AutoPromotionSeconds *int32 `json:"autoPromotionSeconds,omitempty"`
+
+	// Paused suspends the rollout at its current weight.
+	// +optional
+	Paused bool `json:"paused,omitempty"`
+}
+
+// RolloutPhase is the high level state of a rollout.
+type RolloutPhase string
+
+const (
+	RolloutProgressing RolloutPhase = "Progressing"
+	RolloutPaused      RolloutPhase = "Paused"
+	RolloutSucceeded   RolloutPhase = "Succeeded"
+	RolloutFailed      RolloutPhase = "Failed"
+)
+
+// RolloutStrategyStatus records the progress of the rollout.
+type RolloutStrategyStatus struct {
+	// Phase of the rollout.
+	// +optional
+	Phase RolloutPhase `json:"phase,omitempty"`
+
+	// CurrentStepIndex is the index of the canary step being executed.
+	// +optional
+	CurrentStepIndex int32 `json:"currentStepIndex,omitempty"`
+
+	// CanaryWeight is the traffic weight currently applied to the canary
+	// version.
+	// +optional
+	CanaryWeight int32 `json:"canaryWeight,omitempty"`
+
+	// LastTransitionTime is the last time the phase or the weight changed.
+	// +optional
+	LastTransitionTime *metav1.Time `json:"lastTransitionTime,omitempty"`
+
+	// Message explains the current phase, e.g. why the rollout failed.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// +genclient
+// +kubebuilder:object:root=true
+// +k8s:openapi-gen=true
+// +kubebuilder:resource:categories="rollout",path=rolloutstrategies
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Type",type="string",JSONPath=".spec.type"
+// +kubebuilder:printcolumn:name="Phase",type="string",JSONPath=".status.phase"
+// +kubebuilder:printcolumn:name="Weight",type="integer",JSONPath=".status.canaryWeight"
+
+// RolloutStrategy runs a canary or blue-green rollout of a workload by
+// shifting traffic through plain Service/Ingress weights or the Gateway
+// API, for clusters where Istio is not enabled.
+type RolloutStrategy struct {
+	metav1.TypeMeta `json:",inline"`
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec RolloutStrategySpec `json:"spec"`
+
+	// +optional
+	Status RolloutStrategyStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// RolloutStrategyList contains a list of RolloutStrategy
+type RolloutStrategyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []RolloutStrategy `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&RolloutStrategy{}, &RolloutStrategyList{})
+}
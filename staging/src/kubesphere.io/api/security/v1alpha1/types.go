@@ -0,0 +1,72 @@
+/*
+Copyright 2022 The KubeSphere Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	ResourceKindImagePolicy      = "ImagePolicy"
+	ResourcesSingularImagePolicy = "imagepolicy"
+	ResourcesPluralImagePolicy   = "imagepolicies"
+)
+
+// ImagePolicySpec is an allowlist of images workloads may pull. A policy
+// with a workspace only constrains the namespaces of that workspace,
+// otherwise it applies cluster-wide.
+type ImagePolicySpec struct {
+	// Workspace the policy applies to, empty means the whole cluster.
+	// +optional
+	Workspace string `json:"workspace,omitempty"`
+
+	// AllowedImages are registry or repository prefixes, e.g.
+	// docker.io/library or harbor.example.com, an entry with a trailing *
+	// matches by plain prefix. An image is allowed when it matches at
+	// least one entry of one applicable policy.
+	AllowedImages []string `json:"allowedImages"`
+}
+
+// +genclient
+// +genclient:nonNamespaced
+// +kubebuilder:object:root=true
+// +k8s:openapi-gen=true
+// +kubebuilder:printcolumn:name="Workspace",type="string",JSONPath=".spec.workspace"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:categories="security",scope="Cluster"
+
+// ImagePolicy restricts which registries and repositories workloads may
+// pull images from, enforced by an admission webhook on pods.
+type ImagePolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec ImagePolicySpec `json:"spec"`
+}
+
+// +kubebuilder:object:root=true
+
+// ImagePolicyList contains a list of ImagePolicy
+type ImagePolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ImagePolicy `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ImagePolicy{}, &ImagePolicyList{})
+}
@@ -82,6 +82,42 @@ type StrategySpec struct {
 	// strategy policy, how the strategy will be applied
 	// by the strategy controller
 	StrategyPolicy StrategyPolicy `json:"strategyPolicy,omitempty"`
+
+	// Success criteria the canary version has to meet, evaluated against
+	// the monitoring backend. When set, the strategy controller promotes
+	// the canary version once the criteria held for enough iterations and
+	// rolls back when they failed too often. Only meaningful for Canary
+	// strategies.
+	// +optional
+	SuccessCriteria *SuccessCriteria `json:"successCriteria,omitempty"`
+}
+
+// SuccessCriteria defines the metric thresholds a canary version has to
+// meet to be promoted automatically.
+type SuccessCriteria struct {
+	// MaxErrorRatePercent is the highest tolerated request error rate of
+	// the canary version, in percent of all requests.
+	// +optional
+	MaxErrorRatePercent *int32 `json:"maxErrorRatePercent,omitempty"`
+
+	// MaxLatencyMilliseconds is the highest tolerated 99th percentile
+	// request latency of the canary version.
+	// +optional
+	MaxLatencyMilliseconds *int32 `json:"maxLatencyMilliseconds,omitempty"`
+
+	// IntervalSeconds between metric evaluations, defaults to 60.
+	// +optional
+	IntervalSeconds *int32 `json:"intervalSeconds,omitempty"`
+
+	// Iterations is the number of consecutive successful evaluations
+	// required before the canary version is promoted, defaults to 5.
+	// +optional
+	Iterations *int32 `json:"iterations,omitempty"`
+
+	// FailureLimit is the number of consecutive failed evaluations after
+	// which the strategy rolls back, defaults to 2.
+	// +optional
+	FailureLimit *int32 `json:"failureLimit,omitempty"`
 }
 
 // VirtualServiceTemplateSpec
@@ -114,6 +150,34 @@ type StrategyStatus struct {
 	// It is represented in RFC3339 form and is in UTC.
 	// +optional
 	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+
+	// The metric evaluations performed against the success criteria,
+	// newest last, truncated to the most recent entries.
+	// +optional
+	AnalysisHistory []AnalysisRecord `json:"analysisHistory,omitempty"`
+}
+
+// AnalysisRecord is one metric evaluation of the canary version against
+// the success criteria.
+type AnalysisRecord struct {
+	// Time the evaluation was performed.
+	ProbeTime metav1.Time `json:"probeTime,omitempty"`
+
+	// Observed request error rate of the canary version, in percent.
+	// +optional
+	ErrorRatePercent string `json:"errorRatePercent,omitempty"`
+
+	// Observed 99th percentile request latency of the canary version, in
+	// milliseconds.
+	// +optional
+	LatencyMilliseconds string `json:"latencyMilliseconds,omitempty"`
+
+	// Whether the observed metrics met the success criteria.
+	Passed bool `json:"passed"`
+
+	// Human readable reason when the evaluation failed.
+	// +optional
+	Message string `json:"message,omitempty"`
 }
 
 type StrategyConditionType string
@@ -26,6 +26,23 @@ import (
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AnalysisRecord) DeepCopyInto(out *AnalysisRecord) {
+	*out = *in
+	in.ProbeTime.DeepCopyInto(&out.ProbeTime)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AnalysisRecord.
+func (in *AnalysisRecord) DeepCopy() *AnalysisRecord {
+	if in == nil {
+		return nil
+	}
+	out := new(AnalysisRecord)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DestinationRuleSpecTemplate) DeepCopyInto(out *DestinationRuleSpecTemplate) {
 	*out = *in
@@ -264,6 +281,11 @@ func (in *StrategySpec) DeepCopyInto(out *StrategySpec) {
 		(*in).DeepCopyInto(*out)
 	}
 	in.Template.DeepCopyInto(&out.Template)
+	if in.SuccessCriteria != nil {
+		in, out := &in.SuccessCriteria, &out.SuccessCriteria
+		*out = new(SuccessCriteria)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -295,6 +317,13 @@ func (in *StrategyStatus) DeepCopyInto(out *StrategyStatus) {
 		in, out := &in.CompletionTime, &out.CompletionTime
 		*out = (*in).DeepCopy()
 	}
+	if in.AnalysisHistory != nil {
+		in, out := &in.AnalysisHistory, &out.AnalysisHistory
+		*out = make([]AnalysisRecord, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -308,6 +337,47 @@ func (in *StrategyStatus) DeepCopy() *StrategyStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SuccessCriteria) DeepCopyInto(out *SuccessCriteria) {
+	*out = *in
+	if in.MaxErrorRatePercent != nil {
+		in, out := &in.MaxErrorRatePercent, &out.MaxErrorRatePercent
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxLatencyMilliseconds != nil {
+		in, out := &in.MaxLatencyMilliseconds, &out.MaxLatencyMilliseconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.IntervalSeconds != nil {
+		in, out := &in.IntervalSeconds, &out.IntervalSeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Iterations != nil {
+		in, out := &in.Iterations, &out.Iterations
+		*out = new(int32)
+		**out = **in
+	}
+	if in.FailureLimit != nil {
+		in, out := &in.FailureLimit, &out.FailureLimit
+		*out = new(int32)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SuccessCriteria.
+func (in *SuccessCriteria) DeepCopy() *SuccessCriteria {
+	if in == nil {
+		return nil
+	}
+	out := new(SuccessCriteria)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VirtualServiceTemplateSpec) DeepCopyInto(out *VirtualServiceTemplateSpec) {
 	*out = *in
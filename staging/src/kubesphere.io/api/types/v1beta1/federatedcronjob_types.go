@@ -0,0 +1,58 @@
+/*
+Copyright 2022 KubeSphere Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	v1 "k8s.io/api/batch/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	ResourcePluralFederatedCronJob   = "federatedcronjobs"
+	ResourceSingularFederatedCronJob = "federatedcronjob"
+	FederatedCronJobKind             = "FederatedCronJob"
+)
+
+// +genclient
+// +kubebuilder:object:root=true
+// +k8s:openapi-gen=true
+type FederatedCronJob struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              FederatedCronJobSpec `json:"spec"`
+
+	Status *GenericFederatedStatus `json:"status,omitempty"`
+}
+
+type FederatedCronJobSpec struct {
+	Template  CronJobTemplate        `json:"template"`
+	Placement GenericPlacementFields `json:"placement"`
+	Overrides []GenericOverrideItem  `json:"overrides,omitempty"`
+}
+
+type CronJobTemplate struct {
+	Spec v1.CronJobSpec `json:"spec,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// FederatedCronJobList contains a list of federatedcronjoblists
+type FederatedCronJobList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []FederatedCronJob `json:"items"`
+}
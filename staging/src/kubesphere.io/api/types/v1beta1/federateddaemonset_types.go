@@ -0,0 +1,58 @@
+/*
+Copyright 2022 KubeSphere Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	v1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	ResourcePluralFederatedDaemonSet   = "federateddaemonsets"
+	ResourceSingularFederatedDaemonSet = "federateddaemonset"
+	FederatedDaemonSetKind             = "FederatedDaemonSet"
+)
+
+// +genclient
+// +kubebuilder:object:root=true
+// +k8s:openapi-gen=true
+type FederatedDaemonSet struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              FederatedDaemonSetSpec `json:"spec"`
+
+	Status *GenericFederatedStatus `json:"status,omitempty"`
+}
+
+type FederatedDaemonSetSpec struct {
+	Template  DaemonSetTemplate      `json:"template"`
+	Placement GenericPlacementFields `json:"placement"`
+	Overrides []GenericOverrideItem  `json:"overrides,omitempty"`
+}
+
+type DaemonSetTemplate struct {
+	Spec v1.DaemonSetSpec `json:"spec,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// FederatedDaemonSetList contains a list of federateddaemonsetlists
+type FederatedDaemonSetList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []FederatedDaemonSet `json:"items"`
+}
@@ -53,10 +53,16 @@ func init() {
 		&FederatedClusterRoleBindingList{},
 		&FederatedConfigMap{},
 		&FederatedConfigMapList{},
+		&FederatedCronJob{},
+		&FederatedCronJobList{},
+		&FederatedDaemonSet{},
+		&FederatedDaemonSetList{},
 		&FederatedDeployment{},
 		&FederatedDeploymentList{},
 		&FederatedIngress{},
 		&FederatedIngressList{},
+		&FederatedJob{},
+		&FederatedJobList{},
 		&FederatedLimitRange{},
 		&FederatedLimitRangeList{},
 		&FederatedNamespace{},